@@ -1,5 +1,11 @@
-// File assets.go embeds and loads all runtime assets (images, fonts, audio)
-// for the game. Helpers here panic on failure to keep startup deterministic.
+// File assets.go embeds and loads all runtime assets (images, fonts, audio,
+// shaders) for the game. Helpers here panic on failure to keep startup
+// deterministic.
+//
+// Any image may have an optional HiDPI variant beside it, drawn at twice the
+// resolution and named with an "@2x" suffix (player@2x.png next to
+// player.png). The variant is loaded with the image and found with HiDPI;
+// images without one are simply scaled up on dense displays.
 package assets
 
 import (
@@ -9,9 +15,13 @@ import (
 	"image"
 	_ "image/png" // enable PNG decoding
 	"io/fs"
+	"path"
+	"slices"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/audio/vorbis"
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 )
 
@@ -21,38 +31,86 @@ var assets embed.FS
 // Preloaded global assets (sprites, fonts, audio, sequences).
 var (
 	PlayerSprite         = mustLoadImage("images/player.png")
+	DartSprite           = mustLoadImage("images/ship-dart.png")
+	HammerSprite         = mustLoadImage("images/ship-hammer.png")
+	BastionSprite        = mustLoadImage("images/ship-bastion.png")
 	TitleFont            = mustLoadFontFace("fonts/title.ttf")
 	ScoreFont            = mustLoadFontFace("fonts/score.ttf")
 	LevelFont            = mustLoadFontFace("fonts/score.ttf")
 	MeteorSprites        = mustLoadImages("images/meteors/*.png")
+	MeteorSpritesMedium  = mustLoadImages("images/meteors-medium/*.png")
 	MeteorSpritesSmall   = mustLoadImages("images/meteors-small/*.png")
 	LaserSprite          = mustLoadImage("images/laser.png")
+	BoltSprite           = mustLoadImage("images/bolt.png") // White; tinted when drawn.
 	ExplosionSprite      = mustLoadImage("images/explosion.png")
 	ExplosionSmallSprite = mustLoadImage("images/explosion-small.png")
 	Explosion            = createExplosion()
 	ThrustSound          = mustLoadOggVorbis("audio/thrust.ogg")
 	ExhaustSprite        = mustLoadImage("images/fire.png")
-	LaserOneSound        = mustLoadOggVorbis("audio/fire.ogg")
-	LaserTwoSound        = mustLoadOggVorbis("audio/fire.ogg")
-	LaserThreeSound      = mustLoadOggVorbis("audio/fire.ogg")
+	LaserSound           = mustLoadOggVorbis("audio/fire.ogg")
 	ExplosionSound       = mustLoadOggVorbis("audio/explosion.ogg")
 	BeatOneSound         = mustLoadOggVorbis("audio/beat1.ogg")
 	BeatTwoSound         = mustLoadOggVorbis("audio/beat2.ogg")
 	LifeIndicator        = mustLoadImage("images/life-indicator.png")
+	ExtraLifeSprite      = mustLoadImage("images/extra-life.png")
 	ShieldSound          = mustLoadOggVorbis("audio/shield.ogg")
 	ShieldSprite         = mustLoadImage("images/shield.png")
 	ShieldIndicator      = mustLoadImage("images/shield-indicator.png")
+	ShieldRechargeSprite = mustLoadImage("images/shield-recharge.png")
 	HyperspaceIndicator  = mustLoadImage("images/hyperspace.png")
 	AlienSprites         = mustLoadImages("images/aliens/*.png")
 	AlienSound           = mustLoadOggVorbis("audio/alien-sound.ogg")
+	CometSound           = mustLoadOggVorbis("audio/thrust.ogg") // Its own stream of the thrust rumble, for the comet's whoosh.
 	AlienLaserSprite     = mustLoadImage("images/red-laser.png")
+	OrbSprite            = mustLoadImage("images/orb.png") // White; tinted when drawn.
 	AlienLaserSound      = mustLoadOggVorbis("audio/alien-laser.ogg")
+	MusicTrack           = mustLoadWav("audio/music.wav")
+	SpreadShotSprite     = mustLoadImage("images/spread-shot.png")
+	SpreadShotSound      = mustLoadOggVorbis("audio/laser.ogg")
+	WallSound            = mustLoadOggVorbis("audio/beat2.ogg") // Its own stream of the low beat, for thuds off solid walls.
+	EMPSound             = mustLoadOggVorbis("audio/alien.ogg")
+	HUDGlitchShader      = mustLoadShader("shaders/hud-glitch.kage")
 )
 
-// mustLoadImage decodes an embedded image file into an *ebiten.Image.
+// HiDPIScale is the resolution of "@2x" variants relative to their images.
+const HiDPIScale = 2
+
+// hiDPISuffix marks a HiDPI variant's file name, before the extension.
+const hiDPISuffix = "@2x"
+
+// hiDPIVariants maps each loaded image that has a HiDPI variant to it.
+var hiDPIVariants = map[*ebiten.Image]*ebiten.Image{}
+
+// HiDPI returns img's "@2x" variant, or nil if it has none.
+func HiDPI(img *ebiten.Image) *ebiten.Image {
+	return hiDPIVariants[img]
+}
+
+// hiDPIName returns the file name of name's HiDPI variant.
+func hiDPIName(name string) string {
+	ext := path.Ext(name)
+	return strings.TrimSuffix(name, ext) + hiDPISuffix + ext
+}
+
+// isHiDPIName reports whether name is a HiDPI variant's file name.
+func isHiDPIName(name string) bool {
+	return strings.HasSuffix(strings.TrimSuffix(name, path.Ext(name)), hiDPISuffix)
+}
+
+// mustLoadImage decodes an embedded image file into an *ebiten.Image, along
+// with its HiDPI variant if one is embedded.
 //
 // Panics on error to fail fast during startup (asset mismatch is non-recoverable).
 func mustLoadImage(name string) *ebiten.Image {
+	img := mustDecodeImage(name)
+	if _, err := fs.Stat(assets, hiDPIName(name)); err == nil {
+		hiDPIVariants[img] = mustDecodeImage(hiDPIName(name))
+	}
+	return img
+}
+
+// mustDecodeImage decodes one embedded image file.
+func mustDecodeImage(name string) *ebiten.Image {
 	file, err := assets.Open(name)
 	if err != nil {
 		panic(err)
@@ -92,6 +150,8 @@ func mustLoadImages(path string) []*ebiten.Image {
 	if err != nil {
 		panic(err)
 	}
+	// HiDPI variants load with their images, not as frames of their own.
+	matches = slices.DeleteFunc(matches, isHiDPIName)
 	if len(matches) == 0 {
 		panic(fmt.Errorf("no assets matched path %q (check //go:embed patterns and file locations)", path))
 	}
@@ -127,3 +187,34 @@ func mustLoadOggVorbis(name string) *vorbis.Stream {
 	}
 	return stream
 }
+
+// mustLoadShader compiles an embedded Kage shader.
+//
+// Panics on error, like the other loaders; a shader that fails to compile is
+// a build mistake.
+func mustLoadShader(name string) *ebiten.Shader {
+	b, err := assets.ReadFile(name)
+	if err != nil {
+		panic(err)
+	}
+	shader, err := ebiten.NewShader(b)
+	if err != nil {
+		panic(err)
+	}
+	return shader
+}
+
+// mustLoadWav loads an embedded WAV stream decoded without resampling.
+//
+// The returned wav.Stream reports its Length, so it can back an audio.InfiniteLoop.
+func mustLoadWav(name string) *wav.Stream {
+	b, err := assets.ReadFile(name)
+	if err != nil {
+		panic(err)
+	}
+	stream, err := wav.DecodeWithoutResampling(bytes.NewReader(b))
+	if err != nil {
+		panic(err)
+	}
+	return stream
+}
@@ -3,9 +3,11 @@
 package asteroids
 
 import (
+	"image/color"
 	"math"
 
 	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/solarlune/resolv"
 )
@@ -13,21 +15,32 @@ import (
 const (
 	// alienLaserSpeedPerSecond is the travel speed in world units / second.
 	alienLaserSpeedPerSecond = 1000.0
+
+	// alienOrbPulse is the period of a shape-coded orb's pulse, in ticks,
+	// and alienOrbSwell how far it swells past its size.
+	alienOrbPulse = 20
+	alienOrbSwell = 0.15
 )
 
+// empShotTint is the violet of a shape-coded EMP orb.
+var empShotTint = color.RGBA{R: 180, G: 100, B: 255, A: 255}
+
 // AlienLaser models a straight-flying alien projectile with a rectangle collider.
 type AlienLaser struct {
 	position Vector
 	rotation float64
 	sprite   *ebiten.Image
 	laserObj *resolv.ConvexPolygon
+	emp      bool // Scrambles the ship instead of destroying it (see emp.go).
+	age      int  // Ticks in flight, pacing its pulse when shape-coded.
 }
 
-// NewAlienLaser creates a laser at position with rotation.
+// NewAlienLaser returns a laser at position with rotation, reusing a
+// released laser (and its collider) from the scene's pool when possible.
 //
 // The spawn point is adjusted so rotation occurs about the sprite center.
 // A rectangle collider is initialized and tagged for collision queries.
-func NewAlienLaser(position Vector, rotation float64) *AlienLaser {
+func NewAlienLaser(position Vector, rotation float64, g *GameScene) *AlienLaser {
 	sprite := assets.AlienLaserSprite
 
 	// Center-origin adjustment.
@@ -36,33 +49,55 @@ func NewAlienLaser(position Vector, rotation float64) *AlienLaser {
 	halfHeight := float64(bounds.Dy()) / 2
 	position.X -= halfWidth
 	position.Y -= halfHeight
-	alienLaser := &AlienLaser{
+
+	// Reinitialize a pooled projectile; only a fresh one needs a collider.
+	alienLaser := g.pools.alienLasers.Get()
+	laserObj := alienLaser.laserObj
+	if laserObj == nil {
+		laserObj = resolv.NewRectangle(position.X, position.Y, float64(bounds.Dx()), float64(bounds.Dy()))
+		laserObj.Tags().Set(TagLaser | TagOrb)
+	}
+	*alienLaser = AlienLaser{
 		position: position,
 		rotation: rotation,
 		sprite:   sprite,
-		laserObj: resolv.NewRectangle(position.X, position.Y, float64(bounds.Dx()), float64(bounds.Dy())),
+		laserObj: laserObj,
 	}
 	alienLaser.laserObj.SetPosition(position.X, position.Y)
-	alienLaser.laserObj.Tags().Set(TagLaser)
 
 	return alienLaser
 }
 
 // Update advances the laser forward along its facing and syncs the collider.
-//
-// Speed is normalized by ebiten.TPS() for frame-rate–independent motion.
 func (al *AlienLaser) Update() {
-	speed := alienLaserSpeedPerSecond / float64(ebiten.TPS())
+	al.move()
+	al.syncCollider()
+}
 
-	// Advance along rotation; X uses sin, Y uses cos for screen coordinates.
-	al.position.X += math.Sin(al.rotation) * speed
-	al.position.Y += math.Cos(al.rotation) * -speed
+// move advances the laser's own position; safe to call from the worker pool.
+func (al *AlienLaser) move() {
+	al.age++
+	sim.Advance(&al.position, shipHeading(al.rotation).Scale(alienLaserSpeedPerSecond))
+}
 
+// syncCollider keeps the collider aligned with the sprite; must run serially.
+func (al *AlienLaser) syncCollider() {
 	al.laserObj.SetPosition(al.position.X, al.position.Y)
 }
 
-// Draw renders the laser rotated around its center at its current position.
+// Draw renders the laser rotated around its center at its current position:
+// the classic sprite, or a pulsing, tinted orb when shots are shape-coded.
 func (al *AlienLaser) Draw(screen *ebiten.Image) {
+	if config.ShotShapes {
+		tint := currentPalette().AlienShot
+		if al.emp {
+			tint = empShotTint
+		}
+		pulse := 1 + alienOrbSwell*math.Sin(2*math.Pi*float64(al.age)/alienOrbPulse)
+		drawCodedShot(screen, assets.OrbSprite, al.sprite, al.position, al.rotation, pulse, tint)
+		return
+	}
+
 	bounds := al.sprite.Bounds()
 	halfWidth := float64(bounds.Dx()) / 2
 	halfHeight := float64(bounds.Dy()) / 2
@@ -72,6 +107,10 @@ func (al *AlienLaser) Draw(screen *ebiten.Image) {
 	op.GeoM.Rotate(al.rotation)
 	op.GeoM.Translate(halfWidth, halfHeight)
 	op.GeoM.Translate(al.position.X, al.position.Y)
-
-	screen.DrawImage(al.sprite, op)
+	if al.emp {
+		op.ColorScale.Scale(0.7, 0.4, 4, 1) // EMP shots glow violet.
+		drawSprite(screen, al.sprite, op)
+		return
+	}
+	drawRecolored(screen, al.sprite, currentPalette().AlienLaser, op)
 }
@@ -5,9 +5,9 @@ package asteroids
 
 import (
 	"math"
-	"math/rand"
 
 	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/solarlune/resolv"
 )
@@ -20,10 +20,29 @@ type Alien struct {
 	alienObj      *resolv.Circle // Collision object for overlap detection.
 	position      Vector         // On-screen position.
 	angle         float64        // Current movement angle (unused but reserved).
-	movement      Vector         // Velocity vector per tick.
+	movement      Vector         // Velocity.
 	isIntelligent bool           // Flag for targeting logic (true = tracks player).
+	orbit         *alienOrbit    // Ring motion for circling formations; nil moves straight.
+	armor         int            // Laser hits it shrugs off before exploding.
+	inArena       bool           // Has been inside the shrinking arena, so leaving it gets it struck.
+	edge          Contact        // What the field's edge did to it on its last move.
 }
 
+// Alien motion tuning.
+const (
+	alienMargin       = 200   // How far past the screen edge an alien flies before it is culled.
+	alienSweepSpread  = 150.0 // Most a sweeping alien's speed exceeds the base velocity.
+	alienHunterSpread = 90.0  // Most a hunter's speed exceeds the base velocity.
+)
+
+// Alien spawn patterns.
+const (
+	alienSweepLeft  = iota // From the right edge, moving left (non-intelligent).
+	alienSweepRight        // From the left edge, moving right (non-intelligent).
+	alienHunter            // From the perimeter toward the player (intelligent).
+	alienTypeCount         // Number of patterns; keep last.
+)
+
 // NewAlien spawns a new alien with randomized type and behavior.
 //
 // There are three spawn patterns:
@@ -31,19 +50,40 @@ type Alien struct {
 //  2. From the left edge, moving right (non-intelligent).
 //  3. From outside the screen in a random direction toward the player (intelligent).
 //
-// Each alien receives a randomized sprite and initial velocity.
+// The run's difficulty sets the chance of the intelligent pattern; the two
+// sweeps share the rest, weighted by the spawn director away from the edge
+// the ship hugs. Each alien receives a randomized sprite
+// and initial velocity.
 func NewAlien(baseVelocity float64, g *GameScene) *Alien {
+	rng := g.rng.Stream(streamSpawns)
+	if rng.Float64() < g.difficulty.AlienHunter {
+		return newAlienOfType(baseVelocity, g, alienHunter)
+	}
+	if g.spawnFromRight(rng.Float64()) {
+		return newAlienOfType(baseVelocity, g, alienSweepLeft)
+	}
+	return newAlienOfType(baseVelocity, g, alienSweepRight)
+}
+
+// newAlienOfType spawns an alien using the given spawn pattern.
+func newAlienOfType(baseVelocity float64, g *GameScene, alienType int) *Alien {
+	return newAlienFrom(baseVelocity, g, alienType, sim.SideAny)
+}
+
+// newAlienFrom spawns an alien using the given spawn pattern; a hunter
+// enters from the side from, or wherever the spawn director picks for
+// SideAny. Sweeps enter from the side their pattern names.
+func newAlienFrom(baseVelocity float64, g *GameScene, alienType int, from sim.Side) *Alien {
 	var alien Alien
-	alienType := rand.Intn(3)
-	sprite := assets.AlienSprites[rand.Intn(len(assets.AlienSprites))]
+	sprite := assets.AlienSprites[g.rng.Stream(streamSpawns).Intn(len(assets.AlienSprites))]
 
 	switch alienType {
-	case 0:
+	case alienSweepLeft:
 		// From right edge, sweeping left across screen.
 		x := float64(ScreenWidth + 100)
-		y := float64(rand.Intn(ScreenHeight-100) + 100)
+		y := float64(g.rng.Stream(streamSpawns).Intn(ScreenHeight-100) + 100)
 		target := Vector{X: 0, Y: y}
-		velocity := baseVelocity + rand.Float64()*2.5
+		velocity := baseVelocity + g.rng.Stream(streamSpawns).Float64()*alienSweepSpread
 
 		alien = Alien{
 			game:          g,
@@ -55,12 +95,12 @@ func NewAlien(baseVelocity float64, g *GameScene) *Alien {
 		}
 		alien.alienObj.SetPosition(x, y)
 
-	case 1:
+	case alienSweepRight:
 		// From left edge, sweeping right across screen.
 		x := -100.0
-		y := float64(rand.Intn(ScreenHeight-100) + 100)
+		y := float64(g.rng.Stream(streamSpawns).Intn(ScreenHeight-100) + 100)
 		target := Vector{X: 0, Y: y}
-		velocity := baseVelocity + rand.Float64()*2.5
+		velocity := baseVelocity + g.rng.Stream(streamSpawns).Float64()*alienSweepSpread
 
 		alien = Alien{
 			game:          g,
@@ -72,10 +112,10 @@ func NewAlien(baseVelocity float64, g *GameScene) *Alien {
 		}
 		alien.alienObj.SetPosition(x, y)
 
-	case 2:
+	case alienHunter:
 		// Intelligent alien: spawns randomly around the perimeter and targets player.
 		center := Vector{X: ScreenWidth / 2, Y: ScreenHeight / 2}
-		angle := rand.Float64() * 2 * math.Pi
+		angle := g.entryAngle(from, g.rng.Stream(streamSpawns).Float64())
 		radius := ScreenWidth / 2.0
 		position := Vector{
 			X: center.X + radius*math.Cos(angle),
@@ -87,8 +127,8 @@ func NewAlien(baseVelocity float64, g *GameScene) *Alien {
 		direction := Vector{X: target.X - position.X, Y: target.Y - position.Y}
 		normalized := direction.Normalize()
 
-		velocity := baseVelocity + rand.Float64()*1.5
-		movement := Vector{X: normalized.X * velocity, Y: normalized.Y * velocity}
+		velocity := baseVelocity + g.rng.Stream(streamSpawns).Float64()*alienHunterSpread
+		movement := normalized.Scale(velocity)
 
 		alien = Alien{
 			game:          g,
@@ -105,12 +145,31 @@ func NewAlien(baseVelocity float64, g *GameScene) *Alien {
 	return &alien
 }
 
-// Update moves the alien each tick according to its movement vector
-// and synchronizes its collision object’s position.
+// Update moves the alien each tick according to its movement vector (or
+// around its ring, in a circling formation) and synchronizes its collision
+// object’s position, then hands it to the field's boundary policy.
 func (a *Alien) Update() {
-	a.position.X += a.movement.X
-	a.position.Y += a.movement.Y
+	if a.orbit != nil {
+		a.orbit.step()
+		a.position = a.orbit.position()
+	} else {
+		sim.Advance(&a.position, a.movement)
+	}
 	a.alienObj.SetPosition(a.position.X, a.position.Y)
+
+	// Aliens fly through the field rather than wrapping; a lethal wall
+	// destroys them and the scene prunes those that fly far outside it.
+	// Circling formations are spared until they first come in.
+	a.edge = a.game.boundary().release(edgeBody{
+		position: &a.position,
+		movement: &a.movement,
+		margin:   alienMargin,
+		entered:  &a.inArena,
+		arriving: a.orbit != nil,
+	})
+	if a.edge == contactStruck {
+		a.sprite = a.game.explosionSmallSprite
+	}
 }
 
 // Draw renders the alien sprite centered at its position.
@@ -124,5 +183,8 @@ func (a *Alien) Draw(screen *ebiten.Image) {
 	op := &ebiten.DrawImageOptions{}
 	op.GeoM.Translate(-halfW, -halfH)
 	op.GeoM.Translate(a.position.X, a.position.Y)
-	screen.DrawImage(a.sprite, op)
+	if a.armor > 0 {
+		op.ColorScale.Scale(1, 0.55, 0.55, 1) // Armored aliens glow red until their armor is gone.
+	}
+	drawSprite(screen, a.sprite, op)
 }
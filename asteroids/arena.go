@@ -0,0 +1,187 @@
+// File arena.go implements the shrinking arena: in modes with ShrinkingArena
+// an energy boundary closes in on the field during every level, nothing
+// wraps at the screen edges, and the boundary destroys whatever it touches.
+// The ship is held inside it and dies against it unless shielded; pickups
+// bounce off it.
+package asteroids
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Arena tuning.
+const (
+	arenaShrinkDelay = 8 * time.Second // Full-size grace period at the start of each level.
+	arenaShrinkSpeed = 9.0             // Speed at which the side walls close in.
+	arenaMinScale    = 0.4             // Smallest arena as a share of the screen size.
+	arenaWallWidth   = 4.0             // Boundary line thickness in pixels.
+	arenaPulseTicks  = 40              // Ticks per cycle of the boundary glow.
+)
+
+// arenaWallColor is the boundary line; arenaOutsideColor tints the field
+// beyond it.
+var (
+	arenaWallColor    = color.RGBA{R: 255, G: 60, B: 200, A: 255}
+	arenaOutsideColor = color.RGBA{R: 40, G: 0, B: 30, A: 120} // Premultiplied.
+)
+
+// ModeArena is the standard ruleset played in a shrinking arena.
+var ModeArena = Mode{
+	Name:           "Arena",
+	Completion:     CompleteOnMeteorsAndAliens,
+	HyperspaceRisk: true,
+	ShrinkingArena: true,
+	LevelUpgrades:  true,
+}
+
+// Arena is the boundary of the playable field, centered on the screen.
+type Arena struct {
+	delay *Timer  // Grace period before the walls move.
+	inset float64 // Distance the side walls have moved in; top and bottom keep the screen's proportions.
+	ticks int     // Ticks since the level began, for the glow.
+}
+
+// NewArena returns a full-size arena with the grace period ahead of it.
+func NewArena() *Arena {
+	return &Arena{delay: sim.NewTimer(arenaShrinkDelay)}
+}
+
+// reset reopens the arena to full size, as at the start of a level.
+func (a *Arena) reset() {
+	a.delay.Reset()
+	a.inset = 0
+	a.ticks = 0
+}
+
+// Update closes the walls in by one tick once the grace period is over.
+func (a *Arena) Update() {
+	a.ticks++
+	a.delay.Update()
+	if a.delay.IsReady() {
+		a.inset = math.Min(a.inset+sim.PerTick(arenaShrinkSpeed), ScreenWidth*(1-arenaMinScale)/2)
+	}
+}
+
+// bounds returns the top-left and bottom-right corners of the arena.
+func (a *Arena) bounds() (Vector, Vector) {
+	inset := Vector{X: a.inset, Y: a.inset * ScreenHeight / ScreenWidth}
+	return inset, Vector{X: ScreenWidth - inset.X, Y: ScreenHeight - inset.Y}
+}
+
+// contains reports whether p lies inside the arena.
+func (a *Arena) contains(p Vector) bool {
+	lo, hi := a.bounds()
+	return p.X >= lo.X && p.X <= hi.X && p.Y >= lo.Y && p.Y <= hi.Y
+}
+
+// approaching reports whether something at p moving by movement is headed
+// for the arena rather than away from it.
+func (a *Arena) approaching(p, movement Vector) bool {
+	toCenter := Vector{X: ScreenWidth/2 - p.X, Y: ScreenHeight/2 - p.Y}
+	return toCenter.X*movement.X+toCenter.Y*movement.Y > 0
+}
+
+// Draw tints the field outside the arena and outlines it with a pulsing wall.
+func (a *Arena) Draw(screen *ebiten.Image) {
+	lo, hi := a.bounds()
+	x0, y0, x1, y1 := float32(lo.X), float32(lo.Y), float32(hi.X), float32(hi.Y)
+
+	// The four bands outside the wall.
+	fillRect(screen, 0, 0, ScreenWidth, y0, arenaOutsideColor, false)
+	fillRect(screen, 0, y1, ScreenWidth, ScreenHeight-y1, arenaOutsideColor, false)
+	fillRect(screen, 0, y0, x0, y1-y0, arenaOutsideColor, false)
+	fillRect(screen, x1, y0, ScreenWidth-x1, y1-y0, arenaOutsideColor, false)
+
+	pulse := 0.6 + 0.4*math.Sin(float64(a.ticks)*2*math.Pi/arenaPulseTicks)
+	glow := color.RGBA{ // Premultiplied, so every channel fades together.
+		R: uint8(float64(arenaWallColor.R) * pulse),
+		G: uint8(float64(arenaWallColor.G) * pulse),
+		B: uint8(float64(arenaWallColor.B) * pulse),
+		A: uint8(float64(arenaWallColor.A) * pulse),
+	}
+	strokeRect(screen, x0, y0, x1-x0, y1-y0, arenaWallWidth, glow, true)
+}
+
+// activeArena returns the arena in force this tick, or nil when the field
+// is unbounded: outside ShrinkingArena modes and during bonus rounds.
+func (g *GameScene) activeArena() *Arena {
+	if g == nil || g.arena == nil || g.isBonusRound() {
+		return nil
+	}
+	return g.arena
+}
+
+// hyperspaceArea returns the corners of the area a jump may put the top-left
+// of sprite in: the whole screen, or, in an active arena, wherever the
+// sprite lands clear of the wall by half its size.
+func (g *GameScene) hyperspaceArea(sprite *ebiten.Image) (Vector, Vector) {
+	a := g.activeArena()
+	if a == nil {
+		return Vector{}, Vector{X: ScreenWidth, Y: ScreenHeight}
+	}
+	lo, hi := a.bounds()
+	w, h := float64(sprite.Bounds().Dx()), float64(sprite.Bounds().Dy())
+	return Vector{X: lo.X + w/2, Y: lo.Y + h/2}, Vector{X: hi.X - w*3/2, Y: hi.Y - h*3/2}
+}
+
+// updateArena closes the walls in; ShrinkingBoundary does the rest as
+// bodies meet them.
+func (g *GameScene) updateArena() {
+	if a := g.activeArena(); a != nil {
+		a.Update()
+	}
+}
+
+// ShrinkingBoundary is the edge of an active arena: a wall that holds the
+// ship and pickups in and destroys what touches it.
+type ShrinkingBoundary struct {
+	arena *Arena // Arena whose wall is the edge.
+}
+
+// confine holds a body that starts inside the arena there, reporting
+// contactStruck while the wall has it pinned. A body that arrives from
+// outside is struck as release describes.
+func (s ShrinkingBoundary) confine(b edgeBody) Contact {
+	if b.entered != nil {
+		return s.strikeOutside(b)
+	}
+	lo, hi := s.arena.bounds()
+	before := *b.position
+	bounce(b, lo, hi, false)
+	if *b.position != before {
+		return contactStruck
+	}
+	return contactNone
+}
+
+// release culls a body that has strayed past its margin and strikes one
+// outside the wall. A body arriving from outside is spared until it has
+// been in and left, and while it is still heading in. The wall scores
+// nothing.
+func (s ShrinkingBoundary) release(b edgeBody) Contact {
+	if contact := cullOffscreen(b); contact != contactNone {
+		return contact
+	}
+	return s.strikeOutside(b)
+}
+
+// strikeOutside reports contactStruck for a body outside the wall, sparing
+// arrivals as release describes, and marks arrivals that have come in.
+func (s ShrinkingBoundary) strikeOutside(b edgeBody) Contact {
+	c := b.center()
+	if s.arena.contains(c) {
+		if b.entered != nil {
+			*b.entered = true
+		}
+		return contactNone
+	}
+	if b.entered != nil && !*b.entered && (b.arriving || s.arena.approaching(c, *b.movement)) {
+		return contactNone
+	}
+	return contactStruck
+}
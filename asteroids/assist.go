@@ -0,0 +1,244 @@
+// File assist.go implements the assist options: toggle fire and toggle
+// thrust (tap to start, tap again to stop), auto-fire, which shoots
+// whenever something is roughly ahead of the ship, aim assist, which
+// bends lasers toward a target just off their line, and the inertia
+// dampener, which brakes the ship whenever it is not thrusting instead of
+// leaving it to drift. Assists are chosen per
+// control profile, and a run that uses any of them is flagged as assisted
+// and kept off the high-score table. Modes with NoAssists ignore them.
+// Older settings files kept a single auto-fire option that toggled fire;
+// it carries over as toggle fire.
+package asteroids
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/bensabler/asteroids/internal/sim"
+)
+
+// Auto-fire tuning.
+const (
+	autoFireRange     = 500.0        // Farthest a target can be to trigger auto-fire.
+	autoFireHalfAngle = math.Pi / 12 // Half-width of the "roughly ahead" cone (15°).
+	autoFireRays      = 5            // Rays cast across the cone.
+)
+
+// autoFireTargets are the colliders auto-fire shoots at.
+var autoFireTargets = TagMeteor | TagAlien | TagBoss | TagComet
+
+// Aim assist tuning.
+const (
+	aimAssistRange     = 400.0        // Farthest a laser looks for a target.
+	aimAssistHalfAngle = math.Pi / 18 // Half-width of a laser's search cone (10°).
+	aimAssistTurn      = math.Pi / 6  // Turn rate at strength 1 (30° a second).
+)
+
+// aimAssistLevels names the aim assist strengths; the index is the strength.
+var aimAssistLevels = []string{"Off", "Low", "Medium", "High"}
+
+// Assists are the assist options of one control profile.
+type Assists struct {
+	ToggleFire      bool `json:"toggleFire"`      // Tap fire to start and stop firing.
+	AutoFire        bool `json:"autoFire"`        // Fire whenever a target is ahead.
+	ToggleThrust    bool `json:"toggleThrust"`    // Tap thrust to start and stop thrusting.
+	AimAssist       int  `json:"aimAssist"`       // Laser steering strength, an index into aimAssistLevels.
+	InertiaDampener bool `json:"inertiaDampener"` // Brake the ship when it is not thrusting.
+}
+
+// active reports whether any assist is on.
+func (a Assists) active() bool {
+	return a.ToggleFire || a.AutoFire || a.ToggleThrust || a.AimAssist > 0 || a.InertiaDampener
+}
+
+// adoptLegacyAutoFire carries over the "autoFire" option of older settings
+// files, which latched fire on a tap the way ToggleFire does now, to every
+// control profile that has no assists of its own yet. Config does not write
+// the old key back, so this happens once.
+func (c *Config) adoptLegacyAutoFire(data []byte) {
+	var legacy struct {
+		AutoFire bool `json:"autoFire"`
+	}
+	if json.Unmarshal(data, &legacy) != nil || !legacy.AutoFire {
+		return
+	}
+	if c.Assists == nil {
+		c.Assists = map[string]Assists{}
+	}
+	profiles := []string{customPresetName}
+	for _, p := range controlPresets {
+		profiles = append(profiles, p.Name)
+	}
+	for _, profile := range profiles {
+		if _, ok := c.Assists[profile]; !ok {
+			c.Assists[profile] = Assists{ToggleFire: true}
+		}
+	}
+}
+
+// currentAssists returns the assists of the active control profile.
+func currentAssists() Assists {
+	return config.Assists[controlPresetName(config.KeyBindings)]
+}
+
+// assistRow returns an On/Off row editing one assist of the active control
+// profile; field selects which.
+func assistRow(label string, field func(*Assists) *bool) settingsRow {
+	return settingsRow{
+		label: label,
+		value: func() string {
+			a := currentAssists()
+			return onOff(*field(&a))
+		},
+		adjust: func(int) {
+			if config.Assists == nil {
+				config.Assists = map[string]Assists{}
+			}
+			profile := controlPresetName(config.KeyBindings)
+			a := config.Assists[profile]
+			*field(&a) = !*field(&a)
+			config.Assists[profile] = a
+		},
+	}
+}
+
+// aimAssistRow returns the row choosing the active control profile's aim
+// assist strength.
+func aimAssistRow() settingsRow {
+	return settingsRow{
+		label: "Aim Assist",
+		value: func() string {
+			return aimAssistLevels[max(0, min(len(aimAssistLevels)-1, currentAssists().AimAssist))]
+		},
+		adjust: func(step int) {
+			if config.Assists == nil {
+				config.Assists = map[string]Assists{}
+			}
+			profile := controlPresetName(config.KeyBindings)
+			a := config.Assists[profile]
+			a.AimAssist = max(0, min(len(aimAssistLevels)-1, a.AimAssist+step))
+			config.Assists[profile] = a
+		},
+	}
+}
+
+// applyAssists replaces g.input with a copy in which the assists have
+// pressed fire and thrust as needed, so the rest of the tick reads held
+// and just-pressed state the usual way.
+func (g *GameScene) applyAssists() {
+	a := g.rules.assists
+	if !a.active() {
+		g.fireLatched, g.thrustLatched = false, false
+		return
+	}
+	g.assisted = true
+
+	raw := g.input
+	if a.ToggleFire && raw.IsJustPressed(ActionFire) {
+		g.fireLatched = !g.fireLatched
+	}
+	if a.ToggleThrust && raw.IsJustPressed(ActionThrust) {
+		g.thrustLatched = !g.thrustLatched
+	}
+	g.fireLatched = g.fireLatched && a.ToggleFire
+	g.thrustLatched = g.thrustLatched && a.ToggleThrust
+
+	// Assisted actions remember their own previous state; the rest follow the keys.
+	previous := g.assistInput.pressed
+	g.assistInput = *raw
+	in := &g.assistInput
+	in.previous[ActionFire] = previous[ActionFire]
+	in.previous[ActionThrust] = previous[ActionThrust]
+
+	in.pressed[ActionFire] = raw.pressed[ActionFire] || g.fireLatched || (a.AutoFire && g.targetAhead())
+	if a.ToggleThrust {
+		in.pressed[ActionThrust] = g.thrustLatched
+	}
+	g.input = in
+}
+
+// targetAhead reports whether a meteor, alien, boss, or comet lies within
+// the auto-fire cone in front of the ship.
+func (g *GameScene) targetAhead() bool {
+	if g.player.isDying {
+		return false
+	}
+	origin := spriteCenter(g.player.position, g.player.sprite)
+	for i := 0; i < autoFireRays; i++ {
+		angle := g.player.rotation - autoFireHalfAngle + 2*autoFireHalfAngle*float64(i)/float64(autoFireRays-1)
+		if _, ok := g.Raycast(origin, shipHeading(angle), autoFireRange, autoFireTargets); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// steerLasers turns each aim-assisted laser toward the nearest target inside
+// its search cone, by at most the laser's turn rate. Targets are gathered
+// once per tick, in a fixed order so replays steer identically.
+func (g *GameScene) steerLasers() {
+	var targets []Vector
+	gathered := false
+	for _, l := range inOrder(g.lasers) {
+		if l.steer == 0 {
+			continue
+		}
+		if !gathered {
+			targets, gathered = g.aimTargets(), true
+		}
+
+		origin := spriteCenter(l.position, l.sprite)
+		best, bestDistance := 0.0, math.Inf(1)
+		for _, t := range targets {
+			d := distance(origin, t)
+			off := angleBetween(l.rotation, math.Atan2(t.X-origin.X, origin.Y-t.Y))
+			if d < bestDistance && d <= aimAssistRange && math.Abs(off) <= aimAssistHalfAngle {
+				best, bestDistance = off, d
+			}
+		}
+		if !math.IsInf(bestDistance, 1) {
+			turn := sim.PerTick(l.steer)
+			l.rotation += max(-turn, min(turn, best))
+		}
+	}
+}
+
+// aimTargets returns the centers of everything aim assist can steer toward:
+// intact meteors and aliens, the comet, and the boss's weak points.
+func (g *GameScene) aimTargets() []Vector {
+	var targets []Vector
+	for _, m := range inOrder(g.meteors) {
+		if !g.isExploding(m) {
+			targets = append(targets, spriteCenter(m.position, m.sprite))
+		}
+	}
+	for _, a := range inOrder(g.aliens) {
+		if a.sprite != g.explosionSmallSprite {
+			targets = append(targets, a.position)
+		}
+	}
+	if g.comet != nil && !g.comet.spent {
+		targets = append(targets, g.comet.position)
+	}
+	if g.boss != nil {
+		for _, wp := range g.boss.weakPoints {
+			if wp.health > 0 {
+				p := wp.obj.Position()
+				targets = append(targets, Vector{X: p.X, Y: p.Y})
+			}
+		}
+	}
+	return targets
+}
+
+// angleBetween returns the signed turn from rotation from to rotation to,
+// in (-π, π].
+func angleBetween(from, to float64) float64 {
+	d := math.Mod(to-from, 2*math.Pi)
+	if d > math.Pi {
+		d -= 2 * math.Pi
+	} else if d <= -math.Pi {
+		d += 2 * math.Pi
+	}
+	return d
+}
@@ -0,0 +1,37 @@
+// File assist_test.go checks that the auto-fire option of older settings
+// files reaches every control profile's assists without overriding any a
+// profile already has.
+package asteroids
+
+import "testing"
+
+func TestAdoptLegacyAutoFire(t *testing.T) {
+	c := DefaultConfig()
+	c.Assists = map[string]Assists{"Numpad": {AimAssist: 2}}
+	c.adoptLegacyAutoFire([]byte(`{"autoFire": true, "sfxVolume": 0.5}`))
+
+	for _, p := range controlPresets {
+		got := c.Assists[p.Name]
+		if p.Name == "Numpad" {
+			if got != (Assists{AimAssist: 2}) {
+				t.Errorf("Numpad's own assists became %+v", got)
+			}
+			continue
+		}
+		if got != (Assists{ToggleFire: true}) {
+			t.Errorf("%s assists = %+v, want toggle fire", p.Name, got)
+		}
+	}
+	if !c.Assists[customPresetName].ToggleFire {
+		t.Error("custom bindings did not get toggle fire")
+	}
+
+	// Files without the option, or with it off, leave the assists alone.
+	for _, data := range []string{`{}`, `{"autoFire": false}`, `not json`} {
+		c := DefaultConfig()
+		c.adoptLegacyAutoFire([]byte(data))
+		if c.Assists != nil {
+			t.Errorf("%s: assists = %v, want none", data, c.Assists)
+		}
+	}
+}
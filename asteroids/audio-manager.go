@@ -0,0 +1,170 @@
+// File audio-manager.go defines the AudioManager, which owns the shared
+// audio.Context and keeps every player's volume in step with the master,
+// music, and sound-effect levels. Players are never released, so the
+// looping ones each scene needs are made once per process and shared.
+package asteroids
+
+import (
+	"io"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+// audioSampleRate is the sample rate of the shared audio context.
+const audioSampleRate = 48000
+
+// soundCategory selects which volume channel scales a player.
+type soundCategory int
+
+// Volume channels below the master level.
+const (
+	soundSFX   soundCategory = iota // Sound effects (lasers, explosions, beats).
+	soundMusic                      // Background music.
+)
+
+// managedPlayer is a player registered with the AudioManager.
+type managedPlayer struct {
+	*audio.Player               // Underlying Ebiten player.
+	category      soundCategory // Channel that scales this player.
+	gain          float64       // Per-sound level relative to its channel (0–1).
+	ducked        bool          // Lowered while the mix is ducked (see duck).
+}
+
+// AudioManager creates audio players and applies volume levels to them.
+//
+// Ebiten allows only one audio.Context per process, so there is a single
+// shared manager (see sharedAudio). Setters re-apply volumes to every
+// registered player immediately, which lets the settings scene adjust
+// levels live.
+type AudioManager struct {
+	context *audio.Context        // The process-wide audio context.
+	master  float64               // 0–1, scales every channel.
+	music   float64               // 0–1, scales music players.
+	sfx     float64               // 0–1, scales sound-effect players.
+	ducking float64               // 0–1, scales ducked players; 1 when not ducked.
+	players []*managedPlayer      // Every player created through the manager.
+	voices  map[string]*voicePool // Sound-effect voice pools by name, created on first play (see PlaySFX).
+	loops   map[string]any        // Looping players and their streams by name, created on first use (see sharedLoop).
+	muted   bool                  // Ignore requests to play, while a muted scene updates (see whileMuted).
+}
+
+// audioManager is the lazily created process-wide AudioManager.
+var audioManager *AudioManager
+
+// sharedAudio returns the process-wide AudioManager, creating the audio
+// context on first use with volumes taken from the config.
+func sharedAudio() *AudioManager {
+	if audioManager == nil {
+		audioManager = &AudioManager{
+			context: audio.NewContext(audioSampleRate),
+			master:  config.MasterVolume,
+			music:   config.MusicVolume,
+			sfx:     config.SFXVolume,
+			ducking: 1,
+		}
+	}
+	return audioManager
+}
+
+// NewSFXPlayer returns a sound-effect player for src at the given relative gain.
+//
+// Panics on error, matching the fail-fast asset loading elsewhere.
+func (a *AudioManager) NewSFXPlayer(src io.Reader, gain float64) *audio.Player {
+	return a.newPlayer(src, soundSFX, gain).Player
+}
+
+// newPlayer creates, registers, and levels a player in the given channel,
+// returning the registration for callers that adjust it later.
+func (a *AudioManager) newPlayer(src io.Reader, category soundCategory, gain float64) *managedPlayer {
+	p, err := a.context.NewPlayer(src)
+	if err != nil {
+		panic(err)
+	}
+	mp := &managedPlayer{Player: p, category: category, gain: gain, ducked: category == soundMusic}
+	a.players = append(a.players, mp)
+	mp.SetVolume(a.volumeFor(mp))
+	return mp
+}
+
+// sharedLoop returns what create made under name, calling it only the first
+// time. A gameplay scene is built for every run, so the players it loops
+// are made once per process this way, as the voice pools are, instead of a
+// new set registering with the manager for every run.
+func sharedLoop[T any](a *AudioManager, name string, create func() T) T {
+	if v, ok := a.loops[name]; ok {
+		return v.(T)
+	}
+	v := create()
+	if a.loops == nil {
+		a.loops = make(map[string]any)
+	}
+	a.loops[name] = v
+	return v
+}
+
+// whileMuted runs f with every request to play ignored, so a scene can run
+// silently, such as the replay behind the title menu.
+func (a *AudioManager) whileMuted(f func()) {
+	defer func(muted bool) { a.muted = muted }(a.muted)
+	a.muted = true
+	f()
+}
+
+// play starts p unless the mix is muted.
+func (a *AudioManager) play(p *audio.Player) {
+	if !a.muted {
+		p.Play()
+	}
+}
+
+// setGain changes a registered player's relative gain (clamped to 0–1),
+// e.g. to fade it, and applies the result.
+func (a *AudioManager) setGain(mp *managedPlayer, gain float64) {
+	mp.gain = clamp01(gain)
+	mp.SetVolume(a.volumeFor(mp))
+}
+
+// setDucked marks a registered sound-effect player to be lowered while the
+// mix is ducked. Music is always ducked.
+func (a *AudioManager) setDucked(mp *managedPlayer) {
+	mp.ducked = true
+	mp.SetVolume(a.volumeFor(mp))
+}
+
+// SetMasterVolume sets the master level (clamped to 0–1) and applies it.
+func (a *AudioManager) SetMasterVolume(v float64) {
+	a.master = clamp01(v)
+	a.apply()
+}
+
+// SetMusicVolume sets the music level (clamped to 0–1) and applies it.
+func (a *AudioManager) SetMusicVolume(v float64) {
+	a.music = clamp01(v)
+	a.apply()
+}
+
+// SetSFXVolume sets the sound-effect level (clamped to 0–1) and applies it.
+func (a *AudioManager) SetSFXVolume(v float64) {
+	a.sfx = clamp01(v)
+	a.apply()
+}
+
+// apply pushes the current levels to every registered player.
+func (a *AudioManager) apply() {
+	for _, mp := range a.players {
+		mp.SetVolume(a.volumeFor(mp))
+	}
+}
+
+// volumeFor returns the effective volume of a registered player.
+func (a *AudioManager) volumeFor(mp *managedPlayer) float64 {
+	channel := a.sfx
+	if mp.category == soundMusic {
+		channel = a.music
+	}
+	v := a.master * channel * mp.gain
+	if mp.ducked {
+		v *= a.ducking
+	}
+	return v
+}
@@ -0,0 +1,20 @@
+// File audio-manager_test.go checks that gameplay scenes share their
+// looping players instead of registering new ones for every run.
+package asteroids
+
+import "testing"
+
+func TestScenesShareLoopingPlayers(t *testing.T) {
+	first := newGameScene(ModeStandard, 1, Upgrades{}, difficulties[0], shipClasses[0])
+	registered := len(sharedAudio().players)
+	for seed := range int64(5) {
+		g := newGameScene(ModeStandard, seed, Upgrades{}, difficulties[0], shipClasses[0])
+		if g.thrustPlayer != first.thrustPlayer || g.music.player != first.music.player ||
+			g.alienHum.player != first.alienHum.player || g.cometWhoosh.player != first.cometWhoosh.player {
+			t.Fatal("a new scene made its own looping players")
+		}
+	}
+	if got := len(sharedAudio().players); got != registered {
+		t.Errorf("%d players registered after five more scenes, want %d", got, registered)
+	}
+}
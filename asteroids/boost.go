@@ -0,0 +1,101 @@
+// File boost.go implements the afterburner: a short forward speed burst
+// triggered by the boost action or a double-tap of thrust, paid for with
+// energy under energy handling or a cooldown otherwise.
+package asteroids
+
+import (
+	"math"
+	"time"
+
+	"github.com/bensabler/asteroids/internal/sim"
+)
+
+// Afterburner tuning.
+const (
+	boostDuration       = 350 * time.Millisecond // Length of one burst.
+	boostCooldown       = 4 * time.Second        // Gap between bursts without energy handling.
+	boostSpeed          = 840.0                  // Speed during a burst.
+	boostDriftVelocity  = 300.0                  // Speed the ship coasts on at once a burst ends.
+	boostDoubleTapTime  = 250 * time.Millisecond // Max gap between taps of thrust.
+	boostCameraKick     = 10.0                   // Camera displacement at burst start.
+	boostExhaustStretch = 2.5                    // Exhaust length multiplier while boosting.
+)
+
+// updateBoost triggers a burst on request and advances an active one.
+//
+// While a burst runs the ship moves at boostSpeed along the angle it had
+// when the burst began, free of friction, so the burst is never eaten by
+// deceleration. When it ends the ship coasts on at boostDriftVelocity.
+func (p *Player) updateBoost() {
+	if p.boostCooldownTimer != nil {
+		p.boostCooldownTimer.Update()
+	}
+	p.thrustTapTicks++
+	if p.boostRequested() && p.boostTimer == nil && p.takeBoostCharge() {
+		p.startBoost()
+	}
+
+	if p.boostTimer == nil {
+		return
+	}
+	p.boostTimer.Update()
+	p.velocity = shipHeading(p.boostAngle).Scale(boostSpeed)
+
+	// Long exhaust trail behind the ship.
+	bounds := p.sprite.Bounds()
+	spawnPosition := Vector{
+		X: p.position.X + float64(bounds.Dx()/2) + math.Sin(p.boostAngle)*exhaustSpawnOffset*boostExhaustStretch,
+		Y: p.position.Y + float64(bounds.Dy()/2) + math.Cos(p.boostAngle)*-exhaustSpawnOffset*boostExhaustStretch,
+	}
+	p.exhaust = NewExhaust(spawnPosition, p.boostAngle+math.Pi)
+	p.exhaust.stretch = boostExhaustStretch
+
+	if p.boostTimer.IsReady() {
+		p.boostTimer = nil
+		p.exhaust = nil
+		p.velocity = shipHeading(p.boostAngle).Scale(boostDriftVelocity)
+	}
+}
+
+// boostRequested reports a press of the boost action or a double-tap of thrust.
+func (p *Player) boostRequested() bool {
+	if p.input().IsJustPressed(ActionBoost) {
+		return true
+	}
+	if !p.input().IsJustPressed(ActionThrust) {
+		return false
+	}
+	doubleTap := p.thrustTapTicks <= sim.Ticks(boostDoubleTapTime)
+	p.thrustTapTicks = 0
+	return doubleTap
+}
+
+// takeBoostCharge pays for a burst: energy under energy handling, otherwise
+// the cooldown must have elapsed. It reports false, spending nothing, if the
+// cost cannot be met.
+func (p *Player) takeBoostCharge() bool {
+	if p.energy != nil {
+		return p.energy.spend(boostEnergyCost)
+	}
+	if p.boostCooldownTimer != nil && !p.boostCooldownTimer.IsReady() {
+		return false
+	}
+	p.boostCooldownTimer = sim.NewTimer(boostCooldown)
+	return true
+}
+
+// startBoost begins a burst along the current facing and kicks the camera
+// back against the direction of travel.
+func (p *Player) startBoost() {
+	p.boostTimer = sim.NewTimer(boostDuration)
+	p.boostAngle = p.rotation
+	p.game.kickCamera(Vector{
+		X: -math.Sin(p.boostAngle) * boostCameraKick,
+		Y: math.Cos(p.boostAngle) * boostCameraKick,
+	})
+}
+
+// isBoosting reports whether an afterburner burst is in progress.
+func (p *Player) isBoosting() bool {
+	return p.boostTimer != nil
+}
@@ -0,0 +1,255 @@
+// File boss.go defines the boss meteor fought on boss levels: a giant body
+// ringed by destructible weak points, its collision handlers, and its HUD
+// health bar.
+package asteroids
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/solarlune/resolv"
+)
+
+// Boss tuning.
+const (
+	bossScale             = 3.0   // Boss sprite scale relative to a large meteor.
+	bossWeakPoints        = 4     // Weak points spaced evenly around the body.
+	bossWeakPointHealth   = 5     // Laser hits needed to destroy one weak point.
+	bossWeakPointRadius   = 18.0  // Collider and marker radius of a weak point.
+	bossOrbitRadius       = 180.0 // Distance from screen center once in position.
+	bossEntryRadius       = 900.0 // Distance from screen center at spawn.
+	bossEntrySpeed        = 120.0 // Speed while closing in.
+	bossOrbitSpeed        = 0.18  // Radians per second around screen center.
+	bossSpinSpeed         = 0.24  // Radians per second of body rotation.
+	bossSpawnsPerWeakSpot = 2     // Large meteors released when a weak point breaks.
+	bossWeakPointPoints   = 25    // Score for breaking a weak point.
+	bossDefeatPoints      = 200   // Score for breaking the last weak point.
+	bossHealthBarWidth    = 300.0 // HUD bar width in pixels.
+	bossHealthBarHeight   = 8.0   // HUD bar height in pixels.
+	bossHealthBarY        = 110.0 // HUD bar top edge, under the high score.
+)
+
+// WeakPoint is one destructible spot on the boss.
+type WeakPoint struct {
+	offset Vector         // Position relative to the boss center before rotation.
+	health int            // Hits remaining; 0 once destroyed.
+	obj    *resolv.Circle // Collider, centered on the weak point.
+}
+
+// Boss is a giant meteor that closes in on the field and orbits it until
+// every weak point has been destroyed.
+type Boss struct {
+	game            *GameScene     // Owning scene.
+	center          Vector         // Body center in world space.
+	orbitAngle      float64        // Angle around screen center.
+	orbitDist       float64        // Current distance from screen center.
+	rotation        float64        // Body rotation (weak points turn with it).
+	sprite          *ebiten.Image  // Large-meteor sprite, drawn scaled up.
+	bodyObj         *resolv.Circle // Body collider: absorbs lasers, destroys the ship.
+	weakPoints      []*WeakPoint   // Destructible spots, in ring order.
+	weakPointHealth int            // Hits each weak point starts with.
+}
+
+// NewBoss constructs a boss off-screen at a random angle around the center.
+func NewBoss(game *GameScene) *Boss {
+	sprite := assets.MeteorSprites[0]
+	bodyRadius := float64(sprite.Bounds().Dx()) / 2 * bossScale
+
+	b := &Boss{
+		game:            game,
+		weakPointHealth: game.mode.bossWeakPointHealth(),
+		orbitAngle:      game.rng.Stream(streamSpawns).Float64() * 2 * math.Pi,
+		orbitDist:       bossEntryRadius,
+		sprite:          sprite,
+		bodyObj:         resolv.NewCircle(0, 0, bodyRadius*0.8),
+	}
+	b.bodyObj.Tags().Set(TagBoss)
+
+	for i := 0; i < bossWeakPoints; i++ {
+		angle := float64(i) * 2 * math.Pi / bossWeakPoints
+		wp := &WeakPoint{
+			offset: Vector{X: math.Cos(angle) * bodyRadius * 0.85, Y: math.Sin(angle) * bodyRadius * 0.85},
+			health: b.weakPointHealth,
+			obj:    resolv.NewCircle(0, 0, bossWeakPointRadius),
+		}
+		wp.obj.Tags().Set(TagBoss | TagWeak)
+		wp.obj.SetData(&ObjectData{index: i})
+		b.weakPoints = append(b.weakPoints, wp)
+	}
+
+	b.place()
+	return b
+}
+
+// shapes returns every collider the boss registers in the space.
+func (b *Boss) shapes() []resolv.IShape {
+	shapes := []resolv.IShape{b.bodyObj}
+	for _, wp := range b.weakPoints {
+		shapes = append(shapes, wp.obj)
+	}
+	return shapes
+}
+
+// Update closes in toward the orbit, advances the orbit and spin, and
+// moves the colliders along.
+func (b *Boss) Update() {
+	b.orbitDist = math.Max(bossOrbitRadius, b.orbitDist-sim.PerTick(bossEntrySpeed))
+	b.orbitAngle += sim.PerTick(bossOrbitSpeed)
+	b.rotation += sim.PerTick(bossSpinSpeed)
+	b.place()
+}
+
+// place derives the body and weak-point positions from the orbit state.
+func (b *Boss) place() {
+	b.center = Vector{
+		X: ScreenWidth/2 + math.Cos(b.orbitAngle)*b.orbitDist,
+		Y: ScreenHeight/2 + math.Sin(b.orbitAngle)*b.orbitDist,
+	}
+	b.bodyObj.SetPosition(b.center.X, b.center.Y)
+	for _, wp := range b.weakPoints {
+		p := b.weakPointPosition(wp)
+		wp.obj.SetPosition(p.X, p.Y)
+	}
+}
+
+// weakPointPosition returns a weak point's current world position.
+func (b *Boss) weakPointPosition(wp *WeakPoint) Vector {
+	sin, cos := math.Sincos(b.rotation)
+	return Vector{
+		X: b.center.X + wp.offset.X*cos - wp.offset.Y*sin,
+		Y: b.center.Y + wp.offset.X*sin + wp.offset.Y*cos,
+	}
+}
+
+// healthFraction returns the share of total weak-point health remaining.
+func (b *Boss) healthFraction() float64 {
+	total := 0
+	for _, wp := range b.weakPoints {
+		total += wp.health
+	}
+	return float64(total) / float64(bossWeakPoints*b.weakPointHealth)
+}
+
+// isDefeated reports whether every weak point has been destroyed.
+func (b *Boss) isDefeated() bool {
+	for _, wp := range b.weakPoints {
+		if wp.health > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Draw renders the scaled, rotating body and the surviving weak points.
+func (b *Boss) Draw(screen *ebiten.Image) {
+	bounds := b.sprite.Bounds()
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(-float64(bounds.Dx())/2, -float64(bounds.Dy())/2)
+	op.GeoM.Scale(bossScale, bossScale)
+	op.GeoM.Rotate(b.rotation)
+	op.GeoM.Translate(b.center.X, b.center.Y)
+	drawSprite(screen, b.sprite, op)
+
+	for _, wp := range b.weakPoints {
+		if wp.health == 0 {
+			continue
+		}
+		p := b.weakPointPosition(wp)
+		glow := uint8(120 + 135*wp.health/b.weakPointHealth)
+		fillCircle(screen, float32(p.X), float32(p.Y), bossWeakPointRadius*0.6, color.RGBA{R: glow, A: 255}, true)
+		strokeCircle(screen, float32(p.X), float32(p.Y), bossWeakPointRadius, 2, color.RGBA{R: 255, G: 80, B: 80, A: 255}, true)
+	}
+}
+
+// DrawHealthBar renders the boss's remaining health centered under the
+// scores, with one segment per weak point.
+func (b *Boss) DrawHealthBar(screen *ebiten.Image) {
+	x := float32(ScreenWidth-bossHealthBarWidth) / 2
+	drawSegmentedBar(screen, x, bossHealthBarY, bossHealthBarWidth, bossHealthBarHeight, bossWeakPoints, b.healthFraction(), currentPalette().Warning, color.Gray{Y: 60})
+}
+
+// spawnBoss brings in the level's boss once per boss level.
+func (g *GameScene) spawnBoss() {
+	if g.boss != nil || !g.waves.IsBossStanding() {
+		return
+	}
+	g.boss = NewBoss(g)
+	for _, shape := range g.boss.shapes() {
+		g.space.Add(shape)
+	}
+}
+
+// updateBoss advances the boss, if any.
+func (g *GameScene) updateBoss() {
+	if g.boss != nil {
+		g.boss.Update()
+	}
+}
+
+// isBossHitByPlayerLaser damages weak points and lets the body soak up
+// every other laser. Breaking a weak point releases large meteors; breaking
+// the last one defeats the boss.
+func (g *GameScene) isBossHitByPlayerLaser() {
+	if g.boss == nil {
+		return
+	}
+	for i, laser := range inOrder(g.lasers) {
+		hit := false
+		for _, wp := range g.boss.weakPoints {
+			if wp.health == 0 || !g.collisions.intersects(wp.obj, laser.laserObj) {
+				continue
+			}
+			hit = true
+			wp.health--
+			if wp.health == 0 {
+				g.breakWeakPoint(wp)
+			}
+			break
+		}
+		if hit || g.collisions.intersects(g.boss.bodyObj, laser.laserObj) {
+			g.collisions.consume(laser.laserObj)
+			g.laserHit(i)
+		}
+		if g.boss == nil {
+			return // The last weak point just broke.
+		}
+	}
+}
+
+// breakWeakPoint scores a destroyed weak point, releases meteors from it,
+// and defeats the boss if it was the last one.
+func (g *GameScene) breakWeakPoint(wp *WeakPoint) {
+	g.space.Remove(wp.obj)
+	origin := g.boss.weakPointPosition(wp)
+	g.scoreKill(bossWeakPointPoints, origin)
+	sharedAudio().PlaySFXAt(sfxExplosion, origin.X)
+
+	for i := 0; i < bossSpawnsPerWeakSpot; i++ {
+		m := NewMeteor(g.baseVelocity, g, g.meteorCount+1)
+		m.position = origin
+		m.meteorObj.SetPosition(origin.X, origin.Y)
+		g.addMeteor(m)
+		g.waves.TrackSplit()
+	}
+
+	if g.boss.isDefeated() {
+		g.space.Remove(g.boss.bodyObj)
+		g.boss = nil
+		g.score += bossDefeatPoints
+		g.waves.TrackBossDefeated()
+	}
+}
+
+// isPlayerCollidingWithBoss destroys an unshielded ship that touches the body.
+func (g *GameScene) isPlayerCollidingWithBoss() {
+	if g.boss == nil || g.player.isShielded || g.player.isDying {
+		return
+	}
+	if g.collisions.intersects(g.boss.bodyObj, g.player.playerObj) {
+		g.player.isDying = true
+		sharedAudio().PlaySFXAt(sfxExplosion, spriteCenter(g.player.position, g.player.sprite).X)
+	}
+}
@@ -0,0 +1,244 @@
+// File boundary.go defines BoundaryPolicy, which decides what every body
+// does at the edge of the field, and the screen-edge policies: wrapping to
+// the opposite edge (the classic rule), solid walls that bounce bodies back
+// with a burst of sparks and a thud, and culling bodies that stream off.
+// The shrinking arena's policy lives in arena.go.
+package asteroids
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Wall spark tuning.
+const (
+	wallSparkCount = 10    // Sparks thrown per impact.
+	wallSparkSpeed = 180.0 // Top speed of a spark.
+	wallSparkLife  = 20    // Ticks a spark lasts.
+)
+
+// ModeWalls is the standard ruleset with solid screen edges.
+var ModeWalls = Mode{
+	Name:           "Walls",
+	Completion:     CompleteOnMeteorsAndAliens,
+	HyperspaceRisk: true,
+	SolidWalls:     true,
+	LevelUpgrades:  true,
+}
+
+// Contact is what the field's edge did to a body this tick.
+type Contact int
+
+// Edge contacts.
+const (
+	contactNone    Contact = iota // In the field, or carried across it.
+	contactBounced                // Turned back by a solid wall.
+	contactStruck                 // Touched a lethal wall.
+	contactCulled                 // Left the field for good.
+)
+
+// edgeBody is what a BoundaryPolicy needs to know about one body.
+type edgeBody struct {
+	position *Vector // Top-left of the sprite, or the body itself if size is zero; confine may move it.
+	movement *Vector // Velocity; confine may turn it back.
+	size     Vector  // Sprite size; zero for bodies positioned by their center.
+	margin   float64 // How far past the screen edge the body may stray before it is culled.
+	entered  *bool   // For bodies that arrive from outside the field: set once they are in it. Nil for bodies that start inside.
+	arriving bool    // Spared while outside the field and not yet entered, whatever its heading.
+}
+
+// center returns the middle of the body.
+func (b edgeBody) center() Vector {
+	return Vector{X: b.position.X + b.size.X/2, Y: b.position.Y + b.size.Y/2}
+}
+
+// BoundaryPolicy is what happens to a body that reaches the edge of the
+// field. Entities hand themselves to the scene's policy rather than
+// handling edges themselves, so a new kind of field is a new policy.
+type BoundaryPolicy interface {
+	// confine applies the policy to a body that stays in play: the ship,
+	// meteors, and pickups.
+	confine(b edgeBody) Contact
+
+	// release applies the policy to a body that only passes through the
+	// field, such as an alien or a laser. It never moves the body.
+	release(b edgeBody) Contact
+}
+
+// WrapBoundary carries a body that leaves one edge in at the opposite one.
+type WrapBoundary struct{}
+
+// confine wraps the body's position at the screen edges.
+func (WrapBoundary) confine(b edgeBody) Contact {
+	if b.position.X >= float64(ScreenWidth) {
+		b.position.X = 0
+	} else if b.position.X < 0 {
+		b.position.X = float64(ScreenWidth)
+	}
+	if b.position.Y >= float64(ScreenHeight) {
+		b.position.Y = 0
+	} else if b.position.Y < 0 {
+		b.position.Y = float64(ScreenHeight)
+	}
+	return contactNone
+}
+
+// release culls bodies that have strayed past the margin.
+func (WrapBoundary) release(b edgeBody) Contact {
+	return cullOffscreen(b)
+}
+
+// BounceBoundary makes the screen edges solid walls.
+type BounceBoundary struct{}
+
+// confine holds the whole sprite on screen and turns movement back from any
+// edge it is heading out through. A body past an edge but moving inward is
+// left to come in on its own, so meteors drift in from their spawn ring; one
+// at rest against an edge, like a thrusting ship, is held without bouncing.
+func (BounceBoundary) confine(b edgeBody) Contact {
+	lo, hi := Vector{}, Vector{X: ScreenWidth, Y: ScreenHeight}
+	if bounce(b, lo, hi, true) {
+		return contactBounced
+	}
+	return contactNone
+}
+
+// release culls bodies that have strayed past the margin.
+func (BounceBoundary) release(b edgeBody) Contact {
+	return cullOffscreen(b)
+}
+
+// CullBoundary lets bodies leave and culls them once they are past the
+// margin, as gold meteors stream off the far edge.
+type CullBoundary struct{}
+
+// confine culls bodies that have strayed past the margin.
+func (CullBoundary) confine(b edgeBody) Contact {
+	return cullOffscreen(b)
+}
+
+// release culls bodies that have strayed past the margin.
+func (CullBoundary) release(b edgeBody) Contact {
+	return cullOffscreen(b)
+}
+
+// cullOffscreen reports contactCulled for a body more than its margin
+// outside the screen.
+func cullOffscreen(b edgeBody) Contact {
+	if isOffscreen(*b.position, b.margin) {
+		return contactCulled
+	}
+	return contactNone
+}
+
+// bounce holds the sprite of b inside the box from lo to hi, pointing its
+// movement away from any side it has reached, and reports whether it was
+// heading out. With inward set, a body outside the box but heading in is
+// left to come in on its own.
+func bounce(b edgeBody, lo, hi Vector, inward bool) bool {
+	hit := false
+	if b.position.X < lo.X && (!inward || b.movement.X <= 0) {
+		b.position.X = lo.X
+		hit = hit || b.movement.X < 0
+		b.movement.X = math.Abs(b.movement.X)
+	} else if b.position.X+b.size.X > hi.X && (!inward || b.movement.X >= 0) {
+		b.position.X = hi.X - b.size.X
+		hit = hit || b.movement.X > 0
+		b.movement.X = -math.Abs(b.movement.X)
+	}
+	if b.position.Y < lo.Y && (!inward || b.movement.Y <= 0) {
+		b.position.Y = lo.Y
+		hit = hit || b.movement.Y < 0
+		b.movement.Y = math.Abs(b.movement.Y)
+	} else if b.position.Y+b.size.Y > hi.Y && (!inward || b.movement.Y >= 0) {
+		b.position.Y = hi.Y - b.size.Y
+		hit = hit || b.movement.Y > 0
+		b.movement.Y = -math.Abs(b.movement.Y)
+	}
+	return hit
+}
+
+// spriteSize returns the width and height of sprite.
+func spriteSize(sprite *ebiten.Image) Vector {
+	b := sprite.Bounds()
+	return Vector{X: float64(b.Dx()), Y: float64(b.Dy())}
+}
+
+// boundary returns the policy at the edge of the field this tick. Bodies
+// with no scene, such as menu backdrops, wrap.
+func (g *GameScene) boundary() BoundaryPolicy {
+	switch {
+	case g == nil:
+		return WrapBoundary{}
+	case g.activeArena() != nil:
+		return ShrinkingBoundary{arena: g.arena}
+	case g.mode.SolidWalls:
+		return BounceBoundary{}
+	}
+	return WrapBoundary{}
+}
+
+// wallSpark is one glowing fleck thrown off by a wall impact.
+type wallSpark struct {
+	position Vector // World-space position.
+	movement Vector // Drift velocity.
+	life     int    // Ticks remaining.
+}
+
+// wallImpact throws sparks and plays a thud where a body bounced off a wall
+// with its center at center.
+func (g *GameScene) wallImpact(center Vector) {
+	at := nearestEdgePoint(center)
+	rng := g.rng.Stream(streamCosmetics)
+	for range particleCount(wallSparkCount) {
+		angle := rng.Float64() * 2 * math.Pi
+		speed := wallSparkSpeed * (0.3 + 0.7*rng.Float64())
+		g.sparks = append(g.sparks, wallSpark{
+			position: at,
+			movement: Vector{X: math.Cos(angle) * speed, Y: math.Sin(angle) * speed},
+			life:     wallSparkLife,
+		})
+	}
+	sharedAudio().PlaySFXAt(sfxWall, at.X)
+}
+
+// nearestEdgePoint returns the point on the screen edge closest to p.
+func nearestEdgePoint(p Vector) Vector {
+	left, right, top, bottom := p.X, ScreenWidth-p.X, p.Y, ScreenHeight-p.Y
+	switch math.Min(math.Min(left, right), math.Min(top, bottom)) {
+	case left:
+		return Vector{X: 0, Y: p.Y}
+	case right:
+		return Vector{X: ScreenWidth, Y: p.Y}
+	case top:
+		return Vector{X: p.X, Y: 0}
+	}
+	return Vector{X: p.X, Y: ScreenHeight}
+}
+
+// updateSparks moves and ages the wall sparks, dropping spent ones.
+func (g *GameScene) updateSparks() {
+	g.sparks = ageParticles(g.sparks)
+}
+
+// age spends a tick of the spark's life and drifts it if any is left.
+func (s *wallSpark) age() {
+	if s.life--; s.life > 0 {
+		sim.Advance(&s.position, s.movement)
+	}
+}
+
+// alive reports whether the spark has life left.
+func (s *wallSpark) alive() bool { return s.life > 0 }
+
+// drawSparks renders the wall sparks, fading with age.
+func (g *GameScene) drawSparks(screen *ebiten.Image) {
+	for _, s := range g.sparks {
+		t := float32(s.life) / wallSparkLife
+		clr := color.RGBA{R: uint8(255 * t), G: uint8(200 * t), B: uint8(90 * t), A: uint8(255 * t)} // Premultiplied amber.
+		fillCircle(screen, float32(s.position.X), float32(s.position.Y), 1+t, clr, true)
+	}
+}
@@ -0,0 +1,68 @@
+// File collider-view.go defines the collider view, a debug mode toggled
+// with F4 (and listed in the F3 overlay) that outlines every shape in the
+// collision space over the sprites, colored by what it belongs to, so a
+// hit that looks wrong can be told apart from a collider that is.
+package asteroids
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/solarlune/resolv"
+)
+
+// colliderViewKey toggles the collider view.
+const colliderViewKey = ebiten.KeyF4
+
+// colliderView reports whether colliders are outlined. Like the debug
+// overlay it lasts for the session and is not saved.
+var colliderView bool
+
+// Collider outline colors, by what the shape belongs to.
+var (
+	colliderPlayer  = color.RGBA{R: 80, G: 255, B: 80, A: 255}
+	colliderShield  = color.RGBA{R: 80, G: 200, B: 255, A: 255}
+	colliderMeteor  = color.RGBA{R: 255, G: 200, B: 60, A: 255}
+	colliderLaser   = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	colliderAlien   = color.RGBA{R: 255, G: 70, B: 70, A: 255}
+	colliderHazard  = color.RGBA{R: 255, G: 80, B: 255, A: 255}
+	colliderPowerUp = color.RGBA{R: 120, G: 255, B: 200, A: 255}
+)
+
+// colliderColor returns the outline color of shape.
+func (g *GameScene) colliderColor(shape resolv.IShape) color.Color {
+	tags := *shape.Tags()
+	switch {
+	case tags.Has(TagPlayer):
+		return colliderPlayer
+	case tags.Has(TagMeteor):
+		return colliderMeteor
+	case tags.Has(TagLaser):
+		return colliderLaser
+	case tags.Has(TagAlien):
+		return colliderAlien
+	case tags.Has(TagBoss | TagComet | TagMine):
+		return colliderHazard
+	case tags.Has(TagPowerUp):
+		return colliderPowerUp
+	}
+	return colliderShield // The shield is the one untagged shape.
+}
+
+// drawColliders outlines every shape in the collision space.
+func (g *GameScene) drawColliders(screen *ebiten.Image) {
+	for _, shape := range g.space.Shapes() {
+		clr := g.colliderColor(shape)
+		switch s := shape.(type) {
+		case *resolv.Circle:
+			p := s.Position()
+			strokeCircle(screen, float32(p.X), float32(p.Y), float32(s.Radius()), 1, clr, true)
+		case *resolv.ConvexPolygon:
+			pts := s.Transformed()
+			for i, a := range pts {
+				b := pts[(i+1)%len(pts)]
+				strokeLine(screen, float32(a.X), float32(a.Y), float32(b.X), float32(b.Y), 1, clr, true)
+			}
+		}
+	}
+}
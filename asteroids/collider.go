@@ -1,6 +1,8 @@
 // collider.go provides collision queries using the resolv library.
 // The helper wraps IntersectionTest to support broad-phase queries against
-// nearby cells or a specific target collider.
+// nearby cells or a specific target collider, Raycast finds the first shape
+// along a ray, and a per-tick cache keeps the gameplay handlers from
+// re-testing the same pairs.
 package asteroids
 
 import "github.com/solarlune/resolv"
@@ -33,3 +35,112 @@ func (g *GameScene) checkCollision(obj, against *resolv.Circle) bool {
 		},
 	})
 }
+
+// RaycastHit describes the first shape a ray struck.
+type RaycastHit struct {
+	Shape    resolv.IShape // The shape hit.
+	Point    Vector        // Where the ray first touched it.
+	Distance float64       // Distance from the ray origin to Point.
+}
+
+// Raycast casts a ray from origin along dir for up to maxDist and returns
+// the nearest shape carrying any of tagFilter, with where it was hit.
+//
+// dir need not be normalized. A zero direction or non-positive distance
+// never hits.
+func (g *GameScene) Raycast(origin, dir Vector, maxDist float64, tagFilter resolv.Tags) (RaycastHit, bool) {
+	unit := dir.Normalize()
+	if unit == (Vector{}) || maxDist <= 0 {
+		return RaycastHit{}, false
+	}
+
+	start := resolv.NewVector(origin.X, origin.Y)
+	var hit RaycastHit
+	found := resolv.LineTest(resolv.LineTestSettings{
+		Start:       start,
+		End:         resolv.NewVector(origin.X+unit.X*maxDist, origin.Y+unit.Y*maxDist),
+		TestAgainst: g.space.FilterShapes().ByTags(tagFilter),
+		OnIntersect: func(set resolv.IntersectionSet, _, _ int) bool {
+			// Sets arrive nearest first; the first is the hit.
+			point := set.Intersections[0].Point
+			hit = RaycastHit{
+				Shape:    set.OtherShape,
+				Point:    Vector{X: point.X, Y: point.Y},
+				Distance: point.Distance(start),
+			}
+			return false
+		},
+	})
+	return hit, found
+}
+
+// collisionPair identifies two shapes independent of argument order.
+type collisionPair struct {
+	lo, hi uint32 // Shape IDs, lowest first.
+}
+
+// newCollisionPair orders the IDs of a and b so (a, b) and (b, a) share a key.
+func newCollisionPair(a, b resolv.IShape) collisionPair {
+	if a.ID() > b.ID() {
+		a, b = b, a
+	}
+	return collisionPair{lo: a.ID(), hi: b.ID()}
+}
+
+// collisionCache memoizes intersection results for the duration of one tick.
+//
+// Several handlers test overlapping sets of pairs (e.g. every player laser
+// against meteors and then against aliens). The cache keeps each pair to a
+// single narrow-phase test, and the consumed set lets a handler retire a
+// shape, such as a laser that already hit something, so later handlers in
+// the same tick skip it.
+type collisionCache struct {
+	results  map[collisionPair]bool // Memoized IsIntersecting results.
+	consumed map[uint32]bool        // Shapes used up by a hit this tick.
+	bypass   bool                   // Test every pair afresh, for measuring the cache.
+	tests    int                    // Narrow-phase tests run this tick.
+}
+
+// newCollisionCache returns an empty cache ready for the first tick.
+func newCollisionCache() *collisionCache {
+	return &collisionCache{
+		results:  make(map[collisionPair]bool),
+		consumed: make(map[uint32]bool),
+	}
+}
+
+// reset clears all state; call once per tick before collision handlers run.
+func (c *collisionCache) reset() {
+	clear(c.results)
+	clear(c.consumed)
+	c.tests = 0
+}
+
+// intersects reports whether a and b overlap, evaluating each pair at most once
+// per tick. Pairs involving a consumed shape never intersect. With bypass
+// set every call is tested afresh, though consumed shapes still never hit.
+func (c *collisionCache) intersects(a, b resolv.IShape) bool {
+	if c.consumed[a.ID()] || c.consumed[b.ID()] {
+		return false
+	}
+	if c.bypass {
+		c.tests++
+		return a.IsIntersecting(b)
+	}
+
+	key := newCollisionPair(a, b)
+	if hit, ok := c.results[key]; ok {
+		return hit
+	}
+	c.tests++
+	hit := a.IsIntersecting(b)
+	c.results[key] = hit
+	return hit
+}
+
+// consume marks shapes as spent for the rest of the tick.
+func (c *collisionCache) consume(shapes ...resolv.IShape) {
+	for _, s := range shapes {
+		c.consumed[s.ID()] = true
+	}
+}
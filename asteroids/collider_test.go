@@ -0,0 +1,165 @@
+// File collider_test.go checks Raycast against fixed geometry (which shape
+// a ray hits first, how far away, and what cuts it short) and the per-tick
+// collision cache, and benchmarks the gameplay handlers with the cache in
+// use and bypassed.
+package asteroids
+
+import (
+	"math"
+	"testing"
+
+	"github.com/solarlune/resolv"
+)
+
+// raycastScene returns a scene whose space holds, along the line y = 100:
+// a meteor circle of radius 20 centered at x = 300, an alien rectangle 40
+// wide centered at x = 500, and a power-up circle of radius 10 far off the
+// line at (300, 400).
+func raycastScene() (g *GameScene, meteor, alien, powerUp resolv.IShape) {
+	g = &GameScene{space: resolv.NewSpace(ScreenWidth, ScreenHeight, 16, 16)}
+	m := resolv.NewCircle(300, 100, 20)
+	m.Tags().Set(TagMeteor)
+	a := resolv.NewRectangle(500, 100, 40, 40)
+	a.Tags().Set(TagAlien)
+	p := resolv.NewCircle(300, 400, 10)
+	p.Tags().Set(TagPowerUp)
+	g.space.Add(m, a, p)
+	return g, m, a, p
+}
+
+// raycastTolerance is how far off, in pixels, a hit distance may be:
+// resolv places hits on polygon edges to within a few hundredths.
+const raycastTolerance = 0.05
+
+func TestRaycast(t *testing.T) {
+	g, meteor, alien, powerUp := raycastScene()
+	tests := []struct {
+		name     string
+		origin   Vector
+		dir      Vector
+		maxDist  float64
+		tags     resolv.Tags
+		want     resolv.IShape // nil for a miss.
+		distance float64
+	}{
+		{"nearest of two", Vector{X: 100, Y: 100}, Vector{X: 1}, 1000, TagMeteor | TagAlien, meteor, 180},
+		{"nearest from the far side", Vector{X: 900, Y: 100}, Vector{X: -1}, 1000, TagMeteor | TagAlien, alien, 380},
+		{"unnormalized direction", Vector{X: 100, Y: 100}, Vector{X: 25}, 1000, TagMeteor, meteor, 180},
+		{"filter skips what is in front", Vector{X: 100, Y: 100}, Vector{X: 1}, 1000, TagAlien, alien, 380},
+		{"diagonal", Vector{X: 100, Y: 200}, Vector{X: 1, Y: 1}, 1000, TagPowerUp, powerUp, 200*math.Sqrt2 - 10},
+		{"cut short by maxDist", Vector{X: 100, Y: 100}, Vector{X: 1}, 150, TagMeteor | TagAlien, nil, 0},
+		{"reaches just past the edge", Vector{X: 100, Y: 100}, Vector{X: 1}, 181, TagMeteor, meteor, 180},
+		{"miss", Vector{X: 100, Y: 100}, Vector{Y: -1}, 1000, TagMeteor | TagAlien | TagPowerUp, nil, 0},
+		{"zero direction", Vector{X: 100, Y: 100}, Vector{}, 1000, TagMeteor, nil, 0},
+		{"non-positive distance", Vector{X: 100, Y: 100}, Vector{X: 1}, 0, TagMeteor, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hit, ok := g.Raycast(tt.origin, tt.dir, tt.maxDist, tt.tags)
+			if tt.want == nil {
+				if ok {
+					t.Fatalf("hit shape %d at %.2f, want a miss", hit.Shape.ID(), hit.Distance)
+				}
+				return
+			}
+			if !ok {
+				t.Fatal("missed, want a hit")
+			}
+			if hit.Shape != tt.want {
+				t.Errorf("hit shape %d, want %d", hit.Shape.ID(), tt.want.ID())
+			}
+			if math.Abs(hit.Distance-tt.distance) > raycastTolerance {
+				t.Errorf("distance %.6f, want %.6f", hit.Distance, tt.distance)
+			}
+			if d := distance(tt.origin, hit.Point); math.Abs(d-hit.Distance) > 1e-6 {
+				t.Errorf("point %v is %.6f away, but distance is %.6f", hit.Point, d, hit.Distance)
+			}
+		})
+	}
+}
+
+func TestCollisionCacheSkipsConsumedPairs(t *testing.T) {
+	c := newCollisionCache()
+	laser := resolv.NewCircle(100, 100, 5)
+	meteor := resolv.NewCircle(120, 100, 20)
+	alien := resolv.NewCircle(80, 100, 20)
+
+	if !c.intersects(meteor, laser) {
+		t.Fatal("overlapping laser and meteor do not intersect")
+	}
+	if !c.intersects(laser, meteor) || c.tests != 1 {
+		t.Errorf("pair tested %d times, want 1 whichever way round", c.tests)
+	}
+
+	// The laser spends itself on the meteor: nothing involving it is
+	// tested again this tick, cached or not.
+	c.consume(meteor, laser)
+	if c.intersects(meteor, laser) || c.intersects(alien, laser) {
+		t.Error("a consumed laser still intersects")
+	}
+	if c.tests != 1 {
+		t.Errorf("%d tests after consuming, want 1", c.tests)
+	}
+
+	// A new tick forgets both.
+	c.reset()
+	if !c.intersects(alien, laser) || c.tests != 1 {
+		t.Errorf("after reset: %d tests, want 1 hit", c.tests)
+	}
+}
+
+// collisionScene returns a scene whose meteors, aliens, and lasers are
+// placed so that none of them touch: meteors along the top, aliens across
+// the middle below the ship, and player and alien lasers along the bottom.
+// Every handler then tests every pair it can without retiring any.
+func collisionScene() *GameScene {
+	g := newGameScene(ModeStandard, 1, Upgrades{}, difficulties[0], shipClasses[0])
+	g.player.position = Vector{X: ScreenWidth / 2, Y: ScreenHeight / 2}
+	g.player.playerObj.SetPosition(g.player.position.X, g.player.position.Y)
+	for i := range 100 {
+		m := NewMeteor(g.baseVelocity, g, g.meteorCount+1)
+		m.position = Vector{X: float64(i%20) * 64, Y: float64(i/20) * 10}
+		m.syncCollider()
+		g.addMeteor(m)
+	}
+	for i := range 10 {
+		a := NewAlien(g.baseVelocity, g)
+		a.position = Vector{X: float64(i) * ScreenWidth / 10, Y: ScreenHeight/2 + 120}
+		a.alienObj.SetPosition(a.position.X, a.position.Y)
+		g.addAlien(a)
+	}
+	for i := range 50 {
+		g.player.spawnLaser(Vector{X: float64(i) * ScreenWidth / 50, Y: ScreenHeight - 40}, 0)
+	}
+	for i := range 20 {
+		g.alienLaserCount++
+		al := NewAlienLaser(Vector{X: float64(i) * ScreenWidth / 20, Y: ScreenHeight - 10}, 0, g)
+		g.alienLasers[g.alienLaserCount] = al
+		g.space.Add(al.laserObj)
+	}
+	return g
+}
+
+// benchmarkCollisions times the five gameplay collision handlers over one
+// tick's worth of pairs, with the pair cache in use or bypassed.
+func benchmarkCollisions(b *testing.B, bypass bool) {
+	g := collisionScene()
+	g.collisions.bypass = bypass
+	lasers, meteors := len(g.lasers), len(g.meteors)
+	b.ReportAllocs()
+	for b.Loop() {
+		g.collisions.reset()
+		g.isPlayerCollidingWithMeteor()
+		g.isMeteorHitByPlayerLaser()
+		g.isPlayerCollidingWithAlien()
+		g.isPlayerHitByAlienLaser()
+		g.isAlienHitByPlayerLaser()
+	}
+	b.ReportMetric(float64(g.collisions.tests), "tests/op")
+	if len(g.lasers) != lasers || len(g.meteors) != meteors || g.player.isDying {
+		b.Fatal("something collided; the scene must stay untouched")
+	}
+}
+
+func BenchmarkCollisionsCached(b *testing.B)   { benchmarkCollisions(b, false) }
+func BenchmarkCollisionsBypassed(b *testing.B) { benchmarkCollisions(b, true) }
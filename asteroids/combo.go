@@ -0,0 +1,153 @@
+// File combo.go defines the combo meter: each kill within comboWindow of
+// the last raises the multiplier on the points kills are worth (x2, x3, x4,
+// up to comboMaxMultiplier), with a blip that climbs in pitch at each step.
+// Letting the window run out, or the ship taking damage, drops it back to
+// x1. Gold-rush rounds score their own chain and leave the meter alone.
+package asteroids
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Combo tuning.
+const (
+	comboWindow        = 2 * time.Second       // Time after a kill for the next to extend the combo.
+	comboMaxMultiplier = 8                     // Highest multiplier.
+	comboToneBase      = 523.25                // Pitch of the x2 blip (C5), in hertz.
+	comboToneStep      = 2                     // Semitones the blip climbs per step.
+	comboToneLength    = 90 * time.Millisecond // Length of a blip.
+	comboToneDecay     = 30 * time.Millisecond // Time constant of a blip's fade.
+	comboToneGain      = 0.35                  // Level of a blip relative to the SFX channel.
+	comboMeterWidth    = 120.0                 // Width of the HUD window bar at HUD scale 1.
+	comboMeterHeight   = 4.0                   // Height of the HUD window bar at HUD scale 1.
+)
+
+// Combo counts kills in quick succession.
+type Combo struct {
+	kills  int    // Kills in the current combo.
+	window *Timer // Time since the last kill; the combo ends when it is ready.
+}
+
+// newCombo returns a meter at x1.
+func newCombo() *Combo {
+	c := &Combo{window: sim.NewTimer(comboWindow)}
+	c.window.Elapsed = c.window.Target
+	return c
+}
+
+// multiplier returns what kills are worth now, times their points.
+func (c *Combo) multiplier() int {
+	return max(1, min(c.kills, comboMaxMultiplier))
+}
+
+// kill counts a kill, restarting the window, and returns the multiplier
+// it scores at.
+func (c *Combo) kill() int {
+	c.kills++
+	c.window.Reset()
+	return c.multiplier()
+}
+
+// update runs the window down by one tick, ending the combo when it is out.
+func (c *Combo) update() {
+	c.window.Update()
+	if c.window.IsReady() {
+		c.kills = 0
+	}
+}
+
+// reset ends the combo.
+func (c *Combo) reset() {
+	c.kills = 0
+	c.window.Elapsed = c.window.Target
+}
+
+// scoreKill adds a kill worth points, times the combo multiplier, to the
+// score, leaves a popup of what it scored at the impact point at, and
+// sounds the combo's step.
+func (g *GameScene) scoreKill(points int, at Vector) {
+	m := g.combo.kill()
+	g.score += points * m
+	g.popScore(points*m, at)
+	if m > 1 {
+		p := g.comboTones[m-2]
+		_ = p.Rewind()
+		sharedAudio().play(p)
+	}
+}
+
+// updateCombo runs the combo window down and breaks the combo when the
+// ship takes damage.
+func (g *GameScene) updateCombo() {
+	g.combo.update()
+	if g.player.isDying {
+		g.combo.reset()
+	}
+}
+
+// drawCombo draws the multiplier and a bar of the window left, under the
+// high score, while a combo is running.
+func (g *GameScene) drawCombo(screen *ebiten.Image) {
+	m := g.combo.multiplier()
+	if m < 2 {
+		return
+	}
+	hud := currentPalette().HUD
+	scale := hudScale()
+
+	op := &text.DrawOptions{
+		LayoutOptions: text.LayoutOptions{PrimaryAlign: text.AlignCenter},
+	}
+	op.ColorScale.ScaleWithColor(hud)
+	op.GeoM.Translate(ScreenWidth/2, 110*scale)
+	drawText(screen, fmt.Sprintf("Combo x%d", m), &text.GoTextFace{
+		Source: assets.ScoreFont,
+		Size:   16 * scale,
+	}, op)
+
+	left := 1 - float64(g.combo.window.Elapsed)/float64(g.combo.window.Target)
+	w, h := float32(comboMeterWidth*scale), float32(comboMeterHeight*scale)
+	x, y := float32(ScreenWidth/2)-w/2, float32(135*scale)
+	strokeRect(screen, x, y, w, h, 1, hud, false)
+	fillRect(screen, x, y, w*float32(left), h, hud, false)
+}
+
+// comboTonePCM holds the blip for each step from x2, rendered on first use.
+var comboTonePCM [][]byte
+
+// newComboTones returns a player for the blip of each step from x2.
+func newComboTones(sound *AudioManager) []*audio.Player {
+	if comboTonePCM == nil {
+		for step := range comboMaxMultiplier - 1 {
+			comboTonePCM = append(comboTonePCM, comboTone(comboToneBase*math.Pow(2, float64(step*comboToneStep)/12)))
+		}
+	}
+	players := make([]*audio.Player, len(comboTonePCM))
+	for i, pcm := range comboTonePCM {
+		players[i] = sound.NewSFXPlayer(bytes.NewReader(pcm), comboToneGain)
+	}
+	return players
+}
+
+// comboTone renders a short, fading sine blip at freq hertz as 16-bit
+// little-endian stereo PCM at the audio context's rate.
+func comboTone(freq float64) []byte {
+	n := sim.Ticks(comboToneLength) * audioSampleRate / sim.TicksPerSecond
+	pcm := make([]byte, 0, n*4)
+	for i := range n {
+		t := float64(i) / audioSampleRate
+		v := math.Sin(2*math.Pi*freq*t) * math.Exp(-t/comboToneDecay.Seconds())
+		s := int16(v * math.MaxInt16)
+		pcm = append(pcm, byte(s), byte(s>>8), byte(s), byte(s>>8))
+	}
+	return pcm
+}
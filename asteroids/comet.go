@@ -0,0 +1,234 @@
+// File comet.go defines the Comet hazard: a fast streak with a glowing
+// particle tail that crosses the screen diagonally now and then. Shooting it
+// is worth a large bonus; touching it without a shield is fatal.
+package asteroids
+
+import (
+	"image/color"
+	"math/rand"
+	"time"
+
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/solarlune/resolv"
+)
+
+// Comet tuning.
+const (
+	cometSpawnMin       = 15 * time.Second // Shortest wait between comets.
+	cometSpawnMax       = 30 * time.Second // Longest wait between comets.
+	cometSpeed          = 420.0            // Head speed.
+	cometRadius         = 10.0             // Head collider and glow radius.
+	cometPoints         = 250              // Score for shooting a comet.
+	cometTailLife       = 30               // Ticks a tail particle lasts.
+	cometTailPerTick    = 2                // Tail particles emitted per tick.
+	cometTailSpread     = 4.0              // Random jitter of emitted particles.
+	cometTailDrift      = 0.15             // Share of head velocity particles keep.
+	cometEntryMargin    = 60.0             // How far off-screen a comet starts.
+	cometOffscreenBound = 150.0            // How far off-screen a comet's head is retired.
+)
+
+// cometParticle is one glowing dot of a comet's tail.
+type cometParticle struct {
+	position Vector // World-space position.
+	movement Vector // Drift velocity.
+	life     int    // Ticks remaining.
+}
+
+// Comet is a fast hazard crossing the screen from one corner region to the
+// opposite one.
+type Comet struct {
+	position Vector          // Head center in world space.
+	movement Vector          // Head velocity.
+	tail     []cometParticle // Live tail particles, oldest first.
+	cometObj *resolv.Circle  // Head collider.
+	spent    bool            // Shot, burnt out, or off-screen; only the tail remains.
+	tailRNG  *rand.Rand      // Cosmetic stream scattering the tail.
+}
+
+// NewComet constructs a comet just off a random corner, heading diagonally
+// across the screen toward the opposite side. Its path is rolled from rng;
+// tail scatters its tail.
+func NewComet(rng, tail *rand.Rand) *Comet {
+	return NewCometFrom(sim.SideAny, rng, tail)
+}
+
+// NewCometFrom is NewComet with the corner pinned to the side from: the
+// left or right edge, or the top or bottom half. sim.SideAny leaves it
+// random.
+func NewCometFrom(from sim.Side, rng, tail *rand.Rand) *Comet {
+	// Pick a start on the left or right edge, in the top or bottom half, and
+	// aim at a point in the opposite quarter so the path is always diagonal.
+	// Both are rolled whatever from pins, so the rolls after them line up.
+	fromLeft := rng.Intn(2) == 0
+	fromTop := rng.Intn(2) == 0
+	switch from {
+	case sim.SideLeft, sim.SideRight:
+		fromLeft = from == sim.SideLeft
+	case sim.SideTop, sim.SideBottom:
+		fromTop = from == sim.SideTop
+	}
+
+	start := Vector{X: -cometEntryMargin, Y: rng.Float64() * ScreenHeight / 2}
+	target := Vector{X: ScreenWidth, Y: ScreenHeight/2 + rng.Float64()*ScreenHeight/2}
+	if !fromLeft {
+		start.X, target.X = ScreenWidth+cometEntryMargin, 0
+	}
+	if !fromTop {
+		start.Y = ScreenHeight - start.Y
+		target.Y = ScreenHeight - target.Y
+	}
+
+	direction := Vector{X: target.X - start.X, Y: target.Y - start.Y}.Normalize()
+	c := &Comet{
+		position: start,
+		movement: direction.Scale(cometSpeed),
+		cometObj: resolv.NewCircle(start.X, start.Y, cometRadius),
+		tailRNG:  tail,
+	}
+	c.cometObj.SetPosition(start.X, start.Y)
+	c.cometObj.Tags().Set(TagComet)
+	return c
+}
+
+// Update advances the head (unless spent), emits tail particles behind it,
+// and ages the tail.
+func (c *Comet) Update() {
+	if !c.spent {
+		sim.Advance(&c.position, c.movement)
+		c.cometObj.SetPosition(c.position.X, c.position.Y)
+
+		for i := 0; i < particleCount(cometTailPerTick); i++ {
+			c.tail = append(c.tail, cometParticle{
+				position: Vector{
+					X: c.position.X + (c.tailRNG.Float64()*2-1)*cometTailSpread,
+					Y: c.position.Y + (c.tailRNG.Float64()*2-1)*cometTailSpread,
+				},
+				movement: c.movement.Scale(cometTailDrift),
+				life:     cometTailLife,
+			})
+		}
+	}
+
+	// Age the tail and drop dead particles (they die oldest first).
+	c.tail = ageParticles(c.tail)
+}
+
+// age spends a tick of the particle's life and drifts it if any is left.
+func (p *cometParticle) age() {
+	if p.life--; p.life > 0 {
+		sim.Advance(&p.position, p.movement)
+	}
+}
+
+// alive reports whether the particle has life left.
+func (p *cometParticle) alive() bool { return p.life > 0 }
+
+// isGone reports whether nothing of the comet is left to draw: the head is
+// spent and the tail has faded.
+func (c *Comet) isGone() bool {
+	return c.spent && len(c.tail) == 0
+}
+
+// Draw renders the tail, fading and shrinking with age, then the head glow.
+func (c *Comet) Draw(screen *ebiten.Image) {
+	for _, p := range c.tail {
+		t := float32(p.life) / cometTailLife
+		clr := color.RGBA{R: uint8(120 * t), G: uint8(200 * t), B: uint8(255 * t), A: uint8(255 * t)}
+		fillCircle(screen, float32(p.position.X), float32(p.position.Y), 1+3*t, clr, true)
+	}
+	if c.spent {
+		return
+	}
+	x, y := float32(c.position.X), float32(c.position.Y)
+	fillCircle(screen, x, y, cometRadius, color.RGBA{R: 60, G: 110, B: 160, A: 160}, true)
+	fillCircle(screen, x, y, cometRadius/2, color.White, true)
+}
+
+// spawnComet launches a comet whenever the spawn timer runs out, then waits a
+// new random interval. Only one comet flies at a time, and none appear in
+// bonus rounds or practice.
+func (g *GameScene) spawnComet() {
+	if g.practice != nil || g.isBonusRound() || g.level.Spawns(sim.SpawnComet) {
+		return
+	}
+	g.cometSpawnTimer.Update()
+	if !g.cometSpawnTimer.IsReady() || g.comet != nil {
+		return
+	}
+	g.cometSpawnTimer = newCometSpawnTimer(g.rng.Stream(streamSpawns))
+	g.comet = NewComet(g.rng.Stream(streamSpawns), g.rng.Stream(streamCosmetics))
+	g.space.Add(g.comet.cometObj)
+}
+
+// updateComet advances the comet, retires its head once it has crossed the
+// screen, and drops it once the tail has faded too.
+func (g *GameScene) updateComet() {
+	if g.comet == nil {
+		return
+	}
+	g.comet.Update()
+	if !g.comet.spent && isOffscreen(g.comet.position, cometOffscreenBound) {
+		g.spendComet()
+	}
+	if g.comet.isGone() {
+		g.comet = nil
+	}
+}
+
+// cometWhooshSource places the comet's whoosh on its head, for as long as
+// the head is still flying.
+func (g *GameScene) cometWhooshSource() (Vector, bool) {
+	if g.comet == nil || g.comet.spent {
+		return Vector{}, false
+	}
+	return g.comet.position, true
+}
+
+// isCometHitByPlayerLaser awards the comet bonus for the first laser to hit it.
+func (g *GameScene) isCometHitByPlayerLaser() {
+	if g.comet == nil || g.comet.spent {
+		return
+	}
+	for i, l := range inOrder(g.lasers) {
+		if g.collisions.intersects(g.comet.cometObj, l.laserObj) {
+			g.collisions.consume(g.comet.cometObj, l.laserObj)
+			g.laserHit(i)
+			g.spendComet()
+			g.scoreKill(cometPoints, g.comet.position)
+			g.maybeDropExtraLife(g.comet.position)
+			sharedAudio().PlaySFXAt(sfxExplosion, g.comet.position.X)
+			return
+		}
+	}
+}
+
+// isPlayerCollidingWithComet destroys an unshielded ship the comet touches.
+// A shield survives the hit and burns the comet out.
+func (g *GameScene) isPlayerCollidingWithComet() {
+	if g.comet == nil || g.comet.spent || g.player.isDying || g.isBonusRound() {
+		return
+	}
+	if !g.collisions.intersects(g.comet.cometObj, g.player.playerObj) {
+		return
+	}
+	g.collisions.consume(g.comet.cometObj)
+	if g.player.isShielded {
+		g.spendComet()
+		return
+	}
+	g.player.isDying = true
+	sharedAudio().PlaySFXAt(sfxExplosion, spriteCenter(g.player.position, g.player.sprite).X)
+}
+
+// spendComet takes the comet's head out of play and leaves its tail to fade.
+func (g *GameScene) spendComet() {
+	g.comet.spent = true
+	g.space.Remove(g.comet.cometObj)
+}
+
+// newCometSpawnTimer returns a timer for a random wait between comets.
+func newCometSpawnTimer(rng *rand.Rand) *Timer {
+	wait := cometSpawnMin + time.Duration(rng.Int63n(int64(cometSpawnMax-cometSpawnMin)))
+	return sim.NewTimer(wait)
+}
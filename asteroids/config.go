@@ -0,0 +1,171 @@
+// File config.go defines Config, the player's configuration file: window
+// mode, volumes, key bindings, difficulty, ship, starfield density, and the other
+// user-facing options, with their defaults and JSON persistence in the save
+// directory. Settings files from older versions are picked up the first
+// time.
+package asteroids
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"log"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Config file names.
+const (
+	configFileName         = "config.json"   // Config file in the save directory.
+	legacySettingsFileName = "settings.json" // Settings file older versions kept instead.
+)
+
+// HUD scale bounds.
+const (
+	hudScaleMin = 0.75
+	hudScaleMax = 1.5
+)
+
+// Config holds user preferences that persist across runs. Options the
+// player can tune go here rather than into package constants.
+type Config struct {
+	MasterVolume     float64            `json:"masterVolume"`     // 0–1, scales every sound.
+	MusicVolume      float64            `json:"musicVolume"`      // 0–1, scales music.
+	SFXVolume        float64            `json:"sfxVolume"`        // 0–1, scales sound effects.
+	Fullscreen       bool               `json:"fullscreen"`       // Fullscreen vs. windowed.
+	StarDensity      float64            `json:"starDensity"`      // 0–1 fraction of numberOfStars.
+	HUDScale         float64            `json:"hudScale"`         // HUD text size multiplier (hudScaleMin–hudScaleMax).
+	RenderScale      float64            `json:"renderScale"`      // Device pixels per logical pixel; renderScaleAuto follows the monitor.
+	Palette          string             `json:"palette"`          // Name of the HUD Palette.
+	ShotShapes       bool               `json:"shotShapes"`       // Tell shots apart by shape: player bolts, alien ringed orbs.
+	ShipLabels       bool               `json:"shipLabels"`       // Draw name labels above player ships.
+	ReducedMotion    bool               `json:"reducedMotion"`    // No screen shake, slower cross-fades, and fewer particles.
+	Radar            bool               `json:"radar"`            // Show the corner radar of meteors and aliens.
+	MeteorCollisions bool               `json:"meteorCollisions"` // Realistic asteroids: meteors bounce off each other.
+	TitleReplay      bool               `json:"titleReplay"`      // Replay the best saved run behind the title menu.
+	DespawnEffects   bool               `json:"despawnEffects"`   // Fade out entities that expire or are culled instead of removing them at once.
+	DirectorLog      bool               `json:"directorLog"`      // Record how the spawn director steers spawns in run stats.
+	Assists          map[string]Assists `json:"assists"`          // Control profile name → assist options.
+	KeyBindings      KeyBindings        `json:"keyBindings"`      // Action → physical key.
+	LayoutLocalized  bool               `json:"layoutLocalized"`  // Default bindings were fitted to the keyboard layout.
+	Difficulty       string             `json:"difficulty"`       // Name of the Difficulty new runs use.
+	Ship             string             `json:"ship"`             // Name of the ShipClass last picked, which the ship-select screen starts on.
+}
+
+// config is the active configuration, loaded at startup.
+var config = DefaultConfig()
+
+// init loads the persisted configuration (best-effort).
+func init() {
+	c, err := loadConfig()
+	if err != nil {
+		log.Println("Error loading config", err)
+		return
+	}
+	config = c
+}
+
+// DefaultConfig returns the out-of-the-box configuration.
+func DefaultConfig() *Config {
+	return &Config{
+		MasterVolume:   1,
+		MusicVolume:    1,
+		SFXVolume:      1,
+		Fullscreen:     false,
+		StarDensity:    1,
+		HUDScale:       1,
+		Palette:        palettes[0].Name,
+		Radar:          true,
+		TitleReplay:    true,
+		DespawnEffects: true,
+		KeyBindings:    DefaultKeyBindings(),
+		Difficulty:     difficulties[defaultDifficulty].Name,
+		Ship:           shipClasses[0].Name,
+	}
+}
+
+// loadConfig reads the config file, or the settings file of an older
+// version when there is none yet, falling back to defaults for a missing
+// file and for any action missing from the stored bindings.
+func loadConfig() (*Config, error) {
+	c := DefaultConfig()
+
+	data, err := saves.Read(configFileName)
+	if errors.Is(err, fs.ErrNotExist) {
+		data, err = saves.Read(legacySettingsFileName)
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+
+	// Decode into a copy and only adopt it if it parses.
+	loaded := DefaultConfig()
+	loaded.KeyBindings = KeyBindings{}
+	if err := json.Unmarshal(data, loaded); err != nil {
+		return c, err
+	}
+	loaded.adoptLegacyAutoFire(data)
+	for action, key := range DefaultKeyBindings() {
+		if _, ok := loaded.KeyBindings[action]; !ok {
+			loaded.KeyBindings[action] = key
+		}
+	}
+	return loaded, nil
+}
+
+// Save writes the config file to the save directory.
+func (c *Config) Save() error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return saves.Write(configFileName, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// apply pushes window-level options to Ebiten.
+func (c *Config) apply() {
+	ebiten.SetFullscreen(c.Fullscreen)
+}
+
+// localizeBindings fits the default bindings to the keyboard layout the
+// first time the game runs, then remembers having done so; later layout
+// changes are the player's to rebind.
+func (c *Config) localizeBindings() {
+	if c.LayoutLocalized {
+		return
+	}
+	c.KeyBindings.localize()
+	c.LayoutLocalized = true
+	if err := c.Save(); err != nil {
+		log.Println("Error saving config", err)
+	}
+}
+
+// toggleFullscreen flips between fullscreen and windowed mode and remembers
+// the choice for the next session. The logical ScreenWidth x ScreenHeight
+// layout is unaffected; Ebiten scales it to whichever mode is active.
+func (c *Config) toggleFullscreen() {
+	c.Fullscreen = !c.Fullscreen
+	ebiten.SetFullscreen(c.Fullscreen)
+	if err := c.Save(); err != nil {
+		log.Println("Error saving config", err)
+	}
+}
+
+// starCount returns the number of background stars for the current density.
+func starCount() int {
+	return int(float64(numberOfStars) * config.StarDensity)
+}
+
+// hudScale returns the HUD text multiplier, limited to the supported range.
+func hudScale() float64 {
+	return math.Max(hudScaleMin, math.Min(hudScaleMax, config.HUDScale))
+}
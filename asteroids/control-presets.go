@@ -0,0 +1,138 @@
+// File control-presets.go defines the selectable control presets: complete
+// binding profiles for the classic layout, for laptops without a
+// comfortable arrow cluster, for playing with one hand on either side of
+// the keyboard, and for the numeric keypad. Choosing one replaces every
+// binding; individual actions can still be rebound after.
+package asteroids
+
+import (
+	"maps"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ControlPreset is a named binding profile.
+type ControlPreset struct {
+	Name     string             // Display name.
+	bindings func() KeyBindings // Returns a fresh copy of the profile.
+}
+
+// customPresetName names bindings that match no preset.
+const customPresetName = "Custom"
+
+// controlPresets lists the available profiles; the first is the default.
+var controlPresets = []ControlPreset{
+	{Name: "Classic", bindings: classicBindings},
+	{Name: "WASD + JK", bindings: wasdBindings},
+	{Name: "Left Hand", bindings: leftHandBindings},
+	{Name: "Right Hand", bindings: rightHandBindings},
+	{Name: "Numpad", bindings: numpadBindings},
+}
+
+// classicBindings is the default layout fitted to the keyboard layout.
+func classicBindings() KeyBindings {
+	b := DefaultKeyBindings()
+	b.localize()
+	return b
+}
+
+// wasdBindings splits the controls between both hands for laptops: WASD
+// for flight under the left, J and K for fire and shield under the right,
+// and the rest on the keys around them.
+func wasdBindings() KeyBindings {
+	return KeyBindings{
+		ActionRotateLeft:  ebiten.KeyA,
+		ActionRotateRight: ebiten.KeyD,
+		ActionThrust:      ebiten.KeyW,
+		ActionReverse:     ebiten.KeyS,
+		ActionFire:        ebiten.KeyJ,
+		ActionShield:      ebiten.KeyK,
+		ActionHyperspace:  ebiten.KeyL,
+		ActionBoost:       ebiten.KeySpace,
+		ActionSmartBomb:   ebiten.KeyU,
+		ActionTractor:     ebiten.KeyI,
+	}
+}
+
+// leftHandBindings keeps every action within reach of the left hand, with
+// WASD for flight and the thumb on Space.
+func leftHandBindings() KeyBindings {
+	return KeyBindings{
+		ActionRotateLeft:  ebiten.KeyA,
+		ActionRotateRight: ebiten.KeyD,
+		ActionThrust:      ebiten.KeyW,
+		ActionReverse:     ebiten.KeyS,
+		ActionFire:        ebiten.KeySpace,
+		ActionShield:      ebiten.KeyQ,
+		ActionHyperspace:  ebiten.KeyE,
+		ActionBoost:       ebiten.KeyShiftLeft,
+		ActionSmartBomb:   ebiten.KeyF,
+		ActionTractor:     ebiten.KeyR,
+	}
+}
+
+// rightHandBindings mirrors leftHandBindings onto IJKL.
+func rightHandBindings() KeyBindings {
+	return KeyBindings{
+		ActionRotateLeft:  ebiten.KeyJ,
+		ActionRotateRight: ebiten.KeyL,
+		ActionThrust:      ebiten.KeyI,
+		ActionReverse:     ebiten.KeyK,
+		ActionFire:        ebiten.KeySpace,
+		ActionShield:      ebiten.KeyU,
+		ActionHyperspace:  ebiten.KeyO,
+		ActionBoost:       ebiten.KeyShiftRight,
+		ActionSmartBomb:   ebiten.KeyH,
+		ActionTractor:     ebiten.KeyP,
+	}
+}
+
+// numpadBindings puts flight on 4/6/8/5 and everything else on the keys
+// around them.
+func numpadBindings() KeyBindings {
+	return KeyBindings{
+		ActionRotateLeft:  ebiten.KeyNumpad4,
+		ActionRotateRight: ebiten.KeyNumpad6,
+		ActionThrust:      ebiten.KeyNumpad8,
+		ActionReverse:     ebiten.KeyNumpad5,
+		ActionFire:        ebiten.KeyNumpad0,
+		ActionShield:      ebiten.KeyNumpadEnter,
+		ActionHyperspace:  ebiten.KeyNumpadDecimal,
+		ActionBoost:       ebiten.KeyNumpadAdd,
+		ActionSmartBomb:   ebiten.KeyNumpadSubtract,
+		ActionTractor:     ebiten.KeyNumpadMultiply,
+	}
+}
+
+// controlPresetIndex returns the index of the preset b matches exactly, or
+// -1 if b has been customized.
+func controlPresetIndex(b KeyBindings) int {
+	for i, p := range controlPresets {
+		if maps.Equal(b, p.bindings()) {
+			return i
+		}
+	}
+	return -1
+}
+
+// controlPresetName returns the name of the preset b matches, or "Custom".
+func controlPresetName(b KeyBindings) string {
+	if i := controlPresetIndex(b); i >= 0 {
+		return controlPresets[i].Name
+	}
+	return customPresetName
+}
+
+// cycleControlPreset replaces b in place with the preset step places after
+// the one it matches, wrapping at either end. Customized bindings step to
+// the first or last preset. b is updated in place because Input shares it.
+func cycleControlPreset(b KeyBindings, step int) {
+	i := controlPresetIndex(b)
+	if i < 0 && step < 0 {
+		i = 0 // So stepping back from Custom lands on the last preset.
+	}
+	i = (i + step + len(controlPresets)) % len(controlPresets)
+
+	clear(b)
+	maps.Copy(b, controlPresets[i].bindings())
+}
@@ -0,0 +1,88 @@
+// File debug-overlay.go defines the debug overlay, toggled with F3 from any
+// scene: frame and tick rates, and for scenes with a play field, how many
+// entities each map holds, how many shapes the collision space tracks, the
+// meteors' current base velocity, and whether the collider view is on.
+package asteroids
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Debug overlay layout.
+const (
+	debugOverlayKey      = ebiten.KeyF3 // Toggles the overlay.
+	debugOverlayFontSize = 12.0         // Font size of the readout.
+	debugOverlayPadding  = 6.0          // Space between the readout and its panel's edge.
+)
+
+// debugOverlay reports whether the overlay is shown. It lasts for the
+// session and is not saved.
+var debugOverlay bool
+
+// debugReporter is a scene with its own lines for the debug overlay.
+type debugReporter interface {
+	// debugLines returns the scene's readouts, one per line.
+	debugLines() []string
+}
+
+// debugLines returns the readouts of the topmost scene that has any: the
+// one shown, or failing that the scenes suspended beneath it, so overlays
+// like the pause menu still report on the play field under them.
+func (s *SceneManager) debugLines() []string {
+	scenes := []Scene{s.next, s.current}
+	for i := len(s.stack) - 1; i >= 0; i-- {
+		scenes = append(scenes, s.stack[i])
+	}
+	for _, scene := range scenes {
+		if r, ok := scene.(debugReporter); ok {
+			return r.debugLines()
+		}
+	}
+	return nil
+}
+
+// drawDebugOverlay renders the frame and tick rates and lines in the
+// top-left corner on a dark panel.
+func drawDebugOverlay(screen *ebiten.Image, lines []string) {
+	lines = append([]string{fmt.Sprintf("FPS %.1f   TPS %.1f", ebiten.ActualFPS(), ebiten.ActualTPS())}, lines...)
+	body := strings.Join(lines, "\n")
+
+	face := &text.GoTextFace{Source: assets.ScoreFont, Size: debugOverlayFontSize}
+	spacing := debugOverlayFontSize * 1.4
+	w, h := text.Measure(body, face, spacing)
+	fillRect(screen, 0, 0, float32(w+2*debugOverlayPadding), float32(h+2*debugOverlayPadding), color.RGBA{A: 180}, false)
+
+	op := &text.DrawOptions{LayoutOptions: text.LayoutOptions{LineSpacing: spacing}}
+	op.ColorScale.ScaleWithColor(color.RGBA{R: 120, G: 255, B: 120, A: 255})
+	op.GeoM.Translate(debugOverlayPadding, debugOverlayPadding)
+	drawText(screen, body, face, op)
+}
+
+// debugLines reports the entity maps, the collision space, the base
+// velocity, and the collider view.
+func (g *GameScene) debugLines() []string {
+	return []string{
+		fmt.Sprintf("Meteors %d   Lasers %d", len(g.meteors), len(g.lasers)),
+		fmt.Sprintf("Aliens %d   Alien lasers %d", len(g.aliens), len(g.alienLasers)),
+		fmt.Sprintf("Power-ups %d   Mines %d", len(g.powerUps), len(g.mines)),
+		fmt.Sprintf("Space shapes %d", len(g.space.Shapes())),
+		fmt.Sprintf("Base velocity %.1f", g.baseVelocity),
+		fmt.Sprintf("Colliders (F4) %s", onOff(colliderView)),
+	}
+}
+
+// debugLines reports on the shared play field.
+func (v *VersusScene) debugLines() []string {
+	return v.world.debugLines()
+}
+
+// debugLines reports on the frozen play field.
+func (p *PauseScene) debugLines() []string {
+	return p.game.debugLines()
+}
@@ -0,0 +1,81 @@
+// File despawn.go defines despawn effects: the brief fade and shrink an
+// entity plays where it leaves play without being destroyed, as when a
+// pickup expires or the field's edge culls a meteor or alien, so it does
+// not simply vanish. Effects are pooled like the entities they stand in
+// for, and the Despawn Effects option, off at the Low quality preset, skips
+// them altogether.
+package asteroids
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Despawn effect tuning.
+const (
+	despawnLife  = 12  // Ticks a despawn effect lasts.
+	despawnScale = 0.4 // Size a despawning sprite shrinks to, relative to its own.
+)
+
+// despawnEffect is the fading stand-in for one entity that left play.
+type despawnEffect struct {
+	sprite   *ebiten.Image     // Sprite the entity was drawn with.
+	center   Vector            // World-space center of the sprite.
+	rotation float64           // Rotation the entity was drawn at.
+	tint     ebiten.ColorScale // Tint the entity was drawn with.
+	life     int               // Ticks remaining.
+}
+
+// despawn starts a despawn effect for sprite, drawn centered on center at
+// rotation with tint. Entities wholly off-screen leave none, since it
+// would never be seen.
+func (g *GameScene) despawn(sprite *ebiten.Image, center Vector, rotation float64, tint ebiten.ColorScale) {
+	if !config.DespawnEffects {
+		return
+	}
+	size := spriteSize(sprite)
+	if isOffscreen(center, max(size.X, size.Y)/2) {
+		return
+	}
+	e := g.pools.effects.Get()
+	*e = despawnEffect{
+		sprite:   sprite,
+		center:   center,
+		rotation: rotation,
+		tint:     tint,
+		life:     despawnLife,
+	}
+	g.despawns = append(g.despawns, e)
+}
+
+// updateDespawns ages the despawn effects, returning spent ones to the pool.
+func (g *GameScene) updateDespawns() {
+	live := g.despawns[:0]
+	for _, e := range g.despawns {
+		e.life--
+		if e.life <= 0 {
+			g.pools.effects.Put(e)
+			continue
+		}
+		live = append(live, e)
+	}
+	clear(g.despawns[len(live):])
+	g.despawns = live
+}
+
+// drawDespawns renders the despawn effects, shrinking and fading with age.
+func (g *GameScene) drawDespawns(screen *ebiten.Image) {
+	for _, e := range g.despawns {
+		t := float64(e.life) / despawnLife
+		s := despawnScale + (1-despawnScale)*t
+		size := spriteSize(e.sprite)
+
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(-size.X/2, -size.Y/2)
+		op.GeoM.Rotate(e.rotation)
+		op.GeoM.Scale(s, s)
+		op.GeoM.Translate(e.center.X, e.center.Y)
+		op.ColorScale = e.tint
+		op.ColorScale.ScaleAlpha(float32(t))
+		drawSprite(screen, e.sprite, op)
+	}
+}
@@ -0,0 +1,87 @@
+// File difficulty.go defines the difficulty levels chosen in the config:
+// how many meteors a level sends, how fast they fly, and how many hits the
+// large ones take, how often aliens appear, hunt, and fire, how fairly
+// spawns avoid the ship's blind spots, and how many lives and shields a run
+// starts with. A run keeps the difficulty it started on; the HUD shows it,
+// and replays, suspended runs, and high scores record it.
+package asteroids
+
+import (
+	"math"
+	"time"
+)
+
+// Difficulty is one selectable difficulty level.
+type Difficulty struct {
+	Name         string  // Display name and config-file identifier.
+	MeteorBudget float64 // Multiplier on the large meteors each level sends.
+	MeteorSpeed  float64 // Multiplier on meteor speed.
+	MeteorHealth int     // Laser hits a large meteor takes before it splits.
+	AlienSpawn   float64 // Chance that an alien spawn attempt brings aliens.
+	AlienHunter  float64 // Chance that a lone alien hunts the ship instead of sweeping past.
+	AlienFire    float64 // Multiplier on the wait between alien volleys.
+	Fairness     float64 // How hard spawns steer away from the ship's blind spots, 0 to 1.
+	Lives        int     // Lives a run starts with.
+	Shields      int     // Shield charges a run starts with, before upgrades.
+	Ranked       bool    // Runs may enter the high-score table.
+}
+
+// difficulties lists the levels from easiest to hardest.
+var difficulties = []Difficulty{
+	{Name: "Easy", MeteorBudget: 0.75, MeteorSpeed: 0.8, MeteorHealth: 2, AlienSpawn: 0.35, AlienHunter: 0.15, AlienFire: 1.5, Fairness: 1, Lives: 5, Shields: 4},
+	{Name: "Normal", MeteorBudget: 1, MeteorSpeed: 1, MeteorHealth: 2, AlienSpawn: 0.5, AlienHunter: 1.0 / 3, AlienFire: 1, Fairness: 0.75, Lives: 3, Shields: 3, Ranked: true},
+	{Name: "Hard", MeteorBudget: 1.5, MeteorSpeed: 1.25, MeteorHealth: 3, AlienSpawn: 0.7, AlienHunter: 0.6, AlienFire: 0.7, Fairness: 0.4, Lives: 2, Shields: 2, Ranked: true},
+}
+
+// defaultDifficulty is the index of Normal, the out-of-the-box level.
+const defaultDifficulty = 1
+
+// difficultyIndex returns the index of the difficulty called name, or the
+// default if there is none.
+func difficultyIndex(name string) int {
+	for i, d := range difficulties {
+		if d.Name == name {
+			return i
+		}
+	}
+	return defaultDifficulty
+}
+
+// difficultyNamed returns the difficulty called name, or the default.
+func difficultyNamed(name string) Difficulty {
+	return difficulties[difficultyIndex(name)]
+}
+
+// cycleDifficulty returns the name of the difficulty step places after
+// name, stopping at either end.
+func cycleDifficulty(name string, step int) string {
+	i := max(0, min(len(difficulties)-1, difficultyIndex(name)+step))
+	return difficulties[i].Name
+}
+
+// runDifficulty returns the difficulty a new run of mode plays on: the
+// configured one, except that competitive modes always play on Normal.
+func runDifficulty(mode Mode) Difficulty {
+	if mode.NoAssists {
+		return difficulties[defaultDifficulty]
+	}
+	return difficultyNamed(config.Difficulty)
+}
+
+// levelFor returns the definition of level n for this run's mode and
+// difficulty. A level with a meteor budget keeps at least one meteor.
+func (g *GameScene) levelFor(n int) Level {
+	l := g.mode.level(n)
+	if l.MeteorBudget > 0 {
+		l.MeteorBudget = max(1, int(math.Round(float64(l.MeteorBudget)*g.difficulty.MeteorBudget)))
+	}
+	l.MeteorVelocityStart *= g.difficulty.MeteorSpeed
+	l.MeteorVelocityCap *= g.difficulty.MeteorSpeed
+	return l
+}
+
+// alienAttackInterval returns the wait between alien volleys for this
+// run's mode and difficulty.
+func (g *GameScene) alienAttackInterval() time.Duration {
+	return time.Duration(float64(g.mode.alienAttackInterval()) * g.difficulty.AlienFire)
+}
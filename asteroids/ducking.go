@@ -0,0 +1,45 @@
+// File ducking.go defines ducking: when an explosion or alien laser plays,
+// the music and the heartbeat drop for a moment and come back up over half
+// a second, so the sounds that matter cut through the mix.
+package asteroids
+
+import (
+	"time"
+
+	"github.com/bensabler/asteroids/internal/sim"
+)
+
+// Ducking tuning.
+const (
+	duckLevel    = 0.4                    // Level of ducked players the moment a ducking effect plays.
+	duckRecovery = 500 * time.Millisecond // Time to climb from duckLevel back to full.
+)
+
+// sfxDucking lists the effects that duck the mix when they play.
+var sfxDucking = map[string]bool{
+	sfxExplosion:  true,
+	sfxAlienLaser: true,
+}
+
+// sfxDucked lists the effects lowered while the mix is ducked. All music
+// is lowered too.
+var sfxDucked = map[string]bool{
+	sfxBeatOne: true,
+	sfxBeatTwo: true,
+}
+
+// duck drops the ducked players to duckLevel, from where Update brings them
+// back up.
+func (a *AudioManager) duck() {
+	a.ducking = duckLevel
+	a.apply()
+}
+
+// Update brings ducked players back toward full level. Call it every tick.
+func (a *AudioManager) Update() {
+	if a.ducking >= 1 {
+		return
+	}
+	a.ducking = min(1, a.ducking+sim.PerTick((1-duckLevel)/duckRecovery.Seconds()))
+	a.apply()
+}
@@ -0,0 +1,59 @@
+// File emp.go implements the alien EMP shot: from alienEMPLevel on, some
+// alien volleys are EMP bolts that, instead of destroying the ship, apply
+// StatusEMP to lock its weapons out and scramble the HUD through a glitch
+// shader. A raised shield lets a weaker pulse through.
+package asteroids
+
+import (
+	"time"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// EMP tuning.
+const (
+	alienEMPLevel       = 8                       // First level whose aliens fire EMP shots.
+	alienEMPChance      = 0.3                     // Chance that a shot from such an alien is an EMP.
+	empDuration         = 1500 * time.Millisecond // Weapon lockout from a direct hit.
+	empShieldedDuration = 500 * time.Millisecond  // Lockout from a hit through the shield.
+	empShieldedStrength = 0.5                     // HUD scrambling through the shield, as a share of a direct hit.
+	empMinStrength      = 0.3                     // Scrambling as the effect wears off.
+)
+
+// hudLayer is a scratch buffer for drawing the HUD when it is scrambled,
+// sized by renderLayer.
+var hudLayer *ebiten.Image
+
+// firesEMP decides whether the next alien shot is an EMP bolt.
+func (g *GameScene) firesEMP() bool {
+	return g.currentLevel >= alienEMPLevel && g.rng.Stream(streamAI).Float64() < alienEMPChance
+}
+
+// hitByEMP applies an EMP bolt's effect to the ship, weaker through the
+// shield, with its crackle.
+func (g *GameScene) hitByEMP() {
+	g.combo.reset() // A hit breaks the combo, shielded or not.
+	if g.player.isShielded {
+		g.player.status.apply(StatusEMP, empShieldedDuration, empShieldedStrength)
+	} else {
+		g.player.status.apply(StatusEMP, empDuration, 1)
+	}
+	sharedAudio().PlaySFX(sfxEMP)
+}
+
+// drawGlitchedHUD draws the HUD from hudLayer onto screen through the glitch
+// shader, scrambled by emp's strength and settling as it wears off.
+func drawGlitchedHUD(screen *ebiten.Image, emp *StatusEffect) {
+	strength := emp.magnitude * (empMinStrength + (1-empMinStrength)*emp.remaining())
+	op := &ebiten.DrawRectShaderOptions{}
+	op.Images[0] = hudLayer
+	op.Uniforms = map[string]any{
+		"Time":     float32(float64(emp.timer.Elapsed) * sim.TickSeconds()),
+		"Strength": float32(strength),
+		"Scale":    float32(renderScale),
+	}
+	w, h := renderSize()
+	screen.DrawRectShader(w, h, assets.HUDGlitchShader, op)
+}
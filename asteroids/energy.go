@@ -0,0 +1,90 @@
+// File energy.go defines Energy, the shared regenerating resource that the
+// shield, hyperspace, and afterburner draw from under energy handling, and
+// the EnergyMeter HUD element that displays it.
+package asteroids
+
+import (
+	"image/color"
+	"time"
+
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Energy tuning and meter layout.
+const (
+	energyMax                  = 100.0           // Full meter.
+	energyRegenPerSecond       = 8.0             // Passive regeneration.
+	shieldEnergyCost           = 40.0            // Cost to raise the shield.
+	hyperspaceEnergyCost       = 50.0            // Cost per hyperspace jump.
+	boostEnergyCost            = 35.0            // Cost per afterburner burst.
+	energyHyperspaceCooldown   = 1 * time.Second // Minimum gap between jumps.
+	energyMeterWidth           = 150             // Meter width in pixels.
+	energyMeterHeight          = 8               // Meter height in pixels.
+	energyMeterLowFraction     = 0.25            // Below this the meter turns red.
+	energyMeterBackgroundAlpha = 80              // Alpha of the empty part of the meter.
+)
+
+// Energy is a pool that is spent by abilities and refills over time.
+type Energy struct {
+	current float64 // Energy available now (0–energyMax).
+}
+
+// NewEnergy returns a full energy pool.
+func NewEnergy() *Energy {
+	return &Energy{current: energyMax}
+}
+
+// Update regenerates one tick's worth of energy.
+func (e *Energy) Update() {
+	e.refill(sim.PerTick(energyRegenPerSecond))
+}
+
+// spend deducts amount and reports true if enough energy was available;
+// otherwise nothing is deducted.
+func (e *Energy) spend(amount float64) bool {
+	if e.current < amount {
+		return false
+	}
+	e.current -= amount
+	return true
+}
+
+// drain removes up to amount, stopping at empty.
+func (e *Energy) drain(amount float64) {
+	e.current = max(0, e.current-amount)
+}
+
+// refill adds amount, up to the maximum.
+func (e *Energy) refill(amount float64) {
+	e.current = min(energyMax, e.current+amount)
+}
+
+// fraction returns the fill level in [0, 1].
+func (e *Energy) fraction() float64 {
+	return e.current / energyMax
+}
+
+// EnergyMeter draws an Energy pool as a horizontal bar in the HUD.
+type EnergyMeter struct {
+	position Vector  // Top-left of the bar in screen space.
+	energy   *Energy // Pool being displayed.
+}
+
+// NewEnergyMeter creates a meter for energy at the given HUD coordinates.
+func NewEnergyMeter(position Vector, energy *Energy) *EnergyMeter {
+	return &EnergyMeter{position: position, energy: energy}
+}
+
+// Draw renders the bar in the palette's meter color, turning to its
+// warning color when the pool runs low.
+func (m *EnergyMeter) Draw(screen *ebiten.Image) {
+	x, y := float32(m.position.X), float32(m.position.Y)
+	fill := currentPalette().Meter
+	if m.energy.fraction() < energyMeterLowFraction {
+		fill = currentPalette().Warning
+	}
+
+	fillRect(screen, x, y, energyMeterWidth, energyMeterHeight, color.RGBA{R: 255, G: 255, B: 255, A: energyMeterBackgroundAlpha}, false)
+	fillRect(screen, x, y, energyMeterWidth*float32(m.energy.fraction()), energyMeterHeight, fill, false)
+}
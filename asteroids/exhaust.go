@@ -3,15 +3,17 @@
 package asteroids
 
 import (
-	"math"
-
 	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
 const (
 	// exhaustSpawnOffset determines how far behind the ship exhaust spawns.
 	exhaustSpawnOffset = -50.0
+
+	// exhaustSpeed is how fast the flare drifts away from the nozzle.
+	exhaustSpeed = 8.0
 )
 
 // Exhaust represents the visual particle trail emitted from the player’s ship
@@ -20,6 +22,7 @@ type Exhaust struct {
 	position Vector        // Current on-screen position.
 	rotation float64       // Facing direction (aligned opposite to ship thrust).
 	sprite   *ebiten.Image // Exhaust sprite image.
+	stretch  float64       // Length multiplier along the thrust axis (1 = normal).
 }
 
 // NewExhaust constructs a new exhaust particle at the given position and rotation.
@@ -41,6 +44,7 @@ func NewExhaust(position Vector, rotation float64) *Exhaust {
 		position: position,
 		rotation: rotation,
 		sprite:   sprite,
+		stretch:  1,
 	}
 }
 
@@ -54,19 +58,18 @@ func (e *Exhaust) Draw(screen *ebiten.Image) {
 
 	op := &ebiten.DrawImageOptions{}
 	op.GeoM.Translate(-halfW, -halfH) // Rotate around sprite center.
+	op.GeoM.Scale(1, e.stretch)       // Lengthen the flare (afterburner).
 	op.GeoM.Rotate(e.rotation)        // Align to ship thrust vector.
 	op.GeoM.Translate(halfW, halfH)
 	op.GeoM.Translate(e.position.X, e.position.Y)
 
-	screen.DrawImage(e.sprite, op)
+	drawRecolored(screen, e.sprite, currentPalette().Exhaust, op)
 }
 
 // Update moves the exhaust particle outward from its origin.
 //
 // The offset motion gives the appearance of exhaust being pushed away
-// from the ship by engine pressure. The rate is tied to maxAcceleration.
+// from the ship by engine pressure.
 func (e *Exhaust) Update() {
-	speed := maxAcceleration / float64(ebiten.TPS())
-	e.position.X += math.Sin(e.rotation) * speed
-	e.position.Y += math.Cos(e.rotation) * -speed
+	sim.Advance(&e.position, shipHeading(e.rotation).Scale(exhaustSpeed))
 }
@@ -0,0 +1,198 @@
+// File formation.go defines alien formations: descriptors for coordinated
+// groups (a V-shaped sweep, a circling ring, a pair of armored hunters) and
+// the builders that place their aliens. spawnAliens picks one by level.
+package asteroids
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/solarlune/resolv"
+)
+
+// Formation layout and motion tuning.
+const (
+	formationSpacing     = 45.0 // Distance between neighbouring aliens in a V.
+	formationSweepSpeed  = 90.0 // Horizontal speed of a V sweep.
+	formationRingRadius  = 80.0 // Radius of a circling ring.
+	formationRingSpin    = 1.8  // Radians per second a ring turns.
+	formationRingDrift   = 60.0 // Horizontal speed of a ring's center.
+	formationEdgeMargin  = 100  // Keeps formations clear of the top and bottom edges.
+	formationEntryOffset = 60.0 // How far off-screen a formation's leader starts.
+)
+
+// FormationKind selects how a formation is laid out and moves.
+type FormationKind int
+
+const (
+	// FormationSingle is one alien using a random spawn pattern.
+	FormationSingle FormationKind = iota
+
+	// FormationVSweep is a V of aliens sweeping across the screen, leader first.
+	FormationVSweep
+
+	// FormationCircle is a ring of aliens turning around a center that
+	// drifts across the screen.
+	FormationCircle
+
+	// FormationArmoredHunters is a group of armored aliens closing in on
+	// the ship from around the perimeter.
+	FormationArmoredHunters
+
+	// FormationArmoredVSweep is a V sweep led by an armored alien.
+	FormationArmoredVSweep
+)
+
+// Formation describes a group of aliens spawned together.
+type Formation struct {
+	Kind        FormationKind // Layout and motion.
+	Size        int           // Number of aliens in the group.
+	MinLevel    int           // First level on which the formation can appear.
+	NewGamePlus bool          // Appears only in New Game+.
+}
+
+// formations lists every formation spawnAliens may choose from.
+var formations = []Formation{
+	{Kind: FormationSingle, Size: 1, MinLevel: 1},
+	{Kind: FormationVSweep, Size: 5, MinLevel: 2},
+	{Kind: FormationCircle, Size: 4, MinLevel: 3},
+	{Kind: FormationArmoredHunters, Size: 2, MinLevel: 1, NewGamePlus: true},
+	{Kind: FormationArmoredVSweep, Size: 5, MinLevel: 2, NewGamePlus: true},
+}
+
+// alienOrbit is the circling motion of an alien in a ring. Every member of
+// a ring carries an identical copy of the center and drift, so the ring
+// stays together without shared state.
+type alienOrbit struct {
+	center Vector  // Ring center in world space.
+	drift  Vector  // Velocity of the center.
+	radius float64 // Distance from the center.
+	angle  float64 // Current angle around the center.
+	spin   float64 // Radians per second.
+}
+
+// formationFor picks a random formation among those unlocked at level n
+// under mode.
+func formationFor(n int, mode Mode, rng *rand.Rand) Formation {
+	var unlocked []Formation
+	for _, f := range formations {
+		if n >= f.MinLevel && (!f.NewGamePlus || mode.NewGamePlus) {
+			unlocked = append(unlocked, f)
+		}
+	}
+	return unlocked[rng.Intn(len(unlocked))]
+}
+
+// spawnFormation adds every alien of formation f to the scene.
+func (g *GameScene) spawnFormation(f Formation) {
+	var group []*Alien
+	switch f.Kind {
+	case FormationVSweep:
+		group = newVSweep(g, f.Size)
+	case FormationCircle:
+		group = newCircle(g, f.Size)
+	case FormationArmoredHunters:
+		for i := 0; i < f.Size; i++ {
+			alien := newAlienOfType(basedAlienVelocity*g.mode.enemySpeed(), g, alienHunter)
+			alien.armor = newGamePlusArmor
+			group = append(group, alien)
+		}
+	case FormationArmoredVSweep:
+		group = newVSweep(g, f.Size)
+		group[0].armor = newGamePlusArmor
+	default:
+		group = []*Alien{NewAlien(basedAlienVelocity*g.mode.enemySpeed(), g)}
+	}
+
+	for _, alien := range group {
+		g.addAlien(alien)
+	}
+}
+
+// newVSweep builds a V that enters from a side edge the spawn director
+// picks. The leader is
+// at the tip; followers trail behind it in pairs, one above and one below.
+func newVSweep(g *GameScene, size int) []*Alien {
+	sprite := assets.AlienSprites[g.rng.Stream(streamSpawns).Intn(len(assets.AlienSprites))]
+	direction := 1.0 // +1 sweeps right from the left edge, -1 sweeps left.
+	startX := -formationEntryOffset
+	if g.spawnFromRight(g.rng.Stream(streamSpawns).Float64()) {
+		direction = -1
+		startX = ScreenWidth + formationEntryOffset
+	}
+	tipY := float64(g.rng.Stream(streamSpawns).Intn(ScreenHeight-4*formationEdgeMargin) + 2*formationEdgeMargin)
+
+	group := make([]*Alien, 0, size)
+	for slot := 0; slot < size; slot++ {
+		rank := float64((slot + 1) / 2)
+		side := 1.0
+		if slot%2 == 1 {
+			side = -1
+		}
+		position := Vector{
+			X: startX - direction*rank*formationSpacing,
+			Y: tipY + side*rank*formationSpacing,
+		}
+		group = append(group, newFormationAlien(g, sprite, position, Vector{X: direction * formationSweepSpeed * g.mode.enemySpeed()}))
+	}
+	return group
+}
+
+// newCircle builds a ring of evenly spaced aliens whose center enters from a
+// side edge the spawn director picks and drifts across the screen.
+func newCircle(g *GameScene, size int) []*Alien {
+	sprite := assets.AlienSprites[g.rng.Stream(streamSpawns).Intn(len(assets.AlienSprites))]
+	drift := Vector{X: formationRingDrift * g.mode.enemySpeed()}
+	center := Vector{X: -formationEntryOffset - formationRingRadius/2}
+	if g.spawnFromRight(g.rng.Stream(streamSpawns).Float64()) {
+		drift.X = -drift.X
+		center.X = ScreenWidth - center.X
+	}
+	center.Y = float64(g.rng.Stream(streamSpawns).Intn(ScreenHeight-2*formationEdgeMargin) + formationEdgeMargin)
+
+	group := make([]*Alien, 0, size)
+	for slot := 0; slot < size; slot++ {
+		orbit := &alienOrbit{
+			center: center,
+			drift:  drift,
+			radius: formationRingRadius,
+			angle:  float64(slot) * 2 * math.Pi / float64(size),
+			spin:   formationRingSpin,
+		}
+		alien := newFormationAlien(g, sprite, orbit.position(), drift)
+		alien.orbit = orbit
+		group = append(group, alien)
+	}
+	return group
+}
+
+// newFormationAlien constructs one non-intelligent formation member.
+func newFormationAlien(g *GameScene, sprite *ebiten.Image, position, movement Vector) *Alien {
+	alien := &Alien{
+		game:     g,
+		sprite:   sprite,
+		position: position,
+		alienObj: resolv.NewCircle(position.X, position.Y, float64(sprite.Bounds().Dx()/2)),
+		movement: movement,
+	}
+	alien.alienObj.SetPosition(position.X, position.Y)
+	alien.alienObj.Tags().Set(TagAlien)
+	return alien
+}
+
+// step advances the ring one tick.
+func (o *alienOrbit) step() {
+	sim.Advance(&o.center, o.drift)
+	o.angle += sim.PerTick(o.spin)
+}
+
+// position returns the alien's current point on the ring.
+func (o *alienOrbit) position() Vector {
+	return Vector{
+		X: o.center.X + math.Cos(o.angle)*o.radius,
+		Y: o.center.Y + math.Sin(o.angle)*o.radius,
+	}
+}
@@ -1,35 +1,41 @@
 // File game_over_scene.go implements the GameOverScene, which displays
-// a "GAME OVER" banner with ambient meteors and allows restart or quit.
+// a "GAME OVER" banner over the dimmed final state of the run and allows
+// restart or quit.
 package asteroids
 
 import (
+	"fmt"
 	"image/color"
 
 	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
 	"github.com/hajimehoshi/ebiten/v2"
 	inpututil "github.com/hajimehoshi/ebiten/v2/inpututil"
 	text "github.com/hajimehoshi/ebiten/v2/text/v2"
 )
 
-// GameOverScene shows the game-over screen with drifting meteors and stars.
+// GameOverScene shows the game-over screen on top of the finished run.
+//
+// The real GameScene keeps drifting underneath (meteors, aliens, and
+// projectiles move; nothing spawns or collides), so no decorative or
+// half-initialized scene is needed for the backdrop.
 type GameOverScene struct {
-	game        *GameScene      // The gameplay scene to reset/restart.
-	meteors     map[int]*Meteor // Ambient meteors for background motion.
-	meteorCount int             // Monotonic ID for meteors in this scene.
-	stars       []*Star         // Starfield backdrop.
+	game *GameScene // The finished gameplay scene to show and reset/restart.
 }
 
-// Draw renders stars, ambient meteors, the main banner, and a high-score tag.
-func (o *GameOverScene) Draw(screen *ebiten.Image) {
-	// Background stars for continuity with the rest of the game.
-	for _, star := range o.stars {
-		star.Draw(screen)
-	}
+// NewGameOverScene silences the run's looping sounds, starts fading the
+// music, and returns the game-over screen for it.
+func NewGameOverScene(game *GameScene) *GameOverScene {
+	game.pauseLoopingSounds()
+	game.music.FadeOut()
+	return &GameOverScene{game: game}
+}
 
-	// Ambient meteors for subtle motion.
-	for _, meteor := range o.meteors {
-		meteor.Draw(screen)
-	}
+// Draw renders the dimmed final game state, the main banner, and a high-score tag.
+func (o *GameOverScene) Draw(screen *ebiten.Image) {
+	// The run as it ended, pushed into the background.
+	o.game.Draw(screen)
+	dimScreen(screen, 160)
 
 	// Centered "GAME OVER" banner.
 	const title = "GAME OVER"
@@ -38,47 +44,73 @@ func (o *GameOverScene) Draw(screen *ebiten.Image) {
 	}
 	op.ColorScale.ScaleWithColor(color.White)
 	op.GeoM.Translate(float64(ScreenWidth/2), float64(ScreenHeight/2))
-	text.Draw(screen, title, &text.GoTextFace{
+	drawText(screen, title, &text.GoTextFace{
 		Source: assets.TitleFont,
 		Size:   72,
 	}, op)
 
-	// Congratulate for a new high score, if achieved this run.
-	if o.game.score > originalHighScore {
+	// A replay reports whether it reproduced the recorded score.
+	if p := o.game.playback; p != nil {
+		label, c := "Replay Verified", color.Color(color.RGBA{R: 120, G: 220, B: 120, A: 255})
+		if o.game.score != p.replay.Score || p.divergedAt >= 0 {
+			label = fmt.Sprintf("Replay Diverged (recorded %d)", p.replay.Score)
+			if p.divergedAt >= 0 {
+				label = fmt.Sprintf("Replay Diverged at %s (recorded %d)", formatDuration(sim.Duration(p.divergedAt).Seconds()), p.replay.Score)
+			}
+			c = color.RGBA{R: 230, G: 90, B: 90, A: 255}
+		}
+		drawCenteredText(screen, label, assets.TitleFont, 36, ScreenWidth/2, ScreenHeight/2+80, c)
+		return
+	}
+
+	// Congratulate for a place on the high-score table, if earned this run.
+	if rank := o.game.highScoreRank; rank >= 0 {
 		label := "New High Score!"
+		if rank > 0 {
+			label = fmt.Sprintf("High Score #%d!", rank+1)
+		}
 		op := &text.DrawOptions{
 			LayoutOptions: text.LayoutOptions{PrimaryAlign: text.AlignCenter},
 		}
 		op.ColorScale.ScaleWithColor(color.RGBA{R: 255, G: 215, B: 0, A: 255}) // gold-ish
 		op.GeoM.Translate(float64(ScreenWidth/2), float64((ScreenHeight/2)+80))
-		text.Draw(screen, label, &text.GoTextFace{
+		drawText(screen, label, &text.GoTextFace{
 			Source: assets.TitleFont,
 			Size:   48,
 		}, op)
 	}
+
+	// New Game+ reached for the first time this run.
+	if o.game.newGamePlusUnlocked {
+		drawCenteredText(screen, "New Game+ unlocked", assets.ScoreFont, 20, ScreenWidth/2, ScreenHeight/2+185, color.RGBA{R: 255, G: 215, B: 0, A: 255})
+	}
+
+	// Crystals paid into the profile for the shop.
+	if o.game.crystalsEarned > 0 {
+		drawCenteredText(screen, fmt.Sprintf("+%d crystals (%d total)", o.game.crystalsEarned, profile.Crystals),
+			assets.ScoreFont, 20, ScreenWidth/2, ScreenHeight/2+150, color.RGBA{R: 120, G: 200, B: 255, A: 255})
+	}
 }
 
-// Update advances ambient effects and handles restart/quit input.
+// Update keeps the world drifting and handles restart/quit input.
+//
+// Space: reset GameScene and return to play (after a replay, go to the title).
+// Tap:   same as Space.
 //
-// Space: reset GameScene and return to play.
 // Q:     request Ebiten termination.
-// Meteors: maintain a small pool for animation.
 func (o *GameOverScene) Update(state *State) error {
-	// Maintain up to 10 background meteors.
-	if len(o.meteors) < 10 {
-		meteor := NewMeteor(0.25, &GameScene{}, len(o.meteors)-1)
-		o.meteorCount++
-		o.meteors[o.meteorCount] = meteor
-	}
+	o.game.updateBackground()
+	o.game.music.Update()
 
-	// Animate ambient meteors.
-	for _, meteor := range o.meteors {
-		meteor.Update()
+	// A finished replay has nothing to restart.
+	if o.game.playback != nil && (inpututil.IsKeyJustPressed(ebiten.KeySpace) || touchTapped()) {
+		state.SceneManager.GoToScene(NewTitleScene())
+		return nil
 	}
 
 	// Restart game.
-	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
-		o.game.Reset()
+	if inpututil.IsKeyJustPressed(ebiten.KeySpace) || touchTapped() {
+		o.game.restart()
 		state.SceneManager.GoToScene(o.game)
 		return nil
 	}
@@ -1,45 +1,54 @@
 // File game-scene.go implements the core gameplay scene: spawning and
 // updating entities (player, meteors, aliens, lasers), collision handling,
-// simple audio, UI text, and level progression.
+// simple audio, and level progression. The HUD over it lives in hud.go.
 package asteroids
 
 import (
-	"fmt"
-	"image/color"
 	"log"
 	"math"
-	"math/rand"
 	"time"
 
 	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/audio"
-	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+	inpututil "github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/solarlune/resolv"
 )
 
 // Gameplay tuning constants.
 const (
-	baseMeteorVelocity   = 0.25                    // Starting speed for large meteors.
+	baseMeteorVelocity   = sim.MeteorBaseVelocity  // Starting speed for large meteors.
 	meteorSpawnTime      = 100 * time.Millisecond  // Interval between meteor spawns.
-	meteorSpeedUpAmount  = 0.1                     // Per-interval increase in meteor speed.
-	meteorSpeedUpTime    = 1000 * time.Millisecond // Interval to apply meteor speed increase.
+	meteorSpeedUpAmount  = 6.0                     // Per-interval increase in meteor speed (unbounded ramp).
+	meteorSpeedUpTime    = 1000 * time.Millisecond // Interval to apply meteor speed increase (unbounded ramp).
 	cleanUpExplosionTime = 200 * time.Millisecond  // Interval to remove exploded sprites.
 	baseBeatWaitTime     = 1600                    // ms between heartbeat sounds; decreases over time.
 	numberOfStars        = 1000                    // Background star count.
 	alienAttackTime      = 3 * time.Second         // Attack cadence per alien.
 	alienSpawnTime       = 1 * time.Second         // Window to attempt alien spawns.
-	basedAlienVelocity   = 0.5                     // Base alien movement speed.
+	basedAlienVelocity   = 30.0                    // Base alien movement speed.
+	goldRushSpawnTime    = 250 * time.Millisecond  // Interval between gold meteors in a bonus round.
+	goldRushPoints       = 10                      // Points per gold hit, times the chain length.
 )
 
+// worldLayer is a scratch buffer for drawing the world when the camera is
+// displaced, so the HUD can stay fixed on top.
+var worldLayer *ebiten.Image
+
 // GameScene hosts the main play loop, entity maps, timers, and audio handles.
 type GameScene struct {
+	mode                 Mode
+	level                Level
+	levelTicks           int
 	player               *Player
 	baseVelocity         float64
 	meteorCount          int
 	meteorSpawnTimer     *Timer
+	goldSpawnTimer       *Timer
+	goldChain            int
 	meteors              map[int]*Meteor
-	meteorsForLevel      int
+	waves                *WaveManager
 	velocityTimer        *Timer
 	space                *resolv.Space
 	lasers               map[int]*Laser
@@ -50,172 +59,260 @@ type GameScene struct {
 	explosionFrames      []*ebiten.Image
 	cleanUpTimer         *Timer
 	playerIsDead         bool
-	audioContext         *audio.Context
 	thrustPlayer         *audio.Player
-	exhaust              *Exhaust
-	laserOnePlayer       *audio.Player
-	laserTwoPlayer       *audio.Player
-	laserThreePlayer     *audio.Player
-	explosionPlayer      *audio.Player
-	beatOnePlayer        *audio.Player
-	beatTwoPlayer        *audio.Player
 	beatTimer            *Timer
 	beatWaitTime         int
 	playBeatOne          bool
 	stars                []*Star
 	currentLevel         int
-	shield               *Shield
-	shieldsUpPlayer      *audio.Player
 	alienAttackTimer     *Timer
 	alienCount           int
 	alienLaserCount      int
-	alienLaserPlayer     *audio.Player
+	sparks               []wallSpark      // Flecks thrown by bounces off solid walls.
+	warpMotes            []warpMote       // Flecks of the ship where it jumped away.
+	warpFlashes          []warpFlash      // Blooms where the ship came out of a jump.
+	popups               []scorePopup     // Floating scores left by kills.
+	despawns             []*despawnEffect // Fading stand-ins for entities that left play.
 	alienLasers          map[int]*AlienLaser
-	alienSoundPlayer     *audio.Player
+	alienHum             *SoundEmitter
+	music                *Music
 	alienSpawnTimer      *Timer
 	aliens               map[int]*Alien
+	powerUps             map[int]*PowerUp
+	powerUpCount         int
+	scrapPieces          []scrapPiece // Salvage drifting in play.
+	scrap                int          // Scrap collected and not yet spent this run.
+	collisions           *collisionCache
+	practice             *PracticeConfig
+	smartBombReady       bool
+	spareSmartBombs      int // Smart bombs bought in the salvage shop, for after the level's own is spent.
+	hud                  *HUD
+	shockwave            *Shockwave
+	cameraKick           Vector
+	boss                 *Boss
+	tractor              *TractorBeam
+	comet                *Comet
+	cometWhoosh          *SoundEmitter
+	cometSpawnTimer      *Timer
+	scanner              *Scanner
+	mines                map[int]*Mine
+	mineCount            int
+	input                *Input
+	pools                *entityPools       // Recycled lasers and meteors.
+	scratch              updateScratch      // Buffers reused to hand entity maps to the worker pool.
+	seed                 int64              // Seed of the current run.
+	rng                  *RNG               // Named streams for every roll, derived from seed.
+	stats                *RunStats          // Statistics of the current run.
+	wave                 *WaveStats         // Statistics of the wave in play.
+	spawns               *sim.SpawnDirector // Steers spawns away from the ship's blind spots.
+	script               *sim.LevelScript   // Scripted events of the level attempt in play.
+	combo                *Combo             // Kill streak multiplying points.
+	comboTones           []*audio.Player    // Combo blips, from x2 up.
+	replay               *Replay            // Input recorded for this run; nil when not recording.
+	playback             *replayPlayer      // Recorded input being re-simulated; nil in live play.
+	rules                tickRules          // Rule settings in force this tick.
+	assistInput          Input              // Input as rewritten by the assists this tick.
+	fireLatched          bool               // Toggle fire has been tapped on.
+	thrustLatched        bool               // Toggle thrust has been tapped on.
+	assisted             bool               // An assist has been used this run.
+	highScoreRank        int                // Place this run took on the high-score table from 0; -1 if none.
+	upgrades             Upgrades           // Upgrade levels this run flies with.
+	difficulty           Difficulty         // Difficulty this run plays on.
+	ship                 ShipClass          // Ship this run flies.
+	crystalsEarned       int                // Crystals the finished run paid into the profile.
+	newGamePlusUnlocked  bool               // This run reached the New Game+ milestone first.
+	arena                *Arena             // Closing boundary; nil unless the mode has ShrinkingArena.
 }
 
 // NewGameScene constructs and initializes the main gameplay scene.
 //
 // Sets up timers, spaces, entity stores, audio players, and baseline level state.
-func NewGameScene() *GameScene {
+// The mode selects which ruleset the run uses.
+func NewGameScene(mode Mode) *GameScene {
+	return newGameScene(mode, runSeed(), runUpgrades(mode), runDifficulty(mode), runShipClass(mode))
+}
+
+// newGameScene constructs a gameplay scene whose run starts from seed.
+func newGameScene(mode Mode, seed int64, upgrades Upgrades, difficulty Difficulty, ship ShipClass) *GameScene {
 	g := &GameScene{
-		meteorSpawnTimer:     NewTimer(meteorSpawnTime),
+		mode:                 mode,
+		upgrades:             upgrades,
+		difficulty:           difficulty,
+		ship:                 ship,
+		meteorSpawnTimer:     sim.NewTimer(meteorSpawnTime),
+		goldSpawnTimer:       sim.NewTimer(goldRushSpawnTime),
 		baseVelocity:         baseMeteorVelocity,
-		velocityTimer:        NewTimer(meteorSpeedUpTime),
+		velocityTimer:        sim.NewTimer(meteorSpeedUpTime),
 		meteors:              make(map[int]*Meteor),
 		meteorCount:          0,
-		meteorsForLevel:      2,
 		space:                resolv.NewSpace(ScreenWidth, ScreenHeight, 16, 16),
 		lasers:               make(map[int]*Laser),
 		laserCount:           0,
 		explosionSprite:      assets.ExplosionSprite,
 		explosionSmallSprite: assets.ExplosionSmallSprite,
-		cleanUpTimer:         NewTimer(cleanUpExplosionTime),
-		beatTimer:            NewTimer(2 * time.Second),
+		cleanUpTimer:         sim.NewTimer(cleanUpExplosionTime),
+		beatTimer:            sim.NewTimer(2 * time.Second),
 		beatWaitTime:         baseBeatWaitTime,
 		currentLevel:         1,
+		highScoreRank:        -1,
 		aliens:               make(map[int]*Alien),
 		alienCount:           0,
 		alienLasers:          make(map[int]*AlienLaser),
 		alienLaserCount:      0,
-		alienSpawnTimer:      NewTimer(alienSpawnTime),
-		alienAttackTimer:     NewTimer(alienAttackTime),
+		alienSpawnTimer:      sim.NewTimer(alienSpawnTime),
+		powerUps:             make(map[int]*PowerUp),
+		collisions:           newCollisionCache(),
+		smartBombReady:       true,
+		scanner:              NewScanner(),
+		mines:                make(map[int]*Mine),
+		pools:                &entityPools{},
+		seed:                 seed,
+	}
+	g.hud = NewHUD(g)
+	g.level = g.levelFor(1)
+	g.waves = sim.NewWaveManager(g.level)
+	g.script = sim.NewLevelScript(g.level)
+	g.alienAttackTimer = sim.NewTimer(g.alienAttackInterval())
+	g.rng = newRunRNG(g.seed, nil)
+	g.cometSpawnTimer = newCometSpawnTimer(g.rng.Stream(streamSpawns))
+	g.stats = newRunStats(mode, g.seed)
+	g.wave = newWaveStats(g)
+	g.spawns = sim.NewSpawnDirector(ScreenWidth, ScreenHeight, spawnFairness(mode, difficulty))
+	g.combo = newCombo()
+	if !mode.Practice {
+		g.replay = newReplay(mode, g.seed, upgrades, difficulty, ship)
+	}
+
+	if mode.ShrinkingArena {
+		g.arena = NewArena()
+	}
+
+	// Practice runs spawn from the panel's settings instead of the level table.
+	if mode.Practice {
+		g.practice = newPracticeConfig()
 	}
 
 	// Player and world setup.
 	g.player = NewPlayer(g)
 	g.space.Add(g.player.playerObj)
-	g.stars = GenerateStars(numberOfStars)
+	g.stars = GenerateStars(starCount(), g.rng.Stream(streamCosmetics))
 
 	// Explosion animation frames.
 	g.explosionFrames = assets.Explosion
 
-	// Audio wiring: create players for each sound effect / loop.
-	g.audioContext = audio.NewContext(48000)
-
-	thrustPlayer, err := g.audioContext.NewPlayer(assets.ThrustSound)
-	if err != nil {
-		panic(err)
-	}
-	g.thrustPlayer = thrustPlayer
-
-	laserOnePlayer, err := g.audioContext.NewPlayer(assets.LaserOneSound)
-	if err != nil {
-		panic(err)
-	}
-	g.laserOnePlayer = laserOnePlayer
+	// Audio wiring: the process-wide loops, silenced in case a previous run
+	// left one sounding; one-shot effects go through PlaySFX.
+	sound := sharedAudio()
+	g.thrustPlayer = sharedLoop(sound, "thrust", func() *audio.Player { return sound.NewSFXPlayer(assets.ThrustSound, 1) })
+	g.comboTones = sharedLoop(sound, "combo-tones", func() []*audio.Player { return newComboTones(sound) })
+	g.alienHum = NewSoundEmitter("alien-hum", assets.AlienSound, 0.5, g.alienHumSource) // Quieter ambient alien tone.
+	g.cometWhoosh = NewSoundEmitter("comet-whoosh", assets.CometSound, 0.7, g.cometWhooshSource)
+	g.music = NewMusic()
+	g.pauseLoopingSounds()
 
-	laserTwoPlayer, err := g.audioContext.NewPlayer(assets.LaserTwoSound)
-	if err != nil {
-		panic(err)
-	}
-	g.laserTwoPlayer = laserTwoPlayer
-
-	laserThreePlayer, err := g.audioContext.NewPlayer(assets.LaserThreeSound)
-	if err != nil {
-		panic(err)
-	}
-	g.laserThreePlayer = laserThreePlayer
-
-	explosionPlayer, err := g.audioContext.NewPlayer(assets.ExplosionSound)
-	if err != nil {
-		panic(err)
-	}
-	g.explosionPlayer = explosionPlayer
+	return g
+}
 
-	beatOnePlayer, err := g.audioContext.NewPlayer(assets.BeatOneSound)
-	if err != nil {
-		panic(err)
+// Update advances one tick of gameplay.
+//
+// Order is intentional: update player and effects, spawn/advance entities,
+// resolve collisions and scoring, handle pacing, then manage transitions/cleanup.
+func (g *GameScene) Update(state *State) error {
+	g.input = state.Input
+	g.rules = g.currentRules()
+
+	// Replays feed recorded input instead of the keyboard; Escape ends one,
+	// unless it plays behind the title menu.
+	if g.playback != nil {
+		g.playback.verify(g)
+		input, rules, ok := g.playback.next()
+		if !ok || !g.playback.background && inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+			state.SceneManager.GoToScene(NewGameOverScene(g))
+			return nil
+		}
+		g.input, g.rules = input, rules
 	}
-	g.beatOnePlayer = beatOnePlayer
 
-	beatTwoPlayer, err := g.audioContext.NewPlayer(assets.BeatTwoSound)
-	if err != nil {
-		panic(err)
+	// Escape/P freezes the run behind the pause menu.
+	if g.playback == nil && (inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsKeyJustPressed(ebiten.KeyP)) {
+		state.SceneManager.GoToScene(NewPauseScene(g))
+		return nil
 	}
-	g.beatTwoPlayer = beatTwoPlayer
 
-	shieldsUpPlayer, err := g.audioContext.NewPlayer(assets.ShieldSound)
-	if err != nil {
-		panic(err)
+	// Tab opens the practice panel over the frozen run.
+	if g.practice != nil && inpututil.IsKeyJustPressed(ebiten.KeyTab) {
+		state.SceneManager.PushScene(NewPracticeScene(g))
+		return nil
 	}
-	g.shieldsUpPlayer = shieldsUpPlayer
 
-	alienLaserPlayer, err := g.audioContext.NewPlayer(assets.AlienLaserSound)
-	if err != nil {
-		panic(err)
+	// Every tick that simulates is recorded for the run's replay.
+	if g.replay != nil {
+		g.replay.record(g, g.input, g.rules)
 	}
-	g.alienLaserPlayer = alienLaserPlayer
 
-	alienSoundPlayer, err := g.audioContext.NewPlayer(assets.AlienSound)
-	if err != nil {
-		panic(err)
-	}
-	alienSoundPlayer.SetVolume(0.5) // Quieter ambient alien tone.
-	g.alienSoundPlayer = alienSoundPlayer
+	// Assists press fire and thrust on the player's behalf.
+	g.applyAssists()
 
-	return g
-}
+	// Background music runs whenever live play does.
+	g.music.Play()
 
-// Update advances one tick of gameplay.
-//
-// Order is intentional: update player and effects, spawn/advance entities,
-// resolve collisions and scoring, handle pacing, then manage transitions/cleanup.
-func (g *GameScene) Update(state *State) error {
 	g.player.Update()
+	g.updateStatusEffects() // Timed conditions on the ship.
 
 	g.updateExhaust()
 	g.updateShield()
 
 	g.isPlayerDying()     // Progress death animation if in progress.
+	g.updateCombo()       // Run the combo window down; damage breaks it.
 	g.isPlayerDead(state) // Handle life loss / game over transitions.
+	g.waves.Tick()        // Count down timed waves.
+	g.observeSpawns()     // Learn where the ship is exposed.
 	g.spawnMeteors()      // Maintain meteor population for this level.
 	g.spawnAliens()       // Opportunistic alien spawn.
-	for _, alien := range g.aliens {
+	g.runLevelScript()    // The level's scripted moments.
+	g.spawnBoss()         // Boss levels bring in their boss.
+	g.updateBoss()
+	g.spawnComet() // Occasional comet flyby.
+	g.updateComet()
+	g.updateMines()    // Arm, blink, and clear spent mines.
+	g.updateSparks()   // Age the wall-impact sparks.
+	g.updateWarps()    // Age the hyperspace motes and flashes.
+	g.updatePopups()   // Age the score popups.
+	g.updateDespawns() // Age the despawn effects.
+	for _, alien := range inOrder(g.aliens) {
 		alien.Update()
 	}
-	g.letAliensAttack() // Alien fire cadence and laser spawns.
-
-	for _, al := range g.alienLasers {
-		al.Update()
-	}
-	for _, meteor := range g.meteors {
-		meteor.Update()
-	}
-	for _, laser := range g.lasers {
-		laser.Update()
-	}
+	g.letAliensAttack()     // Alien fire cadence and laser spawns.
+	g.letAliensDropMines()  // Now and then an alien leaves a mine behind.
+	g.updatePowerUps()      // Drift and expire pickups.
+	g.updateScrap()         // Drift, pull in, and collect salvage.
+	g.updateShockwave()     // Smart bomb ring effect.
+	g.updateTractorBeam()   // Latch, hold, or fling a meteor.
+	g.updateThrownMeteors() // Flung meteors calm down after a while.
+	g.updateScanner()       // Reveal meteors held in the ship's aim.
+	g.settleCamera()        // Ease any camera kick back to rest.
+
+	g.moveProjectilesAndMeteors() // Bulk movement, fanned out when counts are large.
+	g.collideMeteors()            // Optional meteor-to-meteor bounces.
+	g.updateArena()               // Close the arena walls in.
 
 	g.speedUpMeteors() // Global meteor speed curve.
 
 	// Collisions: order avoids double-accounting and prefers player survival checks early.
+	// The cache is reset so each pair is evaluated at most once this tick.
+	g.collisions.reset()
 	g.isPlayerCollidingWithMeteor()
 	g.isMeteorHitByPlayerLaser()
 	g.isPlayerCollidingWithAlien()
 	g.isPlayerHitByAlienLaser()
 	g.isAlienHitByPlayerLaser()
+	g.isThrownMeteorHittingEnemies()
+	g.isPlayerCollidingWithBoss()
+	g.isPlayerCollidingWithComet()
+	g.isMineTriggered()
+	g.isCometHitByPlayerLaser()
+	g.isBossHitByPlayerLaser()
+	g.isPlayerCollectingPowerUp()
 
 	g.cleanUpMeteorsAndAliens() // Remove exploded entities.
 	g.beatSound()               // Heartbeat pacing SFX.
@@ -223,12 +320,18 @@ func (g *GameScene) Update(state *State) error {
 
 	g.removeOffscreenAliens()
 	g.removeOffscreenLasers()
+	g.removeStreamedMeteors()
+	g.updateSoundEmitters() // Move, or stop, the alien hum and comet whoosh.
+	g.hud.Update()          // Bring the HUD in step with the run.
+
+	g.stats.ticks++
+	g.wave.ticks++
 
 	return nil
 }
 
-// Draw renders background first, then player/effects/entities, then UI text.
-func (g *GameScene) Draw(screen *ebiten.Image) {
+// drawWorld renders background first, then player/effects/entities.
+func (g *GameScene) drawWorld(screen *ebiten.Image) {
 	// Background.
 	for _, star := range g.stars {
 		star.Draw(screen)
@@ -236,14 +339,16 @@ func (g *GameScene) Draw(screen *ebiten.Image) {
 
 	// Player and player-attached effects.
 	g.player.Draw(screen)
-	if g.exhaust != nil {
-		g.exhaust.Draw(screen)
-	}
-	if g.shield != nil {
-		g.shield.Draw(screen)
+	g.player.drawEffects(screen)
+	g.drawTractorBeam(screen)
+	if config.ShipLabels {
+		drawShipLabels(screen, g.shipLabels())
 	}
 
-	// Entities.
+	// Entities. The boss goes first so shed meteors read on top of it.
+	if g.boss != nil {
+		g.boss.Draw(screen)
+	}
 	for _, meteor := range g.meteors {
 		meteor.Draw(screen)
 	}
@@ -256,45 +361,50 @@ func (g *GameScene) Draw(screen *ebiten.Image) {
 	for _, al := range g.alienLasers {
 		al.Draw(screen)
 	}
-
-	// HUD: score.
-	textToDraw := fmt.Sprintf("Score: %06d", g.score)
-	op := &text.DrawOptions{
-		LayoutOptions: text.LayoutOptions{PrimaryAlign: text.AlignCenter},
+	for _, pu := range g.powerUps {
+		pu.Draw(screen)
 	}
-	op.ColorScale.ScaleWithColor(color.White)
-	op.GeoM.Translate(ScreenWidth/2, 40)
-	text.Draw(screen, textToDraw, &text.GoTextFace{
-		Source: assets.ScoreFont,
-		Size:   24,
-	}, op)
-
-	// HUD: high score (session-persistent via init()).
-	if g.score >= highScore {
-		highScore = g.score
+	g.drawScrap(screen)
+	for _, m := range g.mines {
+		m.Draw(screen)
+	}
+	if g.comet != nil {
+		g.comet.Draw(screen)
+	}
+	g.drawDespawns(screen)
+	if g.shockwave != nil {
+		g.shockwave.Draw(screen)
 	}
-	textToDraw = fmt.Sprintf("High Score: %06d", highScore)
-	op = &text.DrawOptions{
-		LayoutOptions: text.LayoutOptions{PrimaryAlign: text.AlignCenter},
+	g.drawSparks(screen)
+	g.drawWarps(screen)
+	g.drawPopups(screen)
+	if a := g.activeArena(); a != nil {
+		a.Draw(screen)
+	}
+	if colliderView {
+		g.drawColliders(screen)
+	}
+}
+
+// Draw renders the world (offset by any camera kick), then the HUD.
+func (g *GameScene) Draw(screen *ebiten.Image) {
+	if g.cameraKick == (Vector{}) {
+		g.drawWorld(screen)
+	} else {
+		layer := renderLayer(&worldLayer)
+		g.drawWorld(layer)
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(g.cameraKick.X, g.cameraKick.Y)
+		drawLayer(screen, layer, op)
 	}
-	op.ColorScale.ScaleWithColor(color.White)
-	op.GeoM.Translate(ScreenWidth/2, 80)
-	text.Draw(screen, textToDraw, &text.GoTextFace{
-		Source: assets.ScoreFont,
-		Size:   16,
-	}, op)
 
-	// HUD: level.
-	textToDraw = fmt.Sprintf("Current Level: %d", g.currentLevel)
-	op = &text.DrawOptions{
-		LayoutOptions: text.LayoutOptions{PrimaryAlign: text.AlignCenter},
+	// HUD, scrambled while an EMP is in effect.
+	if emp := g.player.status.active[StatusEMP]; emp != nil {
+		g.hud.Draw(renderLayer(&hudLayer))
+		drawGlitchedHUD(screen, emp)
+	} else {
+		g.hud.Draw(screen)
 	}
-	op.ColorScale.ScaleWithColor(color.White)
-	op.GeoM.Translate(ScreenWidth/2, ScreenHeight-40)
-	text.Draw(screen, textToDraw, &text.GoTextFace{
-		Source: assets.LevelFont,
-		Size:   16,
-	}, op)
 }
 
 // Layout returns passthrough dimensions when embedding GameScene directly.
@@ -306,169 +416,366 @@ func (g *GameScene) Layout(outsideWidth, outsideHeight int) (ScreenWidth, Screen
 
 // isPlayerCollidingWithAlien kills or ignores based on player shield state.
 func (g *GameScene) isPlayerCollidingWithAlien() {
-	for _, a := range g.aliens {
-		if a.alienObj.IsIntersecting(g.player.playerObj) {
+	if g.isBonusRound() {
+		return // Nothing can kill the ship during a bonus round.
+	}
+	for _, a := range inOrder(g.aliens) {
+		if g.collisions.intersects(a.alienObj, g.player.playerObj) {
 			if !a.game.player.isShielded {
-				// Play explosion once and mark player as dying.
-				if !a.game.explosionPlayer.IsPlaying() {
-					_ = a.game.explosionPlayer.Rewind()
-					a.game.explosionPlayer.Play()
-				}
+				// Play the explosion and mark the player as dying.
+				sharedAudio().PlaySFXAt(sfxExplosion, spriteCenter(g.player.position, g.player.sprite).X)
 				a.game.player.isDying = true
 			}
 		}
 	}
 }
 
-// isPlayerHitByAlienLaser applies damage on hit and removes the laser.
+// isPlayerHitByAlienLaser applies damage on hit, or an EMP's effect, and
+// removes the laser.
 func (g *GameScene) isPlayerHitByAlienLaser() {
-	for _, al := range g.alienLasers {
-		if al.laserObj.IsIntersecting(g.player.playerObj) {
-			if !g.player.isShielded {
-				if !g.explosionPlayer.IsPlaying() {
-					_ = g.explosionPlayer.Rewind()
-					g.explosionPlayer.Play()
-				}
+	if g.isBonusRound() {
+		return // Nothing can kill the ship during a bonus round.
+	}
+	for i, al := range inOrder(g.alienLasers) {
+		if g.collisions.intersects(al.laserObj, g.player.playerObj) {
+			if al.emp {
+				g.hitByEMP()
+			} else if !g.player.isShielded {
+				sharedAudio().PlaySFXAt(sfxExplosion, spriteCenter(g.player.position, g.player.sprite).X)
 				g.player.isDying = true
 			}
 			// Remove collided alien laser from space and map.
-			g.space.Remove(al.laserObj)
-			for i, laser := range g.alienLasers {
-				if laser == al {
-					delete(g.alienLasers, i)
-					break
-				}
-			}
+			g.collisions.consume(al.laserObj)
+			g.removeAlienLaser(i)
 		}
 	}
 }
 
 // isAlienHitByPlayerLaser awards score, plays SFX, and marks explosion sprite.
 func (g *GameScene) isAlienHitByPlayerLaser() {
-	for _, a := range g.aliens {
-		for _, l := range g.lasers {
-			if a.alienObj.IsIntersecting(l.laserObj) {
-				laserData := l.laserObj.Data().(*ObjectData)
-				delete(g.alienLasers, laserData.index) // Clean tracking for player laser.
-				g.space.Remove(l.laserObj)
+	for _, a := range inOrder(g.aliens) {
+		for i, l := range inOrder(g.lasers) {
+			if g.collisions.intersects(a.alienObj, l.laserObj) {
+				// The laser is spent and the alien is exploding; neither can hit again.
+				g.collisions.consume(a.alienObj, l.laserObj)
+				g.laserHit(i)
+
+				// Armor soaks the hit instead.
+				if a.armor > 0 {
+					a.armor--
+					break
+				}
 
 				a.sprite = g.explosionSmallSprite
-				g.score += 50
-				if !g.explosionPlayer.IsPlaying() {
-					_ = g.explosionPlayer.Rewind()
-					g.explosionPlayer.Play()
+				g.scoreKill(50, a.position)
+				g.dropScrap(a.position, scrapAlien)
+				g.maybeDropPowerUp(a.position, powerUpAlienDropRate)
+				if a.isIntelligent {
+					g.maybeDropExtraLife(a.position)
 				}
+				sharedAudio().PlaySFXAt(sfxExplosion, a.position.X)
 			}
 		}
 	}
 }
 
-// removeOffscreenLasers deletes player and alien lasers that leave bounds.
+// laserHit removes a player laser that struck something, counting the hit.
+func (g *GameScene) laserHit(index int) {
+	g.stats.ShotsHit++
+	g.wave.hit()
+	g.removeLaser(index)
+}
+
+// removeLaser deletes a player laser from the map and the collision space
+// and returns it to the pool.
+func (g *GameScene) removeLaser(index int) {
+	if laser, ok := g.lasers[index]; ok {
+		g.space.Remove(laser.laserObj)
+		delete(g.lasers, index)
+		g.pools.lasers.Put(laser)
+	}
+}
+
+// updateBackground advances the world without the player, for scenes that
+// show a finished run behind an overlay: entities keep moving and expired
+// ones are cleaned up, but nothing spawns, collides, or scores.
+func (g *GameScene) updateBackground() {
+	g.updateBoss()
+	g.updateComet()
+	g.updateMines()
+	g.updateSparks()
+	g.updateWarps()
+	for _, alien := range inOrder(g.aliens) {
+		alien.Update()
+	}
+	g.moveProjectilesAndMeteors()
+	g.updatePowerUps()
+	g.updateScrap()
+	g.cleanUpMeteorsAndAliens()
+	g.removeOffscreenAliens()
+	g.removeOffscreenLasers()
+	g.removeStreamedMeteors()
+}
+
+// moveProjectilesAndMeteors advances alien lasers, meteors, and player lasers.
+//
+// Position updates are independent per entity and run on the worker pool;
+// collider syncs touch the shared resolv space and run serially afterwards.
+func (g *GameScene) moveProjectilesAndMeteors() {
+	alienLasers := g.scratch.alienLasers.valuesOf(g.alienLasers)
+	updatePool.run(len(alienLasers), func(i int) { alienLasers[i].move() })
+	for _, al := range alienLasers {
+		al.syncCollider()
+	}
+
+	meteors := g.scratch.meteors.valuesOf(g.meteors)
+	updatePool.run(len(meteors), func(i int) { meteors[i].move() })
+	for _, meteor := range meteors {
+		meteor.syncCollider()
+		g.meteorAtEdge(meteor)
+	}
+
+	g.steerLasers() // Aim assist reads the whole scene, so it runs serially.
+	lasers := g.scratch.lasers.valuesOf(g.lasers)
+	updatePool.run(len(lasers), func(i int) { lasers[i].move() })
+	for _, laser := range lasers {
+		laser.syncCollider()
+	}
+}
+
+// meteorAtEdge answers what the field's edge did to m on its last move: a
+// bounce throws sparks and a lethal wall destroys it, scoring nothing.
+// Meteors that left the field are culled by removeStreamedMeteors.
+func (g *GameScene) meteorAtEdge(m *Meteor) {
+	switch m.edge {
+	case contactBounced:
+		g.wallImpact(spriteCenter(m.position, m.sprite))
+	case contactStruck:
+		if g.isExploding(m) {
+			return
+		}
+		g.explodeMeteor(m)
+		sharedAudio().PlaySFXAt(sfxExplosion, spriteCenter(m.position, m.sprite).X)
+	}
+}
+
+// laserMargin is how far past the screen edge a laser flies before it is culled.
+const laserMargin = 50
+
+// removeOffscreenLasers deletes player and alien lasers the field's edge
+// has culled or absorbed.
 func (g *GameScene) removeOffscreenLasers() {
+	policy := g.boundary()
+	spent := func(p *Vector) bool {
+		return policy.release(edgeBody{position: p, margin: laserMargin}) != contactNone
+	}
 	// Player lasers.
-	for i, laser := range g.lasers {
-		if laser.position.X < -50 || laser.position.X > ScreenWidth+50 ||
-			laser.position.Y < -50 || laser.position.Y > ScreenHeight+50 {
-			g.space.Remove(laser.laserObj)
-			delete(g.lasers, i)
-		}
+	for _, i := range g.scratch.lasers.cull(g.lasers, func(laser *Laser) bool {
+		return spent(&laser.position)
+	}) {
+		g.wave.miss()
+		g.removeLaser(i)
 	}
 	// Alien lasers.
-	for i, alienLaser := range g.alienLasers {
-		if alienLaser.position.X < -50 || alienLaser.position.X > ScreenWidth+50 ||
-			alienLaser.position.Y < -50 || alienLaser.position.Y > ScreenHeight+50 {
-			g.space.Remove(alienLaser.laserObj)
-			delete(g.alienLasers, i)
-		}
+	for _, i := range g.scratch.alienLasers.cull(g.alienLasers, func(alienLaser *AlienLaser) bool {
+		return spent(&alienLaser.position)
+	}) {
+		g.removeAlienLaser(i)
 	}
 }
 
-// spawnAliens opportunistically creates aliens when none are active.
+// isOffscreen reports whether p lies more than margin pixels outside the screen.
+func isOffscreen(p Vector, margin float64) bool {
+	return p.X < -margin || p.X > ScreenWidth+margin ||
+		p.Y < -margin || p.Y > ScreenHeight+margin
+}
+
+// spawnAliens opportunistically creates a formation of aliens (possibly a
+// lone one) when none are active. Larger formations unlock on later levels.
+//
+// When the mode waits for aliens before completing a level, spawning stops
+// once the meteors are cleared so the level can actually end.
 func (g *GameScene) spawnAliens() {
 	g.alienSpawnTimer.Update()
+	if g.practice != nil {
+		g.spawnPracticeAliens()
+		return
+	}
+	if g.mode.Completion == CompleteOnMeteorsAndAliens && g.waves.IsCleared() {
+		return
+	}
+	if g.isBonusRound() || g.level.Spawns(sim.SpawnHunters) || g.level.Spawns(sim.SpawnSweepers) {
+		return
+	}
 	if len(g.aliens) == 0 {
 		if g.alienSpawnTimer.IsReady() {
 			g.alienSpawnTimer.Reset()
-			rnd := rand.Intn(100-1) + 1
-			if rnd > 50 {
-				alien := NewAlien(basedAlienVelocity, g)
-				g.space.Add(alien.alienObj)
-				g.alienCount++
-				g.aliens[g.alienCount] = alien
+			if g.rng.Stream(streamSpawns).Float64() < g.difficulty.AlienSpawn*g.level.AlienSpawnRate {
+				g.spawnFormation(formationFor(g.currentLevel, g.mode, g.rng.Stream(streamSpawns)))
 			}
 		}
 	}
 }
 
-// removeOffscreenAliens prunes aliens that drift far outside view.
+// removeStreamedMeteors prunes gold meteors that have crossed the screen.
+func (g *GameScene) removeStreamedMeteors() {
+	for _, i := range g.scratch.meteors.cull(g.meteors, func(m *Meteor) bool {
+		return m.edge == contactCulled
+	}) {
+		m := g.meteors[i]
+		var tint ebiten.ColorScale
+		if m.gold {
+			tint.Scale(1, 0.84, 0.2, 1)
+		}
+		g.despawn(m.sprite, spriteCenter(m.position, m.sprite), m.rotation, tint)
+		g.removeMeteor(i)
+	}
+}
+
+// isBonusRound reports whether the current level is a gold-rush bonus round.
+func (g *GameScene) isBonusRound() bool {
+	return g.level.Kind == WaveGoldRush
+}
+
+// removeOffscreenAliens prunes aliens the field's edge has culled.
 func (g *GameScene) removeOffscreenAliens() {
-	for i, alien := range g.aliens {
-		if alien.position.X < -200 || alien.position.X > ScreenWidth+200 ||
-			alien.position.Y < -200 || alien.position.Y > ScreenHeight+200 {
-			delete(g.aliens, i)
-			g.space.Remove(alien.alienObj)
+	for _, i := range g.scratch.aliens.cull(g.aliens, func(alien *Alien) bool {
+		return alien.edge == contactCulled
+	}) {
+		a := g.aliens[i]
+		var tint ebiten.ColorScale
+		if a.armor > 0 {
+			tint.Scale(1, 0.55, 0.55, 1)
 		}
+		g.despawn(a.sprite, a.position, 0, tint)
+		g.space.Remove(g.aliens[i].alienObj)
+		delete(g.aliens, i)
 	}
 }
 
-// isMeteorHitByPlayerLaser handles meteor damage/explosion and small splits.
+// isMeteorHitByPlayerLaser handles meteor damage/explosion and splits.
+// A meteor with health to spare is only chipped and flashes.
 func (g *GameScene) isMeteorHitByPlayerLaser() {
-	for _, meteor := range g.meteors {
-		for _, laser := range g.lasers {
-			if meteor.meteorObj.IsIntersecting(laser.laserObj) {
-				if meteor.meteorObj.Tags().Has(TagSmall) {
-					// Small meteor: explode and score.
+	for _, meteor := range inOrder(g.meteors) {
+		// Already destroyed meteors only await cleanup; prune their pairs entirely.
+		if g.isExploding(meteor) {
+			continue
+		}
+		for i, laser := range inOrder(g.lasers) {
+			if g.collisions.intersects(meteor.meteorObj, laser.laserObj) {
+				// One hit per laser and per meteor: retire both for this tick
+				// and take the laser out of play.
+				g.collisions.consume(meteor.meteorObj, laser.laserObj)
+				g.laserHit(i)
+
+				at := spriteCenter(meteor.position, meteor.sprite)
+				if meteor.gold {
+					// Gold meteor: each consecutive hit is worth more.
 					meteor.sprite = g.explosionSmallSprite
-					g.score++
-					if !g.explosionPlayer.IsPlaying() {
-						_ = g.explosionPlayer.Rewind()
-						g.explosionPlayer.Play()
-					}
+					g.goldChain++
+					g.score += goldRushPoints * g.goldChain
+					g.popScore(goldRushPoints*g.goldChain, at)
+					sharedAudio().PlaySFXAt(sfxExplosion, at.X)
+				} else if meteor.health > 1 {
+					// A tough meteor: chip it and let it flash.
+					meteor.health--
+					meteor.hitFlash = sim.NewTimer(meteorHitFlash)
+					sharedAudio().PlaySFXAt(sfxWall, at.X)
 				} else {
-					// Large meteor: explode and optionally split into small ones.
-					oldPosition := meteor.position
-					meteor.sprite = g.explosionSprite
-					g.score++
-					if !g.explosionPlayer.IsPlaying() {
-						_ = g.explosionPlayer.Rewind()
-						g.explosionPlayer.Play()
-					}
-
-					// Spawn a random number of small meteors near the impact.
-					numberToSpawn := rand.Intn(numOfSmallMeteorsFromLargeMeteor)
-					for i := 0; i < numberToSpawn; i++ {
-						child := NewSmallMeteor(baseMeteorVelocity, g, len(meteor.game.meteors)-1)
-						child.position = Vector{
-							X: oldPosition.X + float64(rand.Intn(100-50)+50),
-							Y: oldPosition.Y + float64(rand.Intn(100-50)+50),
-						}
-						child.meteorObj.SetPosition(child.position.X, child.position.Y)
-						g.space.Add(child.meteorObj)
-						g.meteorCount++
-						g.meteors[meteor.game.meteorCount] = child
-					}
+					// Regular meteor: score, salvage, maybe drop a pickup, and shatter.
+					g.scoreKill(meteor.points(), at)
+					g.dropScrap(at, scrapFrom(meteor))
+					g.maybeDropPowerUp(meteor.position, powerUpMeteorDropRate)
+					g.splitMeteor(meteor)
 				}
+				break
 			}
 		}
 	}
 }
 
-// spawnMeteors maintains a level-capped population of large meteors.
+// splitMeteor explodes a meteor shot by a laser. Large meteors also split
+// into meteorSplitCount mediums near the impact, and mediums into as many
+// smalls.
+func (g *GameScene) splitMeteor(meteor *Meteor) {
+	sharedAudio().PlaySFXAt(sfxExplosion, spriteCenter(meteor.position, meteor.sprite).X)
+	g.explodeMeteor(meteor)
+
+	var newPiece func(baseVelocity float64, game *GameScene, index int) *Meteor
+	switch tags := meteor.meteorObj.Tags(); {
+	case tags.Has(TagLarge):
+		newPiece = NewMediumMeteor
+	case tags.Has(TagMedium):
+		newPiece = NewSmallMeteor
+	default:
+		return
+	}
+	for range meteorSplitCount {
+		child := newPiece(baseMeteorVelocity, g, g.meteorCount+1)
+		child.position = Vector{
+			X: meteor.position.X + float64(g.rng.Stream(streamSpawns).Intn(100-50)+50),
+			Y: meteor.position.Y + float64(g.rng.Stream(streamSpawns).Intn(100-50)+50),
+		}
+		child.meteorObj.SetPosition(child.position.X, child.position.Y)
+		g.addMeteor(child)
+		g.waves.TrackSplit()
+	}
+}
+
+// spawnMeteors releases the level's budget of large meteors one per interval,
+// or streams gold meteors while a bonus round's clock is running.
 func (g *GameScene) spawnMeteors() {
+	if g.practice != nil {
+		g.spawnPracticeMeteors()
+		return
+	}
+	if g.isBonusRound() {
+		g.goldSpawnTimer.Update()
+		if g.goldSpawnTimer.IsReady() && g.waves.CanSpawn() {
+			g.goldSpawnTimer.Reset()
+			g.addMeteor(NewGoldMeteor(g.baseVelocity, g, g.meteorCount+1))
+		}
+		return
+	}
+
 	g.meteorSpawnTimer.Update()
 	if g.meteorSpawnTimer.IsReady() {
 		g.meteorSpawnTimer.Reset()
-		if len(g.meteors) < g.meteorsForLevel && g.meteorCount < g.meteorsForLevel {
-			meteor := NewMeteor(g.baseVelocity, g, len(g.meteors)-1)
-			g.space.Add(meteor.meteorObj)
-			g.meteorCount++
-			g.meteors[g.meteorCount] = meteor
+		if g.waves.CanSpawn() {
+			g.addMeteor(NewMeteor(g.baseVelocity, g, g.meteorCount+1))
+			g.waves.TrackSpawn()
 		}
 	}
 }
 
+// addMeteor registers a meteor under the next ID and adds its collider to the
+// space. meteorCount is the monotonic ID source, so the meteor must have been
+// constructed with index meteorCount+1.
+func (g *GameScene) addMeteor(m *Meteor) {
+	g.meteorCount++
+	g.space.Add(m.meteorObj)
+	g.meteors[g.meteorCount] = m
+}
+
+// addAlien puts an alien in play and registers it with the collision space.
+func (g *GameScene) addAlien(a *Alien) {
+	g.space.Add(a.alienObj)
+	g.alienCount++
+	g.aliens[g.alienCount] = a
+}
+
 // speedUpMeteors ramps global meteor velocity over time.
+//
+// By default the ramp follows the current level's bounded curve; modes with
+// UnboundedSpeedRamp add a fixed amount every interval for as long as the
+// level lasts.
 func (g *GameScene) speedUpMeteors() {
+	if !g.mode.UnboundedSpeedRamp {
+		g.levelTicks++
+		g.baseVelocity = g.level.MeteorVelocityAt(g.levelTicks)
+		return
+	}
+
 	g.velocityTimer.Update()
 	if g.velocityTimer.IsReady() {
 		g.velocityTimer.Reset()
@@ -478,14 +785,19 @@ func (g *GameScene) speedUpMeteors() {
 
 // isPlayerCollidingWithMeteor applies damage or bounce depending on shield.
 func (g *GameScene) isPlayerCollidingWithMeteor() {
-	for _, m := range g.meteors {
-		if m.meteorObj.IsIntersecting(g.player.playerObj) {
+	if g.isBonusRound() {
+		return // Nothing can kill the ship during a bonus round.
+	}
+	for _, m := range inOrder(g.meteors) {
+		// Destroyed meteors are harmless debris until cleanup; held and flung
+		// ones belong to the player.
+		if g.isExploding(m) || g.isShipSafeFrom(m) {
+			continue
+		}
+		if g.collisions.intersects(m.meteorObj, g.player.playerObj) {
 			if !g.player.isShielded {
 				m.game.player.isDying = true
-				if !g.explosionPlayer.IsPlaying() {
-					_ = g.explosionPlayer.Rewind()
-					g.explosionPlayer.Play()
-				}
+				sharedAudio().PlaySFXAt(sfxExplosion, spriteCenter(g.player.position, g.player.sprite).X)
 				break
 			}
 			// Shield active: repel meteor away from player vicinity.
@@ -513,14 +825,18 @@ func (g *GameScene) bounceMeteor(m *Meteor) {
 func (g *GameScene) cleanUpMeteorsAndAliens() {
 	g.cleanUpTimer.Update()
 	if g.cleanUpTimer.IsReady() {
-		for i, meteor := range g.meteors {
-			if meteor.sprite == g.explosionSprite || meteor.sprite == g.explosionSmallSprite {
-				delete(g.meteors, i)
-				g.space.Remove(meteor.meteorObj)
+		for i, meteor := range inOrder(g.meteors) {
+			if g.isExploding(meteor) {
+				g.stats.MeteorsDestroyed++
+				if !meteor.gold {
+					g.waves.TrackRemoval()
+				}
+				g.removeMeteor(i)
 			}
 		}
-		for i, alien := range g.aliens {
+		for i, alien := range inOrder(g.aliens) {
 			if alien.sprite == g.explosionSmallSprite {
+				g.stats.AliensDestroyed++
 				delete(g.aliens, i)
 				g.space.Remove(alien.alienObj)
 			}
@@ -529,21 +845,25 @@ func (g *GameScene) cleanUpMeteorsAndAliens() {
 	}
 }
 
+// explodeMeteor swaps m's sprite for the explosion that fits its size,
+// marking it destroyed.
+func (g *GameScene) explodeMeteor(m *Meteor) {
+	if m.meteorObj.Tags().Has(TagLarge) {
+		m.sprite = g.explosionSprite
+		return
+	}
+	m.sprite = g.explosionSmallSprite
+}
+
+// isExploding reports whether a meteor has been destroyed and is only
+// waiting for cleanUpMeteorsAndAliens to remove it.
+func (g *GameScene) isExploding(m *Meteor) bool {
+	return m.sprite == g.explosionSprite || m.sprite == g.explosionSmallSprite
+}
+
 // isPlayerDying steps the player's death animation and flags final state.
 func (g *GameScene) isPlayerDying() {
-	if g.player.isDying {
-		g.player.dyingTimer.Update()
-		if g.player.dyingTimer.IsReady() {
-			g.player.dyingTimer.Reset()
-			g.player.dyingCounter++
-			if g.player.dyingCounter == 12 {
-				g.player.isDying = false
-				g.player.isDead = true
-			} else if g.player.dyingCounter < 12 {
-				g.player.sprite = g.explosionFrames[g.player.dyingCounter]
-			}
-		}
-	}
+	g.player.updateDying(g.explosionFrames)
 }
 
 // isPlayerDead handles life decrement, scene transitions, and state resets.
@@ -552,46 +872,95 @@ func (g *GameScene) isPlayerDying() {
 // Otherwise: soft-resets the scene while preserving score, lives, stars, shields.
 func (g *GameScene) isPlayerDead(state *State) {
 	if g.player.isDead {
-		g.player.livesRemaning--
-		if g.player.livesRemaning == 0 {
-			// High score persistence.
-			if g.score > originalHighScore {
-				if err := updateHighScore(g.score); err != nil {
-					log.Println(err)
-				}
+		if !g.mode.InfiniteLives {
+			g.player.livesRemaning--
+		}
+		if g.player.livesRemaning == 0 && g.playback != nil {
+			// The replay has played out; show how it compares with the record.
+			state.SceneManager.GoToScene(NewGameOverScene(g))
+		} else if g.player.livesRemaning == 0 {
+			g.recordStats()
+			g.updateProfile()
+			g.saveReplay()
+			// Transition to GameOver over the final state of this run, by way
+			// of initials entry for a new high score.
+			if g.earnsHighScore() {
+				state.SceneManager.GoToScene(NewInitialsScene(g, func(state *State) error {
+					state.SceneManager.GoToScene(NewGameOverScene(g))
+					return nil
+				}))
+			} else {
+				state.SceneManager.GoToScene(NewGameOverScene(g))
 			}
-			// Transition to GameOver with fresh decorative state.
-			state.SceneManager.GoToScene(&GameOverScene{
-				game:        g,
-				meteors:     make(map[int]*Meteor),
-				meteorCount: 5,
-				stars:       GenerateStars(numberOfStars),
-			})
 		} else {
 			// Preserve relevant state across the respawn.
 			score := g.score
+			scrap := g.scrap
 			livesRemaining := g.player.livesRemaning
-			lifeSlice := g.player.lifeIndicators[:len(g.player.lifeIndicators)-1]
 			stars := g.stars
 			shieldsRemaining := g.player.shieldsRemaning
-			shieldIndicatorSlice := g.player.shieldIndicators
+			runUpgrades := g.player.runUpgrades
 
 			// Full scene reset, then restore preserved bits.
 			g.Reset()
 			g.player.livesRemaning = livesRemaining
 			g.score = score
-			g.player.lifeIndicators = lifeSlice
+			g.scrap = scrap
 			g.stars = stars
+			g.player.runUpgrades = runUpgrades
 			g.player.shieldsRemaning = shieldsRemaining
-			g.player.shieldIndicators = shieldIndicatorSlice
 		}
 	}
 }
 
+// recordStats adds the run to the statistics log. Practice runs are not
+// recorded.
+func (g *GameScene) recordStats() {
+	if g.mode.Practice || g.playback != nil {
+		return
+	}
+	g.stats.Score = g.score
+	g.stats.Assisted = g.assisted
+	stats.record(*g.stats)
+}
+
+// ranked reports whether the run's score may enter the high-score table:
+// not in an unranked mode or difficulty, not a replay, not assisted, and
+// not on custom levels.
+func (g *GameScene) ranked() bool {
+	return !g.mode.Unranked && g.difficulty.Ranked && g.playback == nil && !g.assisted && !customLevels
+}
+
+// earnsHighScore reports whether the run's score makes the high-score table
+// and has not been entered yet.
+func (g *GameScene) earnsHighScore() bool {
+	return g.ranked() && g.highScoreRank < 0 && g.highScoreTable().qualifies(g.score)
+}
+
+// saveHighScore enters the run on the high-score table under initials, if
+// it qualifies, and saves the table.
+func (g *GameScene) saveHighScore(initials string) {
+	if !g.earnsHighScore() {
+		return
+	}
+	table := g.highScoreTable()
+	g.highScoreRank = table.insert(HighScore{
+		Score:       g.score,
+		Initials:    initials,
+		Level:       g.currentLevel,
+		Difficulty:  g.difficulty.Name,
+		DirectorOff: g.mode.NoSpawnDirector,
+		Date:        time.Now(),
+	})
+	if err := table.Save(); err != nil {
+		log.Println("Error saving high scores", err)
+	}
+}
+
 // updateExhaust advances the player exhaust animation if active.
 func (g *GameScene) updateExhaust() {
-	if g.exhaust != nil {
-		g.exhaust.Update()
+	if g.player.exhaust != nil {
+		g.player.exhaust.Update()
 	}
 }
 
@@ -599,25 +968,84 @@ func (g *GameScene) updateExhaust() {
 //
 // Preserves no score or player state; caller can selectively restore fields.
 func (g *GameScene) Reset() {
+	g.releaseAll()
 	g.player = NewPlayer(g)
 	g.meteors = make(map[int]*Meteor)
 	g.meteorCount = 0
+	g.laserCount = 0
+	g.waves.RestartLevel()
+	g.script = sim.NewLevelScript(g.level)
 	g.lasers = make(map[int]*Laser)
 	g.score = 0
 	g.meteorSpawnTimer.Reset()
-	g.baseVelocity = baseMeteorVelocity
+	g.baseVelocity = g.level.MeteorVelocityStart
+	g.levelTicks = 0
 	g.velocityTimer.Reset()
 	g.playerIsDead = false
-	g.exhaust = nil
 	g.space.RemoveAll()
 	g.space.Add(g.player.playerObj)
-	g.stars = GenerateStars(numberOfStars)
-	g.player.shieldsRemaning = numberOfShields
+	g.stars = GenerateStars(starCount(), g.rng.Stream(streamCosmetics))
+	g.player.shieldsRemaning = g.player.maxShields()
 	g.player.isShielded = false
 	g.aliens = make(map[int]*Alien)
 	g.alienCount = 0
 	g.alienLasers = make(map[int]*AlienLaser)
 	g.alienLaserCount = 0
+	g.powerUps = make(map[int]*PowerUp)
+	g.powerUpCount = 0
+	g.scrapPieces = nil
+	g.scrap = 0
+	g.goldChain = 0
+	g.shockwave = nil
+	g.sparks = nil
+	g.warpMotes = nil
+	g.warpFlashes = nil
+	g.popups = nil
+	g.despawns = nil
+	g.cameraKick = Vector{}
+	g.boss = nil
+	if g.arena != nil {
+		g.arena.reset()
+	}
+	g.tractor = nil
+	g.comet = nil
+	g.cometSpawnTimer = newCometSpawnTimer(g.rng.Stream(streamSpawns))
+	g.scanner = NewScanner()
+	g.mines = make(map[int]*Mine)
+	g.mineCount = 0
+}
+
+// restart begins a brand-new run: Reset plus level progression and tempo.
+func (g *GameScene) restart() {
+	g.seed = runSeed()
+	g.rng = newRunRNG(g.seed, nil)
+	g.stats = newRunStats(g.mode, g.seed)
+	g.fireLatched, g.thrustLatched, g.assisted = false, false, false
+	g.highScoreRank = -1
+	g.crystalsEarned = 0
+	g.newGamePlusUnlocked = false
+	if g.replay != nil {
+		g.replay = newReplay(g.mode, g.seed, g.upgrades, g.difficulty, g.ship)
+	}
+
+	// Every timer the run reads starts over, so a restarted run plays out
+	// exactly like a fresh one from the same seed.
+	g.goldSpawnTimer.Reset()
+	g.cleanUpTimer.Reset()
+	g.alienSpawnTimer.Reset()
+	g.alienAttackTimer.Reset()
+	g.beatTimer.Reset()
+	g.currentLevel = 1
+	g.level = g.levelFor(1)
+	g.Reset()
+	g.wave = newWaveStats(g)
+	g.spawns = sim.NewSpawnDirector(ScreenWidth, ScreenHeight, spawnFairness(g.mode, g.difficulty))
+	g.combo = newCombo()
+	g.waves.StartLevel(g.level)
+	g.beatWaitTime = baseBeatWaitTime
+	g.music.Stop() // The next run starts the track from the top.
+	g.smartBombReady = true
+	g.spareSmartBombs = 0
 }
 
 // beatSound alternates heartbeat SFX and accelerates tempo over time.
@@ -625,12 +1053,10 @@ func (g *GameScene) beatSound() {
 	g.beatTimer.Update()
 	if g.beatTimer.IsReady() {
 		if g.playBeatOne {
-			_ = g.beatOnePlayer.Rewind()
-			g.beatOnePlayer.Play()
+			sharedAudio().PlaySFX(sfxBeatOne)
 			g.beatTimer.Reset()
 		} else {
-			_ = g.beatTwoPlayer.Rewind()
-			g.beatTwoPlayer.Play()
+			sharedAudio().PlaySFX(sfxBeatTwo)
 			g.beatTimer.Reset()
 		}
 
@@ -639,67 +1065,152 @@ func (g *GameScene) beatSound() {
 		// Gradually reduce the wait time to increase tempo, clamped.
 		if g.beatWaitTime > 400 {
 			g.beatWaitTime -= 25
-			g.beatTimer = NewTimer(time.Millisecond * time.Duration(g.beatWaitTime))
+			g.beatTimer = sim.NewTimer(time.Millisecond * time.Duration(g.beatWaitTime))
 		}
 	}
 }
 
-// isLevelComplete advances level on meteor clear, grants life every 5th level,
-// resets beat tempo, and clears any remaining player lasers.
+// levelCleared applies the mode's completion rule to the current field.
+func (g *GameScene) levelCleared() bool {
+	if g.practice != nil {
+		return false // Practice has no levels to finish.
+	}
+	if g.isBonusRound() {
+		return g.waves.IsCleared() // Bonus rounds end on the clock alone.
+	}
+	switch g.mode.Completion {
+	case CompleteOnMeteorsAndAliens:
+		return g.waves.IsCleared() && len(g.aliens) == 0
+	default:
+		return g.waves.IsCleared()
+	}
+}
+
+// isLevelComplete advances level once the completion rule is met, grants life
+// every 5th level, resets beat tempo, and clears any remaining player lasers.
+// Aliens are left in place so, under CompleteOnMeteors, a lingering saucer
+// carries into the next wave.
 func (g *GameScene) isLevelComplete(state *State) {
-	if len(g.meteors) == 0 && g.meteorCount >= g.meteorsForLevel {
-		g.baseVelocity = baseMeteorVelocity
-		g.currentLevel++
-
-		// Award an extra life every 5th level up to a cap.
-		if g.currentLevel%5 == 0 {
-			if g.player.livesRemaning < 6 {
-				g.player.livesRemaning++
-				x := float64(20 + (g.player.livesRemaning * 50.0))
-				y := 20.0
-				g.player.lifeIndicators = append(g.player.lifeIndicators, NewLifeIndicator(Vector{X: x, Y: y}))
+	if g.levelCleared() {
+		summary := g.wave.finish(g.score)
+		if !g.isBonusRound() && levels.HasBonusRoundAfter(g.currentLevel) {
+			// Insert a gold-rush round before the next numbered level.
+			g.level = levels.BonusRoundFor(g.currentLevel)
+			g.goldChain = 0
+		} else {
+			g.removeGoldMeteors()
+			g.currentLevel++
+			g.level = g.levelFor(g.currentLevel)
+			g.stats.Level = g.currentLevel
+			g.unlockNewGamePlus()
+
+			// Award an extra life every 5th level up to a cap.
+			if g.currentLevel%5 == 0 {
+				if g.player.livesRemaning < maxLives {
+					g.player.livesRemaning++
+				}
 			}
 		}
+		g.baseVelocity = g.level.MeteorVelocityStart
+		g.levelTicks = 0
+		g.script = sim.NewLevelScript(g.level)
+		g.smartBombReady = true // One smart bomb per level.
+		if g.arena != nil {
+			g.arena.reset() // Every level starts with the full field.
+		}
 
 		// Reset heartbeat pacing and transition to level-start interlude.
 		g.beatWaitTime = baseBeatWaitTime
+		g.wave = newWaveStats(g)
 		state.SceneManager.GoToScene(&LevelStartsScene{
 			game:           g,
-			nextLevelTimer: NewTimer(3 * time.Second),
-			stars:          GenerateStars(numberOfStars),
+			summary:        summary,
+			summaryTimer:   sim.NewTimer(waveSummaryDuration),
+			nextLevelTimer: sim.NewTimer(3 * time.Second),
+			stars:          GenerateStars(starCount(), g.rng.Stream(streamCosmetics)),
 		})
 
 		// Remove any remaining player lasers for a clean start.
-		for k, v := range g.lasers {
-			delete(g.lasers, k)
-			g.space.Remove(v.laserObj)
+		for k := range inOrder(g.lasers) {
+			g.removeLaser(k)
 		}
 	}
 }
 
+// removeGoldMeteors clears any gold meteors left when a bonus round ends.
+func (g *GameScene) removeGoldMeteors() {
+	for _, i := range g.scratch.meteors.cull(g.meteors, func(m *Meteor) bool { return m.gold }) {
+		g.removeMeteor(i)
+	}
+}
+
+// kickCamera displaces the world view by offset; it eases back over a few
+// ticks. Reduced motion keeps the view still.
+func (g *GameScene) kickCamera(offset Vector) {
+	if config.ReducedMotion {
+		return
+	}
+	g.cameraKick = offset
+}
+
+// settleCamera decays the camera kick toward rest.
+func (g *GameScene) settleCamera() {
+	g.cameraKick.X *= 0.8
+	g.cameraKick.Y *= 0.8
+	if math.Abs(g.cameraKick.X) < 0.1 && math.Abs(g.cameraKick.Y) < 0.1 {
+		g.cameraKick = Vector{}
+	}
+}
+
+// pauseLoopingSounds stops the thrust loop and the entity emitters, for
+// scenes that freeze or end the run.
+func (g *GameScene) pauseLoopingSounds() {
+	if g.thrustPlayer.IsPlaying() {
+		g.thrustPlayer.Pause()
+	}
+	g.alienHum.Pause()
+	g.cometWhoosh.Pause()
+}
+
+// updateSoundEmitters places the entity emitters around the ship.
+func (g *GameScene) updateSoundEmitters() {
+	listener := spriteCenter(g.player.position, g.player.sprite)
+	g.alienHum.Update(listener)
+	g.cometWhoosh.Update(listener)
+}
+
+// alienHumSource places the alien hum on the nearest intact alien.
+func (g *GameScene) alienHumSource() (Vector, bool) {
+	ship := spriteCenter(g.player.position, g.player.sprite)
+	nearest, found := Vector{}, false
+	for _, a := range inOrder(g.aliens) {
+		if a.sprite == g.explosionSmallSprite {
+			continue
+		}
+		if !found || distance(a.position, ship) < distance(nearest, ship) {
+			nearest, found = a.position, true
+		}
+	}
+	return nearest, found
+}
+
 // updateShield advances the shield effect if present.
 func (g *GameScene) updateShield() {
-	if g.shield != nil {
-		g.shield.Update()
+	if g.player.shield != nil {
+		g.player.shield.Update()
 	}
 }
 
 // letAliensAttack drives alien laser spawning and SFX, with optional aim.
 func (g *GameScene) letAliensAttack() {
 	if len(g.aliens) > 0 {
-		// Ambient alien tone while present.
-		if !g.alienSoundPlayer.IsPlaying() {
-			_ = g.alienSoundPlayer.Rewind()
-			g.alienSoundPlayer.Play()
-		}
-
 		// Attack cadence gate.
 		g.alienAttackTimer.Update()
 		if g.alienAttackTimer.IsReady() {
 			g.alienAttackTimer.Reset()
 
 			// Each alien fires one laser.
-			for _, alien := range g.aliens {
+			for _, alien := range inOrder(g.aliens) {
 				bounds := alien.sprite.Bounds()
 				halfWidth := float64(bounds.Dx()) / 2
 				halfHeight := float64(bounds.Dy()) / 2
@@ -707,7 +1218,7 @@ func (g *GameScene) letAliensAttack() {
 				var degreesRadian float64
 				if !alien.isIntelligent {
 					// Random direction.
-					degreesRadian = rand.Float64() * (math.Pi * 2)
+					degreesRadian = g.rng.Stream(streamAI).Float64() * (math.Pi * 2)
 				} else {
 					// Aim toward player with simple arctan2; adjusted for sprite orientation.
 					degreesRadian = math.Atan2(g.player.position.Y-alien.position.Y, g.player.position.X-alien.position.X)
@@ -724,14 +1235,12 @@ func (g *GameScene) letAliensAttack() {
 					Y: alien.position.Y + halfHeight + (math.Cos(r) - offsetY),
 				}
 
-				laser := NewAlienLaser(spawnPosition, r)
+				laser := NewAlienLaser(spawnPosition, r, g)
+				laser.emp = g.firesEMP()
 				g.alienLaserCount++
 				g.alienLasers[g.alienLaserCount] = laser
 
-				if !g.alienLaserPlayer.IsPlaying() {
-					_ = g.alienLaserPlayer.Rewind()
-					g.alienLaserPlayer.Play()
-				}
+				sharedAudio().PlaySFXAt(sfxAlienLaser, spawnPosition.X)
 			}
 		}
 	}
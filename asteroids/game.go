@@ -2,80 +2,122 @@
 // for a 2D Asteroids clone built with Ebiten.
 package asteroids
 
-import "github.com/hajimehoshi/ebiten/v2"
+import (
+	"log"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	inpututil "github.com/hajimehoshi/ebiten/v2/inpututil"
+)
 
 // Game represents the main game runtime and satisfies ebiten.Game.
 // It manages the scene lifecycle and delegates update and draw calls.
 type Game struct {
 	sceneManager *SceneManager // Handles scene switching and updates.
-	input        Input         // Captures user input for the current frame.
-}
-
-// Input represents the player's input state, refreshed each frame.
-type Input struct{}
-
-// Update refreshes the per-frame input state.
-//
-// This placeholder currently does nothing, but in a complete
-// implementation you would poll for key presses, mouse input,
-// or controller states, and store them for scene access.
-func (i *Input) Update() {
-	// Future work:
-	// Example:
-	// i.KeyUp = ebiten.IsKeyPressed(ebiten.KeyUp)
-	// i.MousePressed = inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
+	input        *Input        // Captures user input for the current frame.
+	perf         PerfMonitor   // Watches the frame rate for sustained drops.
+	toast        *Toast        // Notice shown over the active scene; nil for none.
 }
 
 // Update progresses the game state by one tick.
 //
 // Responsibilities:
 //  1. Initialize the SceneManager and enter the TitleScene if needed.
-//  2. Refresh input state each frame.
-//  3. Forward updates to the current active scene.
+//  2. Handle global hotkeys (F11 fullscreen, F3 debug overlay).
+//  3. Warn about a sustained low frame rate and run any toast.
+//  4. Refresh input state each frame.
+//  5. Forward updates to the current active scene.
 func (g *Game) Update() error {
-	// If the scene manager hasn't been created yet,
-	// initialize it and load the TitleScene as the first scene.
+	// If the scene manager hasn't been created yet, apply persisted settings
+	// and fit the bindings to the keyboard layout, then initialize it and
+	// load the TitleScene as the first scene.
 	if g.sceneManager == nil {
+		config.apply()
+		config.localizeBindings()
 		g.sceneManager = &SceneManager{}
+		g.input = NewInput(config.KeyBindings)
+		g.sceneManager.GoToScene(firstScene())
+	}
 
-		// Create an empty meteor collection.
-		// The TitleScene may use this to display background meteors.
-		meteors := make(map[int]*Meteor)
+	// F11 toggles fullscreen from any scene.
+	if inpututil.IsKeyJustPressed(ebiten.KeyF11) {
+		config.toggleFullscreen()
+	}
 
-		// stars holds a collection of stars for the title scene.
-		stars := GenerateStars(numberOfStars)
+	// F3 shows or hides the debug overlay from any scene.
+	if inpututil.IsKeyJustPressed(debugOverlayKey) {
+		debugOverlay = !debugOverlay
+	}
+	// F4 outlines the colliders of any play field.
+	if inpututil.IsKeyJustPressed(colliderViewKey) {
+		colliderView = !colliderView
+	}
 
-		// Generate a starfield and transition into the title scene.
-		g.sceneManager.GoToScene(&TitleScene{
-			meteors: meteors,
-			stars:   stars,
-		})
+	// A toast's key is handled before the scene sees input this tick.
+	g.checkPerformance()
+	if g.toast != nil && g.toast.Update() {
+		g.toast = nil
 	}
 
+	// Ducked music and beats recover whichever scene is showing.
+	sharedAudio().Update()
+
 	// Update player input state before passing control to the active scene.
 	g.input.Update()
 
 	// Pass the updated input to the current scene for logic and transition handling.
-	if err := g.sceneManager.Update(&g.input); err != nil {
+	if err := g.sceneManager.Update(g.input); err != nil {
 		// Return any scene-level errors so Ebiten can handle or log them.
 		return err
 	}
 	return nil
 }
 
-// Draw renders the current scene.
+// firstScene returns the replay named by ReplayFile, or the TitleScene when
+// none is set or it cannot be played.
+func firstScene() Scene {
+	if ReplayFile == "" {
+		return NewTitleScene()
+	}
+	r, err := loadReplay(replayFilePath(ReplayFile))
+	if err == nil {
+		var g *GameScene
+		if g, err = NewReplayScene(r); err == nil {
+			return g
+		}
+	}
+	log.Println("Error loading replay", err)
+	return NewTitleScene()
+}
+
+// Draw renders the current scene, then any toast and the debug overlay
+// over it.
 //
 // This delegates rendering responsibility to the active scene
 // via the SceneManager, allowing each scene to draw independently.
+// Each call is also a frame sample for the performance monitor.
 func (g *Game) Draw(screen *ebiten.Image) {
+	g.perf.frame(time.Now())
+
 	// SceneManager handles all drawing logic for the current scene.
 	g.sceneManager.Draw(screen)
+	if g.toast != nil {
+		g.toast.Draw(screen)
+	}
+	if debugOverlay {
+		drawDebugOverlay(screen, g.sceneManager.debugLines())
+	}
 }
 
-// Layout defines the logical resolution of the backbuffer.
+// Layout defines the resolution of the backbuffer.
 //
-// This keeps rendering consistent regardless of the user's
-// actual window size or screen scaling factor.
-func (g *Game) Layout(_, _ int) (screenWidth, sceenHeight int) {
-	return ScreenWidth, ScreenHeight
+// The logical surface is always ScreenWidth x ScreenHeight regardless of the
+// window size. Ebiten scales it uniformly to fit the window (or fullscreen
+// display) and letterboxes the remainder, so the 16:9 aspect ratio is kept
+// and HUD elements stay anchored to the same logical coordinates at any size.
+// On a HiDPI display the backbuffer holds that surface at renderScale times
+// the size so it is drawn at the display's density (see render-scale.go).
+func (g *Game) Layout(_, _ int) (screenWidth, screenHeight int) {
+	updateRenderScale()
+	return renderSize()
 }
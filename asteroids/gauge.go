@@ -0,0 +1,78 @@
+// File gauge.go provides vector-drawn HUD gauges shared by indicators:
+// outlined and segmented bars, arcs and arc gauges, and dashed circles.
+// Angles are in radians, measured clockwise from the top (12 o'clock).
+package asteroids
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// gaugeSegmentGap is the space between the cells of a segmented bar.
+const gaugeSegmentGap = 2
+
+// drawBar renders a horizontal bar outlined in track and filled with fill
+// from the left up to fraction (clamped to 0–1).
+func drawBar(screen *ebiten.Image, x, y, w, h float32, fraction float64, fill, track color.Color) {
+	strokeRect(screen, x, y, w, h, 1, track, false)
+	fillRect(screen, x, y, w*float32(clamp01(fraction)), h, fill, false)
+}
+
+// drawSegmentedBar renders a horizontal bar split into segments cells. Cells
+// are filled left to right up to fraction; a partly covered cell is filled
+// partway. Empty cells are drawn in empty.
+func drawSegmentedBar(screen *ebiten.Image, x, y, w, h float32, segments int, fraction float64, fill, empty color.Color) {
+	if segments < 1 {
+		return
+	}
+	cell := (w - gaugeSegmentGap*float32(segments-1)) / float32(segments)
+	filled := clamp01(fraction) * float64(segments)
+	for i := 0; i < segments; i++ {
+		cx := x + float32(i)*(cell+gaugeSegmentGap)
+		fillRect(screen, cx, y, cell, h, empty, false)
+		if part := math.Min(1, filled-float64(i)); part > 0 {
+			fillRect(screen, cx, y, cell*float32(part), h, fill, false)
+		}
+	}
+}
+
+// drawArc strokes an arc of radius r around (cx, cy), starting at start and
+// sweeping clockwise by sweep.
+func drawArc(screen *ebiten.Image, cx, cy, r, width, start, sweep float32, clr color.Color) {
+	if sweep <= 0 {
+		return
+	}
+	// Path angles run clockwise from 3 o'clock; shift so 0 is 12 o'clock.
+	from := start - math.Pi/2
+	var path vector.Path
+	path.Arc(device(cx), device(cy), device(r), from, from+sweep, vector.Clockwise)
+
+	op := &vector.DrawPathOptions{AntiAlias: true}
+	op.ColorScale.ScaleWithColor(clr)
+	vector.StrokePath(screen, &path, &vector.StrokeOptions{Width: device(width)}, op)
+}
+
+// drawArcGauge renders a full ring in track with a clockwise arc from the
+// top in fill covering fraction (clamped to 0–1) of it, as for cooldowns and
+// timers. A nil track draws the fill arc alone.
+func drawArcGauge(screen *ebiten.Image, cx, cy, r, width float32, fraction float64, fill, track color.Color) {
+	if track != nil {
+		strokeCircle(screen, cx, cy, r, width, track, true)
+	}
+	drawArc(screen, cx, cy, r, width, 0, 2*math.Pi*float32(clamp01(fraction)), fill)
+}
+
+// drawDashedCircle strokes a circle as dashes evenly spaced dashes, each
+// covering half of its share of the circumference.
+func drawDashedCircle(screen *ebiten.Image, cx, cy, r, width float32, dashes int, clr color.Color) {
+	if dashes < 1 {
+		return
+	}
+	step := 2 * math.Pi / float32(dashes)
+	for i := 0; i < dashes; i++ {
+		drawArc(screen, cx, cy, r, width, float32(i)*step, step/2, clr)
+	}
+}
@@ -0,0 +1,98 @@
+// File high-scores-scene.go implements the HighScoresScene, which lists the
+// local top-ten tables over a starfield, one board at a time.
+package asteroids
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+	inpututil "github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// highScoreBoard is one table the scene can show.
+type highScoreBoard struct {
+	title string          // Heading over the table.
+	table *HighScoreTable // Entries shown.
+}
+
+// highScoreBoards lists the boards in the order Left/Right steps through.
+var highScoreBoards = []highScoreBoard{
+	{title: "HIGH SCORES", table: highScores},
+	{title: "NEW GAME+ HIGH SCORES", table: newGamePlusScores},
+}
+
+// HighScoresScene shows the high-score tables.
+type HighScoresScene struct {
+	stars []*Star // Backdrop starfield.
+	menu  *Menu   // Back.
+	board int     // Index into highScoreBoards.
+}
+
+// NewHighScoresScene returns the high-score screen.
+func NewHighScoresScene() *HighScoresScene {
+	return &HighScoresScene{
+		stars: GenerateStars(starCount(), ambientRNG),
+		menu:  NewMenu("Back"),
+	}
+}
+
+// Update handles input.
+//
+// Left/Right:  switch between the standard and New Game+ boards.
+// Back/Escape: return to the screen that opened this one.
+func (s *HighScoresScene) Update(state *State) error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyLeft) {
+		s.board = (s.board + len(highScoreBoards) - 1) % len(highScoreBoards)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyRight) {
+		s.board = (s.board + 1) % len(highScoreBoards)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) || s.menu.Update() == 0 {
+		state.SceneManager.PopScene()
+	}
+	return nil
+}
+
+// Draw renders the current board, best first, with the top entry in gold.
+func (s *HighScoresScene) Draw(screen *ebiten.Image) {
+	for _, star := range s.stars {
+		star.Draw(screen)
+	}
+	board := highScoreBoards[s.board]
+	drawCenteredText(screen, board.title, assets.TitleFont, 48, ScreenWidth/2, 60, color.White)
+
+	gray := color.Gray{Y: 180}
+	if len(board.table.Entries) == 0 {
+		drawCenteredText(screen, "No high scores yet", assets.ScoreFont, 18, ScreenWidth/2, 160, gray)
+	}
+	for i, e := range board.table.Entries {
+		initials, level, difficulty, date := e.Initials, "-", e.Difficulty, "----------"
+		if initials == "" {
+			initials = "---"
+		}
+		if e.Level > 0 {
+			level = fmt.Sprint(e.Level)
+		}
+		if difficulty == "" {
+			difficulty = "-"
+		}
+		if !e.Date.IsZero() {
+			date = e.Date.Format("2006-01-02")
+		}
+		line := fmt.Sprintf("%2d.  %-3s  %06d  level %2s  %-6s  %s", i+1, initials, e.Score, level, difficulty, date)
+		if e.DirectorOff {
+			line += "  director: off"
+		}
+
+		c := color.Color(color.White)
+		if i == 0 {
+			c = color.RGBA{R: 255, G: 215, B: 0, A: 255}
+		}
+		drawCenteredText(screen, line, assets.ScoreFont, 20, ScreenWidth/2, float64(140+i*36), c)
+	}
+
+	s.menu.Draw(screen, ScreenWidth/2, 540)
+	drawCenteredText(screen, "Left/Right: switch board", assets.ScoreFont, 14, ScreenWidth/2, ScreenHeight-60, gray)
+}
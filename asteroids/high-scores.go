@@ -0,0 +1,159 @@
+// File high-scores.go keeps the local high-score tables, one for the
+// standard modes and one for New Game+: the ten best ranked runs with the
+// initials, level, difficulty, and date of each, saved as JSON in the save directory. A
+// score file from before the tables existed is carried over as the first
+// entry of the standard table.
+package asteroids
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// High-score table files and size.
+const (
+	highScoresFileName            = "high-scores.json"
+	newGamePlusHighScoresFileName = "high-scores-new-game-plus.json"
+	legacyHighScoreFile           = "high-score.txt" // Single "score [initials]" best from older versions.
+	highScoreTableLength          = 10
+)
+
+// HighScore is one entry of the table.
+type HighScore struct {
+	Score       int       `json:"score"`                 // Final score.
+	Initials    string    `json:"initials"`              // Three letters entered after the run.
+	Level       int       `json:"level"`                 // Level reached; 0 if unknown.
+	Difficulty  string    `json:"difficulty,omitempty"`  // Name of the run's Difficulty; empty if unknown.
+	DirectorOff bool      `json:"directorOff,omitempty"` // The run's mode turned the spawn director off.
+	Date        time.Time `json:"date"`                  // When the run ended; zero if unknown.
+}
+
+// HighScoreTable is a persisted table, best score first.
+type HighScoreTable struct {
+	Entries []HighScore `json:"entries"` // At most highScoreTableLength entries.
+	file    string      // File name in the save directory.
+}
+
+// highScores is the standard table and newGamePlusScores the New Game+
+// one, loaded at startup.
+var (
+	highScores        = &HighScoreTable{file: highScoresFileName}
+	newGamePlusScores = &HighScoreTable{file: newGamePlusHighScoresFileName}
+)
+
+// init loads the persisted tables (best-effort).
+func init() {
+	for _, t := range []*HighScoreTable{highScores, newGamePlusScores} {
+		if err := t.load(); err != nil {
+			log.Println("Error getting high scores", err)
+		}
+	}
+}
+
+// highScoreTable returns the table the run's mode ranks on.
+func (g *GameScene) highScoreTable() *HighScoreTable {
+	if g.mode.NewGamePlus {
+		return newGamePlusScores
+	}
+	return highScores
+}
+
+// best returns the top score, or 0 for an empty table.
+func (t *HighScoreTable) best() int {
+	if len(t.Entries) == 0 {
+		return 0
+	}
+	return t.Entries[0].Score
+}
+
+// qualifies reports whether score would earn a place on the table.
+func (t *HighScoreTable) qualifies(score int) bool {
+	if score <= 0 {
+		return false
+	}
+	return len(t.Entries) < highScoreTableLength || score > t.Entries[len(t.Entries)-1].Score
+}
+
+// insert places entry on the table below any equal scores, drops whatever
+// falls off the bottom, and returns entry's rank from 0, or -1 if it did not
+// qualify.
+func (t *HighScoreTable) insert(entry HighScore) int {
+	if !t.qualifies(entry.Score) {
+		return -1
+	}
+	rank := len(t.Entries)
+	for i, e := range t.Entries {
+		if entry.Score > e.Score {
+			rank = i
+			break
+		}
+	}
+	t.Entries = slices.Insert(t.Entries, rank, entry)
+	t.Entries = t.Entries[:min(len(t.Entries), highScoreTableLength)]
+	return rank
+}
+
+// Save writes the table to the save directory.
+func (t *HighScoreTable) Save() error {
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	return saves.Write(t.file, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// load reads the table's file, leaving the table empty on error. Without a
+// file, the standard table is seeded from the legacy single-score file.
+func (t *HighScoreTable) load() error {
+	data, err := saves.Read(t.file)
+	if errors.Is(err, fs.ErrNotExist) {
+		if t.file != highScoresFileName {
+			return nil
+		}
+		return t.loadLegacy()
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, t); err != nil {
+		t.Entries = nil
+		return err
+	}
+	return nil
+}
+
+// loadLegacy turns the old "score [initials]" file into the table's only
+// entry.
+func (t *HighScoreTable) loadLegacy() error {
+	data, err := saves.Read(legacyHighScoreFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return nil
+	}
+	score, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return err
+	}
+	entry := HighScore{Score: score}
+	if len(fields) > 1 {
+		entry.Initials = fields[1]
+	}
+	t.insert(entry)
+	return nil
+}
@@ -0,0 +1,188 @@
+// File hud.go defines the HUD: the meters, indicators, and readouts drawn
+// over the world. It owns every HUD element and keeps them in step with the
+// run each tick, so a new element is added here without touching the scene
+// or the entities it reports on.
+package asteroids
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// HUD layout, at HUD scale 1.
+const (
+	hudIconSpacing = 50.0 // Horizontal distance between life or shield icons.
+	hudLivesX      = 20.0 // X of the first life icon.
+	hudLivesY      = 20.0 // Y of the life icons.
+	hudShieldsX    = 45.0 // X of the first shield icon.
+	hudShieldsY    = 60.0 // Y of the shield icons.
+)
+
+// HUD draws the run's state over the world for one GameScene.
+type HUD struct {
+	game       *GameScene           // Scene whose run is shown.
+	lives      []*LifeIndicator     // One icon per life left.
+	shields    []*ShieldIndicator   // One icon per shield charge left.
+	hyperspace *HyperspaceIndicator // Jump readiness and cooldown.
+	spreadShot *SpreadShotIndicator // Shown while spread shot is active.
+	smartBomb  *SmartBombIndicator  // This level's smart bomb, ready or spent, and any spares.
+	energy     *EnergyMeter         // Energy pool under energy handling; nil otherwise.
+}
+
+// NewHUD returns the HUD for g.
+func NewHUD(g *GameScene) *HUD {
+	return &HUD{
+		game:       g,
+		hyperspace: NewHyperspaceIndicator(Vector{X: 37.0, Y: 95.0}),
+		spreadShot: NewSpreadShotIndicator(Vector{X: ScreenWidth - 80.0, Y: 20.0}),
+		smartBomb:  NewSmartBombIndicator(Vector{X: 30, Y: 160}),
+	}
+}
+
+// Update brings the elements in step with the run: one icon per life and
+// shield charge left, the hyperspace cooldown, the spread shot's time left,
+// and the ship's energy pool, which is new whenever the ship is.
+func (h *HUD) Update() {
+	p := h.game.player
+
+	for len(h.lives) < p.livesRemaning {
+		h.lives = append(h.lives, NewLifeIndicator(Vector{X: hudLivesX + float64(len(h.lives))*hudIconSpacing, Y: hudLivesY}))
+	}
+	h.lives = h.lives[:max(0, p.livesRemaning)]
+
+	for len(h.shields) < p.shieldsRemaning {
+		h.shields = append(h.shields, NewShieldIndicator(Vector{X: hudShieldsX + float64(len(h.shields))*hudIconSpacing, Y: hudShieldsY}))
+	}
+	h.shields = h.shields[:max(0, p.shieldsRemaning)]
+
+	h.hyperspace.Update(p.hyperSpaceTimer)
+
+	if t := p.spreadShotTimer; t != nil {
+		h.spreadShot.remaining = 1 - float64(t.Elapsed)/float64(t.Target)
+	}
+
+	switch {
+	case p.energy == nil:
+		h.energy = nil
+	case h.energy == nil || h.energy.energy != p.energy:
+		h.energy = NewEnergyMeter(Vector{X: 20, Y: 130}, p.energy)
+	}
+}
+
+// Draw renders every element.
+func (h *HUD) Draw(screen *ebiten.Image) {
+	g, p := h.game, h.game.player
+
+	// Lives, and either shield charges and the hyperspace cooldown or the
+	// energy pool that replaces them under energy handling.
+	for _, li := range h.lives {
+		li.Draw(screen)
+	}
+	if h.energy != nil {
+		h.energy.Draw(screen)
+	} else {
+		for _, si := range h.shields {
+			si.Draw(screen)
+		}
+		h.hyperspace.Draw(screen)
+	}
+
+	// Smart bomb charge for this level.
+	h.smartBomb.Draw(screen, g.smartBombReady, g.spareSmartBombs)
+
+	// Active weapon effects and status effects.
+	if p.spreadShotTimer != nil {
+		h.spreadShot.Draw(screen)
+	}
+	p.status.Draw(screen)
+
+	// Readouts of the upgrades this run flies with.
+	g.drawUpgrades(screen)
+
+	// Scan readout beside the aimed-at meteor.
+	g.drawScanTooltip(screen)
+
+	// Boss health.
+	if g.boss != nil {
+		g.boss.DrawHealthBar(screen)
+	}
+
+	h.drawScores(screen)
+	h.drawScrap(screen)
+	g.drawCombo(screen)
+	h.drawLevel(screen)
+	h.drawRadar(screen)
+
+	// Touch controls, while the player is using them.
+	if in := g.input; in != nil && in.touch != nil {
+		in.touch.Draw(screen)
+	}
+}
+
+// drawScores renders the score and, under it, the high score to beat.
+// Colors come from the palette and sizes follow the HUD scale.
+func (h *HUD) drawScores(screen *ebiten.Image) {
+	g := h.game
+	hud := currentPalette().HUD
+	scale := hudScale()
+
+	op := &text.DrawOptions{
+		LayoutOptions: text.LayoutOptions{PrimaryAlign: text.AlignCenter},
+	}
+	op.ColorScale.ScaleWithColor(hud)
+	op.GeoM.Translate(ScreenWidth/2, 40*scale)
+	drawText(screen, fmt.Sprintf("Score: %06d", g.score), &text.GoTextFace{
+		Source: assets.ScoreFont,
+		Size:   24 * scale,
+	}, op)
+
+	// High score (session-persistent via init()).
+	best := g.highScoreTable().best()
+	if g.ranked() {
+		best = max(best, g.score)
+	}
+	op = &text.DrawOptions{
+		LayoutOptions: text.LayoutOptions{PrimaryAlign: text.AlignCenter},
+	}
+	op.ColorScale.ScaleWithColor(hud)
+	op.GeoM.Translate(ScreenWidth/2, 80*scale)
+	drawText(screen, fmt.Sprintf("High Score: %06d", best), &text.GoTextFace{
+		Source: assets.ScoreFont,
+		Size:   16 * scale,
+	}, op)
+}
+
+// drawLevel renders the level, or the bonus-round clock and chain, along
+// the bottom of the screen.
+func (h *HUD) drawLevel(screen *ebiten.Image) {
+	g := h.game
+	textToDraw := fmt.Sprintf("Current Level: %d   %s", g.currentLevel, g.difficulty.Name)
+	if g.isBonusRound() {
+		secs := int(math.Ceil(g.waves.TimeLeft().Seconds()))
+		textToDraw = fmt.Sprintf("Bonus Round: %ds   Chain x%d", secs, g.goldChain)
+	}
+	if g.practice != nil {
+		textToDraw = "Practice   Tab: spawn panel"
+	}
+	if g.playback != nil {
+		textToDraw = "Replay   Esc: stop"
+		if g.playback.background {
+			textToDraw = "Best Run Replay"
+		}
+	}
+
+	scale := hudScale()
+	op := &text.DrawOptions{
+		LayoutOptions: text.LayoutOptions{PrimaryAlign: text.AlignCenter},
+	}
+	op.ColorScale.ScaleWithColor(currentPalette().HUD)
+	op.GeoM.Translate(ScreenWidth/2, ScreenHeight-40*scale)
+	drawText(screen, textToDraw, &text.GoTextFace{
+		Source: assets.LevelFont,
+		Size:   16 * scale,
+	}, op)
+}
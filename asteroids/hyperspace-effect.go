@@ -0,0 +1,123 @@
+// File hyperspace-effect.go defines the look and sound of a hyperspace jump:
+// the ship dissolves into motes that drift apart where it left, a flash
+// blooms where it arrives, and a falling warp tone plays, so a jump reads as
+// a jump rather than the ship snapping across the screen. Reduced Motion
+// thins the motes like the other particles.
+package asteroids
+
+import (
+	"bytes"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Hyperspace effect tuning.
+const (
+	warpMoteCount   = 40                     // Motes the departing ship dissolves into.
+	warpMoteSpeed   = 120.0                  // Top drift speed of a mote.
+	warpMoteLife    = 30                     // Ticks a mote lasts.
+	warpMoteSpread  = 0.5                    // Distance from the ship's center motes start within, as a share of its size.
+	warpFlashLife   = 18                     // Ticks the arrival flash lasts.
+	warpFlashRadius = 60.0                   // Radius the arrival flash blooms to.
+	warpToneLength  = 250 * time.Millisecond // Length of the warp tone.
+	warpToneHigh    = 1400.0                 // Pitch the warp tone starts at, in hertz.
+	warpToneLow     = 180.0                  // Pitch the warp tone falls to, in hertz.
+	warpToneGain    = 0.35                   // Peak level of the warp tone relative to the SFX channel.
+)
+
+// warpMote is one fleck of a ship that jumped away.
+type warpMote struct {
+	position Vector // World-space position.
+	movement Vector // Drift velocity.
+	life     int    // Ticks remaining.
+}
+
+// warpFlash is the bloom where a ship came out of hyperspace.
+type warpFlash struct {
+	center Vector // World-space center.
+	life   int    // Ticks remaining.
+}
+
+// hyperspaceWarp dissolves sprite at from and flashes it in at to, with the
+// warp tone.
+func (g *GameScene) hyperspaceWarp(sprite *ebiten.Image, from, to Vector) {
+	rng := g.rng.Stream(streamCosmetics)
+	size := spriteSize(sprite)
+	spread := max(size.X, size.Y) * warpMoteSpread
+	for range particleCount(warpMoteCount) {
+		angle := rng.Float64() * 2 * math.Pi
+		offset := rng.Float64() * spread
+		speed := warpMoteSpeed * (0.2 + 0.8*rng.Float64())
+		g.warpMotes = append(g.warpMotes, warpMote{
+			position: Vector{X: from.X + math.Cos(angle)*offset, Y: from.Y + math.Sin(angle)*offset},
+			movement: Vector{X: math.Cos(angle) * speed, Y: math.Sin(angle) * speed},
+			life:     warpMoteLife,
+		})
+	}
+	g.warpFlashes = append(g.warpFlashes, warpFlash{center: to, life: warpFlashLife})
+	sharedAudio().PlaySFXAt(sfxHyperspace, to.X)
+}
+
+// updateWarps moves and ages the motes and flashes, dropping spent ones.
+func (g *GameScene) updateWarps() {
+	g.warpMotes = ageParticles(g.warpMotes)
+
+	flashes := g.warpFlashes[:0]
+	for _, f := range g.warpFlashes {
+		f.life--
+		if f.life > 0 {
+			flashes = append(flashes, f)
+		}
+	}
+	g.warpFlashes = flashes
+}
+
+// age spends a tick of the mote's life and drifts it if any is left.
+func (m *warpMote) age() {
+	if m.life--; m.life > 0 {
+		sim.Advance(&m.position, m.movement)
+	}
+}
+
+// alive reports whether the mote has life left.
+func (m *warpMote) alive() bool { return m.life > 0 }
+
+// drawWarps renders the motes, fading with age, and the flashes, blooming
+// outward as they fade.
+func (g *GameScene) drawWarps(screen *ebiten.Image) {
+	for _, m := range g.warpMotes {
+		t := float32(m.life) / warpMoteLife
+		clr := color.RGBA{R: uint8(140 * t), G: uint8(220 * t), B: uint8(255 * t), A: uint8(255 * t)} // Premultiplied pale blue.
+		fillCircle(screen, float32(m.position.X), float32(m.position.Y), 1+t, clr, true)
+	}
+	for _, f := range g.warpFlashes {
+		t := float32(f.life) / warpFlashLife
+		r := float32(warpFlashRadius) * (1 - t*t)
+		x, y := float32(f.center.X), float32(f.center.Y)
+		core := color.RGBA{R: uint8(200 * t), G: uint8(240 * t), B: uint8(255 * t), A: uint8(200 * t)} // Premultiplied.
+		fillCircle(screen, x, y, r*0.5, core, true)
+		strokeCircle(screen, x, y, r, 2, color.RGBA{R: uint8(140 * t), G: uint8(220 * t), B: uint8(255 * t), A: uint8(255 * t)}, true)
+	}
+}
+
+// warpTone renders the warp tone, a sine falling from warpToneHigh to
+// warpToneLow that swells and fades, as 16-bit little-endian stereo PCM at
+// the audio context's rate.
+func warpTone() *bytes.Reader {
+	n := sim.Ticks(warpToneLength) * audioSampleRate / sim.TicksPerSecond
+	pcm := make([]byte, 0, n*4)
+	phase := 0.0
+	for i := range n {
+		t := float64(i) / float64(n)
+		freq := warpToneHigh * math.Pow(warpToneLow/warpToneHigh, t)
+		phase += 2 * math.Pi * freq / audioSampleRate
+		v := warpToneGain * math.Sin(phase) * math.Sin(math.Pi*t)
+		s := int16(v * math.MaxInt16)
+		pcm = append(pcm, byte(s), byte(s>>8), byte(s), byte(s>>8))
+	}
+	return bytes.NewReader(pcm)
+}
@@ -3,18 +3,30 @@
 package asteroids
 
 import (
+	"image/color"
+	"math"
+
 	"github.com/bensabler/asteroids/assets"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/colorm"
 )
 
-// HyperspaceIndicator represents a faded icon in the HUD that becomes
-// visible when hyperspace is ready to use. It mirrors the life and shield
-// indicators for layout consistency.
+// Hyperspace indicator look.
+const (
+	hyperspaceCoolingAlpha = 0.3 // Opacity of the icon while the jump recharges.
+	hyperspaceGaugeGap     = 4.0 // Space between the icon and its cooldown ring.
+	hyperspaceGaugeWidth   = 2.0 // Thickness of the cooldown ring.
+)
+
+// HyperspaceIndicator represents the hyperspace icon in the HUD: fully
+// opaque when a jump is ready, and dimmed inside a filling ring while the
+// cooldown runs. It mirrors the life and shield indicators for layout
+// consistency.
 type HyperspaceIndicator struct {
 	position Vector        // Screen position for HUD placement.
 	rotation float64       // Unused but reserved for future rotation effects.
 	sprite   *ebiten.Image // Hyperspace icon sprite.
+	charge   float64       // Share of the cooldown run (0–1); 1 when a jump is ready.
 }
 
 // NewHyperspaceIndicator creates a new indicator at the provided HUD coordinates.
@@ -22,15 +34,22 @@ func NewHyperspaceIndicator(position Vector) *HyperspaceIndicator {
 	return &HyperspaceIndicator{
 		position: position,
 		sprite:   assets.HyperspaceIndicator,
+		charge:   1,
 	}
 }
 
-// Update is a placeholder for interface uniformity with other drawable entities.
-// Hyperspace indicators remain static.
-func (hi *HyperspaceIndicator) Update() {}
+// Update reads the cooldown from the ship's hyperspace timer, which is nil
+// until the first jump.
+func (hi *HyperspaceIndicator) Update(cooldown *Timer) {
+	if cooldown == nil || cooldown.IsReady() {
+		hi.charge = 1
+		return
+	}
+	hi.charge = float64(cooldown.Elapsed) / float64(cooldown.Target)
+}
 
-// Draw renders the hyperspace icon with subtle transparency,
-// visually indicating ability readiness without distracting the player.
+// Draw renders the hyperspace icon, opaque when a jump is ready and
+// otherwise dimmed within a ring that fills clockwise as the cooldown runs.
 func (hi *HyperspaceIndicator) Draw(screen *ebiten.Image) {
 	b := hi.sprite.Bounds()
 	halfW := float64(b.Dx()) / 2
@@ -41,7 +60,15 @@ func (hi *HyperspaceIndicator) Draw(screen *ebiten.Image) {
 	op.GeoM.Translate(hi.position.X, hi.position.Y)
 
 	cm := colorm.ColorM{}
-	cm.Scale(1.0, 1.0, 1.0, 0.2)
+	if hi.charge < 1 {
+		cm.Scale(1.0, 1.0, 1.0, hyperspaceCoolingAlpha)
+	}
+	drawSpriteColorM(screen, hi.sprite, cm, op)
 
-	colorm.DrawImage(screen, hi.sprite, cm, op)
+	if hi.charge < 1 {
+		cx := float32(hi.position.X + 2*halfW)
+		cy := float32(hi.position.Y + 2*halfH)
+		r := float32(math.Max(halfW, halfH) + hyperspaceGaugeGap)
+		drawArcGauge(screen, cx, cy, r, hyperspaceGaugeWidth, hi.charge, currentPalette().Meter, color.Gray{Y: 70})
+	}
 }
@@ -0,0 +1,91 @@
+// File initials-scene.go implements the InitialsScene, the classic
+// three-letter entry shown after a new high score. Letters can be typed or
+// dialed in with the arrow keys; the initials are saved with the score.
+package asteroids
+
+import (
+	"fmt"
+	"image/color"
+	"unicode"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+	inpututil "github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// initialsLength is the number of letters in a set of initials.
+const initialsLength = 3
+
+// InitialsScene collects initials for a new high score over the finished run.
+type InitialsScene struct {
+	game    *GameScene               // The finished run, drawn behind the entry.
+	letters [initialsLength]rune     // Letters entered so far.
+	cursor  int                      // Letter being edited.
+	then    func(state *State) error // Where to go once the initials are saved.
+}
+
+// NewInitialsScene silences the run and returns the initials entry for its
+// score. then runs after the initials are saved, to pick the next scene.
+func NewInitialsScene(game *GameScene, then func(state *State) error) *InitialsScene {
+	game.pauseLoopingSounds()
+	game.music.FadeOut()
+	return &InitialsScene{game: game, letters: [initialsLength]rune{'A', 'A', 'A'}, then: then}
+}
+
+// Update handles entry.
+//
+// Letters:    set the current letter and move to the next.
+// Up/Down:    dial the current letter through A–Z.
+// Left/Right: move between letters (Backspace also moves back).
+// Enter:      save the initials with the score and continue; so does a tap.
+func (s *InitialsScene) Update(state *State) error {
+	s.game.updateBackground()
+	s.game.music.Update()
+
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if r = unicode.ToUpper(r); r >= 'A' && r <= 'Z' {
+			s.letters[s.cursor] = r
+			s.cursor = min(s.cursor+1, initialsLength-1)
+		}
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyUp):
+		s.letters[s.cursor] = 'A' + (s.letters[s.cursor]-'A'+1)%26
+	case inpututil.IsKeyJustPressed(ebiten.KeyDown):
+		s.letters[s.cursor] = 'A' + (s.letters[s.cursor]-'A'+25)%26
+	case inpututil.IsKeyJustPressed(ebiten.KeyLeft), inpututil.IsKeyJustPressed(ebiten.KeyBackspace):
+		s.cursor = max(s.cursor-1, 0)
+	case inpututil.IsKeyJustPressed(ebiten.KeyRight):
+		s.cursor = min(s.cursor+1, initialsLength-1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter), touchTapped():
+		s.game.saveHighScore(string(s.letters[:]))
+		return s.then(state)
+	}
+	return nil
+}
+
+// Draw renders the dimmed run, the score, and the letters with the current
+// one highlighted.
+func (s *InitialsScene) Draw(screen *ebiten.Image) {
+	s.game.Draw(screen)
+	dimScreen(screen, 160)
+
+	gold := color.RGBA{R: 255, G: 215, B: 0, A: 255}
+	drawCenteredText(screen, "NEW HIGH SCORE", assets.TitleFont, 56, ScreenWidth/2, ScreenHeight/2-180, gold)
+	drawCenteredText(screen, fmt.Sprintf("%06d", s.game.score), assets.ScoreFont, 32, ScreenWidth/2, ScreenHeight/2-90, color.White)
+
+	const spacing = 70.0
+	for i, r := range s.letters {
+		x := ScreenWidth/2 + (float64(i)-float64(initialsLength-1)/2)*spacing
+		c := color.Color(color.White)
+		if i == s.cursor {
+			c = gold
+			drawCenteredText(screen, "_", assets.TitleFont, 72, x, ScreenHeight/2+10, gold)
+		}
+		drawCenteredText(screen, string(r), assets.TitleFont, 72, x, ScreenHeight/2, c)
+	}
+
+	drawCenteredText(screen, "Type or Up/Down to choose   Left/Right to move   Enter to save",
+		assets.ScoreFont, 16, ScreenWidth/2, ScreenHeight-80, color.Gray{Y: 180})
+}
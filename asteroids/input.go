@@ -0,0 +1,144 @@
+// File input.go defines the key bindings for the logical actions in
+// internal/sim and the per-frame Input snapshot scenes read instead of
+// polling keys directly.
+//
+// Bindings store physical keys: ebiten.Key names a key position on a US
+// layout, whatever the OS layout prints on it. Positional controls (the
+// arrow cluster) therefore work on any layout, mnemonic defaults are moved
+// to the key that types their letter, and every prompt shows the character
+// the player's own layout produces.
+package asteroids
+
+import (
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// actionMnemonics are the letters the default letter bindings stand for.
+// localize keeps these on the key that types the letter, not on the US
+// position of that letter.
+var actionMnemonics = map[Action]rune{
+	ActionShield:     's',
+	ActionHyperspace: 'h',
+	ActionSmartBomb:  'b',
+	ActionTractor:    't',
+}
+
+// KeyBindings maps each action to the key that triggers it.
+type KeyBindings map[Action]ebiten.Key
+
+// DefaultKeyBindings returns the classic arrow-key layout.
+func DefaultKeyBindings() KeyBindings {
+	return KeyBindings{
+		ActionRotateLeft:  ebiten.KeyLeft,
+		ActionRotateRight: ebiten.KeyRight,
+		ActionThrust:      ebiten.KeyUp,
+		ActionReverse:     ebiten.KeyDown,
+		ActionFire:        ebiten.KeySpace,
+		ActionShield:      ebiten.KeyS,
+		ActionHyperspace:  ebiten.KeyH,
+		ActionBoost:       ebiten.KeyShiftLeft,
+		ActionSmartBomb:   ebiten.KeyB,
+		ActionTractor:     ebiten.KeyT,
+	}
+}
+
+// Bind assigns key to action. If another action already uses key, the two
+// actions swap keys so no key ends up driving two actions.
+func (b KeyBindings) Bind(action Action, key ebiten.Key) {
+	previous := b[action]
+	for other, k := range b {
+		if other != action && k == key {
+			b[other] = previous
+		}
+	}
+	b[action] = key
+}
+
+// localize moves each action still on its default mnemonic key to the key
+// that types that letter on the current keyboard layout, wherever that key
+// sits, so Shield stays on "S" for Dvorak players, whose S is the US ";"
+// key. Letters the layout types from no key are left as they are. Actions
+// go in order so any swaps come out the same on every launch. It needs a
+// running game; before that every layout looks empty.
+func (b KeyBindings) localize() {
+	defaults := DefaultKeyBindings()
+	for action := Action(0); action < actionCount; action++ {
+		letter, ok := actionMnemonics[action]
+		if !ok || b[action] != defaults[action] {
+			continue // Customized by the player.
+		}
+		if key, ok := keyTyping(letter); ok {
+			b.Bind(action, key)
+		}
+	}
+}
+
+// keyTyping returns the key that types r on the current layout, searching
+// every key rather than only the US letter positions: AZERTY types M from
+// the US ";" key, for one. Keys that type nothing have no name and never
+// match.
+func keyTyping(r rune) (ebiten.Key, bool) {
+	for key := ebiten.Key(0); key <= ebiten.KeyMax; key++ {
+		if strings.EqualFold(ebiten.KeyName(key), string(r)) {
+			return key, true
+		}
+	}
+	return 0, false
+}
+
+// keyLabel returns the text to show for key: the character it types on the
+// current layout when it types a visible one, otherwise Ebiten's name for
+// it ("Space", "Enter").
+func keyLabel(key ebiten.Key) string {
+	if name := ebiten.KeyName(key); strings.TrimSpace(name) != "" {
+		return strings.ToUpper(name)
+	}
+	return key.String()
+}
+
+// Input represents the player's input state, refreshed each frame.
+//
+// Scenes query actions rather than keys so bindings can change at runtime.
+type Input struct {
+	bindings KeyBindings       // Active bindings; shared with Config.
+	touch    *TouchControls    // On-screen touch controls; nil for input that is not live.
+	pressed  [actionCount]bool // Action state this frame.
+	previous [actionCount]bool // Action state last frame.
+}
+
+// NewInput returns an Input driven by the provided bindings and by touch.
+func NewInput(bindings KeyBindings) *Input {
+	return &Input{bindings: bindings, touch: &TouchControls{}}
+}
+
+// Update polls the keyboard and the touch controls for every bound action.
+func (i *Input) Update() {
+	i.previous = i.pressed
+	if i.touch != nil {
+		i.touch.Update()
+	}
+	for a := Action(0); a < actionCount; a++ {
+		key, ok := i.bindings[a]
+		i.pressed[a] = ok && ebiten.IsKeyPressed(key)
+		if i.touch != nil {
+			i.pressed[a] = i.pressed[a] || i.touch.held[a]
+		}
+	}
+}
+
+// IsPressed reports whether the action is held this frame.
+func (i *Input) IsPressed(a Action) bool {
+	return i.pressed[a]
+}
+
+// IsJustPressed reports whether the action went down this frame.
+func (i *Input) IsJustPressed(a Action) bool {
+	return i.pressed[a] && !i.previous[a]
+}
+
+// IsJustReleased reports whether the action went up this frame.
+func (i *Input) IsJustReleased(a Action) bool {
+	return !i.pressed[a] && i.previous[a]
+}
@@ -3,9 +3,8 @@
 package asteroids
 
 import (
-	"math"
-
 	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/solarlune/resolv"
 )
@@ -22,9 +21,13 @@ type Laser struct {
 	rotation float64
 	sprite   *ebiten.Image
 	laserObj *resolv.ConvexPolygon
+	owner    *Player    // Ship that fired it.
+	steer    float64    // Aim-assist turn rate in radians per second; 0 flies straight.
+	data     ObjectData // Collider data; kept here so a pooled laser reuses it.
 }
 
-// NewLaser constructs a laser at position with facing rotation and ID.
+// NewLaser returns a laser at position with facing rotation and ID, reusing
+// a released laser (and its collider) from the scene's pool when possible.
 //
 // The spawn position is adjusted to center-origin so rotation occurs around
 // the sprite center. A rectangle collider is initialized and tagged.
@@ -37,40 +40,53 @@ func NewLaser(position Vector, rotation float64, index int, g *GameScene) *Laser
 	position.X -= halfW
 	position.Y -= halfH
 
-	// Assemble projectile and rectangular collider.
-	laser := &Laser{
+	// Reinitialize a pooled projectile; only a fresh one needs a collider.
+	laser := g.pools.lasers.Get()
+	laserObj := laser.laserObj
+	if laserObj == nil {
+		laserObj = resolv.NewRectangle(position.X, position.Y, float64(bounds.Dx()), float64(bounds.Dy()))
+		laserObj.Tags().Set(TagLaser | TagBolt)
+	}
+	*laser = Laser{
 		game:     g,
 		position: position,
 		rotation: rotation,
 		sprite:   sprite,
-		laserObj: resolv.NewRectangle(position.X, position.Y, float64(bounds.Dx()), float64(bounds.Dy())),
+		laserObj: laserObj,
+		data:     ObjectData{index: index},
 	}
 
 	// Collider bookkeeping for spatial queries and ID.
 	laser.laserObj.SetPosition(position.X, position.Y)
-	laser.laserObj.SetData(&ObjectData{index: index})
-	laser.laserObj.Tags().Set(TagLaser)
+	laser.laserObj.SetData(&laser.data)
 
 	return laser
 }
 
 // Update advances the laser forward along its rotation and syncs the collider.
-//
-// Speed is normalized by ebiten.TPS() to remain framerate-independent.
 func (l *Laser) Update() {
-	// Convert per-second speed to per-tick delta.
-	speed := laserSpeedPerSecond / float64(ebiten.TPS())
-	dx := math.Sin(l.rotation) * speed
-	dy := math.Cos(l.rotation) * -speed
+	l.move()
+	l.syncCollider()
+}
 
-	// Apply motion and keep collider aligned.
-	l.position.X += dx
-	l.position.Y += dy
+// move advances the laser's own position; safe to call from the worker pool.
+func (l *Laser) move() {
+	sim.Advance(&l.position, shipHeading(l.rotation).Scale(laserSpeedPerSecond))
+}
+
+// syncCollider keeps the collider aligned with the sprite; must run serially.
+func (l *Laser) syncCollider() {
 	l.laserObj.SetPosition(l.position.X, l.position.Y)
 }
 
-// Draw renders the laser rotated around its center at the current position.
+// Draw renders the laser rotated around its center at the current position:
+// the classic sprite, or a tinted bolt when shots are shape-coded.
 func (l *Laser) Draw(screen *ebiten.Image) {
+	if config.ShotShapes {
+		drawCodedShot(screen, assets.BoltSprite, l.sprite, l.position, l.rotation, 1, currentPalette().PlayerShot)
+		return
+	}
+
 	b := l.sprite.Bounds()
 	halfW := float64(b.Dx()) / 2
 	halfH := float64(b.Dy()) / 2
@@ -81,5 +97,5 @@ func (l *Laser) Draw(screen *ebiten.Image) {
 	op.GeoM.Translate(halfW, halfH)
 	op.GeoM.Translate(l.position.X, l.position.Y)
 
-	screen.DrawImage(l.sprite, op)
+	drawSprite(screen, l.sprite, op)
 }
@@ -0,0 +1,54 @@
+// File level-script.go runs the current level's scripted events: each tick
+// the scene's sim.LevelScript says which have come due, and the scene
+// spawns what they call for. A level that scripts comets or aliens gets no
+// random ones of that kind, so the script alone sets its special moments.
+package asteroids
+
+import "github.com/bensabler/asteroids/internal/sim"
+
+// runLevelScript fires the level's events that came due this tick.
+func (g *GameScene) runLevelScript() {
+	if g.practice != nil {
+		return
+	}
+	for _, e := range g.script.Tick(g.waves.MeteorsLeft()) {
+		g.fireEvent(e)
+	}
+}
+
+// fireEvent brings in what e calls for, from the side it names.
+func (g *GameScene) fireEvent(e sim.LevelEvent) {
+	rng := g.rng.Stream(streamSpawns)
+	alienVelocity := basedAlienVelocity * g.mode.enemySpeed()
+	switch e.Spawn {
+	case sim.SpawnMeteors:
+		// Extra meteors count as in play but not against the budget, like
+		// fragments, so the level still clears once they are destroyed.
+		for range e.Count {
+			g.addMeteor(NewMeteorFrom(g.baseVelocity, g, g.meteorCount+1, g.entryAngle(e.From, rng.Float64())))
+			g.waves.TrackSplit()
+		}
+	case sim.SpawnHunters:
+		for range e.Count {
+			g.addAlien(newAlienFrom(alienVelocity, g, alienHunter, e.From))
+		}
+	case sim.SpawnSweepers:
+		for range e.Count {
+			fromRight := e.From == sim.SideRight
+			if e.From != sim.SideLeft && e.From != sim.SideRight {
+				fromRight = g.spawnFromRight(rng.Float64())
+			}
+			alienType := alienSweepRight
+			if fromRight {
+				alienType = alienSweepLeft
+			}
+			g.addAlien(newAlienOfType(alienVelocity, g, alienType))
+		}
+	case sim.SpawnComet:
+		// One comet at a time; a comet already crossing stands in for it.
+		if g.comet == nil {
+			g.comet = NewCometFrom(e.From, rng, g.rng.Stream(streamCosmetics))
+			g.space.Add(g.comet.cometObj)
+		}
+	}
+}
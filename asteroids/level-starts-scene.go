@@ -1,67 +1,190 @@
 // File level_start_scene.go implements the interstitial scene displayed
-// before each level. It shows the level number, clears transient state,
-// and returns control to the active GameScene after a short delay or on input.
+// before each level. It first sums up the wave just cleared (points,
+// accuracy, time, and best chain, counting up from zero), then shows the
+// level number, clears transient state, and returns control to the active
+// GameScene after a short delay or on input. Space skips either part.
 package asteroids
 
 import (
 	"fmt"
 	"image/color"
+	"math"
+	"time"
 
 	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
 	"github.com/hajimehoshi/ebiten/v2"
 	inpututil "github.com/hajimehoshi/ebiten/v2/inpututil"
 	text "github.com/hajimehoshi/ebiten/v2/text/v2"
 )
 
-// LevelStartsScene shows the current level banner and transitions back to gameplay.
+// Wave summary pacing.
+const (
+	waveSummaryDuration = 3 * time.Second         // Time the summary shows before the banner.
+	waveSummaryCountUp  = 1200 * time.Millisecond // Time the figures take to count up.
+)
+
+// LevelStartsScene shows a summary of the wave just cleared, then the
+// current level banner, and transitions back to gameplay.
 type LevelStartsScene struct {
 	game           *GameScene // The gameplay scene to resume.
+	summary        *WaveStats // The wave just cleared; nil once skipped or shown.
+	summaryTimer   *Timer     // Time the summary has shown.
 	nextLevelTimer *Timer     // Delay before automatic resume.
 	stars          []*Star    // Decorative starfield backdrop.
 }
 
-// Draw renders the starfield and centered "LEVEL N" banner.
+// Draw renders the starfield, then either the wave summary or the centered
+// "LEVEL N" banner with any notice for the level (a boss warning or a newly
+// unlocked ability).
 func (l *LevelStartsScene) Draw(screen *ebiten.Image) {
 	// Background stars for continuity with gameplay visuals.
 	for _, star := range l.stars {
 		star.Draw(screen)
 	}
 
+	if l.summary != nil {
+		l.drawSummary(screen)
+		return
+	}
+
 	// Centered level label.
 	label := fmt.Sprintf("LEVEL %d", l.game.currentLevel)
+	if l.game.isBonusRound() {
+		label = "BONUS ROUND"
+	}
 	op := &text.DrawOptions{
 		LayoutOptions: text.LayoutOptions{PrimaryAlign: text.AlignCenter},
 	}
 	op.ColorScale.ScaleWithColor(color.White)
 	op.GeoM.Translate(float64(ScreenWidth/2), float64(ScreenHeight/2))
-	text.Draw(screen, label, &text.GoTextFace{
+	drawText(screen, label, &text.GoTextFace{
 		Source: assets.TitleFont,
 		Size:   72,
 	}, op)
+
+	// Boss levels get a warning under the level number.
+	if l.game.level.Kind == WaveBoss {
+		drawCenteredText(screen, "BOSS INCOMING", assets.TitleFont, 36, ScreenWidth/2, ScreenHeight/2+100, color.RGBA{R: 255, G: 80, B: 80, A: 255})
+	}
+
+	// Announce New Game+ on the run that unlocks it.
+	if l.game.newGamePlusUnlocked && l.game.currentLevel == newGamePlusMilestone && !l.game.isBonusRound() {
+		drawCenteredText(screen, "NEW GAME+ UNLOCKED - FIND IT ON THE TITLE SCREEN", assets.ScoreFont, 18, ScreenWidth/2, ScreenHeight/2+200, color.RGBA{R: 255, G: 215, B: 0, A: 255})
+	}
+
+	// Announce the tractor beam on the level that unlocks it.
+	if l.game.currentLevel == tractorUnlockLevel && !l.game.isBonusRound() {
+		hint := fmt.Sprintf("TRACTOR BEAM ONLINE - HOLD %s", keyLabel(config.KeyBindings[ActionTractor]))
+		drawCenteredText(screen, hint, assets.ScoreFont, 18, ScreenWidth/2, ScreenHeight/2+160, color.RGBA{R: 160, G: 255, B: 255, A: 255})
+	}
 }
 
-// Update advances the timer and resumes gameplay either when the timer completes
-// or when the player presses Space. It also resets level-capped meteors and
-// clears any stray player lasers for a clean start.
+// drawSummary renders the cleared wave's figures, counted up by how long
+// the summary has shown.
+func (l *LevelStartsScene) drawSummary(screen *ebiten.Image) {
+	w := l.summary
+	title := fmt.Sprintf("LEVEL %d COMPLETE", w.Level)
+	if w.BonusRound {
+		title = "BONUS ROUND COMPLETE"
+	}
+	drawCenteredText(screen, title, assets.TitleFont, 48, ScreenWidth/2, ScreenHeight/2-160, color.White)
+
+	// Ease out so the figures race up and settle on their totals.
+	t := math.Min(1, float64(l.summaryTimer.Elapsed)/float64(sim.Ticks(waveSummaryCountUp)))
+	t = 1 - (1-t)*(1-t)
+	rows := []struct {
+		label, value string
+	}{
+		{"POINTS", fmt.Sprintf("%d", int(math.Round(float64(w.Points)*t)))},
+		{"ACCURACY", fmt.Sprintf("%.0f%%", w.accuracy()*100*t)},
+		{"TIME", formatDuration(w.seconds() * t)},
+		{"BEST CHAIN", fmt.Sprintf("x%d", int(math.Round(float64(w.BestChain)*t)))},
+	}
+	for i, r := range rows {
+		y := ScreenHeight/2 - 60 + float64(i)*50
+		drawAlignedText(screen, r.label, assets.ScoreFont, 24, ScreenWidth/2-20, y, text.AlignEnd, color.RGBA{R: 160, G: 160, B: 160, A: 255})
+		drawAlignedText(screen, r.value, assets.ScoreFont, 24, ScreenWidth/2+20, y, text.AlignStart, color.White)
+	}
+
+	drawCenteredText(screen, "SPACE TO SKIP", assets.ScoreFont, 16, ScreenWidth/2, ScreenHeight/2+200, color.RGBA{R: 120, G: 120, B: 120, A: 255})
+}
+
+// drawAlignedText draws s with its anchor at (x, y) aligned by align.
+func drawAlignedText(screen *ebiten.Image, s string, src *text.GoTextFaceSource, size, x, y float64, align text.Align, c color.Color) {
+	op := &text.DrawOptions{
+		LayoutOptions: text.LayoutOptions{PrimaryAlign: align},
+	}
+	op.ColorScale.ScaleWithColor(c)
+	op.GeoM.Translate(x, y)
+	drawText(screen, s, &text.GoTextFace{Source: src, Size: size}, op)
+}
+
+// Update shows the wave summary until its time is up or Space (or a tap)
+// skips it, then advances the banner timer and resumes gameplay either when
+// the timer completes or when the player presses Space or taps, by way of
+// the salvage shop and run upgrade pick when the level offers them. It also opens the level's
+// meteor budget and clears any stray player lasers for a clean start.
 func (l *LevelStartsScene) Update(state *State) error {
+	if l.summary != nil {
+		l.summaryTimer.Update()
+		if l.summaryTimer.IsReady() || inpututil.IsKeyJustPressed(ebiten.KeySpace) || touchTapped() {
+			l.summary = nil
+		}
+		return nil
+	}
+
 	l.nextLevelTimer.Update()
 	ready := l.nextLevelTimer.IsReady()
-	pressed := inpututil.IsKeyJustPressed(ebiten.KeySpace)
+	pressed := inpututil.IsKeyJustPressed(ebiten.KeySpace) || touchTapped()
 
 	if ready || pressed {
-		// Scale difficulty: +2 meteors per level; reset current spawn count.
-		l.game.meteorsForLevel += 2
-		l.game.meteorCount = 0
+		// Open the new level's meteor budget (or bonus-round clock).
+		l.game.waves.StartLevel(l.game.level)
 
 		// Remove any leftover lasers from the previous level.
-		for k, v := range l.game.lasers {
-			delete(l.game.lasers, k)
-			l.game.space.Remove(v.laserObj)
+		for k := range inOrder(l.game.lasers) {
+			l.game.removeLaser(k)
 		}
 
-		// Hand control back to active gameplay.
-		state.SceneManager.GoToScene(l.game)
+		state.SceneManager.GoToScene(l.game.afterLevelBanner())
 	}
 
 	return nil
 }
+
+// afterLevelBanner returns the scene that follows the level banner: the
+// salvage shop when the level opens one, else whatever follows the shop.
+// Playback makes the recorded purchases instead of opening it.
+func (g *GameScene) afterLevelBanner() Scene {
+	switch {
+	case !g.offersSalvageShop():
+	case g.playback != nil:
+		for {
+			s, ok := g.playback.nextPurchase()
+			if !ok || s == noSupply {
+				break
+			}
+			g.buySupply(s)
+		}
+	default:
+		return NewSalvageShopScene(g)
+	}
+	return g.afterSalvageShop()
+}
+
+// afterSalvageShop returns the scene that follows the salvage shop: the run
+// upgrade pick when the level offers one, else active gameplay. Playback
+// takes the recorded pick instead of offering it.
+func (g *GameScene) afterSalvageShop() Scene {
+	switch {
+	case !g.offersRunUpgrade():
+	case g.playback != nil:
+		if u, ok := g.playback.nextPick(); ok {
+			g.takeRunUpgrade(u)
+		}
+	default:
+		return NewRunUpgradeScene(g)
+	}
+	return g
+}
@@ -0,0 +1,47 @@
+// File levels.go chooses the level progression: the built-in table from
+// internal/sim, or a levels.json in the save directory that replaces it.
+// Runs on a replaced table play normally but are unranked, since their
+// scores are not comparable with the built-in levels.
+package asteroids
+
+import (
+	"errors"
+	"io/fs"
+	"log"
+
+	"github.com/bensabler/asteroids/internal/sim"
+)
+
+// levelsFileName is the optional level definition override.
+const levelsFileName = "levels.json"
+
+// levels is the level progression runs play through.
+var levels = sim.DefaultLevels()
+
+// customLevels reports whether levels came from the save directory.
+var customLevels bool
+
+// init loads the level definition override, if any (best-effort).
+func init() {
+	t, err := loadLevels()
+	if err != nil {
+		log.Println("Error loading levels", err)
+		return
+	}
+	if t != nil {
+		levels, customLevels = t, true
+	}
+}
+
+// loadLevels reads the level definition override, returning nil if
+// there is none.
+func loadLevels() (*sim.LevelTable, error) {
+	data, err := saves.Read(levelsFileName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sim.ParseLevelTable(data)
+}
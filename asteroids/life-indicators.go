@@ -46,5 +46,5 @@ func (li *LifeIndicator) Draw(screen *ebiten.Image) {
 	cm := colorm.ColorM{}
 	cm.Scale(1.0, 1.0, 1.0, 0.2)
 
-	colorm.DrawImage(screen, li.sprite, cm, op)
+	drawSpriteColorM(screen, li.sprite, cm, op)
 }
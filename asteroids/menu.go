@@ -0,0 +1,107 @@
+// File menu.go defines Menu, a small vertical list of text options shared by
+// the menu-style scenes (pause, settings, title). It owns selection state and
+// keyboard, gamepad, and touch navigation; scenes decide what each confirmed
+// option does.
+package asteroids
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+	inpututil "github.com/hajimehoshi/ebiten/v2/inpututil"
+	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Menu is a list of labelled options navigated with Up/Down or a gamepad's
+// D-pad and confirmed with Enter, Space, or the gamepad's bottom face
+// button, or chosen with a tap.
+type Menu struct {
+	items    []string // Option labels, top to bottom.
+	selected int      // Index of the highlighted option.
+	origin   Vector   // Where Draw last placed the list, for hit-testing taps.
+}
+
+// Menu layout.
+const (
+	menuFontSize = 24 // Font size in points.
+	menuSpacing  = 40 // Vertical distance between options.
+
+	menuTapHalfWidth = 240 // How far either side of the center line a tap picks an option.
+)
+
+// NewMenu returns a menu with the first item selected.
+func NewMenu(items ...string) *Menu {
+	return &Menu{items: items}
+}
+
+// Update applies navigation input and returns the index of the option
+// confirmed this tick, or -1 when nothing was confirmed.
+func (m *Menu) Update() int {
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) || gamepadJustPressed(ebiten.StandardGamepadButtonLeftTop) {
+		m.selected = (m.selected - 1 + len(m.items)) % len(m.items)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) || gamepadJustPressed(ebiten.StandardGamepadButtonLeftBottom) {
+		m.selected = (m.selected + 1) % len(m.items)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeySpace) ||
+		gamepadJustPressed(ebiten.StandardGamepadButtonRightBottom) {
+		return m.selected
+	}
+	for _, id := range inpututil.AppendJustPressedTouchIDs(nil) {
+		if i, ok := m.itemAt(ebiten.TouchPosition(id)); ok {
+			m.selected = i
+			return i
+		}
+	}
+	return -1
+}
+
+// gamepadJustPressed reports whether b was pressed this tick on any
+// connected gamepad with a standard layout.
+func gamepadJustPressed(b ebiten.StandardGamepadButton) bool {
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if inpututil.IsStandardGamepadButtonJustPressed(id, b) {
+			return true
+		}
+	}
+	return false
+}
+
+// itemAt returns the index of the option whose row holds the screen point
+// x, y, if any.
+func (m *Menu) itemAt(x, y int) (int, bool) {
+	if math.Abs(float64(x)-m.origin.X) > menuTapHalfWidth {
+		return 0, false
+	}
+	row := math.Floor((float64(y) - m.origin.Y + (menuSpacing-menuFontSize)/2) / menuSpacing)
+	if row < 0 || row >= float64(len(m.items)) {
+		return 0, false
+	}
+	return int(row), true
+}
+
+// Draw renders the options centered horizontally on x, starting at y.
+// The selected option is drawn in gold with chevrons.
+func (m *Menu) Draw(screen *ebiten.Image, x, y float64) {
+	m.origin = Vector{X: x, Y: y}
+	for i, item := range m.items {
+		label := item
+		c := color.Color(color.White)
+		if i == m.selected {
+			label = "> " + item + " <"
+			c = color.RGBA{R: 255, G: 215, B: 0, A: 255} // gold-ish
+		}
+
+		op := &text.DrawOptions{
+			LayoutOptions: text.LayoutOptions{PrimaryAlign: text.AlignCenter},
+		}
+		op.ColorScale.ScaleWithColor(c)
+		op.GeoM.Translate(x, y+float64(i*menuSpacing))
+		drawText(screen, label, &text.GoTextFace{
+			Source: assets.ScoreFont,
+			Size:   menuFontSize,
+		}, op)
+	}
+}
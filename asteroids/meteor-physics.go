@@ -0,0 +1,66 @@
+// File meteor-physics.go implements the optional "realistic asteroids" rule:
+// meteors collide with one another and bounce apart with an approximate
+// momentum exchange instead of passing through each other.
+package asteroids
+
+import (
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/solarlune/resolv"
+)
+
+// meteorRestitution is the share of closing speed kept after a bounce
+// (1 is perfectly elastic).
+const meteorRestitution = 0.9
+
+// collideMeteors bounces every pair of overlapping meteors apart when the
+// realistic asteroids setting is on.
+//
+// The response is sim.Collide, weighting each meteor by its collider area.
+// Gold, exploding, held, and flung meteors are left out: they have their
+// own rules.
+func (g *GameScene) collideMeteors() {
+	if !g.rules.meteorCollisions {
+		return
+	}
+	// Gather pairs first: bouncing moves colliders between grid cells, which
+	// must not happen while the cells are being walked.
+	var pairs [][2]*Meteor
+	for i, m := range inOrder(g.meteors) {
+		if !g.isPhysical(m) {
+			continue
+		}
+		m.meteorObj.SelectTouchingCells(1).FilterShapes().ByTags(TagMeteor).ForEach(func(shape resolv.IShape) bool {
+			// Handle each pair once, from the side with the lower meteor key.
+			// (Keys rather than collider IDs, which pooling reassigns.)
+			data, ok := shape.Data().(*ObjectData)
+			if !ok || data.index <= i || !m.meteorObj.IsIntersecting(shape) {
+				return true
+			}
+			if other := g.meteors[data.index]; other != nil && g.isPhysical(other) {
+				pairs = append(pairs, [2]*Meteor{m, other})
+			}
+			return true
+		})
+	}
+	for _, p := range pairs {
+		bounceMeteors(p[0], p[1])
+	}
+}
+
+// isPhysical reports whether a meteor takes part in meteor-to-meteor collisions.
+func (g *GameScene) isPhysical(m *Meteor) bool {
+	return !m.gold && m.thrownTimer == nil && !g.isExploding(m) &&
+		(g.tractor == nil || g.tractor.meteor != m)
+}
+
+// bounceMeteors pushes two overlapping meteors apart and exchanges their
+// momentum, then moves their colliders along.
+func bounceMeteors(a, b *Meteor) {
+	sim.Collide(
+		sim.Body{Position: &a.position, Velocity: &a.movement, Radius: a.meteorObj.Radius()},
+		sim.Body{Position: &b.position, Velocity: &b.movement, Radius: b.meteorObj.Radius()},
+		meteorRestitution,
+	)
+	a.syncCollider()
+	b.syncCollider()
+}
@@ -1,36 +1,80 @@
 // File meteor.go defines drifting asteroid entities, including construction,
-// update (movement + rotation), drawing, and screen-wrapping behavior.
+// update (movement + rotation), drawing, and screen-edge behavior. Meteors
+// come in three sizes, as in the arcade original: a large one splits into
+// mediums, a medium into smalls, and each size down is worth more. Large
+// meteors take the difficulty's MeteorHealth in laser hits to split,
+// flashing on each hit that does not.
 package asteroids
 
 import (
 	"math"
 	"math/rand"
+	"time"
 
 	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/solarlune/resolv"
 )
 
 const (
-	// rotationSpeedMin and rotationSpeedMax bound the randomized spin rate.
-	rotationSpeedMin = -0.02
-	rotationSpeedMax = 0.02
-
-	// numOfSmallMeteorsFromLargeMeteor controls the split count after a break.
-	// (Referenced by game logic elsewhere.)
-	numOfSmallMeteorsFromLargeMeteor = 4
+	// meteorSpinRoll scales a meteor's spin from its speed over its radius,
+	// the rate it would turn rolling along the ground: small fast rocks
+	// tumble, big slow ones turn over lazily.
+	meteorSpinRoll = 0.6
+
+	// meteorSpinJitter is the most a meteor's spin strays either way from
+	// its rolling rate, as a fraction of it, so equal rocks don't turn in step.
+	meteorSpinJitter = 0.4
+
+	// meteorSpinMin and meteorSpinMax bound the spin rate (radians per
+	// second) either way, so the slowest rocks still turn and the fastest
+	// don't blur.
+	meteorSpinMin = 0.3
+	meteorSpinMax = 6.0
+
+	// meteorSpeedSpread is the most a meteor's speed exceeds the base
+	// velocity it was spawned with, for variety.
+	meteorSpeedSpread = 90.0
+
+	// goldMeteorDrift is the spread of a gold meteor's vertical speed.
+	goldMeteorDrift = 60.0
+
+	// meteorSplitCount is how many meteors of the next size down a large or
+	// medium meteor breaks into.
+	meteorSplitCount = 2
+
+	// meteorPointsLarge, meteorPointsMedium, and meteorPointsSmall are the
+	// score for shooting down a meteor of each size.
+	meteorPointsLarge  = 1
+	meteorPointsMedium = 2
+	meteorPointsSmall  = 3
+
+	// streamedMeteorMargin is how far past the screen edge a meteor that
+	// streams off it travels before it is culled.
+	streamedMeteorMargin = 100
+
+	// meteorHitFlash is how long a meteor glows after a hit it survives.
+	meteorHitFlash = 120 * time.Millisecond
 )
 
 // Meteor represents an asteroid: its sprite, motion, rotation, and collider.
 type Meteor struct {
-	game          *GameScene     // Owning scene (for callbacks / scoring).
+	game          *GameScene     // Owning scene (for callbacks / scoring); nil if decorative.
 	position      Vector         // World-space position.
 	rotation      float64        // Current rotation (radians).
-	movement      Vector         // Per-frame delta (velocity vector).
+	movement      Vector         // Velocity.
 	angle         float64        // Unused externally; seed for rotation/variance.
-	rotationSpeed float64        // Spin rate (radians per frame).
+	rotationSpeed float64        // Spin rate (radians per second).
 	sprite        *ebiten.Image  // Visual representation.
-	meteorObj     *resolv.Circle // Collision shape (circle).
+	meteorObj     *resolv.Circle // Collision shape (circle); nil if decorative.
+	gold          bool           // Bonus-round meteor: harmless, streams across without wrapping.
+	thrownTimer   *Timer         // Non-nil while flung by the tractor beam; counts down its danger to enemies.
+	inArena       bool           // Has been inside the shrinking arena, so leaving it gets it struck.
+	edge          Contact        // What the field's edge did to it on its last move.
+	health        int            // Laser hits left before it splits; one or less breaks on the next.
+	hitFlash      *Timer         // Non-nil while it glows from a hit it survived.
+	data          ObjectData     // Collider data; kept here so a pooled meteor reuses it.
 }
 
 // NewMeteor constructs a large meteor drifting toward the screen center.
@@ -38,59 +82,83 @@ type Meteor struct {
 // It spawns the meteor off-screen on a circle around the center, then computes
 // a normalized direction pointing inward and applies a randomized speed.
 func NewMeteor(baseVelocity float64, game *GameScene, index int) *Meteor {
-	// Compute the spawn ring around screen center.
-	target := Vector{X: ScreenWidth / 2, Y: ScreenHeight / 2}
-	angle := rand.Float64() * 2 * math.Pi
-	radius := (ScreenWidth / 2.0) + 500
+	return NewMeteorFrom(baseVelocity, game, index, game.spawnAngle(game.rng.Stream(streamSpawns).Float64()))
+}
 
-	// Position lies on the ring at the chosen angle.
-	position := Vector{
-		X: target.X + math.Cos(angle)*radius,
-		Y: target.Y + math.Sin(angle)*radius,
-	}
+// NewMeteorFrom constructs a large meteor drifting toward the screen center
+// from angle on the spawn ring.
+func NewMeteorFrom(baseVelocity float64, game *GameScene, index int, angle float64) *Meteor {
+	meteor := game.pools.meteors.Get()
+	rng := game.rng.Stream(streamSpawns)
+	meteor.reuse(newDriftingMeteor(baseVelocity, assets.MeteorSprites, angle, rng))
+	meteor.health = game.difficulty.MeteorHealth
+	meteor.attach(game, index, TagMeteor|TagLarge)
+	return meteor
+}
 
-	// Speed = baseVelocity + small random delta for variety.
-	velocity := baseVelocity + rand.Float64()*1.5
+// NewMediumMeteor constructs a medium meteor with similar inward drift,
+// using the medium-sprite atlas and TagMedium for collision categorization.
+func NewMediumMeteor(baseVelocity float64, game *GameScene, index int) *Meteor {
+	meteor := game.pools.meteors.Get()
+	rng := game.rng.Stream(streamSpawns)
+	meteor.reuse(newDriftingMeteor(baseVelocity, assets.MeteorSpritesMedium, game.spawnAngle(rng.Float64()), rng))
+	meteor.attach(game, index, TagMeteor|TagMedium)
+	return meteor
+}
 
-	// Direction points from spawn toward center; normalize for unit length.
-	direction := Vector{X: target.X - position.X, Y: target.Y - position.Y}
-	normalizedDirection := direction.Normalize()
+// NewSmallMeteor constructs a small meteor with similar inward drift,
+// using the small-sprite atlas and TagSmall for collision categorization.
+func NewSmallMeteor(baseVelocity float64, game *GameScene, index int) *Meteor {
+	meteor := game.pools.meteors.Get()
+	rng := game.rng.Stream(streamSpawns)
+	meteor.reuse(newDriftingMeteor(baseVelocity, assets.MeteorSpritesSmall, game.spawnAngle(rng.Float64()), rng))
+	meteor.attach(game, index, TagMeteor|TagSmall)
+	return meteor
+}
 
-	// Movement is direction * speed; applied each Update().
+// NewGoldMeteor constructs a harmless gold meteor for a bonus round.
+//
+// It enters just off the left edge at a random height and streams straight
+// across to the right instead of wrapping.
+func NewGoldMeteor(baseVelocity float64, game *GameScene, index int) *Meteor {
+	rng := game.rng.Stream(streamSpawns)
+	sprite := assets.MeteorSpritesSmall[rng.Intn(len(assets.MeteorSpritesSmall))]
+	position := Vector{
+		X: -float64(sprite.Bounds().Dx()),
+		Y: rng.Float64() * (ScreenHeight - float64(sprite.Bounds().Dy())),
+	}
 	movement := Vector{
-		X: normalizedDirection.X * velocity,
-		Y: normalizedDirection.Y * velocity,
+		X: baseVelocity + rng.Float64()*meteorSpeedSpread,
+		Y: (rng.Float64() - 0.5) * goldMeteorDrift,
 	}
-
-	// Choose a random large-meteor sprite and build a circular collider.
-	sprite := assets.MeteorSprites[rand.Intn(len(assets.MeteorSprites))]
-	meteorObj := resolv.NewCircle(position.X, position.Y, float64(sprite.Bounds().Dx()/2))
-
-	// Assemble the meteor with randomized spin and starting rotation.
-	meteor := &Meteor{
-		game:          game,
+	meteor := game.pools.meteors.Get()
+	meteor.reuse(Meteor{
 		position:      position,
 		movement:      movement,
-		rotationSpeed: rotationSpeedMin + rand.Float64()*(rotationSpeedMax-rotationSpeedMin),
+		rotationSpeed: meteorSpin(movement.Length(), sprite, rng.Float64()),
 		sprite:        sprite,
-		angle:         rand.Float64() * 2 * math.Pi,
-		meteorObj:     meteorObj,
-	}
-
-	// Initialize collider state and tags for broad-phase queries.
-	meteor.meteorObj.SetPosition(position.X, position.Y)
-	meteor.meteorObj.Tags().Set(TagMeteor | TagLarge)
-	meteor.meteorObj.SetData(&ObjectData{index: index})
-
+		angle:         rng.Float64() * 2 * math.Pi,
+		gold:          true,
+	})
+	meteor.attach(game, index, TagMeteor|TagSmall)
 	return meteor
 }
 
-// NewSmallMeteor constructs a small meteor with similar inward drift,
-// using the small-sprite atlas and TagSmall for collision categorization.
-func NewSmallMeteor(baseVelocity float64, game *GameScene, index int) *Meteor {
+// NewDecorativeMeteor constructs a large meteor for menu backdrops.
+//
+// It moves and draws like any other meteor but belongs to no scene and has
+// no collider, so it can never call back into gameplay state.
+func NewDecorativeMeteor(baseVelocity float64) *Meteor {
+	meteor := newDriftingMeteor(baseVelocity, assets.MeteorSprites, ambientRNG.Float64()*2*math.Pi, ambientRNG)
+	return &meteor
+}
+
+// newDriftingMeteor builds the motion and look of a meteor heading inward
+// from angle on an off-screen spawn ring, using a random sprite from
+// sprites and drawing every other roll from rng.
+func newDriftingMeteor(baseVelocity float64, sprites []*ebiten.Image, angle float64, rng *rand.Rand) Meteor {
 	// Compute the spawn ring around screen center.
 	target := Vector{X: ScreenWidth / 2, Y: ScreenHeight / 2}
-	angle := rand.Float64() * 2 * math.Pi
 	radius := (ScreenWidth / 2.0) + 500
 
 	// Position lies on the ring at the chosen angle.
@@ -100,56 +168,121 @@ func NewSmallMeteor(baseVelocity float64, game *GameScene, index int) *Meteor {
 	}
 
 	// Speed = baseVelocity + small random delta for variety.
-	velocity := baseVelocity + rand.Float64()*1.5
+	velocity := baseVelocity + rng.Float64()*meteorSpeedSpread
 
 	// Direction points from spawn toward center; normalize for unit length.
 	direction := Vector{X: target.X - position.X, Y: target.Y - position.Y}
 	normalizedDirection := direction.Normalize()
 
 	// Movement is direction * speed; applied each Update().
-	movement := Vector{
-		X: normalizedDirection.X * velocity,
-		Y: normalizedDirection.Y * velocity,
-	}
-
-	// Choose a random small-meteor sprite and build a circular collider.
-	sprite := assets.MeteorSpritesSmall[rand.Intn(len(assets.MeteorSpritesSmall))]
-	meteorObj := resolv.NewCircle(position.X, position.Y, float64(sprite.Bounds().Dx()/2))
+	movement := normalizedDirection.Scale(velocity)
 
-	// Assemble the meteor with randomized spin and starting rotation.
-	meteor := &Meteor{
-		game:          game,
+	// Assemble the meteor with a random sprite, a spin to match its speed
+	// and size, and a random starting rotation.
+	roll := rng.Float64()
+	sprite := sprites[rng.Intn(len(sprites))]
+	return Meteor{
 		position:      position,
 		movement:      movement,
-		rotationSpeed: rotationSpeedMin + rand.Float64()*(rotationSpeedMax-rotationSpeedMin),
+		rotationSpeed: meteorSpin(velocity, sprite, roll),
 		sprite:        sprite,
-		angle:         rand.Float64() * 2 * math.Pi,
-		meteorObj:     meteorObj,
+		angle:         rng.Float64() * 2 * math.Pi,
 	}
+}
+
+// meteorSpin returns a spin rate (radians per second) for a meteor of
+// sprite moving at speed: its rolling rate, jittered, clamped to the spin
+// bounds, and turning either way.
+//
+// One uniform roll in [0, 1) picks both the direction and the jitter, so a
+// meteor draws no more random numbers than it did with a flat random spin.
+func meteorSpin(speed float64, sprite *ebiten.Image, roll float64) float64 {
+	radius := max(float64(sprite.Bounds().Dx())/2, 1)
+
+	spin := meteorSpinRoll * speed / radius
+	spin *= 1 + meteorSpinJitter*(2*math.Mod(roll*2, 1)-1)
+	spin = max(meteorSpinMin, min(meteorSpinMax, spin))
+	if roll < 0.5 {
+		return -spin
+	}
+	return spin
+}
 
-	// Initialize collider state and tags for broad-phase queries.
-	meteor.meteorObj.SetPosition(position.X, position.Y)
-	meteor.meteorObj.Tags().Set(TagMeteor | TagSmall)
-	meteor.meteorObj.SetData(&ObjectData{index: index})
+// points returns the score for shooting the meteor down, by its size.
+func (m *Meteor) points() int {
+	switch tags := m.meteorObj.Tags(); {
+	case tags.Has(TagSmall):
+		return meteorPointsSmall
+	case tags.Has(TagMedium):
+		return meteorPointsMedium
+	}
+	return meteorPointsLarge
+}
 
-	return meteor
+// reuse overwrites a (possibly pooled) meteor with fresh, keeping the
+// collider it already owns so attach can recycle it.
+func (m *Meteor) reuse(fresh Meteor) {
+	obj := m.meteorObj
+	*m = fresh
+	m.meteorObj = obj
+}
+
+// attach binds the meteor to a gameplay scene and gives it a circular
+// collider tagged for broad-phase queries, recycling the one it already owns
+// if it came from the pool. The caller adds it to the space.
+func (m *Meteor) attach(game *GameScene, index int, tags resolv.Tags) {
+	m.game = game
+	radius := float64(m.sprite.Bounds().Dx() / 2)
+	if m.meteorObj == nil {
+		m.meteorObj = resolv.NewCircle(m.position.X, m.position.Y, radius)
+	} else {
+		m.meteorObj.SetRadius(radius)
+		m.meteorObj.Tags().Clear()
+	}
+	m.meteorObj.SetPosition(m.position.X, m.position.Y)
+	m.meteorObj.Tags().Set(tags)
+	m.data.index = index
+	m.meteorObj.SetData(&m.data)
 }
 
 // Update advances the meteor's position and rotation, then enforces wrap-around.
 //
 // The collider is kept in sync with the visual position for accurate queries.
 func (m *Meteor) Update() {
+	m.move()
+	m.syncCollider()
+}
+
+// move applies velocity, spin, and the screen boundary to the meteor's own state.
+//
+// It touches nothing shared, so scenes may call it from the worker pool.
+func (m *Meteor) move() {
 	// Apply velocity.
-	m.position.X += m.movement.X
-	m.position.Y += m.movement.Y
+	sim.Advance(&m.position, m.movement)
 
 	// Spin the sprite by its per-entity rotation speed.
-	m.rotation += m.rotationSpeed
+	m.rotation += sim.PerTick(m.rotationSpeed)
+
+	// Fade the hit flash.
+	if m.hitFlash != nil {
+		m.hitFlash.Update()
+		if m.hitFlash.IsReady() {
+			m.hitFlash = nil
+		}
+	}
 
-	// Wrap around the screen edges to maintain continuous motion.
+	// Wrap or bounce at the screen edges to keep the meteor in play.
 	m.keepOnScreen()
+}
 
-	// Sync collider with visual position.
+// syncCollider moves the collider to the visual position.
+//
+// This updates the resolv space's cell membership and must run serially.
+// Decorative meteors have no collider and skip it.
+func (m *Meteor) syncCollider() {
+	if m.meteorObj == nil {
+		return
+	}
 	m.meteorObj.SetPosition(m.position.X, m.position.Y)
 }
 
@@ -170,28 +303,38 @@ func (m *Meteor) Draw(screen *ebiten.Image) {
 	// Place sprite at world position.
 	op.GeoM.Translate(m.position.X, m.position.Y)
 
-	screen.DrawImage(m.sprite, op)
+	// Gold meteors keep their tint through the explosion; a fresh hit
+	// lights a meteor up.
+	if m.gold {
+		op.ColorScale.Scale(1, 0.84, 0.2, 1)
+	}
+	if m.hitFlash != nil {
+		op.ColorScale.Scale(2, 1.6, 1.4, 1)
+	}
+
+	drawSprite(screen, m.sprite, op)
 }
 
-// keepOnScreen wraps the meteor when crossing any screen edge.
+// keepOnScreen hands the meteor to its boundary policy.
 //
-// This preserves motion continuity and updates the collider in lockstep.
+// Only the meteor's own state is adjusted; syncCollider brings the collider
+// along. What the edge did is kept in edge for the scene to answer serially,
+// since this may run on the worker pool.
 func (m *Meteor) keepOnScreen() {
-	// Horizontal wrapping.
-	if m.position.X >= float64(ScreenWidth) {
-		m.position.X = 0
-		m.meteorObj.SetPosition(0, m.position.Y)
-	} else if m.position.X < 0 {
-		m.position.X = float64(ScreenWidth)
-		m.meteorObj.SetPosition(ScreenWidth, m.position.Y)
-	}
+	m.edge = m.boundary().confine(edgeBody{
+		position: &m.position,
+		movement: &m.movement,
+		size:     spriteSize(m.sprite),
+		margin:   streamedMeteorMargin,
+		entered:  &m.inArena,
+	})
+}
 
-	// Vertical wrapping.
-	if m.position.Y >= float64(ScreenHeight) {
-		m.position.Y = 0
-		m.meteorObj.SetPosition(m.position.X, 0)
-	} else if m.position.Y < 0 {
-		m.position.Y = float64(ScreenHeight)
-		m.meteorObj.SetPosition(m.position.X, ScreenHeight)
+// boundary returns the policy the meteor answers to: the scene's, except
+// that gold meteors stream off the far edge and are culled.
+func (m *Meteor) boundary() BoundaryPolicy {
+	if m.gold {
+		return CullBoundary{}
 	}
+	return m.game.boundary()
 }
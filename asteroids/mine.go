@@ -0,0 +1,187 @@
+// File mine.go defines proximity space mines: aliens drop them now and then,
+// they arm after a short delay, and they detonate when the ship or a laser
+// comes close, destroying meteors and setting off other mines in the blast.
+package asteroids
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/solarlune/resolv"
+)
+
+// Mine tuning.
+const (
+	mineDropPerSecond = 0.08                    // Chance per second that an alien drops a mine.
+	mineMaxCount      = 4                       // Mines allowed on the field at once.
+	mineArmDelay      = 1500 * time.Millisecond // Time from drop to armed.
+	mineTriggerRadius = 60.0                    // Proximity that sets off an armed mine.
+	mineBlastRadius   = 120.0                   // Radius the explosion damages.
+	mineBlastDuration = 300 * time.Millisecond  // Time for the blast ring to expand.
+	mineBodyRadius    = 8.0                     // Drawn body radius.
+	mineBlinkTicks    = 15                      // Ticks per half-cycle of the armed light.
+	minePoints        = 5                       // Score for shooting a mine.
+	mineMeteorPoints  = 1                       // Score per meteor caught in a blast.
+)
+
+// Mine is a stationary explosive dropped by an alien.
+type Mine struct {
+	position   Vector         // Center in world space.
+	armTimer   *Timer         // Counts down to armed.
+	blast      *Timer         // Blast ring progress; nil until detonated.
+	ticks      int            // Ticks since the drop, for blinking.
+	triggerObj *resolv.Circle // Proximity trigger collider.
+}
+
+// NewMine drops an unarmed mine at position.
+func NewMine(position Vector, index int) *Mine {
+	m := &Mine{
+		position:   position,
+		armTimer:   sim.NewTimer(mineArmDelay),
+		triggerObj: resolv.NewCircle(position.X, position.Y, mineTriggerRadius),
+	}
+	m.triggerObj.SetPosition(position.X, position.Y)
+	m.triggerObj.SetData(&ObjectData{index: index})
+	m.triggerObj.Tags().Set(TagMine)
+	return m
+}
+
+// Update advances the arming delay, the blink cycle, and any blast.
+func (m *Mine) Update() {
+	m.ticks++
+	m.armTimer.Update()
+	if m.blast != nil {
+		m.blast.Update()
+	}
+}
+
+// isArmed reports whether the mine can be set off.
+func (m *Mine) isArmed() bool {
+	return m.armTimer.IsReady() && m.blast == nil
+}
+
+// isSpent reports whether the mine has detonated and its blast has finished.
+func (m *Mine) isSpent() bool {
+	return m.blast != nil && m.blast.IsReady()
+}
+
+// Draw renders the spiked body with its status light, or the blast ring once
+// detonated. The light is dim while arming; once armed it blinks red and the
+// trigger radius is outlined.
+func (m *Mine) Draw(screen *ebiten.Image) {
+	x, y := float32(m.position.X), float32(m.position.Y)
+
+	if m.blast != nil {
+		t := float32(m.blast.Elapsed) / float32(max(1, m.blast.Target))
+		alpha := uint8(255 * (1 - t))
+		c := color.RGBA{R: alpha, G: alpha / 2, A: alpha} // Premultiplied orange.
+		fillCircle(screen, x, y, mineBlastRadius*t, color.RGBA{R: alpha / 3, G: alpha / 6, A: alpha / 3}, true)
+		strokeCircle(screen, x, y, mineBlastRadius*t, 3, c, true)
+		return
+	}
+
+	body := color.RGBA{R: 110, G: 110, B: 120, A: 255}
+	for i := 0; i < 4; i++ {
+		sin, cos := math.Sincos(float64(i) * math.Pi / 4)
+		dx, dy := float32(cos*mineBodyRadius*1.6), float32(sin*mineBodyRadius*1.6)
+		strokeLine(screen, x-dx, y-dy, x+dx, y+dy, 2, body, true)
+	}
+	fillCircle(screen, x, y, mineBodyRadius, body, true)
+
+	light := color.RGBA{R: 60, G: 20, B: 20, A: 255}
+	if m.armTimer.IsReady() {
+		// Armed: outline the trigger radius and blink the light.
+		drawDashedCircle(screen, x, y, mineTriggerRadius, 1, 16, color.RGBA{R: 90, G: 20, B: 20, A: 90})
+		if (m.ticks/mineBlinkTicks)%2 == 0 {
+			light = color.RGBA{R: 255, G: 40, B: 40, A: 255}
+		}
+	}
+	fillCircle(screen, x, y, mineBodyRadius/2, light, true)
+}
+
+// letAliensDropMines gives every live alien a small chance each tick to
+// drop a mine where it is, up to the field cap.
+func (g *GameScene) letAliensDropMines() {
+	if g.isBonusRound() {
+		return
+	}
+	chance := sim.PerTick(mineDropPerSecond)
+	for _, a := range inOrder(g.aliens) {
+		if len(g.mines) >= mineMaxCount {
+			return
+		}
+		if a.sprite == g.explosionSmallSprite || isOffscreen(a.position, 0) || g.rng.Stream(streamAI).Float64() >= chance {
+			continue
+		}
+		g.mineCount++
+		mine := NewMine(a.position, g.mineCount)
+		g.mines[g.mineCount] = mine
+		g.space.Add(mine.triggerObj)
+	}
+}
+
+// updateMines advances every mine and removes those whose blast has finished.
+func (g *GameScene) updateMines() {
+	for _, m := range inOrder(g.mines) {
+		m.Update()
+	}
+	for _, i := range g.scratch.mines.cull(g.mines, (*Mine).isSpent) {
+		delete(g.mines, i)
+	}
+}
+
+// isMineTriggered sets off armed mines the ship flies near or a laser enters.
+// Shooting a mine scores it.
+func (g *GameScene) isMineTriggered() {
+	for _, m := range inOrder(g.mines) {
+		if !m.isArmed() {
+			continue
+		}
+		if !g.player.isDying && g.collisions.intersects(m.triggerObj, g.player.playerObj) {
+			g.detonateMine(m)
+			continue
+		}
+		for i, l := range inOrder(g.lasers) {
+			if g.collisions.intersects(m.triggerObj, l.laserObj) {
+				g.collisions.consume(l.laserObj)
+				g.laserHit(i)
+				g.scoreKill(minePoints, m.position)
+				g.detonateMine(m)
+				break
+			}
+		}
+	}
+}
+
+// detonateMine explodes a mine: meteors in the blast are destroyed, an
+// unshielded ship in it is lost, and other mines in it go off in turn.
+func (g *GameScene) detonateMine(m *Mine) {
+	if m.blast != nil {
+		return // Already detonated, e.g. by a neighbour in a chain.
+	}
+	m.blast = sim.NewTimer(mineBlastDuration)
+	g.space.Remove(m.triggerObj)
+	sharedAudio().PlaySFXAt(sfxExplosion, m.position.X)
+
+	for _, meteor := range inOrder(g.meteors) {
+		if meteor.gold || g.isExploding(meteor) || !withinRadius(spriteCenter(meteor.position, meteor.sprite), m.position, mineBlastRadius) {
+			continue
+		}
+		g.shatterMeteor(meteor, mineMeteorPoints)
+	}
+
+	ship := spriteCenter(g.player.position, g.player.sprite)
+	if !g.player.isShielded && !g.player.isDying && !g.isBonusRound() && withinRadius(ship, m.position, mineBlastRadius) {
+		g.player.isDying = true
+	}
+
+	// Chain reaction: armed or not, mines in the blast go off too.
+	for _, other := range inOrder(g.mines) {
+		if withinRadius(other.position, m.position, mineBlastRadius) {
+			g.detonateMine(other)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// File mine_test.go checks mines in live play: aliens drop them as the
+// scene updates, they arm after mineArmDelay, and an armed one goes off
+// when the ship or a laser comes close.
+package asteroids
+
+import (
+	"testing"
+
+	"github.com/bensabler/asteroids/internal/sim"
+)
+
+func TestAliensDropMinesThatArmAndDetonate(t *testing.T) {
+	g := newGameScene(ModeStandard, 1, Upgrades{}, difficulties[0], shipClasses[0])
+	state := &State{SceneManager: &SceneManager{}, Input: &Input{}}
+	state.SceneManager.GoToScene(g)
+	for i := range 8 {
+		a := NewAlien(g.baseVelocity, g)
+		a.position = Vector{X: float64(i+1) * ScreenWidth / 10, Y: 120}
+		a.alienObj.SetPosition(a.position.X, a.position.Y)
+		g.addAlien(a)
+	}
+
+	// Play until an alien leaves a mine behind.
+	var mine *Mine
+	for tick := 0; mine == nil; tick++ {
+		if tick == 60*sim.TicksPerSecond {
+			t.Fatal("no alien dropped a mine in a minute of play")
+		}
+		if err := g.Update(state); err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range inOrder(g.mines) {
+			mine = m
+			break
+		}
+	}
+	if mine.isArmed() {
+		t.Fatal("a freshly dropped mine is already armed")
+	}
+
+	// Play on through the arming delay.
+	for range sim.Ticks(mineArmDelay) {
+		if err := g.Update(state); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !mine.isArmed() {
+		t.Fatalf("mine not armed after %v of play", mineArmDelay)
+	}
+
+	// The ship flying into an armed mine sets it off. Colliders touch at
+	// their edges on the way in; resolv does not count one wholly inside
+	// another as intersecting.
+	g.player.playerObj.SetPosition(mine.position.X+mineTriggerRadius, mine.position.Y)
+	g.collisions.reset()
+	g.isMineTriggered()
+	if mine.blast == nil {
+		t.Error("the ship flew into an armed mine without setting it off")
+	}
+
+	// So does a laser.
+	g.mineCount++
+	shot := NewMine(Vector{X: ScreenWidth / 2, Y: ScreenHeight - 100}, g.mineCount)
+	g.mines[g.mineCount] = shot
+	g.space.Add(shot.triggerObj)
+	for range sim.Ticks(mineArmDelay) {
+		shot.Update()
+	}
+	g.player.spawnLaser(Vector{X: shot.position.X, Y: shot.position.Y + mineTriggerRadius}, 0)
+	lasers := len(g.lasers)
+	g.collisions.reset()
+	g.isMineTriggered()
+	if shot.blast == nil {
+		t.Error("a laser entered an armed mine without setting it off")
+	}
+	if len(g.lasers) != lasers-1 {
+		t.Error("the laser that set off the mine is still in play")
+	}
+}
@@ -0,0 +1,100 @@
+// File mode.go defines Mode, the set of rule switches that distinguish game
+// variants. GameScene consults its Mode wherever behavior differs between
+// variants instead of branching on ad-hoc flags.
+package asteroids
+
+// CompletionRule decides when a level counts as finished.
+type CompletionRule int
+
+const (
+	// CompleteOnMeteors finishes the level once its meteor budget is cleared.
+	// Aliens still on screen carry over into the next wave.
+	CompleteOnMeteors CompletionRule = iota
+
+	// CompleteOnMeteorsAndAliens additionally waits for every alien to be
+	// destroyed or leave. No new aliens spawn once the meteors are gone.
+	CompleteOnMeteorsAndAliens
+)
+
+// Mode bundles the rule switches for one game variant.
+type Mode struct {
+	Name string // Display name.
+
+	// Completion selects the level-complete predicate.
+	Completion CompletionRule
+
+	// UnboundedSpeedRamp restores the classic behavior of raising meteor
+	// speed every second for as long as a level lasts. When false, speed
+	// follows the current Level's bounded curve.
+	UnboundedSpeedRamp bool
+
+	// InfiniteLives respawns the ship after every death without spending a life.
+	InfiniteLives bool
+
+	// Unranked keeps the run's score out of the high-score table.
+	Unranked bool
+
+	// EnergyHandling makes the shield, hyperspace, and afterburner draw from
+	// one regenerating energy meter instead of shield charges and the
+	// hyperspace cooldown.
+	EnergyHandling bool
+
+	// NoAssists ignores the assist options, for competitive play.
+	NoAssists bool
+
+	// Practice replaces level progression with player-chosen spawns, edited
+	// from the practice panel.
+	Practice bool
+
+	// NoUpgrades flies a stock ship whatever the profile has bought, for
+	// competitive play.
+	NoUpgrades bool
+
+	// HyperspaceRisk gives every hyperspace jump a chance to malfunction and
+	// destroy the ship, unless Safe Hyperspace has been bought.
+	HyperspaceRisk bool
+
+	// NewGamePlus scales up enemy stats, unlocks the armored alien
+	// formations, and ranks runs on their own high-score table.
+	NewGamePlus bool
+
+	// ShrinkingArena closes an energy boundary in on the field during each
+	// level and turns off screen wrapping; the boundary destroys what it
+	// touches.
+	ShrinkingArena bool
+
+	// SolidWalls turns the screen edges into walls the ship, meteors, and
+	// pickups bounce off instead of wrapping across.
+	SolidWalls bool
+
+	// LevelUpgrades makes kills drop scrap and offers a pick of run
+	// upgrades, bought with it, before every numbered level after the first,
+	// with a salvage shop selling supplies every few levels.
+	LevelUpgrades bool
+
+	// NoSpawnDirector turns the spawn director off, for competitive play:
+	// spawns fall uniformly around the field wherever the ship is, so every
+	// player faces the same odds however they fly.
+	NoSpawnDirector bool
+}
+
+// Built-in modes.
+var (
+	// ModeStandard is the default ruleset.
+	ModeStandard = Mode{Name: "Standard", Completion: CompleteOnMeteorsAndAliens, HyperspaceRisk: true, LevelUpgrades: true}
+
+	// ModeClassic keeps the original arcade-style rules.
+	ModeClassic = Mode{Name: "Classic", Completion: CompleteOnMeteors, UnboundedSpeedRamp: true, HyperspaceRisk: true}
+
+	// ModeModern uses energy handling on top of the default ruleset.
+	ModeModern = Mode{Name: "Modern", Completion: CompleteOnMeteorsAndAliens, EnergyHandling: true, HyperspaceRisk: true, LevelUpgrades: true}
+
+	// ModePractice is a sandbox for learning the mechanics.
+	ModePractice = Mode{
+		Name:          "Practice",
+		Completion:    CompleteOnMeteors,
+		InfiniteLives: true,
+		Unranked:      true,
+		Practice:      true,
+	}
+)
@@ -0,0 +1,89 @@
+// File music.go defines Music, a looping background track that can be
+// started, paused, and faded out independently of the sound effects.
+package asteroids
+
+import (
+	"time"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+// Music tuning constants.
+const (
+	musicGain     = 0.6             // Track level relative to the music channel.
+	musicFadeTime = 2 * time.Second // Duration of a fade-out.
+)
+
+// Music plays the background track on an endless loop.
+//
+// The owning scene decides when it plays; scenes that merely sit in front
+// of gameplay (such as LevelStartsScene) leave it running.
+type Music struct {
+	player *managedPlayer // Looping player in the music channel.
+	level  float64        // Current fade level (1 = full, 0 = silent).
+	fading bool           // Fading out; see Update.
+}
+
+// NewMusic returns a stopped, full-level player for the background track.
+// Every Music plays through the one player made the first time, rewound.
+func NewMusic() *Music {
+	a := sharedAudio()
+	m := &Music{player: sharedLoop(a, "music", func() *managedPlayer {
+		return a.newPlayer(audio.NewInfiniteLoop(assets.MusicTrack, assets.MusicTrack.Length()), soundMusic, musicGain)
+	})}
+	m.Stop()
+	return m
+}
+
+// Play starts or resumes the track at full level, cancelling any fade.
+func (m *Music) Play() {
+	if m.fading || m.level != 1 {
+		m.fading = false
+		m.setLevel(1)
+	}
+	if !m.player.IsPlaying() {
+		sharedAudio().play(m.player.Player)
+	}
+}
+
+// Pause holds the track at its current position.
+func (m *Music) Pause() {
+	m.player.Pause()
+}
+
+// Stop pauses the track and rewinds it so the next Play starts from the top.
+func (m *Music) Stop() {
+	m.fading = false
+	m.player.Pause()
+	_ = m.player.Rewind()
+	m.setLevel(1)
+}
+
+// FadeOut begins lowering the track to silence over musicFadeTime.
+// Call Update every tick while fading.
+func (m *Music) FadeOut() {
+	if m.player.IsPlaying() {
+		m.fading = true
+	}
+}
+
+// Update advances an active fade and pauses the track once it is silent.
+func (m *Music) Update() {
+	if !m.fading {
+		return
+	}
+	step := sim.PerTick(1 / musicFadeTime.Seconds())
+	m.setLevel(m.level - step)
+	if m.level <= 0 {
+		m.fading = false
+		m.player.Pause()
+	}
+}
+
+// setLevel applies a fade level on top of the track's base gain.
+func (m *Music) setLevel(level float64) {
+	m.level = clamp01(level)
+	sharedAudio().setGain(m.player, musicGain*m.level)
+}
@@ -0,0 +1,94 @@
+// File new-game-plus.go defines New Game+, the harder replay of the standard
+// ruleset unlocked by reaching newGamePlusMilestone in a ranked run. The
+// ship keeps its upgrades; meteors and aliens move faster, aliens fire more
+// often, bosses take more punishment, and armored alien formations join the
+// mix. New Game+ keeps its own high-score table.
+package asteroids
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// New Game+ tuning.
+const (
+	newGamePlusMilestone  = 10                       // Level whose start unlocks New Game+.
+	newGamePlusSpeed      = 1.3                      // Meteor and alien speed multiplier.
+	newGamePlusAttackTime = alienAttackTime * 7 / 10 // Wait between alien volleys.
+	newGamePlusBossHealth = 3                        // Extra hits each boss weak point takes.
+	newGamePlusArmor      = 2                        // Hits an armored alien shrugs off before exploding.
+)
+
+// ModeNewGamePlus is the standard ruleset with New Game+ scaling.
+var ModeNewGamePlus = Mode{
+	Name:           "New Game+",
+	Completion:     CompleteOnMeteorsAndAliens,
+	HyperspaceRisk: true,
+	NewGamePlus:    true,
+	LevelUpgrades:  true,
+}
+
+// NewGamePlusProgress is the profile's record of New Game+.
+type NewGamePlusProgress struct {
+	Unlocked  bool `json:"unlocked"`  // The milestone has been reached.
+	Runs      int  `json:"runs"`      // New Game+ runs finished.
+	BestLevel int  `json:"bestLevel"` // Highest level reached in New Game+.
+}
+
+// enemySpeed returns the multiplier on meteor and alien speed.
+func (m Mode) enemySpeed() float64 {
+	if m.NewGamePlus {
+		return newGamePlusSpeed
+	}
+	return 1
+}
+
+// level returns the definition of level n under m, faster in New Game+.
+func (m Mode) level(n int) Level {
+	l := levels.Level(n)
+	l.MeteorVelocityStart *= m.enemySpeed()
+	l.MeteorVelocityCap *= m.enemySpeed()
+	return l
+}
+
+// alienAttackInterval returns the wait between alien volleys.
+func (m Mode) alienAttackInterval() time.Duration {
+	if m.NewGamePlus {
+		return newGamePlusAttackTime
+	}
+	return alienAttackTime
+}
+
+// bossWeakPointHealth returns the hits each boss weak point takes.
+func (m Mode) bossWeakPointHealth() int {
+	if m.NewGamePlus {
+		return bossWeakPointHealth + newGamePlusBossHealth
+	}
+	return bossWeakPointHealth
+}
+
+// unlockNewGamePlus records the milestone when a ranked run outside New
+// Game+ reaches it.
+func (g *GameScene) unlockNewGamePlus() {
+	if g.currentLevel < newGamePlusMilestone || g.mode.Unranked || g.mode.NewGamePlus || g.playback != nil {
+		return
+	}
+	if profile.NewGamePlus.Unlocked {
+		return
+	}
+	profile.NewGamePlus.Unlocked = true
+	g.newGamePlusUnlocked = true
+	if err := profile.Save(); err != nil {
+		log.Println("Error saving profile", err)
+	}
+}
+
+// newGamePlusLabel returns the title menu label for New Game+, which names
+// the milestone while it is still locked.
+func newGamePlusLabel() string {
+	if profile.NewGamePlus.Unlocked {
+		return "New Game+"
+	}
+	return fmt.Sprintf("New Game+ (reach level %d)", newGamePlusMilestone)
+}
@@ -0,0 +1,33 @@
+// File ordered.go provides deterministic iteration over the scene's entity
+// maps. Go randomizes map order, so gameplay loops whose outcome depends on
+// order (which laser hits first, which alien rolls which number) walk their
+// maps through inOrder; that keeps a seeded run reproducible tick for tick.
+package asteroids
+
+import (
+	"iter"
+	"slices"
+)
+
+// inOrder yields the entries of m by ascending key. Keys are snapshotted up
+// front, so the loop body may delete entries: deleted entries that have not
+// been reached yet are skipped, and entries added during the loop are not
+// visited.
+func inOrder[V any](m map[int]V) iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		keys := make([]int, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			v, ok := m[k]
+			if !ok {
+				continue
+			}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
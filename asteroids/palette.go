@@ -0,0 +1,146 @@
+// File palette.go defines the selectable color palettes: the HUD's text
+// and accent colors, the tint of projectiles drawn with shape coding, and
+// for the colorblind palettes the alien laser, exhaust, and shield too.
+// Everything drawn in one of these colors looks it up here rather than
+// hardcoding it, so a palette recolors all of it at once.
+package asteroids
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/colorm"
+)
+
+// Palette is a named color scheme for the HUD and the colors gameplay
+// depends on telling apart.
+type Palette struct {
+	Name       string     // Display name and config-file identifier.
+	HUD        color.RGBA // Score, high score, and level text.
+	Meter      color.RGBA // Fill of the energy and spread-shot meters.
+	Warning    color.RGBA // Low energy, boss health, and alien radar contacts.
+	PlayerShot color.RGBA // Tint of shape-coded player bolts.
+	AlienShot  color.RGBA // Tint of shape-coded alien orbs.
+	AlienLaser color.RGBA // Recolors the alien laser sprite; transparent keeps its own red.
+	Exhaust    color.RGBA // Recolors the exhaust flare; transparent keeps its own orange.
+	Shield     color.RGBA // Tints the shield glow; transparent keeps it white.
+}
+
+// Accent colors shared by the palettes that do not change them.
+var (
+	classicMeter   = color.RGBA{R: 120, G: 220, B: 255, A: 255}
+	classicWarning = color.RGBA{R: 255, G: 80, B: 80, A: 255}
+)
+
+// palettes lists the available schemes; the first is the default. The
+// colorblind palettes draw on the Okabe-Ito set, keeping the ship's colors
+// and the aliens' on either side of the confusion the name describes.
+var palettes = []Palette{
+	{
+		Name:       "Classic",
+		HUD:        color.RGBA{R: 255, G: 255, B: 255, A: 255},
+		Meter:      classicMeter,
+		Warning:    classicWarning,
+		PlayerShot: color.RGBA{R: 54, G: 187, B: 245, A: 255},
+		AlienShot:  color.RGBA{R: 240, G: 80, B: 80, A: 255},
+	},
+	{
+		Name:       "Amber",
+		HUD:        color.RGBA{R: 255, G: 176, B: 0, A: 255},
+		Meter:      classicMeter,
+		Warning:    classicWarning,
+		PlayerShot: color.RGBA{R: 255, G: 176, B: 0, A: 255},
+		AlienShot:  color.RGBA{R: 255, G: 240, B: 200, A: 255},
+	},
+	{
+		Name:       "Phosphor",
+		HUD:        color.RGBA{R: 51, G: 255, B: 102, A: 255},
+		Meter:      classicMeter,
+		Warning:    classicWarning,
+		PlayerShot: color.RGBA{R: 51, G: 255, B: 102, A: 255},
+		AlienShot:  color.RGBA{R: 200, G: 255, B: 210, A: 255},
+	},
+	{
+		Name:       "Ice",
+		HUD:        color.RGBA{R: 150, G: 220, B: 255, A: 255},
+		Meter:      classicMeter,
+		Warning:    classicWarning,
+		PlayerShot: color.RGBA{R: 150, G: 220, B: 255, A: 255},
+		AlienShot:  color.RGBA{R: 255, G: 170, B: 210, A: 255},
+	},
+	{
+		Name:       "Deuteranopia",
+		HUD:        color.RGBA{R: 255, G: 255, B: 255, A: 255},
+		Meter:      color.RGBA{R: 86, G: 180, B: 233, A: 255},
+		Warning:    color.RGBA{R: 230, G: 159, B: 0, A: 255},
+		PlayerShot: color.RGBA{R: 86, G: 180, B: 233, A: 255},
+		AlienShot:  color.RGBA{R: 230, G: 159, B: 0, A: 255},
+		AlienLaser: color.RGBA{R: 230, G: 159, B: 0, A: 255},
+		Exhaust:    color.RGBA{R: 240, G: 228, B: 66, A: 255},
+		Shield:     color.RGBA{R: 86, G: 180, B: 233, A: 255},
+	},
+	{
+		Name:       "Protanopia",
+		HUD:        color.RGBA{R: 255, G: 255, B: 255, A: 255},
+		Meter:      color.RGBA{R: 86, G: 180, B: 233, A: 255},
+		Warning:    color.RGBA{R: 240, G: 228, B: 66, A: 255},
+		PlayerShot: color.RGBA{R: 86, G: 180, B: 233, A: 255},
+		AlienShot:  color.RGBA{R: 240, G: 228, B: 66, A: 255},
+		AlienLaser: color.RGBA{R: 240, G: 228, B: 66, A: 255},
+		Exhaust:    color.RGBA{R: 255, G: 255, B: 255, A: 255},
+		Shield:     color.RGBA{R: 86, G: 180, B: 233, A: 255},
+	},
+	{
+		Name:       "Tritanopia",
+		HUD:        color.RGBA{R: 255, G: 255, B: 255, A: 255},
+		Meter:      color.RGBA{R: 0, G: 200, B: 200, A: 255},
+		Warning:    color.RGBA{R: 255, G: 70, B: 110, A: 255},
+		PlayerShot: color.RGBA{R: 0, G: 200, B: 200, A: 255},
+		AlienShot:  color.RGBA{R: 255, G: 70, B: 110, A: 255},
+		AlienLaser: color.RGBA{R: 255, G: 70, B: 110, A: 255},
+		Exhaust:    color.RGBA{R: 255, G: 170, B: 170, A: 255},
+		Shield:     color.RGBA{R: 0, G: 200, B: 200, A: 255},
+	},
+}
+
+// paletteIndex returns the index of the palette called name, or 0 (the
+// default) if there is none.
+func paletteIndex(name string) int {
+	for i, p := range palettes {
+		if p.Name == name {
+			return i
+		}
+	}
+	return 0
+}
+
+// currentPalette returns the palette selected in the config.
+func currentPalette() Palette {
+	return palettes[paletteIndex(config.Palette)]
+}
+
+// cyclePalette returns the name of the palette step places after name,
+// wrapping at either end.
+func cyclePalette(name string, step int) string {
+	i := (paletteIndex(name) + step + len(palettes)) % len(palettes)
+	return palettes[i].Name
+}
+
+// drawRecolored draws src like drawSprite, redrawn in tint with its shading
+// kept: each pixel takes tint scaled by the sum of its channels, so a
+// sprite's core reaches the full tint and its faint edges stay faint. A
+// transparent tint draws src as it is.
+func drawRecolored(dst, src *ebiten.Image, tint color.RGBA, op *ebiten.DrawImageOptions) {
+	if tint.A == 0 {
+		drawSprite(dst, src, op)
+		return
+	}
+	var cm colorm.ColorM
+	for row, c := range []uint8{tint.R, tint.G, tint.B} {
+		v := float64(c) / 255
+		for col := range 3 {
+			cm.SetElement(row, col, v)
+		}
+	}
+	drawSpriteColorM(dst, src, cm, &colorm.DrawImageOptions{GeoM: op.GeoM, Blend: op.Blend})
+}
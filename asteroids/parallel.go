@@ -0,0 +1,167 @@
+// File parallel.go provides a small worker pool used to fan independent
+// per-tick work (entity movement, effect particles, off-screen culling)
+// across goroutines when entity counts are large enough to make it
+// worthwhile. The starfield is static and has no per-tick work to share.
+package asteroids
+
+import (
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// SingleThreaded forces all pooled work to run on the calling goroutine.
+//
+// It is the fallback for debugging, profiling, and platforms where the
+// goroutine hand-off costs more than it saves. Set it before the game starts.
+var SingleThreaded = false
+
+// parallelThreshold is the minimum number of items before work is split
+// across workers. Below it the serial loop is always faster.
+const parallelThreshold = 256
+
+// updatePool is the shared pool used by scenes during Update.
+var updatePool = newWorkerPool(runtime.NumCPU())
+
+// poolJob is one contiguous chunk of a parallel loop.
+type poolJob struct {
+	start, end int             // Half-open index range [start, end).
+	fn         func(i int)     // Work for a single index.
+	wg         *sync.WaitGroup // Signalled when the chunk completes.
+}
+
+// workerPool owns a fixed set of long-lived goroutines fed by a job channel.
+type workerPool struct {
+	workers int          // Number of worker goroutines.
+	jobs    chan poolJob // Pending chunks.
+}
+
+// newWorkerPool starts n workers. A pool with fewer than two workers
+// never spawns goroutines and always runs work serially.
+func newWorkerPool(n int) *workerPool {
+	p := &workerPool{workers: n}
+	if n < 2 {
+		return p
+	}
+
+	p.jobs = make(chan poolJob, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			for job := range p.jobs {
+				for j := job.start; j < job.end; j++ {
+					job.fn(j)
+				}
+				job.wg.Done()
+			}
+		}()
+	}
+	return p
+}
+
+// run calls fn for every index in [0, n) and returns once all calls finish.
+//
+// fn must only touch state owned by index i; shared structures such as the
+// resolv space are not safe to mutate from inside fn.
+func (p *workerPool) run(n int, fn func(i int)) {
+	if SingleThreaded || p.workers < 2 || n < parallelThreshold {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+
+	// Split into one chunk per worker; the last chunk absorbs the remainder.
+	chunk := (n + p.workers - 1) / p.workers
+	var wg sync.WaitGroup
+	for start := 0; start < n; start += chunk {
+		end := start + chunk
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		p.jobs <- poolJob{start: start, end: end, fn: fn, wg: &wg}
+	}
+	wg.Wait()
+}
+
+// updateScratch holds the buffers a scene reuses every tick to hand its
+// entity maps to the worker pool, one per map.
+type updateScratch struct {
+	lasers      poolScratch[*Laser]      // Player lasers.
+	alienLasers poolScratch[*AlienLaser] // Alien lasers.
+	meteors     poolScratch[*Meteor]     // Meteors.
+	aliens      poolScratch[*Alien]      // Aliens.
+	powerUps    poolScratch[*PowerUp]    // Power-ups.
+	mines       poolScratch[*Mine]       // Mines.
+}
+
+// poolScratch is the reusable storage for laying out one entity map as
+// slices the worker pool can index. Each call reuses the slices of the
+// last, so a result is only valid until the next call on the same scratch.
+type poolScratch[V any] struct {
+	keys   []int  // Keys of the map, ascending.
+	values []V    // Values, in key order.
+	flags  []bool // Per-value verdicts of the last cull.
+	culled []int  // Keys the last cull dropped.
+}
+
+// load lays out the entries of m by ascending key.
+func (s *poolScratch[V]) load(m map[int]V) {
+	s.keys = s.keys[:0]
+	for k := range m {
+		s.keys = append(s.keys, k)
+	}
+	slices.Sort(s.keys)
+	s.values = s.values[:0]
+	for _, k := range s.keys {
+		s.values = append(s.values, m[k])
+	}
+}
+
+// valuesOf returns the values of m by ascending key, so they can be indexed
+// by the worker pool and walked afterwards in the same order on every run.
+func (s *poolScratch[V]) valuesOf(m map[int]V) []V {
+	s.load(m)
+	return s.values
+}
+
+// cull evaluates remove for every entry of m on the worker pool and
+// returns the keys whose entries should be dropped, in ascending order. The
+// caller performs the actual deletion serially so map and space mutations
+// stay single-threaded and happen in the same order on every run.
+func (s *poolScratch[V]) cull(m map[int]V, remove func(V) bool) []int {
+	s.load(m)
+	s.flags = slices.Grow(s.flags[:0], len(s.values))[:len(s.values)]
+	updatePool.run(len(s.values), func(i int) {
+		s.flags[i] = remove(s.values[i])
+	})
+
+	s.culled = s.culled[:0]
+	for i, flagged := range s.flags {
+		if flagged {
+			s.culled = append(s.culled, s.keys[i])
+		}
+	}
+	return s.culled
+}
+
+// particle is an effect particle the worker pool can age: age spends a
+// tick of its life and moves it if any is left, touching nothing else.
+type particle[P any] interface {
+	*P
+	age()
+	alive() bool
+}
+
+// ageParticles ages every particle of ps on the worker pool, then drops the
+// dead ones in place, keeping the living in order.
+func ageParticles[P any, PP particle[P]](ps []P) []P {
+	updatePool.run(len(ps), func(i int) { PP(&ps[i]).age() })
+	live := ps[:0]
+	for i := range ps {
+		if PP(&ps[i]).alive() {
+			live = append(live, ps[i])
+		}
+	}
+	return live
+}
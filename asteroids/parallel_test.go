@@ -0,0 +1,77 @@
+// File parallel_test.go checks that pooled per-tick work does on many
+// workers exactly what it does serially, and benchmarks the two at entity
+// counts large enough for the pool to split the work.
+package asteroids
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Entity counts for crowdedScene, well past parallelThreshold.
+const (
+	crowdedMeteors = 1000
+	crowdedSparks  = 4000
+)
+
+// crowdedScene returns a scene from seed filled with drifting meteors and
+// long-lived wall sparks.
+func crowdedScene(seed int64) *GameScene {
+	g := newGameScene(ModeStandard, seed, Upgrades{}, difficulties[0], shipClasses[0])
+	for range crowdedMeteors {
+		g.addMeteor(NewMeteor(g.baseVelocity, g, g.meteorCount+1))
+	}
+	for range crowdedSparks / wallSparkCount {
+		g.wallImpact(Vector{X: 0, Y: ScreenHeight / 2})
+	}
+	for i := range g.sparks {
+		g.sparks[i].life = 1 << 30 // Outlive any benchmark.
+	}
+	return g
+}
+
+// pooledTick runs the scene's pooled per-tick work once.
+func pooledTick(g *GameScene) {
+	g.moveProjectilesAndMeteors()
+	g.updateSparks()
+	g.removeStreamedMeteors()
+}
+
+func TestParallelUpdateMatchesSerial(t *testing.T) {
+	defer func(p *workerPool, single bool) { updatePool, SingleThreaded = p, single }(updatePool, SingleThreaded)
+	updatePool = newWorkerPool(4) // Split the work even on one CPU.
+
+	run := func(single bool) (meteors []Vector, sparks []wallSpark) {
+		SingleThreaded = single
+		g := crowdedScene(7)
+		for range 30 {
+			pooledTick(g)
+		}
+		for _, m := range g.scratch.meteors.valuesOf(g.meteors) {
+			meteors = append(meteors, m.position)
+		}
+		return meteors, g.sparks
+	}
+	serialMeteors, serialSparks := run(true)
+	parallelMeteors, parallelSparks := run(false)
+	if !reflect.DeepEqual(parallelMeteors, serialMeteors) {
+		t.Error("meteors moved differently on the pool than serially")
+	}
+	if !reflect.DeepEqual(parallelSparks, serialSparks) {
+		t.Error("sparks aged differently on the pool than serially")
+	}
+}
+
+// benchmarkUpdate times pooledTick over a crowded scene.
+func benchmarkUpdate(b *testing.B, single bool) {
+	defer func(single bool) { SingleThreaded = single }(SingleThreaded)
+	SingleThreaded = single
+	g := crowdedScene(7)
+	b.ReportAllocs()
+	for b.Loop() {
+		pooledTick(g)
+	}
+}
+
+func BenchmarkUpdateSerial(b *testing.B)   { benchmarkUpdate(b, true) }
+func BenchmarkUpdateParallel(b *testing.B) { benchmarkUpdate(b, false) }
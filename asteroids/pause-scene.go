@@ -0,0 +1,116 @@
+// File pause-scene.go implements the PauseScene, which freezes an in-progress
+// GameScene, dims its last frame behind a "PAUSED" banner, and offers
+// resume, settings, restart, and quit options, plus save-and-quit for runs
+// that can be continued later.
+package asteroids
+
+import (
+	"image/color"
+	"log"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+	inpututil "github.com/hajimehoshi/ebiten/v2/inpututil"
+	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Pause menu option indices.
+const (
+	pauseResume = iota
+	pauseSettings
+	pauseRestart
+	pauseQuit
+	pauseSaveQuit // Only offered when the run can be suspended.
+)
+
+// PauseScene overlays the pause menu on a frozen GameScene.
+//
+// While it is active the GameScene's Update is never called, so none of its
+// tick-based Timers (spawns, cooldowns, shield duration, heartbeat) advance.
+type PauseScene struct {
+	game *GameScene // The frozen gameplay scene.
+	menu *Menu      // Resume / Settings / Restart / Quit [/ Save & Quit].
+}
+
+// NewPauseScene silences the game's looping sounds and music and returns a pause menu
+// for it.
+func NewPauseScene(game *GameScene) *PauseScene {
+	game.pauseLoopingSounds()
+	game.music.Pause()
+
+	menu := NewMenu("Resume", "Settings", "Restart", "Quit")
+	if game.canSuspend() {
+		menu.items = append(menu.items, "Save & Quit")
+	}
+	return &PauseScene{
+		game: game,
+		menu: menu,
+	}
+}
+
+// Draw renders the frozen game frame, dims it, then draws the banner and menu.
+func (p *PauseScene) Draw(screen *ebiten.Image) {
+	p.game.Draw(screen)
+	dimScreen(screen, 160)
+
+	const title = "PAUSED"
+	op := &text.DrawOptions{
+		LayoutOptions: text.LayoutOptions{PrimaryAlign: text.AlignCenter},
+	}
+	op.ColorScale.ScaleWithColor(color.White)
+	op.GeoM.Translate(float64(ScreenWidth/2), float64(ScreenHeight/2-120))
+	drawText(screen, title, &text.GoTextFace{
+		Source: assets.TitleFont,
+		Size:   72,
+	}, op)
+
+	p.menu.Draw(screen, float64(ScreenWidth/2), float64(ScreenHeight/2))
+}
+
+// Update handles menu navigation.
+//
+// Escape/P: resume immediately.
+// Resume:   return to the frozen GameScene.
+// Settings: open the options over the frozen frame, returning here afterwards.
+// Restart:  reset the run to level 1 and resume.
+// Quit:     take initials for a high-score entry, then request Ebiten termination.
+// Save & Quit: suspend the run for "Continue" on the title screen, then
+// request Ebiten termination. The run is not over, so nothing is scored.
+func (p *PauseScene) Update(state *State) error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsKeyJustPressed(ebiten.KeyP) {
+		state.SceneManager.GoToScene(p.game)
+		return nil
+	}
+
+	switch p.menu.Update() {
+	case pauseResume:
+		state.SceneManager.GoToScene(p.game)
+	case pauseSettings:
+		state.SceneManager.PushScene(NewSettingsScene(p.game))
+	case pauseRestart:
+		p.game.restart()
+		state.SceneManager.GoToScene(p.game)
+	case pauseQuit:
+		p.game.recordStats()
+		p.game.updateProfile()
+		if p.game.earnsHighScore() {
+			state.SceneManager.GoToScene(NewInitialsScene(p.game, func(*State) error {
+				return ebiten.Termination
+			}))
+			return nil
+		}
+		return ebiten.Termination
+	case pauseSaveQuit:
+		if err := p.game.suspend(); err != nil {
+			log.Println("Error saving run", err)
+			return nil
+		}
+		return ebiten.Termination
+	}
+	return nil
+}
+
+// dimScreen darkens everything drawn so far by blending black at alpha.
+func dimScreen(screen *ebiten.Image, alpha uint8) {
+	fillRect(screen, 0, 0, ScreenWidth, ScreenHeight, color.RGBA{A: alpha}, false)
+}
@@ -0,0 +1,103 @@
+// File perf-monitor.go defines PerfMonitor, which samples the time between
+// drawn frames and notices when the game has run below its target frame
+// rate for several seconds in a row, so it can suggest a lighter quality
+// preset before the player puts up with a choppy game.
+package asteroids
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Performance monitor tuning.
+const (
+	perfTargetFPS = 50.0             // Frame rate below which a frame counts as slow.
+	perfSmoothing = 0.1              // Weight of each new frame in the average frame time.
+	perfSustain   = 5 * time.Second  // How long the average must stay slow before warning.
+	perfStall     = 1 * time.Second  // Gaps longer than this (a minimized window) are not sampled.
+	perfToastTime = 10 * time.Second // How long the warning stays up.
+)
+
+// PerfMonitor tracks a smoothed frame time from one drawn frame to the next.
+type PerfMonitor struct {
+	last      time.Time     // When the previous frame was drawn; zero before the first.
+	frameTime float64       // Smoothed seconds per frame; zero until sampled.
+	slowFor   time.Duration // How long the average has stayed below perfTargetFPS.
+	warned    string        // Quality preset already warned about, so each is suggested once.
+}
+
+// frame samples a frame drawn at now.
+func (m *PerfMonitor) frame(now time.Time) {
+	last := m.last
+	m.last = now
+	if last.IsZero() {
+		return
+	}
+	dt := now.Sub(last)
+	if dt > perfStall {
+		m.slowFor = 0
+		return
+	}
+	if m.frameTime == 0 {
+		m.frameTime = dt.Seconds()
+	} else {
+		m.frameTime += (dt.Seconds() - m.frameTime) * perfSmoothing
+	}
+	if m.fps() < perfTargetFPS {
+		m.slowFor += dt
+	} else {
+		m.slowFor = 0
+	}
+}
+
+// fps returns the smoothed frame rate, or 0 before any frame is sampled.
+func (m *PerfMonitor) fps() float64 {
+	if m.frameTime == 0 {
+		return 0
+	}
+	return 1 / m.frameTime
+}
+
+// degraded reports whether the frame rate has been low for perfSustain and
+// the player has not yet been warned about the current quality preset. It
+// counts as the warning: the same preset is not reported again.
+func (m *PerfMonitor) degraded() bool {
+	if m.slowFor < perfSustain {
+		return false
+	}
+	m.slowFor = 0
+	preset := qualityPresetName(config)
+	if m.warned == preset {
+		return false
+	}
+	m.warned = preset
+	return true
+}
+
+// perfApplyKey applies the quality preset a performance warning suggests.
+const perfApplyKey = ebiten.KeyF10
+
+// checkPerformance logs a sustained drop in frame rate and, when a lighter
+// quality preset is available, offers it in a toast.
+func (g *Game) checkPerformance() {
+	if !g.perf.degraded() {
+		return
+	}
+	p, ok := lowerQualityPreset(config)
+	if !ok {
+		log.Printf("Performance warning: %.0f FPS at the %s quality preset, the lightest available", g.perf.fps(), qualityPresetName(config))
+		return
+	}
+	log.Printf("Performance warning: %.0f FPS at the %s quality preset; suggesting %s", g.perf.fps(), qualityPresetName(config), p.Name)
+	message := fmt.Sprintf("Running slowly. Press F10 for %s quality.", p.Name)
+	g.toast = NewToast(message, perfToastTime).withAction(perfApplyKey, func() {
+		p.apply(config)
+		if err := config.Save(); err != nil {
+			log.Println("Error saving config", err)
+		}
+		log.Println("Applied quality preset", p.Name)
+	})
+}
@@ -1,68 +1,82 @@
 // File player.go defines the Player entity, input handling, movement,
-// shooting, shields, hyperspace, and related HUD indicators.
+// shooting, shields, and hyperspace. Its HUD indicators belong to the HUD.
 package asteroids
 
 import (
 	"math"
-	"math/rand"
 	"time"
 
-	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/solarlune/resolv"
 )
 
 const (
-	rotationPerSecond    = math.Pi                // Angular velocity for rotation input.
-	maxAcceleration      = 8.0                    // Cap for forward acceleration.
-	ScreenWidth          = 1280                   // Logical backbuffer width.
-	ScreenHeight         = 720                    // Logical backbuffer height.
-	shootCoolDown        = time.Millisecond * 150 // Min delay between shots in a burst.
-	burstCoolDown        = time.Millisecond * 500 // Delay before a new 3-shot burst.
-	laserSpawnOffset     = 50.0                   // Distance from ship nose to laser spawn.
-	maxShotsPerBurst     = 3                      // Burst size.
-	dyingAnimationAmount = 50 * time.Millisecond  // Frame time for player death anim.
-	numberOfLives        = 3
-	numberOfShields      = 3
-	shieldDuration       = 6 * time.Second
-	hyperSpaceCooldown   = 10 * time.Second
-	driftTime            = 30 * time.Second // Passive drift duration after thrust.
+	thrustAcceleration          = 960.0                  // Acceleration along the nose while thrust is held.
+	reverseAcceleration         = 360.0                  // Acceleration against the nose while reverse is held.
+	shipFriction                = 0.4                    // Share of its speed the ship loses per second.
+	shipRestSpeed               = 1.0                    // Speed below which a coasting ship comes to rest.
+	dampenerFriction            = 3.0                    // Share of its speed an unthrusted ship loses per second with the inertia dampener on.
+	ScreenWidth                 = 1280                   // Logical backbuffer width.
+	ScreenHeight                = 720                    // Logical backbuffer height.
+	shootCoolDown               = time.Millisecond * 150 // Min delay between shots in a burst.
+	burstCoolDown               = time.Millisecond * 500 // Delay before a new burst.
+	laserSpawnOffset            = 50.0                   // Distance from ship nose to laser spawn.
+	dyingAnimationAmount        = 50 * time.Millisecond  // Frame time for player death anim.
+	shieldDuration              = 6 * time.Second
+	hyperSpaceCooldown          = 10 * time.Second
+	hyperspaceMalfunctionChance = 0.08             // Chance a jump destroys the ship, in modes with HyperspaceRisk.
+	spreadShotDuration          = 10 * time.Second // Spread-shot power-up lifetime.
+	spreadShotAngle             = math.Pi / 12     // Angle between lasers in a spread fan.
+	maxLives                    = 6                // Most lives a ship can hold; extra lives past it are lost.
 )
 
-// curAcceleration and shotsFired track transient thrust/burst state.
-var curAcceleration float64
-var shotsFired = 0
-
 // Player represents the player's ship, state, timers, and HUD indicators.
 type Player struct {
-	game                *GameScene
-	sprite              *ebiten.Image
-	rotation            float64
-	position            Vector
-	playerVelocity      float64
-	playerObj           *resolv.Circle
-	shootCoolDown       *Timer
-	burstCoolDown       *Timer
-	isShielded          bool
-	isDying             bool
-	isDead              bool
-	dyingTimer          *Timer
-	dyingCounter        int
-	livesRemaning       int
-	lifeIndicators      []*LifeIndicator
-	shieldTimer         *Timer
-	shieldsRemaning     int
-	shieldIndicators    []*ShieldIndicator
-	hyperspaceIndicator *HyperspaceIndicator
-	hyperSpaceTimer     *Timer
-	driftTimer          *Timer
-	driftAngle          float64
+	game               *GameScene
+	class              ShipClass            // Hull and handling, from the run's ship.
+	runUpgrades        [runUpgradeCount]int // Levels of each between-level upgrade taken this run.
+	sprite             *ebiten.Image
+	rotation           float64
+	position           Vector
+	velocity           Vector // World units per second; kept between thrusts and worn down by friction.
+	playerObj          *resolv.Circle
+	shootCoolDown      *Timer
+	burstCoolDown      *Timer
+	isShielded         bool
+	isDying            bool
+	isDead             bool
+	dyingTimer         *Timer
+	dyingCounter       int
+	livesRemaning      int
+	shieldTimer        *Timer
+	shieldsRemaning    int
+	hyperSpaceTimer    *Timer
+	spreadShotTimer    *Timer        // Remaining spread-shot time; nil when inactive.
+	energy             *Energy       // Shared ability pool; nil unless the mode uses energy handling.
+	boostTimer         *Timer        // Active afterburner burst; nil otherwise.
+	boostCooldownTimer *Timer        // Gap between bursts without energy handling.
+	boostAngle         float64       // Heading locked in when the burst began.
+	thrustTapTicks     int           // Ticks since thrust was last pressed (double-tap detection).
+	burstShots         int           // Shots fired in the current burst.
+	exhaust            *Exhaust      // Engine flare while thrusting; nil otherwise.
+	shield             *Shield       // Active shield effect; nil otherwise.
+	controls           *Input        // Input for this ship; nil to use the scene's.
+	status             StatusEffects // Timed conditions such as Slow, EMP, and Burning.
 }
 
-// NewPlayer constructs a centered player, collider, and HUD indicators.
+// input returns the input that steers this ship.
+func (p *Player) input() *Input {
+	if p.controls != nil {
+		return p.controls
+	}
+	return p.game.input
+}
+
+// NewPlayer constructs a centered player of the run's ship class, with its
+// collider and HUD indicators.
 func NewPlayer(game *GameScene) *Player {
-	sprite := assets.PlayerSprite
+	sprite := game.ship.Sprite
 
 	// Center the player sprite.
 	bounds := sprite.Bounds()
@@ -76,41 +90,29 @@ func NewPlayer(game *GameScene) *Player {
 	// Circular collider centered at current position.
 	playerObj := resolv.NewCircle(pos.X, pos.Y, float64(sprite.Bounds().Dx()/2))
 
-	// Life indicators along top-left.
-	var lifeIndicators []*LifeIndicator
-	xPosition := 20.0
-	for i := 0; i < numberOfLives; i++ {
-		lifeIndicators = append(lifeIndicators, NewLifeIndicator(Vector{X: xPosition, Y: 20}))
-		xPosition += 50.0
+	p := &Player{
+		sprite:          sprite,
+		game:            game,
+		class:           game.ship,
+		position:        pos,
+		playerObj:       playerObj,
+		shootCoolDown:   sim.NewTimer(shootCoolDown),
+		burstCoolDown:   sim.NewTimer(burstCoolDown),
+		isShielded:      false,
+		isDying:         false,
+		isDead:          false,
+		dyingTimer:      sim.NewTimer(dyingAnimationAmount),
+		dyingCounter:    0,
+		livesRemaning:   game.difficulty.Lives,
+		hyperSpaceTimer: nil,
+		thrustTapTicks:  math.MaxInt32, // No earlier tap to pair with.
 	}
 
-	// Shield indicators below lives.
-	var shieldIndicators []*ShieldIndicator
-	xPosition = 45.0
-	for i := 0; i < numberOfShields; i++ {
-		shieldIndicators = append(shieldIndicators, NewShieldIndicator(Vector{X: xPosition, Y: 60}))
-		xPosition += 50.0
-	}
+	p.shieldsRemaning = p.maxShields()
 
-	p := &Player{
-		sprite:              sprite,
-		game:                game,
-		position:            pos,
-		playerObj:           playerObj,
-		shootCoolDown:       NewTimer(shootCoolDown),
-		burstCoolDown:       NewTimer(burstCoolDown),
-		isShielded:          false,
-		isDying:             false,
-		isDead:              false,
-		dyingTimer:          NewTimer(dyingAnimationAmount),
-		dyingCounter:        0,
-		livesRemaning:       numberOfLives,
-		lifeIndicators:      lifeIndicators,
-		shieldsRemaning:     numberOfShields,
-		shieldIndicators:    shieldIndicators,
-		hyperspaceIndicator: NewHyperspaceIndicator(Vector{X: 37.0, Y: 95.0}),
-		hyperSpaceTimer:     nil,
-		driftTimer:          nil,
+	// Energy handling replaces shield charges and the hyperspace cooldown.
+	if game.mode.EnergyHandling {
+		p.energy = NewEnergy()
 	}
 
 	// Initialize collider state and tag.
@@ -135,42 +137,65 @@ func (p *Player) Draw(screen *ebiten.Image) {
 	op.GeoM.Translate(halfWidth, halfHeight)
 	op.GeoM.Translate(p.position.X, p.position.Y)
 
-	screen.DrawImage(p.sprite, op)
+	drawSprite(screen, p.sprite, op)
+}
+
+// drawEffects renders the ship's exhaust and shield, when present.
+func (p *Player) drawEffects(screen *ebiten.Image) {
+	if p.exhaust != nil {
+		p.exhaust.Draw(screen)
+	}
+	if p.shield != nil {
+		p.shield.Draw(screen)
+	}
 }
 
 // Update processes input, movement, weapons, shield, hyperspace, and timers.
 func (p *Player) Update() {
-	// Rotation granularity: convert per-second rotation to per-tick.
-	speed := rotationPerSecond / float64(ebiten.TPS())
+	// Rotation granularity: one tick's share of the turn rate.
+	speed := sim.PerTick(p.turnRate())
 
 	p.isPlayerDead()
 
+	// Passive energy regeneration.
+	if p.energy != nil {
+		p.energy.Update()
+	}
+
 	// Rotation input.
-	if ebiten.IsKeyPressed(ebiten.KeyLeft) {
+	if p.input().IsPressed(ActionRotateLeft) {
 		p.rotation -= speed
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyRight) {
+	if p.input().IsPressed(ActionRotateRight) {
 		p.rotation += speed
 	}
 
-	// Movement & effects.
+	// Movement & effects. Thrust, reverse, and the afterburner change the
+	// velocity; coast then moves the ship along it.
 	p.accelerate()          // Up arrow thrust + exhaust + sound.
 	p.useShield()           // Shield activation / expiry.
-	p.isDoneAccelerating()  // Handle thrust key release → drift.
+	p.isDoneAccelerating()  // Stop thrust sound when thrust key released.
 	p.reverse()             // Down arrow reverse + exhaust + sound.
 	p.isDoneReversing()     // Stop thrust sound when reverse key released.
-	p.isPlayerDrifting()    // Apply residual drift motion.
-	p.isDriftingFinished()  // End drift on timer expiry.
+	p.updateBoost()         // Afterburner burst.
+	p.coast()               // Friction, then motion along the velocity.
 	p.updateExhaustSprite() // Hide exhaust when not thrusting.
 
-	// Sync collider with latest position.
-	p.playerObj.SetPosition(p.position.X, p.position.Y)
+	// Apply the field's edge, which may have closed in without the ship
+	// moving, and sync the collider with the latest position.
+	p.keepOnScreen()
 
 	// Weapons timers and firing.
 	p.burstCoolDown.Update()
 	p.shootCoolDown.Update()
+	p.updateSpreadShot()
 	p.fireLasers()
 
+	// Smart bomb (once per level).
+	if p.input().IsJustPressed(ActionSmartBomb) && !p.isDying && !p.isDead && !p.status.has(StatusEMP) {
+		p.game.detonateSmartBomb()
+	}
+
 	// Hyperspace handling with cooldown.
 	p.hyperSpace()
 	if p.hyperSpaceTimer != nil {
@@ -178,37 +203,48 @@ func (p *Player) Update() {
 	}
 }
 
-// isPlayerDrifting advances drift motion while the drift timer is active.
-func (p *Player) isPlayerDrifting() {
-	if p.driftTimer != nil {
-		p.keepOnScreen() // Wrap at edges during drift.
-		p.driftTimer.Update()
-
-		// Decelerate drift over time; scale per-tick.
-		decelerationSpeed := p.playerVelocity / float64(ebiten.TPS()) * 4
-		p.position.X += math.Sin(p.driftAngle) * decelerationSpeed
-		p.position.Y += math.Cos(p.driftAngle) * -decelerationSpeed
-
-		p.playerObj.SetPosition(p.position.X, p.position.Y)
+// coast wears the velocity down by friction, bringing a slow ship to rest,
+// and moves the ship along what is left. Turning alone never changes course.
+// With the inertia dampener on, a ship not thrusting either way brakes hard
+// instead of drifting.
+func (p *Player) coast() {
+	if !p.isBoosting() {
+		friction := shipFriction
+		if p.game.rules.assists.InertiaDampener && !p.input().IsPressed(ActionThrust) && !p.input().IsPressed(ActionReverse) {
+			friction = dampenerFriction
+		}
+		p.velocity = p.velocity.Scale(1 - sim.PerTick(friction))
+		if math.Hypot(p.velocity.X, p.velocity.Y) < shipRestSpeed {
+			p.velocity = Vector{}
+		}
 	}
+	sim.Advance(&p.position, p.velocity.Scale(p.status.speedFactor()))
+	p.playerObj.SetPosition(p.position.X, p.position.Y)
 }
 
-// isDriftingFinished stops drift when the timer elapses.
-func (p *Player) isDriftingFinished() {
-	if p.driftTimer != nil && p.driftTimer.IsReady() {
-		p.driftTimer = nil
-		p.playerVelocity = 0
+// push accelerates the ship along direction at rate. Thrust cannot raise
+// the speed past the class's top speed, but it does not brake a ship already faster,
+// as one coming out of an afterburner burst is.
+func (p *Player) push(direction Vector, rate float64) {
+	before := math.Hypot(p.velocity.X, p.velocity.Y)
+	step := direction.Scale(sim.PerTick(rate))
+	p.velocity = Vector{X: p.velocity.X + step.X, Y: p.velocity.Y + step.Y}
+	limit := math.Max(p.class.TopSpeed, before)
+	if speed := math.Hypot(p.velocity.X, p.velocity.Y); speed > limit {
+		p.velocity = p.velocity.Scale(limit / speed)
 	}
 }
 
 // hyperSpace teleports the ship to a random position with a cooldown.
 func (p *Player) hyperSpace() {
-	if ebiten.IsKeyPressed(ebiten.KeyH) && (p.hyperSpaceTimer == nil || p.hyperSpaceTimer.IsReady()) {
-		// Find a random (x,y). Note: current collision check is a stub hook.
+	if p.input().IsPressed(ActionHyperspace) && (p.hyperSpaceTimer == nil || p.hyperSpaceTimer.IsReady()) && p.takeHyperspaceCharge() {
+		// Find a random (x,y) on the field. Note: current collision check is a stub hook.
+		lo, hi := p.game.hyperspaceArea(p.sprite)
+		rng := p.game.rng.Stream(streamShip)
 		var randX, randY int
 		for {
-			randX = rand.Intn(ScreenWidth)
-			randY = rand.Intn(ScreenHeight)
+			randX = int(lo.X) + rng.Intn(int(hi.X-lo.X))
+			randY = int(lo.Y) + rng.Intn(int(hi.Y-lo.Y))
 			collision := p.game.checkCollision(p.playerObj, nil) // Placeholder hook.
 			if !collision {
 				break
@@ -216,13 +252,50 @@ func (p *Player) hyperSpace() {
 		}
 
 		// Commit teleport and start/reset cooldown.
+		from := spriteCenter(p.position, p.sprite)
 		p.position.X = float64(randX)
 		p.position.Y = float64(randY)
+		p.game.hyperspaceWarp(p.sprite, from, spriteCenter(p.position, p.sprite))
 
 		if p.hyperSpaceTimer == nil {
-			p.hyperSpaceTimer = NewTimer(hyperSpaceCooldown)
+			p.hyperSpaceTimer = sim.NewTimer(p.hyperspaceCooldown())
 		}
 		p.hyperSpaceTimer.Reset()
+
+		// A malfunctioning jump arrives as wreckage.
+		if p.hyperspaceMalfunctions() {
+			p.isDying = true
+			sharedAudio().PlaySFXAt(sfxExplosion, spriteCenter(p.position, p.sprite).X)
+		}
+	}
+}
+
+// takeHyperspaceCharge pays the energy cost of a jump under energy handling
+// and reports whether the jump may go ahead. Without energy handling only
+// the cooldown applies and it always succeeds.
+func (p *Player) takeHyperspaceCharge() bool {
+	if p.energy == nil {
+		return true
+	}
+	return p.energy.spend(hyperspaceEnergyCost)
+}
+
+// updateDying steps the death animation through explosion frames and marks
+// the ship dead after the last one.
+func (p *Player) updateDying(frames []*ebiten.Image) {
+	if !p.isDying {
+		return
+	}
+	p.dyingTimer.Update()
+	if p.dyingTimer.IsReady() {
+		p.dyingTimer.Reset()
+		p.dyingCounter++
+		if p.dyingCounter == 12 {
+			p.isDying = false
+			p.isDead = true
+		} else if p.dyingCounter < 12 {
+			p.sprite = frames[p.dyingCounter]
+		}
 	}
 }
 
@@ -233,207 +306,194 @@ func (p *Player) isPlayerDead() {
 	}
 }
 
-// fireLasers handles burst-gated firing and plays per-shot audio variants.
+// fireLasers handles burst-gated firing and plays the shot sound from the
+// ship's nose.
+// An EMP locks the weapons out.
 func (p *Player) fireLasers() {
+	if p.status.has(StatusEMP) {
+		return
+	}
 	if p.burstCoolDown.IsReady() {
 		// Gate shots by a per-shot cooldown and Space key; accumulate within the burst.
-		if p.shootCoolDown.IsReady() && ebiten.IsKeyPressed(ebiten.KeySpace) {
-			p.shootCoolDown.Reset()
-			shotsFired++
+		if p.shootCoolDown.IsReady() && p.input().IsPressed(ActionFire) {
+			p.shootCoolDown = sim.NewTimer(p.shotInterval())
+			p.burstShots++
 
 			// Up to max shots per burst.
-			if shotsFired <= maxShotsPerBurst {
+			if p.burstShots <= p.burstSize() {
 				// Compute laser spawn at ship nose (rotation-aligned offset).
 				bounds := p.sprite.Bounds()
 				halfWidth := float64(bounds.Dx() / 2)
 				halfHeight := float64(bounds.Dy() / 2)
 
 				spawnPosition := Vector{
-					p.position.X + halfWidth + (math.Sin(p.rotation) * laserSpawnOffset),
-					p.position.Y + halfHeight + (math.Cos(p.rotation) * -laserSpawnOffset),
+					X: p.position.X + halfWidth + (math.Sin(p.rotation) * laserSpawnOffset),
+					Y: p.position.Y + halfHeight + (math.Cos(p.rotation) * -laserSpawnOffset),
 				}
 
-				// Create and register the laser.
-				p.game.laserCount++
-				laser := NewLaser(spawnPosition, p.rotation, p.game.laserCount, p.game)
-				p.game.lasers[p.game.laserCount] = laser
-				p.game.space.Add(laser.laserObj)
-
-				// Cycle SFX by shot number within the burst.
-				switch shotsFired {
-				case 1:
-					if !p.game.laserOnePlayer.IsPlaying() {
-						_ = p.game.laserOnePlayer.Rewind()
-						p.game.laserOnePlayer.Play()
-					}
-				case 2:
-					if !p.game.laserTwoPlayer.IsPlaying() {
-						_ = p.game.laserTwoPlayer.Rewind()
-						p.game.laserTwoPlayer.Play()
-					}
-				case 3:
-					if !p.game.laserThreePlayer.IsPlaying() {
-						_ = p.game.laserThreePlayer.Rewind()
-						p.game.laserThreePlayer.Play()
+				// Spread shot: a three-laser fan with its own SFX.
+				if p.spreadShotTimer != nil {
+					for _, offset := range []float64{-spreadShotAngle, 0, spreadShotAngle} {
+						p.spawnLaser(spawnPosition, p.rotation+offset)
 					}
+					sharedAudio().PlaySFXAt(sfxSpreadShot, spawnPosition.X)
+					return
 				}
+
+				// Create and register the laser.
+				p.spawnLaser(spawnPosition, p.rotation)
+
+				sharedAudio().PlaySFXAt(sfxLaser, spawnPosition.X)
 			} else {
 				// Burst finished: start burst cooldown and reset shot counter.
-				p.burstCoolDown.Reset()
-				shotsFired = 0
+				p.burstCoolDown = sim.NewTimer(p.burstInterval())
+				p.burstShots = 0
 			}
 		}
 	}
 }
 
-// accelerate applies forward thrust, spawns exhaust, and plays thrust SFX.
-func (p *Player) accelerate() {
-	if ebiten.IsKeyPressed(ebiten.KeyUp) {
-		p.driftTimer = nil // Cancel any residual drift while thrusting.
-		p.keepOnScreen()
+// spawnLaser creates a player laser at position heading along rotation and
+// registers it with the scene and the collision space.
+func (p *Player) spawnLaser(position Vector, rotation float64) {
+	p.game.laserCount++
+	laser := NewLaser(position, rotation, p.game.laserCount, p.game)
+	laser.owner = p
+	laser.steer = aimAssistTurn * float64(p.game.rules.assists.AimAssist)
+	p.game.lasers[p.game.laserCount] = laser
+	p.game.stats.ShotsFired++
+	p.game.wave.fired()
+	p.game.space.Add(laser.laserObj)
+}
 
-		// Ramp acceleration up to a cap.
-		if curAcceleration < maxAcceleration {
-			curAcceleration = p.playerVelocity + 4
-		}
-		if curAcceleration >= 8 {
-			curAcceleration = 8
-		}
-		p.playerVelocity = curAcceleration
+// updateSpreadShot counts down an active spread-shot power-up.
+func (p *Player) updateSpreadShot() {
+	if p.spreadShotTimer == nil {
+		return
+	}
+	p.spreadShotTimer.Update()
+	if p.spreadShotTimer.IsReady() {
+		p.spreadShotTimer = nil
+		return
+	}
+}
 
-		// Move forward along the facing vector.
-		dx := math.Sin(p.rotation) * curAcceleration
-		dy := math.Cos(p.rotation) * -curAcceleration
+// accelerate applies forward thrust, spawns exhaust, and plays thrust SFX.
+func (p *Player) accelerate() {
+	if p.input().IsPressed(ActionThrust) {
+		p.push(shipHeading(p.rotation), thrustAcceleration)
 
 		// Spawn exhaust behind the ship.
 		bounds := p.sprite.Bounds()
 		halfWidth := float64(bounds.Dx() / 2)
 		halfHeight := float64(bounds.Dy() / 2)
 		spawnPosition := Vector{
-			p.position.X + halfWidth + math.Sin(p.rotation)*exhaustSpawnOffset,
-			p.position.Y + halfHeight + math.Cos(p.rotation)*-exhaustSpawnOffset,
+			X: p.position.X + halfWidth + math.Sin(p.rotation)*exhaustSpawnOffset,
+			Y: p.position.Y + halfHeight + math.Cos(p.rotation)*-exhaustSpawnOffset,
 		}
-		p.game.exhaust = NewExhaust(spawnPosition, p.rotation+180.0*math.Pi/180.0)
-
-		// Apply movement.
-		p.position.X += dx
-		p.position.Y += dy
+		p.exhaust = NewExhaust(spawnPosition, p.rotation+180.0*math.Pi/180.0)
 
 		// Thrust loop.
 		if !p.game.thrustPlayer.IsPlaying() {
 			_ = p.game.thrustPlayer.Rewind()
-			p.game.thrustPlayer.Play()
+			sharedAudio().play(p.game.thrustPlayer)
 		}
 	}
 }
 
-// isDoneAccelerating finalizes a thrust phase and enters timed drift.
+// isDoneAccelerating stops the thrust loop when the thrust key is
+// released. The ship coasts on at its velocity.
 func (p *Player) isDoneAccelerating() {
-	if inpututil.IsKeyJustReleased(ebiten.KeyUp) {
-		// Stop thrust loop.
+	if p.input().IsJustReleased(ActionThrust) {
 		if p.game.thrustPlayer.IsPlaying() {
 			p.game.thrustPlayer.Pause()
 		}
-
-		// Seed drift speed (bounded and non-negative).
-		if p.game.player.playerVelocity < curAcceleration*10 {
-			p.playerVelocity = curAcceleration*10 - 5.0
-		}
-		if p.playerVelocity < 0 {
-			p.playerVelocity = 0
-		}
-		curAcceleration = 0
-
-		// Start drift timer and record drift direction.
-		p.driftTimer = NewTimer(driftTime)
-		p.driftAngle = p.rotation
 	}
 }
 
 // updateExhaustSprite hides the exhaust effect when not thrusting/reversing.
 func (p *Player) updateExhaustSprite() {
-	if !ebiten.IsKeyPressed(ebiten.KeyUp) && !ebiten.IsKeyPressed(ebiten.KeyDown) && p.game.exhaust != nil {
-		p.game.exhaust = nil
+	if !p.input().IsPressed(ActionThrust) && !p.input().IsPressed(ActionReverse) && !p.isBoosting() && p.exhaust != nil {
+		p.exhaust = nil
 	}
 }
 
-// keepOnScreen wraps player position and collider at screen edges.
+// keepOnScreen hands the ship to the field's boundary policy and syncs its
+// collider. The ship's velocity is its movement against a wall, so a moving
+// ship bounces off a solid one and one at rest is simply held. A lethal wall
+// destroys the ship unless it is shielded.
 func (p *Player) keepOnScreen() {
-	if p.position.X >= float64(ScreenWidth) {
-		p.position.X = 0
-		p.playerObj.SetPosition(0, p.position.Y)
-	}
-	if p.position.X < 0 {
-		p.position.X = ScreenWidth
-		p.playerObj.SetPosition(ScreenWidth, p.position.Y)
-	}
-	if p.position.Y >= float64(ScreenHeight) {
-		p.position.Y = 0
-		p.playerObj.SetPosition(p.position.X, 0)
-	}
-	if p.position.Y < 0 {
-		p.position.Y = ScreenHeight
-		p.playerObj.SetPosition(p.position.X, ScreenHeight)
+	contact := p.game.boundary().confine(edgeBody{
+		position: &p.position,
+		movement: &p.velocity,
+		size:     spriteSize(p.sprite),
+	})
+	switch contact {
+	case contactBounced:
+		p.game.wallImpact(spriteCenter(p.position, p.sprite))
+	case contactStruck:
+		if !p.isShielded && !p.isDying {
+			p.isDying = true
+			sharedAudio().PlaySFXAt(sfxExplosion, spriteCenter(p.position, p.sprite).X)
+		}
 	}
+	p.playerObj.SetPosition(p.position.X, p.position.Y)
 }
 
-// reverse applies slow backward thrust with exhaust and SFX.
+// reverse applies gentler thrust against the nose, with exhaust and SFX.
 func (p *Player) reverse() {
-	if ebiten.IsKeyPressed(ebiten.KeyDown) {
-		p.driftTimer = nil
-		p.keepOnScreen()
-
-		// Move opposite the facing vector.
-		dx := math.Sin(p.rotation) * -3
-		dy := math.Cos(p.rotation) * 3
+	if p.input().IsPressed(ActionReverse) {
+		p.push(shipHeading(p.rotation).Scale(-1), reverseAcceleration)
 
 		// Exhaust spawn point (opposite side).
 		bounds := p.sprite.Bounds()
 		halfWidth := float64(bounds.Dx() / 2)
 		halfHeight := float64(bounds.Dy() / 2)
 		spawnPosition := Vector{
-			p.position.X + halfWidth + math.Sin(p.rotation)*-exhaustSpawnOffset,
-			p.position.Y + halfHeight + math.Cos(p.rotation)*exhaustSpawnOffset,
+			X: p.position.X + halfWidth + math.Sin(p.rotation)*-exhaustSpawnOffset,
+			Y: p.position.Y + halfHeight + math.Cos(p.rotation)*exhaustSpawnOffset,
 		}
-		p.game.exhaust = NewExhaust(spawnPosition, p.rotation+180.0*math.Pi/180.0)
-
-		// Apply reverse motion and sync collider.
-		p.position.X += dx
-		p.position.Y += dy
-		p.playerObj.SetPosition(p.position.X, p.position.Y)
+		p.exhaust = NewExhaust(spawnPosition, p.rotation+180.0*math.Pi/180.0)
 
 		// Thrust loop.
 		if !p.game.thrustPlayer.IsPlaying() {
 			_ = p.game.thrustPlayer.Rewind()
-			p.game.thrustPlayer.Play()
+			sharedAudio().play(p.game.thrustPlayer)
 		}
 	}
 }
 
 // isDoneReversing stops thrust audio when reverse key is released.
 func (p *Player) isDoneReversing() {
-	if inpututil.IsKeyJustReleased(ebiten.KeyDown) {
+	if p.input().IsJustReleased(ActionReverse) {
 		if p.game.thrustPlayer.IsPlaying() {
 			p.game.thrustPlayer.Pause()
 		}
 	}
 }
 
-// useShield activates a timed shield (S key) and manages indicator/HUD state.
+// takeShieldCharge pays for raising the shield: energy under energy
+// handling, otherwise one charge. It reports false,
+// spending nothing, if the cost cannot be met.
+func (p *Player) takeShieldCharge() bool {
+	if p.energy != nil {
+		return p.energy.spend(shieldEnergyCost)
+	}
+	if p.shieldsRemaning <= 0 {
+		return false
+	}
+	p.shieldsRemaning--
+	return true
+}
+
+// useShield activates a timed shield (shield action) and manages indicator/HUD state.
 func (p *Player) useShield() {
 	// Activation path (requires charges and not already shielded).
-	if ebiten.IsKeyPressed(ebiten.KeyS) && p.shieldsRemaning > 0 && !p.isShielded {
-		if !p.game.shieldsUpPlayer.IsPlaying() {
-			_ = p.game.shieldsUpPlayer.Rewind()
-			p.game.shieldsUpPlayer.Play()
-		}
+	if p.input().IsPressed(ActionShield) && !p.isShielded && p.takeShieldCharge() {
+		sharedAudio().PlaySFX(sfxShieldsUp)
 		p.isShielded = true
-		p.shieldTimer = NewTimer(shieldDuration)
-		p.game.shield = NewShield(Vector{}, p.rotation, p.game)
-
-		// Consume a shield and pop one HUD indicator.
-		p.shieldsRemaning--
-		p.shieldIndicators = p.shieldIndicators[:len(p.shieldIndicators)-1]
+		p.shieldTimer = sim.NewTimer(p.shieldDuration())
+		p.shield = NewShield(Vector{}, p.rotation, p)
 	}
 
 	// Timer progression.
@@ -445,7 +505,7 @@ func (p *Player) useShield() {
 	if p.shieldTimer != nil && p.shieldTimer.IsReady() {
 		p.shieldTimer = nil
 		p.isShielded = false
-		p.game.space.Remove(p.game.shield.shieldObj)
-		p.game.shield = nil
+		p.game.space.Remove(p.shield.shieldObj)
+		p.shield = nil
 	}
 }
@@ -0,0 +1,73 @@
+// File player_test.go checks the ship's thrust and friction against their
+// per-second tuning: over simulated seconds the ship approaches the speeds
+// and distances the continuous motion predicts, whatever the tick rate.
+package asteroids
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/solarlune/resolv"
+)
+
+// motionPlayer returns a stock ship at the origin, nose up, with no input.
+func motionPlayer() *Player {
+	return &Player{
+		game:      &GameScene{input: &Input{}},
+		class:     shipClasses[0],
+		playerObj: resolv.NewCircle(0, 0, 10),
+	}
+}
+
+// within reports whether got is within frac of want.
+func within(got, want, frac float64) bool {
+	return math.Abs(got-want) <= math.Abs(want)*frac
+}
+
+func TestThrustFromRest(t *testing.T) {
+	p := motionPlayer()
+	const seconds = 0.25
+	for range int(seconds * sim.TicksPerSecond) {
+		p.push(shipHeading(p.rotation), thrustAcceleration)
+		p.coast()
+	}
+	// dv/dt = a - f·v from rest: v = a/f · (1 - e^(-f·t)).
+	want := thrustAcceleration / shipFriction * (1 - math.Exp(-shipFriction*seconds))
+	if speed := p.velocity.Length(); !within(speed, want, 0.01) {
+		t.Errorf("speed after %.2f s of thrust = %.1f, want %.1f", seconds, speed, want)
+	}
+	if p.velocity.Y >= 0 || math.Abs(p.velocity.X) > 1e-9 {
+		t.Errorf("velocity %v, want straight up", p.velocity)
+	}
+}
+
+func TestThrustStopsAtTopSpeed(t *testing.T) {
+	p := motionPlayer()
+	for range 5 * sim.TicksPerSecond {
+		p.push(shipHeading(p.rotation), thrustAcceleration)
+		p.coast()
+	}
+	top := p.class.TopSpeed * (1 - sim.PerTick(shipFriction)) // Capped, then one tick of friction.
+	if speed := p.velocity.Length(); math.Abs(speed-top) > 1e-9 {
+		t.Errorf("speed after 5 s of thrust = %.3f, want %.3f", speed, top)
+	}
+}
+
+func TestFrictionWhileCoasting(t *testing.T) {
+	p := motionPlayer()
+	const v0, seconds = 300.0, 1
+	p.velocity = Vector{X: v0}
+	for range seconds * sim.TicksPerSecond {
+		p.coast()
+	}
+	// dv/dt = -f·v: v = v0·e^(-f·t), covering v0/f · (1 - e^(-f·t)).
+	wantSpeed := v0 * math.Exp(-shipFriction*seconds)
+	wantDistance := v0 / shipFriction * (1 - math.Exp(-shipFriction*seconds))
+	if !within(p.velocity.X, wantSpeed, 0.01) {
+		t.Errorf("speed after %d s of coasting = %.1f, want %.1f", seconds, p.velocity.X, wantSpeed)
+	}
+	if !within(p.position.X, wantDistance, 0.01) {
+		t.Errorf("distance after %d s of coasting = %.1f, want %.1f", seconds, p.position.X, wantDistance)
+	}
+}
@@ -0,0 +1,86 @@
+// File pool.go defines free-list pools for the entities spawned most often
+// (player lasers, alien lasers, and meteors) and for despawn effects, so
+// long sessions reuse them, colliders included, instead of allocating on
+// every shot and split.
+package asteroids
+
+// Pool is a free list of reusable values of type T.
+//
+// Get hands out a released value when one is available and a zero value
+// otherwise; callers reinitialize whatever they get. Pools are owned by a
+// single GameScene and are not safe for concurrent use.
+type Pool[T any] struct {
+	free []*T // Released values ready for reuse.
+}
+
+// Get returns a released value, or a freshly allocated one if none is free.
+func (p *Pool[T]) Get() *T {
+	if n := len(p.free); n > 0 {
+		v := p.free[n-1]
+		p.free[n-1] = nil
+		p.free = p.free[:n-1]
+		return v
+	}
+	return new(T)
+}
+
+// Put releases v for reuse. v must no longer be referenced by the scene.
+func (p *Pool[T]) Put(v *T) {
+	p.free = append(p.free, v)
+}
+
+// entityPools groups the scene's pools.
+type entityPools struct {
+	lasers      Pool[Laser]         // Player lasers.
+	alienLasers Pool[AlienLaser]    // Alien lasers.
+	meteors     Pool[Meteor]        // Meteors of every size.
+	effects     Pool[despawnEffect] // Despawn effects.
+}
+
+// removeAlienLaser deletes an alien laser from the map and the collision
+// space and returns it to the pool.
+func (g *GameScene) removeAlienLaser(index int) {
+	if al, ok := g.alienLasers[index]; ok {
+		g.space.Remove(al.laserObj)
+		delete(g.alienLasers, index)
+		g.pools.alienLasers.Put(al)
+	}
+}
+
+// removeMeteor deletes a meteor from the map and the collision space and
+// returns it to the pool. Wave bookkeeping is left to the caller.
+func (g *GameScene) removeMeteor(index int) {
+	m, ok := g.meteors[index]
+	if !ok {
+		return
+	}
+	g.space.Remove(m.meteorObj)
+	delete(g.meteors, index)
+
+	// Drop references that would otherwise follow the meteor into its next use.
+	if g.tractor != nil && g.tractor.meteor == m {
+		g.tractor = nil
+	}
+	if g.scanner.target == m {
+		g.scanner.target = nil
+	}
+	g.pools.meteors.Put(m)
+}
+
+// releaseAll returns every pooled entity and effect still in play to its pool, for a
+// reset that is about to clear the collision space wholesale. Entities go
+// back by key so the next level draws the same ones from each pool.
+func (g *GameScene) releaseAll() {
+	for _, l := range inOrder(g.lasers) {
+		g.pools.lasers.Put(l)
+	}
+	for _, al := range inOrder(g.alienLasers) {
+		g.pools.alienLasers.Put(al)
+	}
+	for _, m := range inOrder(g.meteors) {
+		g.pools.meteors.Put(m)
+	}
+	for _, e := range g.despawns {
+		g.pools.effects.Put(e)
+	}
+}
@@ -0,0 +1,244 @@
+// File power-up.go defines collectible PowerUp entities: drop chances,
+// drifting motion, expiry, and the kind → effect registry applied on pickup.
+package asteroids
+
+import (
+	"bytes"
+	"math"
+	"time"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/solarlune/resolv"
+)
+
+// Power-up tuning constants.
+const (
+	powerUpLifetime        = 8 * time.Second // Time a power-up stays collectible.
+	powerUpBlinkTime       = 2 * time.Second // Final stretch during which it blinks.
+	powerUpSpeed           = 30.0            // Drift speed.
+	powerUpMeteorDropRate  = 0.05            // Drop chance for a destroyed meteor.
+	powerUpAlienDropRate   = 0.5             // Drop chance for a destroyed alien.
+	powerUpColliderPadding = 6.0             // Extra pickup radius beyond the sprite.
+	extraLifeDropRate      = 0.1             // Drop chance of a 1-UP for a destroyed hunter alien or comet.
+)
+
+// Pickup chimes: rising notes, each a short fading sine like the combo blips.
+const (
+	chimeNote  = 70 * time.Millisecond // Length of a note.
+	chimeDecay = 40 * time.Millisecond // Time constant of a note's fade.
+	chimeGain  = 0.4                   // Peak level relative to the SFX channel.
+)
+
+// PowerUpKind identifies which effect a power-up grants.
+type PowerUpKind int
+
+// Available power-up kinds.
+const (
+	PowerUpShield     PowerUpKind = iota // Restores one shield charge (or energy).
+	PowerUpSpreadShot                    // Fires three-laser fans for a while.
+	PowerUpExtraLife                     // Grants a life. Rare; never a random drop.
+	powerUpKindCount                     // Number of kinds; keep last.
+)
+
+// powerUpDropKinds are the kinds maybeDropPowerUp chooses among.
+var powerUpDropKinds = []PowerUpKind{PowerUpShield, PowerUpSpreadShot}
+
+// powerUpEffect describes how a kind looks and sounds and what collecting
+// it does.
+type powerUpEffect struct {
+	sprite *ebiten.Image      // Pickup sprite.
+	sound  string             // Sound effect played on pickup.
+	apply  func(g *GameScene) // Applies the effect to the run.
+}
+
+// powerUpEffects is the kind → effect registry consulted on spawn and pickup.
+var powerUpEffects = [powerUpKindCount]powerUpEffect{
+	PowerUpShield: {
+		sprite: assets.ShieldRechargeSprite,
+		sound:  sfxShieldRecharge,
+		apply: func(g *GameScene) {
+			p := g.player
+			if p.energy != nil {
+				p.energy.refill(shieldEnergyCost) // Energy handling has no charges.
+				return
+			}
+			if p.shieldsRemaning >= p.maxShields() {
+				return
+			}
+			p.shieldsRemaning++
+		},
+	},
+	PowerUpSpreadShot: {
+		sprite: assets.SpreadShotSprite,
+		sound:  sfxShieldsUp,
+		apply: func(g *GameScene) {
+			// A second pickup restarts the clock rather than stacking.
+			g.player.spreadShotTimer = sim.NewTimer(spreadShotDuration)
+		},
+	},
+	PowerUpExtraLife: {
+		sprite: assets.ExtraLifeSprite,
+		sound:  sfxExtraLife,
+		apply: func(g *GameScene) {
+			if g.player.livesRemaning < maxLives {
+				g.player.livesRemaning++
+			}
+		},
+	},
+}
+
+// PowerUp is a collectible that drifts across the field until it expires.
+type PowerUp struct {
+	game       *GameScene     // Owning scene.
+	kind       PowerUpKind    // Effect granted on pickup.
+	position   Vector         // Top-left of the sprite in world space.
+	movement   Vector         // Drift velocity.
+	sprite     *ebiten.Image  // Visual representation.
+	expiry     *Timer         // Counts down the collectible lifetime.
+	powerUpObj *resolv.Circle // Pickup collider.
+}
+
+// NewPowerUp constructs a power-up of the given kind centered on center,
+// drifting in a random direction.
+func NewPowerUp(kind PowerUpKind, center Vector, index int, game *GameScene) *PowerUp {
+	sprite := powerUpEffects[kind].sprite
+	bounds := sprite.Bounds()
+	position := Vector{
+		X: center.X - float64(bounds.Dx())/2,
+		Y: center.Y - float64(bounds.Dy())/2,
+	}
+
+	angle := game.rng.Stream(streamSpawns).Float64() * 2 * math.Pi
+	radius := float64(max(bounds.Dx(), bounds.Dy()))/2 + powerUpColliderPadding
+
+	pu := &PowerUp{
+		game:       game,
+		kind:       kind,
+		position:   position,
+		movement:   Vector{X: math.Cos(angle) * powerUpSpeed, Y: math.Sin(angle) * powerUpSpeed},
+		sprite:     sprite,
+		expiry:     sim.NewTimer(powerUpLifetime),
+		powerUpObj: resolv.NewCircle(position.X, position.Y, radius),
+	}
+
+	// Collider bookkeeping for spatial queries and ID.
+	pu.powerUpObj.SetPosition(position.X, position.Y)
+	pu.powerUpObj.SetData(&ObjectData{index: index})
+	pu.powerUpObj.Tags().Set(TagPowerUp)
+
+	return pu
+}
+
+// Update drifts the power-up, hands it to the field's boundary policy, and
+// advances expiry.
+func (pu *PowerUp) Update() {
+	sim.Advance(&pu.position, pu.movement)
+
+	// Keep to the field like meteors so a drop never drifts out of reach.
+	// Drops bounce off walls quietly and survive lethal ones.
+	pu.game.boundary().confine(edgeBody{
+		position: &pu.position,
+		movement: &pu.movement,
+		size:     spriteSize(pu.sprite),
+	})
+
+	pu.powerUpObj.SetPosition(pu.position.X, pu.position.Y)
+	pu.expiry.Update()
+}
+
+// isExpired reports whether the power-up's lifetime has run out.
+func (pu *PowerUp) isExpired() bool {
+	return pu.expiry.IsReady()
+}
+
+// Draw renders the power-up, blinking during the last powerUpBlinkTime.
+func (pu *PowerUp) Draw(screen *ebiten.Image) {
+	remaining := pu.expiry.Target - pu.expiry.Elapsed
+	blinkTicks := sim.Ticks(powerUpBlinkTime)
+	if remaining < blinkTicks && (remaining/8)%2 == 0 {
+		return
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(pu.position.X, pu.position.Y)
+	drawSprite(screen, pu.sprite, op)
+}
+
+// maybeDropPowerUp spawns a random power-up at center with probability chance.
+func (g *GameScene) maybeDropPowerUp(center Vector, chance float64) {
+	if g.rng.Stream(streamSpawns).Float64() >= chance {
+		return
+	}
+	g.dropPowerUp(powerUpDropKinds[g.rng.Stream(streamSpawns).Intn(len(powerUpDropKinds))], center)
+}
+
+// maybeDropExtraLife spawns a 1-UP at center with probability
+// extraLifeDropRate.
+func (g *GameScene) maybeDropExtraLife(center Vector) {
+	if g.rng.Stream(streamSpawns).Float64() < extraLifeDropRate {
+		g.dropPowerUp(PowerUpExtraLife, center)
+	}
+}
+
+// dropPowerUp spawns a power-up of kind at center.
+func (g *GameScene) dropPowerUp(kind PowerUpKind, center Vector) {
+	g.powerUpCount++
+	pu := NewPowerUp(kind, center, g.powerUpCount, g)
+	g.powerUps[g.powerUpCount] = pu
+	g.space.Add(pu.powerUpObj)
+}
+
+// updatePowerUps advances every power-up and removes the expired ones.
+func (g *GameScene) updatePowerUps() {
+	for _, pu := range inOrder(g.powerUps) {
+		pu.Update()
+	}
+	for _, i := range g.scratch.powerUps.cull(g.powerUps, (*PowerUp).isExpired) {
+		pu := g.powerUps[i]
+		g.despawn(pu.sprite, spriteCenter(pu.position, pu.sprite), 0, ebiten.ColorScale{})
+		g.removePowerUp(i)
+	}
+}
+
+// isPlayerCollectingPowerUp applies and removes every power-up the ship touches.
+func (g *GameScene) isPlayerCollectingPowerUp() {
+	if g.player.isDying || g.player.isDead {
+		return
+	}
+	for i, pu := range inOrder(g.powerUps) {
+		if g.collisions.intersects(pu.powerUpObj, g.player.playerObj) {
+			g.collisions.consume(pu.powerUpObj)
+			powerUpEffects[pu.kind].apply(g)
+			g.removePowerUp(i)
+			sharedAudio().PlaySFX(powerUpEffects[pu.kind].sound)
+		}
+	}
+}
+
+// removePowerUp deletes a power-up from the map and the collision space.
+func (g *GameScene) removePowerUp(index int) {
+	if pu, ok := g.powerUps[index]; ok {
+		g.space.Remove(pu.powerUpObj)
+		delete(g.powerUps, index)
+	}
+}
+
+// chime renders a pickup chime as 16-bit little-endian stereo PCM at the
+// audio context's rate: one note per step, that many semitones above base
+// hertz.
+func chime(base float64, steps ...int) *bytes.Reader {
+	n := sim.Ticks(chimeNote) * audioSampleRate / sim.TicksPerSecond
+	pcm := make([]byte, 0, len(steps)*n*4)
+	for _, step := range steps {
+		freq := base * math.Pow(2, float64(step)/12)
+		for i := range n {
+			t := float64(i) / audioSampleRate
+			v := chimeGain * math.Sin(2*math.Pi*freq*t) * math.Exp(-t/chimeDecay.Seconds())
+			s := int16(v * math.MaxInt16)
+			pcm = append(pcm, byte(s), byte(s>>8), byte(s), byte(s>>8))
+		}
+	}
+	return bytes.NewReader(pcm)
+}
@@ -0,0 +1,257 @@
+// File practice.go implements practice mode: the PracticeConfig that drives
+// spawning in place of level progression, and the PracticeScene panel used
+// to edit it over the frozen game.
+package asteroids
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/hajimehoshi/ebiten/v2"
+	inpututil "github.com/hajimehoshi/ebiten/v2/inpututil"
+	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Practice tuning and panel layout.
+const (
+	practiceMeteorInterval = 1 * time.Second // Time between meteor spawns at 100% rate.
+	practiceAlienInterval  = 4 * time.Second // Time between alien spawns at 100% rate.
+	practiceMaxMeteors     = 16              // Field cap for meteors of any size.
+	practiceMaxAliens      = 3               // Field cap for aliens.
+	practiceRateStep       = 0.25            // Left/Right change for spawn rates.
+	practiceRateMax        = 3.0             // Highest spawn-rate multiplier.
+	practicePanelWidth     = 360             // Panel width in pixels.
+	practiceRowSpacing     = 30              // Vertical distance between rows.
+)
+
+// PracticeConfig selects what practice mode spawns and how often.
+type PracticeConfig struct {
+	LargeMeteors  bool    // Spawn large meteors.
+	MediumMeteors bool    // Spawn medium meteors.
+	SmallMeteors  bool    // Spawn small meteors.
+	Sweepers      bool    // Spawn aliens that cross the screen edge to edge.
+	Hunters       bool    // Spawn aliens that fly at the player.
+	MeteorRate    float64 // Meteor spawn-rate multiplier (0 stops spawns).
+	AlienRate     float64 // Alien spawn-rate multiplier (0 stops spawns).
+
+	meteorProgress float64 // Accumulated spawn progress toward the next meteor.
+	alienProgress  float64 // Accumulated spawn progress toward the next alien.
+	nextMeteor     int     // Takes the enabled sizes in turn.
+	nextAlien      int     // Alternates alien types when both are enabled.
+}
+
+// newPracticeConfig returns the starting sandbox: large meteors only.
+func newPracticeConfig() *PracticeConfig {
+	return &PracticeConfig{
+		LargeMeteors: true,
+		MeteorRate:   1,
+		AlienRate:    1,
+	}
+}
+
+// spawnPracticeMeteors adds meteors of the enabled sizes at the chosen rate.
+func (g *GameScene) spawnPracticeMeteors() {
+	pc := g.practice
+	var sizes []func(baseVelocity float64, game *GameScene, index int) *Meteor
+	if pc.LargeMeteors {
+		sizes = append(sizes, NewMeteor)
+	}
+	if pc.MediumMeteors {
+		sizes = append(sizes, NewMediumMeteor)
+	}
+	if pc.SmallMeteors {
+		sizes = append(sizes, NewSmallMeteor)
+	}
+	if len(sizes) == 0 {
+		return
+	}
+	pc.meteorProgress += pc.MeteorRate
+	if pc.meteorProgress < practiceTicks(practiceMeteorInterval) || len(g.meteors) >= practiceMaxMeteors {
+		return
+	}
+	pc.meteorProgress = 0
+
+	newMeteor := sizes[pc.nextMeteor%len(sizes)]
+	pc.nextMeteor++
+	g.addMeteor(newMeteor(g.baseVelocity, g, g.meteorCount+1))
+}
+
+// spawnPracticeAliens adds aliens of the enabled types at the chosen rate.
+func (g *GameScene) spawnPracticeAliens() {
+	pc := g.practice
+	if !pc.Sweepers && !pc.Hunters {
+		return
+	}
+	pc.alienProgress += pc.AlienRate
+	if pc.alienProgress < practiceTicks(practiceAlienInterval) || len(g.aliens) >= practiceMaxAliens {
+		return
+	}
+	pc.alienProgress = 0
+
+	var alienType int
+	switch {
+	case pc.Sweepers && pc.Hunters:
+		alienType = pc.nextAlien % alienTypeCount
+	case pc.Hunters:
+		alienType = alienHunter
+	default:
+		alienType = alienSweepLeft + pc.nextAlien%2
+	}
+	pc.nextAlien++
+
+	alien := newAlienOfType(basedAlienVelocity, g, alienType)
+	g.space.Add(alien.alienObj)
+	g.alienCount++
+	g.aliens[g.alienCount] = alien
+}
+
+// clearField removes every meteor, alien, mine, and projectile from play,
+// by key, so pooled ones are reused in the same order on every run.
+func (g *GameScene) clearField() {
+	for i := range inOrder(g.meteors) {
+		g.removeMeteor(i)
+	}
+	for i, a := range inOrder(g.aliens) {
+		g.space.Remove(a.alienObj)
+		delete(g.aliens, i)
+	}
+	for i := range inOrder(g.lasers) {
+		g.removeLaser(i)
+	}
+	for i := range inOrder(g.alienLasers) {
+		g.removeAlienLaser(i)
+	}
+	for i, m := range inOrder(g.mines) {
+		g.space.Remove(m.triggerObj)
+		delete(g.mines, i)
+	}
+	g.waves.RestartLevel()
+}
+
+// practiceTicks converts a duration to a tick count as a float, for
+// comparing against spawn progress.
+func practiceTicks(d time.Duration) float64 {
+	return d.Seconds() * sim.TicksPerSecond
+}
+
+// PracticeScene is the practice panel, shown beside the frozen game.
+type PracticeScene struct {
+	game     *GameScene    // The practice run being configured.
+	rows     []settingsRow // Options, top to bottom.
+	selected int           // Highlighted row.
+}
+
+// NewPracticeScene silences the game's loops and builds the panel rows.
+func NewPracticeScene(game *GameScene) *PracticeScene {
+	game.pauseLoopingSounds()
+
+	pc := game.practice
+	p := &PracticeScene{game: game}
+	p.rows = []settingsRow{
+		toggleRow("Large Meteors", &pc.LargeMeteors),
+		toggleRow("Medium Meteors", &pc.MediumMeteors),
+		toggleRow("Small Meteors", &pc.SmallMeteors),
+		toggleRow("Sweeper Aliens", &pc.Sweepers),
+		toggleRow("Hunter Aliens", &pc.Hunters),
+		rateRow("Meteor Rate", &pc.MeteorRate),
+		rateRow("Alien Rate", &pc.AlienRate),
+		{
+			label: "Clear Field",
+			value: func() string { return "" },
+			enter: func(*State) { game.clearField() },
+		},
+		{
+			label: "Resume",
+			value: func() string { return "" },
+			enter: func(state *State) { state.SceneManager.PopScene() },
+		},
+	}
+	return p
+}
+
+// toggleRow returns an On/Off row editing the flag at b.
+func toggleRow(label string, b *bool) settingsRow {
+	return settingsRow{
+		label:  label,
+		value:  func() string { return onOff(*b) },
+		adjust: func(int) { *b = !*b },
+		enter:  func(*State) { *b = !*b },
+	}
+}
+
+// rateRow returns a percentage row editing the multiplier at v.
+func rateRow(label string, v *float64) settingsRow {
+	return settingsRow{
+		label: label,
+		value: func() string { return fmt.Sprintf("%d%%", int(*v*100+0.5)) },
+		adjust: func(step int) {
+			*v = math.Max(0, math.Min(practiceRateMax, *v+float64(step)*practiceRateStep))
+		},
+	}
+}
+
+// Draw renders the game frame with the panel along the right edge.
+func (p *PracticeScene) Draw(screen *ebiten.Image) {
+	p.game.Draw(screen)
+
+	left := float64(ScreenWidth - practicePanelWidth - 20)
+	top := 120.0
+	height := float64(len(p.rows)*practiceRowSpacing + 80)
+	fillRect(screen, float32(left), float32(top), practicePanelWidth, float32(height), color.RGBA{A: 200}, false)
+	strokeRect(screen, float32(left), float32(top), practicePanelWidth, float32(height), 1, color.Gray{Y: 160}, false)
+
+	drawCenteredText(screen, "PRACTICE", assets.ScoreFont, 24, left+practicePanelWidth/2, top+16, color.White)
+
+	face := &text.GoTextFace{Source: assets.ScoreFont, Size: 16}
+	for i, row := range p.rows {
+		y := top + 60 + float64(i*practiceRowSpacing)
+		c := color.Color(color.White)
+		if i == p.selected {
+			c = color.RGBA{R: 255, G: 215, B: 0, A: 255} // gold-ish
+		}
+
+		op := &text.DrawOptions{}
+		op.ColorScale.ScaleWithColor(c)
+		op.GeoM.Translate(left+20, y)
+		drawText(screen, row.label, face, op)
+
+		op = &text.DrawOptions{
+			LayoutOptions: text.LayoutOptions{PrimaryAlign: text.AlignEnd},
+		}
+		op.ColorScale.ScaleWithColor(c)
+		op.GeoM.Translate(left+practicePanelWidth-20, y)
+		drawText(screen, row.value(), face, op)
+	}
+}
+
+// Update handles panel navigation. Tab or Escape returns to the run.
+func (p *PracticeScene) Update(state *State) error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyTab) || inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		state.SceneManager.PopScene()
+		return nil
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
+		p.selected = (p.selected - 1 + len(p.rows)) % len(p.rows)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) {
+		p.selected = (p.selected + 1) % len(p.rows)
+	}
+
+	row := p.rows[p.selected]
+	if row.adjust != nil {
+		if inpututil.IsKeyJustPressed(ebiten.KeyLeft) {
+			row.adjust(-1)
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyRight) {
+			row.adjust(1)
+		}
+	}
+	if row.enter != nil && inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		row.enter(state)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// File profile.go keeps the player profile: the crystals earned across runs,
+// the upgrades bought with them, and New Game+ progress, saved as JSON in
+// the save directory.
+package asteroids
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"log"
+)
+
+// profileFileName is the profile file inside the save directory.
+const profileFileName = "profile.json"
+
+// crystalScoreRate is how many points of a finished run earn one crystal.
+const crystalScoreRate = 10
+
+// Profile is the persisted progress that carries between runs.
+type Profile struct {
+	Crystals    int                 `json:"crystals"`    // Unspent crystals.
+	Upgrades    Upgrades            `json:"upgrades"`    // Upgrade levels bought.
+	NewGamePlus NewGamePlusProgress `json:"newGamePlus"` // New Game+ unlock and record.
+}
+
+// profile is the player profile, loaded at startup.
+var profile = &Profile{}
+
+// init loads the persisted profile (best-effort).
+func init() {
+	p, err := loadProfile()
+	if err != nil {
+		log.Println("Error loading profile", err)
+		return
+	}
+	profile = p
+}
+
+// loadProfile reads the profile file; a missing file is a fresh profile.
+func loadProfile() (*Profile, error) {
+	p := &Profile{}
+	data, err := saves.Read(profileFileName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return p, nil
+	}
+	if err != nil {
+		return p, err
+	}
+	if err := json.Unmarshal(data, p); err != nil {
+		return &Profile{}, err
+	}
+	return p, nil
+}
+
+// Save writes the profile to the save directory.
+func (p *Profile) Save() error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return saves.Write(profileFileName, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// buy spends crystals on the next level of u and saves the profile. It
+// reports false, spending nothing, if u is maxed out or unaffordable.
+func (p *Profile) buy(u Upgrade) bool {
+	cost, ok := u.nextCost(p.Upgrades[u.ID])
+	if !ok || p.Crystals < cost {
+		return false
+	}
+	if p.Upgrades == nil {
+		p.Upgrades = Upgrades{}
+	}
+	p.Crystals -= cost
+	p.Upgrades[u.ID]++
+	if err := p.Save(); err != nil {
+		log.Println("Error saving profile", err)
+	}
+	return true
+}
+
+// updateProfile folds a finished run into the profile: it pays out the
+// run's crystals, scored and salvaged from unspent scrap, and, for New Game+, records the run. Unranked modes and
+// replays leave the profile alone.
+func (g *GameScene) updateProfile() {
+	if g.mode.Unranked || g.playback != nil {
+		return
+	}
+	g.crystalsEarned = g.score/crystalScoreRate + g.scrap/scrapCrystalRate
+	profile.Crystals += g.crystalsEarned
+	if g.mode.NewGamePlus {
+		profile.NewGamePlus.Runs++
+		profile.NewGamePlus.BestLevel = max(profile.NewGamePlus.BestLevel, g.currentLevel)
+	}
+	if err := profile.Save(); err != nil {
+		log.Println("Error saving profile", err)
+	}
+}
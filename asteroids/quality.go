@@ -0,0 +1,98 @@
+// File quality.go defines the selectable quality presets: named bundles of
+// the video options that cost the most to draw, from full quality down to
+// settings that keep the frame rate up on slow machines. Choosing one sets
+// each of its options; they can still be tuned one by one after.
+package asteroids
+
+// QualityPreset is a named bundle of video options.
+type QualityPreset struct {
+	Name        string  // Display name.
+	StarDensity float64 // Config.StarDensity it sets.
+	RenderScale float64 // Config.RenderScale it sets.
+	TitleReplay bool    // Config.TitleReplay it sets.
+	Despawns    bool    // Config.DespawnEffects it sets.
+}
+
+// qualityPresets lists the available presets from the most to the least
+// demanding; the first matches the default configuration.
+var qualityPresets = []QualityPreset{
+	{Name: "High", StarDensity: 1, RenderScale: renderScaleAuto, TitleReplay: true, Despawns: true},
+	{Name: "Medium", StarDensity: 0.5, RenderScale: 1, TitleReplay: true, Despawns: true},
+	{Name: "Low", StarDensity: 0.25, RenderScale: 1, TitleReplay: false, Despawns: false},
+}
+
+// matches reports whether c has exactly the options of p.
+func (p QualityPreset) matches(c *Config) bool {
+	return c.StarDensity == p.StarDensity && c.RenderScale == p.RenderScale &&
+		c.TitleReplay == p.TitleReplay && c.DespawnEffects == p.Despawns
+}
+
+// apply sets the options of p on c.
+func (p QualityPreset) apply(c *Config) {
+	c.StarDensity = p.StarDensity
+	c.RenderScale = p.RenderScale
+	c.TitleReplay = p.TitleReplay
+	c.DespawnEffects = p.Despawns
+}
+
+// cheaperThan reports whether p asks less of the machine than c does: no
+// option of p costs more, and p is not what c already has.
+func (p QualityPreset) cheaperThan(c *Config) bool {
+	return !p.matches(c) &&
+		p.StarDensity <= c.StarDensity &&
+		effectiveRenderScale(p.RenderScale) <= effectiveRenderScale(c.RenderScale) &&
+		(c.TitleReplay || !p.TitleReplay) &&
+		(c.DespawnEffects || !p.Despawns)
+}
+
+// effectiveRenderScale returns the device pixels per logical pixel the
+// config.RenderScale value s renders at on the current monitor.
+func effectiveRenderScale(s float64) float64 {
+	if s == renderScaleAuto {
+		return renderScale
+	}
+	return s
+}
+
+// qualityPresetIndex returns the index of the preset c matches exactly, or
+// -1 if its options have been tuned individually.
+func qualityPresetIndex(c *Config) int {
+	for i, p := range qualityPresets {
+		if p.matches(c) {
+			return i
+		}
+	}
+	return -1
+}
+
+// qualityPresetName returns the name of the preset c matches, or "Custom".
+func qualityPresetName(c *Config) string {
+	if i := qualityPresetIndex(c); i >= 0 {
+		return qualityPresets[i].Name
+	}
+	return "Custom"
+}
+
+// cycleQualityPreset applies to c the preset step places after the one it
+// matches, wrapping at either end. Custom options step to the first or last
+// preset.
+func cycleQualityPreset(c *Config, step int) {
+	i := qualityPresetIndex(c)
+	if i < 0 && step < 0 {
+		i = 0 // So stepping back from Custom lands on the last preset.
+	}
+	i = (i + step + len(qualityPresets)) % len(qualityPresets)
+	qualityPresets[i].apply(c)
+}
+
+// lowerQualityPreset returns the most demanding preset that still asks
+// less of the machine than c, and false if c is already as light as the
+// presets go.
+func lowerQualityPreset(c *Config) (QualityPreset, bool) {
+	for _, p := range qualityPresets {
+		if p.cheaperThan(c) {
+			return p, true
+		}
+	}
+	return QualityPreset{}, false
+}
@@ -0,0 +1,67 @@
+// File radar.go defines the HUD radar: a small disc in the bottom-right
+// corner that plots meteors and aliens around the ship's field. It reaches
+// out to the off-screen ring meteors spawn on, so the player can see a
+// threat coming before it crosses the screen edge.
+package asteroids
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Radar tuning. Sizes are HUD pixels at HUD scale 1.
+const (
+	radarRadius = 60.0                  // Radius of the disc.
+	radarMargin = 20.0                  // Gap between the disc and the screen corner.
+	radarRange  = ScreenWidth/2.0 + 500 // World distance from the field's center the rim stands for: the meteor spawn ring.
+	radarDot    = 2.0                   // Radius of a contact's dot.
+)
+
+// Radar colors. Aliens take the palette's warning color.
+var (
+	radarBackground = color.RGBA{A: 140}
+	radarRim        = color.RGBA{R: 90, G: 90, B: 90, A: 255}
+	radarMeteor     = color.RGBA{R: 200, G: 200, B: 200, A: 255}
+)
+
+// drawRadar plots the field's screen, the ship, and every live meteor and
+// alien on the radar. Contacts past the range sit on the rim.
+func (h *HUD) drawRadar(screen *ebiten.Image) {
+	if !config.Radar {
+		return
+	}
+	g := h.game
+	scale := hudScale()
+	r := radarRadius * scale
+	cx, cy := ScreenWidth-radarMargin*scale-r, ScreenHeight-radarMargin*scale-r
+	k := r / radarRange
+
+	fillCircle(screen, float32(cx), float32(cy), float32(r), radarBackground, true)
+	strokeCircle(screen, float32(cx), float32(cy), float32(r), 1, radarRim, true)
+
+	// The visible field, as a rectangle around the disc's center.
+	w, hgt := ScreenWidth*k, ScreenHeight*k
+	strokeRect(screen, float32(cx-w/2), float32(cy-hgt/2), float32(w), float32(hgt), 1, radarRim, false)
+
+	// plot maps a world position onto the disc.
+	plot := func(p Vector, clr color.Color) {
+		dx, dy := p.X-ScreenWidth/2, p.Y-ScreenHeight/2
+		if d := math.Hypot(dx, dy); d > radarRange {
+			dx, dy = dx*radarRange/d, dy*radarRange/d
+		}
+		fillCircle(screen, float32(cx+dx*k), float32(cy+dy*k), float32(radarDot*scale), clr, true)
+	}
+	for _, m := range g.meteors {
+		if !g.isExploding(m) {
+			plot(spriteCenter(m.position, m.sprite), radarMeteor)
+		}
+	}
+	for _, a := range g.aliens {
+		if a.sprite != g.explosionSmallSprite {
+			plot(a.position, currentPalette().Warning)
+		}
+	}
+	plot(spriteCenter(g.player.position, g.player.sprite), currentPalette().HUD)
+}
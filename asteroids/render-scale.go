@@ -0,0 +1,194 @@
+// File render-scale.go renders the logical ScreenWidth × ScreenHeight
+// surface at the display's pixel density. On a HiDPI ("retina") display the
+// backbuffer is renderScale times the logical size, and every draw goes
+// through the helpers here, which map logical coordinates onto it: sprites
+// use their 2x variants where assets has them, text is rasterized at the
+// scaled size, and vector shapes are stroked at device resolution, so
+// nothing is drawn at 1x and then stretched.
+//
+// Scenes keep working in logical coordinates throughout; only these helpers
+// and the offscreen layers from renderLayer know about device pixels.
+package asteroids
+
+import (
+	"image/color"
+	"math"
+	"slices"
+	"strconv"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/colorm"
+	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Render scale limits and settings.
+const (
+	renderScaleAuto = 0 // config.RenderScale value that follows the monitor.
+	renderScaleMin  = 1 // Never render below the logical size.
+	renderScaleMax  = 3 // Cap on backbuffer size; past this little is gained.
+)
+
+// renderScaleChoices are the values the settings menu cycles through.
+var renderScaleChoices = []float64{renderScaleAuto, 1, 2}
+
+// renderScale is the backbuffer's device pixels per logical pixel this
+// frame, set by updateRenderScale.
+var renderScale = 1.0
+
+// updateRenderScale sets renderScale from the setting, or from the
+// monitor's device scale factor when the setting is automatic.
+func updateRenderScale() {
+	s := config.RenderScale
+	if s == renderScaleAuto {
+		s = 1
+		if m := ebiten.Monitor(); m != nil {
+			s = m.DeviceScaleFactor()
+		}
+	}
+	renderScale = math.Max(renderScaleMin, math.Min(renderScaleMax, s))
+}
+
+// renderScaleLabel describes a render scale setting for the settings menu.
+func renderScaleLabel(s float64) string {
+	if s == renderScaleAuto {
+		return "Auto (" + strconv.FormatFloat(renderScale, 'f', -1, 64) + "x)"
+	}
+	return strconv.FormatFloat(s, 'f', -1, 64) + "x"
+}
+
+// cycleRenderScale returns the render scale setting step places after s,
+// stopping at either end.
+func cycleRenderScale(s float64, step int) float64 {
+	i := max(0, slices.Index(renderScaleChoices, s))
+	return renderScaleChoices[max(0, min(len(renderScaleChoices)-1, i+step))]
+}
+
+// renderSize returns the backbuffer size in device pixels.
+func renderSize() (width, height int) {
+	return int(math.Round(ScreenWidth * renderScale)), int(math.Round(ScreenHeight * renderScale))
+}
+
+// renderLayer returns *layer cleared, first reallocating it at the
+// backbuffer size if it is missing or the render scale has changed.
+func renderLayer(layer **ebiten.Image) *ebiten.Image {
+	w, h := renderSize()
+	if *layer == nil || (*layer).Bounds().Dx() != w || (*layer).Bounds().Dy() != h {
+		if *layer != nil {
+			(*layer).Deallocate()
+		}
+		*layer = ebiten.NewImage(w, h)
+		return *layer
+	}
+	(*layer).Clear()
+	return *layer
+}
+
+// toDevice returns m followed by the logical-to-device scale, for
+// geometry drawn in logical pixels.
+func toDevice(m ebiten.GeoM) ebiten.GeoM {
+	m.Scale(renderScale, renderScale)
+	return m
+}
+
+// conjugate returns m carried over to device pixels, for geometry that is
+// already drawn in device pixels (offscreen layers and rasterized text):
+// translations are scaled, the pixels themselves are not.
+func conjugate(m ebiten.GeoM) ebiten.GeoM {
+	var c ebiten.GeoM
+	c.Scale(1/renderScale, 1/renderScale)
+	c.Concat(m)
+	c.Scale(renderScale, renderScale)
+	return c
+}
+
+// spriteGeoM returns the device geometry and image for drawing src with
+// logical geometry m, switching to src's 2x variant when the backbuffer is
+// dense enough to show it.
+func spriteGeoM(src *ebiten.Image, m ebiten.GeoM) (*ebiten.Image, ebiten.GeoM) {
+	if hi := assets.HiDPI(src); hi != nil && renderScale > 1 {
+		var g ebiten.GeoM
+		g.Scale(1/assets.HiDPIScale, 1/assets.HiDPIScale)
+		g.Concat(m)
+		return hi, toDevice(g)
+	}
+	return src, toDevice(m)
+}
+
+// drawSprite draws src onto dst with op's geometry in logical pixels.
+func drawSprite(dst, src *ebiten.Image, op *ebiten.DrawImageOptions) {
+	o := ebiten.DrawImageOptions{}
+	if op != nil {
+		o = *op
+	}
+	img, g := spriteGeoM(src, o.GeoM)
+	o.GeoM = g
+	if renderScale != math.Trunc(renderScale) || img != src {
+		o.Filter = ebiten.FilterLinear // Fractional or reduced: smooth rather than shimmer.
+	}
+	dst.DrawImage(img, &o)
+}
+
+// drawSpriteColorM is drawSprite through a color matrix.
+func drawSpriteColorM(dst, src *ebiten.Image, cm colorm.ColorM, op *colorm.DrawImageOptions) {
+	o := *op
+	img, g := spriteGeoM(src, o.GeoM)
+	o.GeoM = g
+	if renderScale != math.Trunc(renderScale) || img != src {
+		o.Filter = ebiten.FilterLinear
+	}
+	colorm.DrawImage(dst, img, cm, &o)
+}
+
+// drawLayer composites an offscreen layer from renderLayer onto dst with
+// op's geometry in logical pixels.
+func drawLayer(dst, layer *ebiten.Image, op *ebiten.DrawImageOptions) {
+	o := ebiten.DrawImageOptions{}
+	if op != nil {
+		o = *op
+	}
+	o.GeoM = conjugate(o.GeoM)
+	dst.DrawImage(layer, &o)
+}
+
+// drawText draws s onto dst in face with op's geometry and layout in
+// logical pixels, rasterizing the glyphs at device size.
+func drawText(dst *ebiten.Image, s string, face *text.GoTextFace, op *text.DrawOptions) {
+	f := *face
+	f.Size *= renderScale
+	o := *op
+	o.GeoM = conjugate(o.GeoM)
+	o.LineSpacing *= renderScale
+	text.Draw(dst, s, &f, &o)
+}
+
+// device converts a logical length or coordinate to device pixels.
+func device(v float32) float32 {
+	return v * float32(renderScale)
+}
+
+// fillRect is vector.FillRect in logical pixels.
+func fillRect(dst *ebiten.Image, x, y, w, h float32, clr color.Color, antialias bool) {
+	vector.FillRect(dst, device(x), device(y), device(w), device(h), clr, antialias)
+}
+
+// strokeRect is vector.StrokeRect in logical pixels.
+func strokeRect(dst *ebiten.Image, x, y, w, h, width float32, clr color.Color, antialias bool) {
+	vector.StrokeRect(dst, device(x), device(y), device(w), device(h), device(width), clr, antialias)
+}
+
+// fillCircle is vector.FillCircle in logical pixels.
+func fillCircle(dst *ebiten.Image, cx, cy, r float32, clr color.Color, antialias bool) {
+	vector.FillCircle(dst, device(cx), device(cy), device(r), clr, antialias)
+}
+
+// strokeCircle is vector.StrokeCircle in logical pixels.
+func strokeCircle(dst *ebiten.Image, cx, cy, r, width float32, clr color.Color, antialias bool) {
+	vector.StrokeCircle(dst, device(cx), device(cy), device(r), device(width), clr, antialias)
+}
+
+// strokeLine is vector.StrokeLine in logical pixels.
+func strokeLine(dst *ebiten.Image, x0, y0, x1, y1, width float32, clr color.Color, antialias bool) {
+	vector.StrokeLine(dst, device(x0), device(y0), device(x1), device(y1), device(width), clr, antialias)
+}
@@ -0,0 +1,584 @@
+// File replay.go defines Replay, a compact record of a run: the mode, RNG
+// seed, upgrades, difficulty, and ship it started from plus the action state of
+// every tick it played, the run upgrades picked between levels, and the
+// supplies bought in the salvage shop. Given those, a GameScene re-simulates
+// the run exactly, which makes replays useful for bug reports, for sharing
+// runs, and for verifying high scores.
+//
+// Input is stored run-length encoded, since held keys change rarely compared
+// with the tick rate. Every replayCheckpointInterval the recording also
+// keeps a checkpoint of the entities in play, encoded as a sim.Snapshot for
+// the first and as a sim.Delta from the one before for the rest, so
+// playback can tell the moment it strays from the recorded run instead of
+// only noticing a different final score. The file layout is:
+//
+//	magic "ASTR", version byte
+//	seed (varint), mode name (uvarint length + bytes), score (varint)
+//	upgrade count (uvarint), then per upgrade in ID order:
+//	    ID (uvarint length + bytes), level (uvarint)
+//	difficulty name (uvarint length + bytes)
+//	ship class name (uvarint length + bytes)
+//	run count (uvarint), then per run: tick state (uvarint), ticks (uvarint)
+//	pick count (uvarint), then per pick: run upgrade + 1, or 0 if passed up (uvarint)
+//	purchase count (uvarint), then per purchase: supply + 1, or 0 on leaving the shop (uvarint)
+//	checkpoint count (uvarint), then per checkpoint:
+//	    encoded snapshot or delta (uvarint length + bytes)
+package asteroids
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/bensabler/asteroids/internal/sim"
+)
+
+// Replay file format.
+const (
+	replayMagic   = "ASTR"
+	replayVersion = 15
+)
+
+// replayCheckpointInterval is the play time between checkpoints.
+const replayCheckpointInterval = 30 * time.Second
+
+// replayCheckpointLimit bounds one encoded checkpoint when decoding.
+const replayCheckpointLimit = 1 << 20
+
+// Replay files inside the save directory.
+const (
+	replayLastFile = "last.replay"
+	replayBestFile = "best.replay"
+)
+
+// ReplayFile, when set before the game starts (from the -replay flag), is a
+// replay to play back instead of showing the title screen.
+var ReplayFile string
+
+// Tick state bits: the actions held this tick, the actions held on the
+// previous frame (for just-pressed edges), and settings that change rules.
+const (
+	tickPreviousShift    = actionCount
+	tickMeteorCollisions = 1 << (2 * actionCount)
+	tickToggleFire       = 1 << (2*actionCount + 1)
+	tickAutoFire         = 1 << (2*actionCount + 2)
+	tickToggleThrust     = 1 << (2*actionCount + 3)
+	tickAimAssistShift   = 2*actionCount + 4 // Two bits of aim assist strength.
+	tickInertiaDampener  = 1 << (2*actionCount + 6)
+)
+
+// tickRules are the settings that change how a tick plays out. GameScene
+// reads them once per tick so a replay can supply the recorded values.
+type tickRules struct {
+	meteorCollisions bool    // Realistic asteroids.
+	assists          Assists // Assists in force.
+}
+
+// currentRules returns the rules the settings select for g's mode.
+func (g *GameScene) currentRules() tickRules {
+	rules := tickRules{meteorCollisions: config.MeteorCollisions}
+	if !g.mode.NoAssists {
+		rules.assists = currentAssists()
+	}
+	return rules
+}
+
+// replayRun is a stretch of consecutive ticks with the same state.
+type replayRun struct {
+	state uint32 // Packed tick state.
+	ticks int    // Number of ticks it lasted.
+}
+
+// Replay is a recorded run.
+type Replay struct {
+	Mode       string       // Name of the run's Mode.
+	Seed       int64        // Seed of the run's RNG.
+	Score      int          // Final score, checked on playback.
+	Upgrades   Upgrades     // Upgrade levels the ship flew with.
+	Difficulty string       // Name of the run's Difficulty.
+	Ship       string       // Name of the run's ShipClass.
+	runs       []replayRun  // Per-tick state, run-length encoded.
+	picks      []RunUpgrade // Run upgrades picked between levels, in order.
+	purchases  []Supply     // Supplies bought in the salvage shop, each visit ended by noSupply.
+
+	checkpoints [][]byte     // Encoded checkpoints, oldest first.
+	recorded    int          // Ticks recorded so far.
+	last        sim.Snapshot // Latest checkpoint, the base for the next delta.
+}
+
+// newReplay starts recording a run of mode from seed with upgrades on
+// difficulty, flying ship.
+func newReplay(mode Mode, seed int64, upgrades Upgrades, difficulty Difficulty, ship ShipClass) *Replay {
+	return &Replay{Mode: mode.Name, Seed: seed, Upgrades: upgrades, Difficulty: difficulty.Name, Ship: ship.Name}
+}
+
+// record appends one tick of g played with input under rules, taking a
+// checkpoint of g first when one is due.
+func (r *Replay) record(g *GameScene, input *Input, rules tickRules) {
+	if r.recorded%sim.Ticks(replayCheckpointInterval) == 0 {
+		r.checkpoint(g.snapshot(r.recorded))
+	}
+	r.recorded++
+
+	state := tickState(input, rules)
+	if n := len(r.runs); n > 0 && r.runs[n-1].state == state {
+		r.runs[n-1].ticks++
+		return
+	}
+	r.runs = append(r.runs, replayRun{state: state, ticks: 1})
+}
+
+// checkpoint encodes s as the next checkpoint.
+func (r *Replay) checkpoint(s sim.Snapshot) {
+	var data []byte
+	var err error
+	if len(r.checkpoints) == 0 {
+		data, err = s.MarshalBinary()
+	} else {
+		data, err = sim.Diff(r.last, s).MarshalBinary()
+	}
+	if err != nil {
+		log.Println("Error recording replay checkpoint", err)
+		return
+	}
+	r.checkpoints = append(r.checkpoints, data)
+	r.last = s
+}
+
+// tickState packs input and rules into a tick state.
+func tickState(input *Input, rules tickRules) uint32 {
+	var state uint32
+	for a := Action(0); a < actionCount; a++ {
+		if input.pressed[a] {
+			state |= 1 << a
+		}
+		if input.previous[a] {
+			state |= 1 << (tickPreviousShift + a)
+		}
+	}
+	if rules.meteorCollisions {
+		state |= tickMeteorCollisions
+	}
+	if rules.assists.ToggleFire {
+		state |= tickToggleFire
+	}
+	if rules.assists.AutoFire {
+		state |= tickAutoFire
+	}
+	if rules.assists.ToggleThrust {
+		state |= tickToggleThrust
+	}
+	state |= uint32(rules.assists.AimAssist&3) << tickAimAssistShift
+	if rules.assists.InertiaDampener {
+		state |= tickInertiaDampener
+	}
+	return state
+}
+
+// MarshalBinary encodes the replay in the file format above.
+func (r *Replay) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(replayMagic)
+	buf.WriteByte(replayVersion)
+	buf.Write(binary.AppendVarint(nil, r.Seed))
+	buf.Write(binary.AppendUvarint(nil, uint64(len(r.Mode))))
+	buf.WriteString(r.Mode)
+	buf.Write(binary.AppendVarint(nil, int64(r.Score)))
+	buf.Write(binary.AppendUvarint(nil, uint64(len(r.Upgrades))))
+	for _, id := range slices.Sorted(maps.Keys(r.Upgrades)) {
+		buf.Write(binary.AppendUvarint(nil, uint64(len(id))))
+		buf.WriteString(id)
+		buf.Write(binary.AppendUvarint(nil, uint64(r.Upgrades[id])))
+	}
+	buf.Write(binary.AppendUvarint(nil, uint64(len(r.Difficulty))))
+	buf.WriteString(r.Difficulty)
+	buf.Write(binary.AppendUvarint(nil, uint64(len(r.Ship))))
+	buf.WriteString(r.Ship)
+	buf.Write(binary.AppendUvarint(nil, uint64(len(r.runs))))
+	for _, run := range r.runs {
+		buf.Write(binary.AppendUvarint(nil, uint64(run.state)))
+		buf.Write(binary.AppendUvarint(nil, uint64(run.ticks)))
+	}
+	buf.Write(binary.AppendUvarint(nil, uint64(len(r.picks))))
+	for _, u := range r.picks {
+		buf.Write(binary.AppendUvarint(nil, uint64(u+1)))
+	}
+	buf.Write(binary.AppendUvarint(nil, uint64(len(r.purchases))))
+	for _, s := range r.purchases {
+		buf.Write(binary.AppendUvarint(nil, uint64(s+1)))
+	}
+	buf.Write(binary.AppendUvarint(nil, uint64(len(r.checkpoints))))
+	for _, c := range r.checkpoints {
+		buf.Write(binary.AppendUvarint(nil, uint64(len(c))))
+		buf.Write(c)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a replay, rejecting other formats and versions.
+func (r *Replay) UnmarshalBinary(data []byte) error {
+	rd := bufio.NewReader(bytes.NewReader(data))
+	header := make([]byte, len(replayMagic)+1)
+	if _, err := io.ReadFull(rd, header); err != nil || string(header[:len(replayMagic)]) != replayMagic {
+		return errors.New("asteroids: not a replay file")
+	}
+	if v := header[len(replayMagic)]; v != replayVersion {
+		return fmt.Errorf("asteroids: unsupported replay version %d", v)
+	}
+
+	seed, err := binary.ReadVarint(rd)
+	if err != nil {
+		return err
+	}
+	name, err := readReplayName(rd, "mode")
+	if err != nil {
+		return err
+	}
+	score, err := binary.ReadVarint(rd)
+	if err != nil {
+		return err
+	}
+	upgrades, err := readReplayUpgrades(rd)
+	if err != nil {
+		return err
+	}
+	difficulty, err := readReplayName(rd, "difficulty")
+	if err != nil {
+		return err
+	}
+	ship, err := readReplayName(rd, "ship")
+	if err != nil {
+		return err
+	}
+	count, err := binary.ReadUvarint(rd)
+	if err != nil {
+		return err
+	}
+
+	var runs []replayRun
+	for i := uint64(0); i < count; i++ {
+		state, err := binary.ReadUvarint(rd)
+		if err != nil {
+			return err
+		}
+		ticks, err := binary.ReadUvarint(rd)
+		if err != nil {
+			return err
+		}
+		runs = append(runs, replayRun{state: uint32(state), ticks: int(ticks)})
+	}
+	picks, err := readReplayPicks(rd)
+	if err != nil {
+		return err
+	}
+	purchases, err := readReplayPurchases(rd)
+	if err != nil {
+		return err
+	}
+	checkpoints, err := readReplayCheckpoints(rd)
+	if err != nil {
+		return err
+	}
+
+	*r = Replay{Mode: name, Seed: seed, Score: int(score), Upgrades: upgrades, Difficulty: difficulty, Ship: ship, runs: runs, picks: picks, purchases: purchases, checkpoints: checkpoints}
+	return nil
+}
+
+// readReplayPicks decodes the run upgrades picked between levels.
+func readReplayPicks(rd *bufio.Reader) ([]RunUpgrade, error) {
+	count, err := binary.ReadUvarint(rd)
+	if err != nil {
+		return nil, err
+	}
+	var picks []RunUpgrade
+	for i := uint64(0); i < count; i++ {
+		u, err := binary.ReadUvarint(rd)
+		if err != nil {
+			return nil, err
+		}
+		if u > uint64(runUpgradeCount) {
+			return nil, fmt.Errorf("asteroids: corrupt replay run upgrade %d", u)
+		}
+		picks = append(picks, RunUpgrade(u)-1)
+	}
+	return picks, nil
+}
+
+// readReplayPurchases decodes the supplies bought in the salvage shop.
+func readReplayPurchases(rd *bufio.Reader) ([]Supply, error) {
+	count, err := binary.ReadUvarint(rd)
+	if err != nil {
+		return nil, err
+	}
+	var purchases []Supply
+	for i := uint64(0); i < count; i++ {
+		s, err := binary.ReadUvarint(rd)
+		if err != nil {
+			return nil, err
+		}
+		if s > uint64(supplyCount) {
+			return nil, fmt.Errorf("asteroids: corrupt replay supply %d", s)
+		}
+		purchases = append(purchases, Supply(s)-1)
+	}
+	return purchases, nil
+}
+
+// readReplayCheckpoints decodes the encoded checkpoints ending a replay.
+// Their contents are decoded during playback.
+func readReplayCheckpoints(rd *bufio.Reader) ([][]byte, error) {
+	count, err := binary.ReadUvarint(rd)
+	if err != nil {
+		return nil, err
+	}
+	var checkpoints [][]byte
+	for i := uint64(0); i < count; i++ {
+		n, err := binary.ReadUvarint(rd)
+		if err != nil {
+			return nil, err
+		}
+		if n > replayCheckpointLimit {
+			return nil, errors.New("asteroids: corrupt replay checkpoint")
+		}
+		c := make([]byte, n)
+		if _, err := io.ReadFull(rd, c); err != nil {
+			return nil, err
+		}
+		checkpoints = append(checkpoints, c)
+	}
+	return checkpoints, nil
+}
+
+// readReplayName decodes a length-prefixed name from a replay header; what
+// names the field for errors.
+func readReplayName(rd *bufio.Reader, what string) (string, error) {
+	n, err := binary.ReadUvarint(rd)
+	if err != nil {
+		return "", err
+	}
+	if n > 64 {
+		return "", fmt.Errorf("asteroids: corrupt replay %s name", what)
+	}
+	name := make([]byte, n)
+	if _, err := io.ReadFull(rd, name); err != nil {
+		return "", err
+	}
+	return string(name), nil
+}
+
+// readReplayUpgrades decodes the upgrade levels of a replay header.
+func readReplayUpgrades(rd *bufio.Reader) (Upgrades, error) {
+	count, err := binary.ReadUvarint(rd)
+	if err != nil {
+		return nil, err
+	}
+	if count > uint64(len(upgradeCatalog)) {
+		return nil, errors.New("asteroids: corrupt replay upgrades")
+	}
+	upgrades := Upgrades{}
+	for i := uint64(0); i < count; i++ {
+		idLen, err := binary.ReadUvarint(rd)
+		if err != nil {
+			return nil, err
+		}
+		if idLen > 64 {
+			return nil, errors.New("asteroids: corrupt replay upgrade ID")
+		}
+		id := make([]byte, idLen)
+		if _, err := io.ReadFull(rd, id); err != nil {
+			return nil, err
+		}
+		level, err := binary.ReadUvarint(rd)
+		if err != nil {
+			return nil, err
+		}
+		upgrades[string(id)] = int(level)
+	}
+	return upgrades, nil
+}
+
+// loadReplay reads a replay file.
+func loadReplay(path string) (*Replay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &Replay{}
+	if err := r.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// save writes the replay to name in the save directory.
+func (r *Replay) save(name string) error {
+	data, err := r.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return saves.Write(name, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// replayPlayer feeds a replay's ticks back as input.
+type replayPlayer struct {
+	replay     *Replay      // Replay being played.
+	run        int          // Index of the current run.
+	tick       int          // Ticks already played from the current run.
+	input      *Input       // Input rebuilt for the current tick.
+	played     int          // Ticks played so far.
+	checkpoint int          // Index of the next checkpoint to compare.
+	expected   sim.Snapshot // Latest checkpoint, the base for the next delta.
+	divergedAt int          // Tick at which play first strayed from a checkpoint; -1 while it matches.
+	pick       int          // Index of the next run upgrade pick.
+	purchase   int          // Index of the next salvage shop purchase.
+	background bool         // Playing behind the title menu, which keeps the keyboard.
+}
+
+// newReplayPlayer returns a player positioned at the replay's first tick.
+func newReplayPlayer(r *Replay) *replayPlayer {
+	return &replayPlayer{replay: r, input: &Input{}, divergedAt: -1}
+}
+
+// verify compares g with the replay's checkpoint for the tick about to be
+// played, if there is one, and notes the first tick they disagree.
+func (p *replayPlayer) verify(g *GameScene) {
+	if p.divergedAt >= 0 || p.checkpoint >= len(p.replay.checkpoints) || p.played%sim.Ticks(replayCheckpointInterval) != 0 {
+		return
+	}
+	data := p.replay.checkpoints[p.checkpoint]
+	var err error
+	if p.checkpoint == 0 {
+		err = p.expected.UnmarshalBinary(data)
+	} else {
+		var d sim.Delta
+		if err = d.UnmarshalBinary(data); err == nil {
+			p.expected, err = d.Apply(p.expected)
+		}
+	}
+	p.checkpoint++
+	if err != nil {
+		log.Println("Error reading replay checkpoint", err)
+		p.checkpoint = len(p.replay.checkpoints) // Later deltas have no base.
+		return
+	}
+	if d := sim.Diff(p.expected, g.snapshot(p.played)); !d.IsEmpty() {
+		p.divergedAt = p.played
+		log.Printf("Replay diverged at tick %d: %d created, %d changed, %d destroyed", p.played, len(d.Created), len(d.Updated), len(d.Destroyed))
+	}
+}
+
+// nextPick returns the next run upgrade picked between levels. ok is false
+// once every recorded pick has been taken.
+func (p *replayPlayer) nextPick() (u RunUpgrade, ok bool) {
+	if p.pick >= len(p.replay.picks) {
+		return 0, false
+	}
+	p.pick++
+	return p.replay.picks[p.pick-1], true
+}
+
+// nextPurchase returns the next supply bought in the salvage shop, or
+// noSupply where a visit ended. ok is false once every recorded purchase
+// has been made.
+func (p *replayPlayer) nextPurchase() (s Supply, ok bool) {
+	if p.purchase >= len(p.replay.purchases) {
+		return 0, false
+	}
+	p.purchase++
+	return p.replay.purchases[p.purchase-1], true
+}
+
+// next advances one tick and returns its input and rules. ok is false once
+// every recorded tick has been played.
+func (p *replayPlayer) next() (input *Input, rules tickRules, ok bool) {
+	runs := p.replay.runs
+	for p.run < len(runs) && p.tick >= runs[p.run].ticks {
+		p.run++
+		p.tick = 0
+	}
+	if p.run >= len(runs) {
+		return nil, tickRules{}, false
+	}
+	p.tick++
+	p.played++
+
+	state := runs[p.run].state
+	for a := Action(0); a < actionCount; a++ {
+		p.input.pressed[a] = state&(1<<a) != 0
+		p.input.previous[a] = state&(1<<(tickPreviousShift+a)) != 0
+	}
+	rules = tickRules{
+		meteorCollisions: state&tickMeteorCollisions != 0,
+		assists: Assists{
+			ToggleFire:      state&tickToggleFire != 0,
+			AutoFire:        state&tickAutoFire != 0,
+			ToggleThrust:    state&tickToggleThrust != 0,
+			AimAssist:       int(state>>tickAimAssistShift) & 3,
+			InertiaDampener: state&tickInertiaDampener != 0,
+		},
+	}
+	return p.input, rules, true
+}
+
+// modeNamed returns the built-in mode called name.
+func modeNamed(name string) (Mode, bool) {
+	for _, m := range []Mode{ModeStandard, ModeClassic, ModeModern, ModeNewGamePlus, ModeArena, ModeWalls} {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return Mode{}, false
+}
+
+// NewReplayScene returns a GameScene that re-simulates r.
+func NewReplayScene(r *Replay) (*GameScene, error) {
+	mode, ok := modeNamed(r.Mode)
+	if !ok {
+		return nil, fmt.Errorf("asteroids: replay of unknown mode %q", r.Mode)
+	}
+	g := newGameScene(mode, r.Seed, r.Upgrades, difficultyNamed(r.Difficulty), shipClassNamed(r.Ship))
+	g.replay = nil
+	g.playback = newReplayPlayer(r)
+	return g, nil
+}
+
+// saveReplay stores the finished run as the last replay and, if it is the
+// best score so far, as the best replay too.
+func (g *GameScene) saveReplay() {
+	if g.replay == nil {
+		return
+	}
+	g.replay.Score = g.score
+	if err := g.replay.save(replayLastFile); err != nil {
+		log.Println("Error saving replay", err)
+	}
+	if g.score > 0 && g.score >= g.highScoreTable().best() {
+		if err := g.replay.save(replayBestFile); err != nil {
+			log.Println("Error saving replay", err)
+		}
+	}
+}
+
+// replayFilePath resolves the -replay argument: an existing path is used
+// as is, and "last" or "best" name the replays in the save directory.
+func replayFilePath(arg string) string {
+	switch arg {
+	case "last":
+		return saves.Path(replayLastFile)
+	case "best":
+		return saves.Path(replayBestFile)
+	}
+	return filepath.Clean(arg)
+}
@@ -0,0 +1,140 @@
+// File rng.go defines how runs get their random number generators. Each
+// GameScene owns an RNG of named streams derived from the run seed, one per
+// subsystem (spawns, alien AI, the ship, cosmetics), so two runs started
+// from the same seed see the same meteors and aliens in the same places,
+// and a subsystem that rolls more or less often cannot shift the others.
+package asteroids
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// RNG stream names.
+const (
+	streamSpawns    = "spawns"    // What enters the field and where: meteors and their splits, aliens, formations, comets, the boss, and drops. Sprites size colliders, so their choice is rolled here too.
+	streamAI        = "ai"        // Alien decisions: where to shoot, what with, and whether to set off a mine.
+	streamShip      = "ship"      // The ship's systems: hyperspace landings and malfunctions.
+	streamCosmetics = "cosmetics" // Looks only: the starfield, sparks, comet tails, and hyperspace motes.
+)
+
+// RNG is a run's set of named random streams. Each stream is seeded from
+// the run seed and its name alone.
+type RNG struct {
+	seed    int64                      // Run seed.
+	streams map[string]*countingSource // Streams made so far, by name.
+	rands   map[string]*rand.Rand      // Generators over those streams.
+}
+
+// newRunRNG returns the streams for seed, each fast-forwarded past the
+// number of values draws records for it.
+func newRunRNG(seed int64, draws map[string]int64) *RNG {
+	r := &RNG{seed: seed, streams: map[string]*countingSource{}, rands: map[string]*rand.Rand{}}
+	for name, n := range draws {
+		src := r.source(name)
+		for range n {
+			src.Int63()
+		}
+	}
+	return r
+}
+
+// Stream returns the generator called name, making it on first use.
+func (r *RNG) Stream(name string) *rand.Rand {
+	if rnd, ok := r.rands[name]; ok {
+		return rnd
+	}
+	rnd := rand.New(r.source(name))
+	r.rands[name] = rnd
+	return rnd
+}
+
+// source returns the counting source of the stream called name, making it
+// on first use.
+func (r *RNG) source(name string) *countingSource {
+	if src, ok := r.streams[name]; ok {
+		return src
+	}
+	src := &countingSource{src: rand.NewSource(streamSeed(r.seed, name)).(rand.Source64)}
+	r.streams[name] = src
+	return src
+}
+
+// draws returns how many values each stream has drawn, for suspending.
+func (r *RNG) draws() map[string]int64 {
+	counts := make(map[string]int64, len(r.streams))
+	for name, src := range r.streams {
+		counts[name] = src.draws
+	}
+	return counts
+}
+
+// streamSeed derives the seed of the stream called name from the run seed.
+func streamSeed(seed int64, name string) int64 {
+	h := fnv.New64a()
+	_ = binary.Write(h, binary.LittleEndian, seed)
+	_, _ = h.Write([]byte(name))
+	return int64(h.Sum64())
+}
+
+// Seed fixes the seed of every run when non-zero. It is set before the game
+// starts, from the -seed flag or a challenge code.
+var Seed int64
+
+// ambientRNG drives cosmetic randomness outside any run: menu backdrops and
+// the decorative meteors that drift behind them. In a run, the cosmetics
+// stream plays this part.
+var ambientRNG = newRNG(time.Now().UnixNano())
+
+// newRNG returns a generator seeded with seed.
+func newRNG(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
+// countingSource is a rand.Source64 that counts the values drawn from it.
+// A suspended run saves the counts so its streams can be rebuilt at the
+// same point in their sequences: every rand.Rand method consumes whole
+// draws from its source, and Int63 and Uint64 each advance it by one step.
+type countingSource struct {
+	src   rand.Source64 // Underlying generator.
+	draws int64         // Values drawn since seeding.
+}
+
+// Int63 draws a non-negative 63-bit value.
+func (s *countingSource) Int63() int64 {
+	s.draws++
+	return s.src.Int63()
+}
+
+// Uint64 draws a 64-bit value.
+func (s *countingSource) Uint64() uint64 {
+	s.draws++
+	return s.src.Uint64()
+}
+
+// Seed reseeds the generator and restarts the count.
+func (s *countingSource) Seed(seed int64) {
+	s.draws = 0
+	s.src.Seed(seed)
+}
+
+// runSeed returns the seed for a new run: Seed when one is fixed, otherwise
+// a fresh time-based seed.
+func runSeed() int64 {
+	if Seed != 0 {
+		return Seed
+	}
+	return time.Now().UnixNano()
+}
+
+// SeedFromCode turns a challenge code into a run seed. Codes are compared
+// case-insensitively and without surrounding spaces, so "ABC" and " abc "
+// start the same run.
+func SeedFromCode(code string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(strings.ToUpper(strings.TrimSpace(code))))
+	return int64(h.Sum64())
+}
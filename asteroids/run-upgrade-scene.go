@@ -0,0 +1,80 @@
+// File run-upgrade-scene.go implements the RunUpgradeScene, shown after
+// the level banner in modes with LevelUpgrades, where the player may spend
+// scrap on one run upgrade before play resumes.
+package asteroids
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// RunUpgradeScene lists the run upgrades the ship can still take with
+// their prices.
+type RunUpgradeScene struct {
+	game    *GameScene   // The gameplay scene to resume.
+	choices []RunUpgrade // Upgrades on offer, in menu order.
+	stars   []*Star      // Backdrop starfield.
+	menu    *Menu        // One row per choice, then Skip.
+	status  string       // Why the last choice was refused, shown under the menu.
+}
+
+// NewRunUpgradeScene returns the pick for the level g is about to start.
+func NewRunUpgradeScene(g *GameScene) *RunUpgradeScene {
+	s := &RunUpgradeScene{
+		game:    g,
+		choices: g.player.runUpgradeChoices(),
+		stars:   GenerateStars(starCount(), ambientRNG),
+		menu:    NewMenu(),
+	}
+	for _, u := range s.choices {
+		s.menu.items = append(s.menu.items, fmt.Sprintf("%s  %d/%d  %d scrap", runUpgradeInfo[u].name, g.player.runUpgrades[u], runUpgradeMaxLevel, g.runUpgradePrice(u)))
+	}
+	s.menu.items = append(s.menu.items, "Skip")
+	return s
+}
+
+// Update handles menu input.
+//
+// Upgrade: buy it, if the scrap covers it, and resume play.
+// Skip:    resume play, keeping the scrap.
+func (s *RunUpgradeScene) Update(state *State) error {
+	choice := s.menu.Update()
+	switch {
+	case choice == len(s.choices):
+		s.game.takeRunUpgrade(noRunUpgrade)
+		state.SceneManager.GoToScene(s.game)
+	case choice >= 0:
+		u := s.choices[choice]
+		if price := s.game.runUpgradePrice(u); s.game.scrap < price {
+			s.status = fmt.Sprintf("%s needs %d scrap", runUpgradeInfo[u].name, price)
+			return nil
+		}
+		s.game.takeRunUpgrade(u)
+		state.SceneManager.GoToScene(s.game)
+	}
+	return nil
+}
+
+// Draw renders the scrap on hand, the choices, and the effect of the
+// selected one.
+func (s *RunUpgradeScene) Draw(screen *ebiten.Image) {
+	for _, star := range s.stars {
+		star.Draw(screen)
+	}
+	gray := color.Gray{Y: 180}
+	drawCenteredText(screen, "CHOOSE AN UPGRADE", assets.TitleFont, 48, ScreenWidth/2, 120, color.White)
+	drawCenteredText(screen, fmt.Sprintf("Scrap: %d", s.game.scrap), assets.ScoreFont, 20, ScreenWidth/2, 200, scrapColor)
+
+	s.menu.Draw(screen, ScreenWidth/2, 280)
+	if s.menu.selected < len(s.choices) {
+		drawCenteredText(screen, runUpgradeInfo[s.choices[s.menu.selected]].effect, assets.ScoreFont, 16, ScreenWidth/2, 520, gray)
+	}
+	if s.status != "" {
+		drawCenteredText(screen, s.status, assets.ScoreFont, 14, ScreenWidth/2, 560, gray)
+	}
+	drawCenteredText(screen, fmt.Sprintf("Upgrades last for the rest of this run, from level %d", s.game.currentLevel),
+		assets.ScoreFont, 14, ScreenWidth/2, ScreenHeight-60, gray)
+}
@@ -0,0 +1,122 @@
+// File run-upgrades.go defines the upgrades offered between levels: faster
+// fire, an extra shield, tighter turning, and a larger burst. Unlike the
+// shop's upgrades they last only for the run and are bought with the run's
+// scrap, each level dearer than the last. The ship keeps how many
+// levels of each it has taken, and firing, turning, and the shield charges
+// read them where they apply. Each pick, or passing one up, is recorded in
+// the run's replay so playback takes the same ones.
+package asteroids
+
+import (
+	"math"
+	"time"
+)
+
+// RunUpgrade is one of the upgrades offered between levels.
+type RunUpgrade int
+
+// Run upgrades, in the order the pick screen lists them.
+const (
+	runUpgradeFireRate RunUpgrade = iota
+	runUpgradeShield
+	runUpgradeTurning
+	runUpgradeBurst
+	runUpgradeCount // Number of run upgrades; keep last.
+
+	noRunUpgrade RunUpgrade = -1 // A pick passed up.
+)
+
+// Run upgrade tuning.
+const (
+	runUpgradeMaxLevel = 3   // Times one upgrade can be taken in a run.
+	runUpgradeCost     = 10  // Scrap price of an upgrade's first level; each level after costs that much more.
+	fireRateStep       = 0.8 // Multiplier on the shot and burst cooldowns per level.
+	turningStep        = 0.2 // Share of the class's turn rate added per level.
+)
+
+// runUpgradeInfo names each upgrade and what a level of it does, for the
+// pick screen.
+var runUpgradeInfo = [runUpgradeCount]struct {
+	name, effect string
+}{
+	runUpgradeFireRate: {"Faster Fire", "-20% between shots and bursts"},
+	runUpgradeShield:   {"Extra Shield", "+1 shield charge"},
+	runUpgradeTurning:  {"Tighter Turning", "+20% turn rate"},
+	runUpgradeBurst:    {"Larger Burst", "+1 laser per burst"},
+}
+
+// offersRunUpgrade reports whether the level about to start opens with an
+// upgrade pick: in modes with LevelUpgrades, before every numbered level,
+// while the run's scrap can pay for an upgrade the ship can still take.
+func (g *GameScene) offersRunUpgrade() bool {
+	if !g.mode.LevelUpgrades || g.isBonusRound() {
+		return false
+	}
+	for _, u := range g.player.runUpgradeChoices() {
+		if g.scrap >= g.runUpgradePrice(u) {
+			return true
+		}
+	}
+	return false
+}
+
+// runUpgradePrice returns the scrap the next level of u costs.
+func (g *GameScene) runUpgradePrice(u RunUpgrade) int {
+	return runUpgradeCost * (g.player.runUpgrades[u] + 1)
+}
+
+// runUpgradeChoices returns the upgrades the ship can still take, in order.
+// Energy handling has no shield charges to add to.
+func (p *Player) runUpgradeChoices() []RunUpgrade {
+	var choices []RunUpgrade
+	for u := RunUpgrade(0); u < runUpgradeCount; u++ {
+		if p.runUpgrades[u] < runUpgradeMaxLevel && (u != runUpgradeShield || p.energy == nil) {
+			choices = append(choices, u)
+		}
+	}
+	return choices
+}
+
+// takeRunUpgrade pays for a level of u and gives it to the ship, with the
+// extra shield charge already filled, and records the pick in the run's
+// replay. noRunUpgrade passes the pick up.
+func (g *GameScene) takeRunUpgrade(u RunUpgrade) {
+	if g.replay != nil {
+		g.replay.picks = append(g.replay.picks, u)
+	}
+	if u == noRunUpgrade {
+		return
+	}
+	p := g.player
+	g.scrap -= g.runUpgradePrice(u)
+	p.runUpgrades[u]++
+	if u == runUpgradeShield {
+		p.shieldsRemaning++
+	}
+}
+
+// fireRateFactor returns the multiplier Faster Fire puts on the ship's
+// cooldowns.
+func (p *Player) fireRateFactor() float64 {
+	return math.Pow(fireRateStep, float64(p.runUpgrades[runUpgradeFireRate]))
+}
+
+// shotInterval returns the wait between shots in a burst.
+func (p *Player) shotInterval() time.Duration {
+	return time.Duration(float64(shootCoolDown) * p.fireRateFactor())
+}
+
+// burstInterval returns the wait before a new burst.
+func (p *Player) burstInterval() time.Duration {
+	return time.Duration(float64(burstCoolDown) * p.fireRateFactor())
+}
+
+// turnRate returns how fast the ship turns, in radians a second.
+func (p *Player) turnRate() float64 {
+	return p.class.TurnRate * (1 + turningStep*float64(p.runUpgrades[runUpgradeTurning]))
+}
+
+// burstSize returns how many lasers a burst fires.
+func (p *Player) burstSize() int {
+	return p.class.BurstSize + p.runUpgrades[runUpgradeBurst]
+}
@@ -0,0 +1,113 @@
+// File salvage-shop-scene.go implements the SalvageShopScene, shown after
+// the level banner every few levels in modes with LevelUpgrades, where the
+// player may spend scrap on supplies before play resumes. Any number of
+// supplies can be bought; Continue moves on.
+package asteroids
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+	inpututil "github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// SalvageShopScene lists the supplies with their prices and what the ship
+// already carries.
+type SalvageShopScene struct {
+	game   *GameScene // The gameplay scene to resume.
+	stars  []*Star    // Backdrop starfield.
+	menu   *Menu      // One row per supply, then Continue.
+	status string     // Result of the last purchase, shown under the menu.
+}
+
+// NewSalvageShopScene returns the shop for the level g is about to start.
+func NewSalvageShopScene(g *GameScene) *SalvageShopScene {
+	s := &SalvageShopScene{
+		game:  g,
+		stars: GenerateStars(starCount(), ambientRNG),
+		menu:  NewMenu(),
+	}
+	s.refresh()
+	return s
+}
+
+// refresh rebuilds the menu rows from the ship's current stock.
+func (s *SalvageShopScene) refresh() {
+	g := s.game
+	stock := [supplyCount]string{
+		supplySmartBomb:    fmt.Sprintf("%d/%d", g.spareSmartBombs, maxSpareSmartBombs),
+		supplyShieldCharge: fmt.Sprintf("%d/%d", g.player.shieldsRemaning, g.player.maxShields()),
+		supplyExtraLife:    fmt.Sprintf("%d/%d", g.player.livesRemaning, maxLives),
+	}
+	if g.player.energy != nil {
+		stock[supplyShieldCharge] = "-"
+	}
+	items := make([]string, 0, supplyCount+1)
+	for i := Supply(0); i < supplyCount; i++ {
+		items = append(items, fmt.Sprintf("%s  %s  %d scrap", supplyInfo[i].name, stock[i], supplyInfo[i].price))
+	}
+	s.menu.items = append(items, "Continue")
+}
+
+// Update handles menu input.
+//
+// Supply:          buy one, if the ship has room and the scrap covers it.
+// Continue/Escape: leave the shop.
+func (s *SalvageShopScene) Update(state *State) error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) || gamepadJustPressed(ebiten.StandardGamepadButtonRightRight) {
+		s.leave(state)
+		return nil
+	}
+
+	choice := s.menu.Update()
+	switch {
+	case choice == int(supplyCount):
+		s.leave(state)
+	case choice >= 0:
+		g, supply := s.game, Supply(choice)
+		info := supplyInfo[supply]
+		switch {
+		case !g.canStock(supply):
+			s.status = fmt.Sprintf("No room for another %s", info.name)
+			sharedAudio().PlaySFX(sfxRefused)
+		case g.scrap < info.price:
+			s.status = fmt.Sprintf("%s needs %d scrap", info.name, info.price)
+			sharedAudio().PlaySFX(sfxRefused)
+		default:
+			g.buySupply(supply)
+			s.status = "Bought " + info.name
+			sharedAudio().PlaySFX(sfxPurchase)
+		}
+		s.refresh()
+	}
+	return nil
+}
+
+// leave records leaving the shop and moves on toward play.
+func (s *SalvageShopScene) leave(state *State) {
+	s.game.buySupply(noSupply)
+	state.SceneManager.GoToScene(s.game.afterSalvageShop())
+}
+
+// Draw renders the scrap on hand, the supply rows, and the effect of the
+// selected one.
+func (s *SalvageShopScene) Draw(screen *ebiten.Image) {
+	for _, star := range s.stars {
+		star.Draw(screen)
+	}
+	gray := color.Gray{Y: 180}
+	drawCenteredText(screen, "SALVAGE SHOP", assets.TitleFont, 48, ScreenWidth/2, 120, color.White)
+	drawCenteredText(screen, fmt.Sprintf("Scrap: %d", s.game.scrap), assets.ScoreFont, 20, ScreenWidth/2, 200, scrapColor)
+
+	s.menu.Draw(screen, ScreenWidth/2, 280)
+	if s.menu.selected < int(supplyCount) {
+		drawCenteredText(screen, supplyInfo[s.menu.selected].effect, assets.ScoreFont, 16, ScreenWidth/2, 480, gray)
+	}
+	if s.status != "" {
+		drawCenteredText(screen, s.status, assets.ScoreFont, 14, ScreenWidth/2, 520, gray)
+	}
+	drawCenteredText(screen, fmt.Sprintf("The shop opens every %d levels", salvageShopInterval),
+		assets.ScoreFont, 14, ScreenWidth/2, ScreenHeight-60, gray)
+}
@@ -0,0 +1,75 @@
+// File save-manager.go defines SaveManager, the one place that knows where
+// persisted data lives: the config file, high scores, the profile, stats,
+// replays, and the suspended run all read and write through it. Where the
+// bytes actually go is a SaveStore: files in the user's config directory
+// on desktop builds (save-store-file.go), and the browser's localStorage
+// under js/wasm (save-store-js.go).
+package asteroids
+
+import (
+	"bytes"
+	"io"
+)
+
+// SaveStore holds the named files a SaveManager reads and writes.
+type SaveStore interface {
+	// Read returns the contents of name. A missing file reports an error
+	// satisfying errors.Is(err, fs.ErrNotExist).
+	Read(name string) ([]byte, error)
+
+	// Exists reports whether name has been saved.
+	Exists(name string) bool
+
+	// Write replaces name with data.
+	Write(name string, data []byte) error
+
+	// Remove deletes name. A file that does not exist is not an error.
+	Remove(name string) error
+
+	// Path returns where name is kept, for display.
+	Path(name string) string
+}
+
+// SaveManager reads and writes named files in the platform's SaveStore.
+type SaveManager struct {
+	store SaveStore // Where the files are kept.
+}
+
+// saves is the save directory every persisted file goes through.
+var saves = NewSaveManager()
+
+// NewSaveManager returns a SaveManager over the platform's store.
+func NewSaveManager() *SaveManager {
+	return &SaveManager{store: newSaveStore()}
+}
+
+// Path returns where name is written, for display.
+func (s *SaveManager) Path(name string) string {
+	return s.store.Path(name)
+}
+
+// Read returns the contents of name. A file missing everywhere reports
+// an error satisfying errors.Is(err, fs.ErrNotExist).
+func (s *SaveManager) Read(name string) ([]byte, error) {
+	return s.store.Read(name)
+}
+
+// Exists reports whether name has been saved.
+func (s *SaveManager) Exists(name string) bool {
+	return s.store.Exists(name)
+}
+
+// Write fills name with what write produces. Nothing is saved if write
+// fails, so a failed save leaves the previous contents in place.
+func (s *SaveManager) Write(name string, write func(io.Writer) error) error {
+	var buf bytes.Buffer
+	if err := write(&buf); err != nil {
+		return err
+	}
+	return s.store.Write(name, buf.Bytes())
+}
+
+// Remove deletes name. A file that does not exist is not an error.
+func (s *SaveManager) Remove(name string) error {
+	return s.store.Remove(name)
+}
@@ -0,0 +1,148 @@
+//go:build !js
+
+// File save-store-file.go defines the desktop SaveStore: files in an
+// Asteroids directory under the user's config directory. Files an older
+// version kept in its hand-built per-OS directory are still found there
+// until they are next saved.
+package asteroids
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+)
+
+// Save directory names.
+const (
+	saveDirName     = "Asteroids"  // Directory inside the user config directory.
+	homeSaveDirName = ".asteroids" // Directory inside the home directory when there is no config directory.
+)
+
+// FileStore keeps saved files in a directory.
+type FileStore struct {
+	dir    string // Save directory; empty if none could be resolved.
+	legacy string // Directory older versions saved to; empty if unknown.
+	err    error  // Why dir could not be resolved.
+}
+
+// newSaveStore returns the desktop store.
+func newSaveStore() SaveStore {
+	return NewFileStore()
+}
+
+// NewFileStore resolves the save directory: Asteroids in the user config
+// directory (~/.config on Linux, ~/Library/Application Support on macOS,
+// %AppData% on Windows), or .asteroids in the home directory if the
+// platform has no config directory.
+func NewFileStore() *FileStore {
+	s := &FileStore{legacy: legacySaveDir()}
+	if dir, err := os.UserConfigDir(); err == nil {
+		s.dir = filepath.Join(dir, saveDirName)
+		return s
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		s.err = fmt.Errorf("asteroids: no save directory: %w", err)
+		return s
+	}
+	s.dir = filepath.Join(home, homeSaveDirName)
+	return s
+}
+
+// legacySaveDir returns the directory older versions built by hand from the
+// user name, or "" if the user cannot be looked up. Nothing is written
+// there any more.
+func legacySaveDir() string {
+	u, err := user.Current()
+	if err != nil {
+		return ""
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		return filepath.Join("/Users", u.Username, "Library", "Application Support", "Asteroids")
+	case "windows":
+		return filepath.Join(`C:\Users`, u.Username, "AppData")
+	case "linux":
+		return filepath.Join("/users", u.Username)
+	default:
+		return filepath.Join("/home", u.Username, homeSaveDirName)
+	}
+}
+
+// Path returns where name is written. An unresolvable save directory
+// yields name alone.
+func (s *FileStore) Path(name string) string {
+	if s.err != nil {
+		return name
+	}
+	return filepath.Join(s.dir, name)
+}
+
+// locate returns the path name is read from: the save directory, or the
+// legacy directory if only that has it.
+func (s *FileStore) locate(name string) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	path := filepath.Join(s.dir, name)
+	if s.legacy == "" || s.legacy == s.dir || fileExists(path) {
+		return path, nil
+	}
+	if legacy := filepath.Join(s.legacy, name); fileExists(legacy) {
+		return legacy, nil
+	}
+	return path, nil
+}
+
+// Read returns the contents of name.
+func (s *FileStore) Read(name string) ([]byte, error) {
+	path, err := s.locate(name)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
+
+// Exists reports whether name has been saved.
+func (s *FileStore) Exists(name string) bool {
+	path, err := s.locate(name)
+	return err == nil && fileExists(path)
+}
+
+// Write creates (or truncates) name in the save directory, creating the
+// directory if needed.
+func (s *FileStore) Write(name string, data []byte) error {
+	if s.err != nil {
+		return s.err
+	}
+	if err := os.MkdirAll(s.dir, 0750); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.dir, name), data, 0666)
+}
+
+// Remove deletes name, including any copy left in the legacy directory.
+func (s *FileStore) Remove(name string) error {
+	if s.err != nil {
+		return s.err
+	}
+	for _, dir := range []string{s.dir, s.legacy} {
+		if dir == "" {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// fileExists reports whether path names an existing file.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
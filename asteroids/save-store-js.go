@@ -0,0 +1,76 @@
+//go:build js
+
+// File save-store-js.go defines the browser SaveStore: each file is one
+// localStorage item, base64-encoded since replays and the suspended run
+// are binary and localStorage holds only strings. Storage the page cannot
+// reach (disabled, or full) fails saves the way an unwritable directory
+// does on desktop.
+package asteroids
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/fs"
+	"syscall/js"
+)
+
+// localStorageKeyPrefix namespaces the game's items among the page's.
+const localStorageKeyPrefix = "asteroids/"
+
+// LocalStorageStore keeps saved files in the browser's localStorage.
+type LocalStorageStore struct{}
+
+// newSaveStore returns the browser store.
+func newSaveStore() SaveStore {
+	return LocalStorageStore{}
+}
+
+// call invokes method on window.localStorage, turning the exceptions the
+// browser throws when storage is unavailable or full into errors.
+func (LocalStorageStore) call(method string, args ...any) (v js.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("asteroids: localStorage %s: %v", method, r)
+		}
+	}()
+	storage := js.Global().Get("localStorage")
+	if storage.IsUndefined() || storage.IsNull() {
+		return js.Value{}, fmt.Errorf("asteroids: no localStorage")
+	}
+	return storage.Call(method, args...), nil
+}
+
+// Path returns the localStorage key name is kept under.
+func (LocalStorageStore) Path(name string) string {
+	return "localStorage:" + localStorageKeyPrefix + name
+}
+
+// Read returns the contents of name.
+func (s LocalStorageStore) Read(name string) ([]byte, error) {
+	v, err := s.call("getItem", localStorageKeyPrefix+name)
+	if err != nil {
+		return nil, err
+	}
+	if v.IsNull() {
+		return nil, &fs.PathError{Op: "read", Path: s.Path(name), Err: fs.ErrNotExist}
+	}
+	return base64.StdEncoding.DecodeString(v.String())
+}
+
+// Exists reports whether name has been saved.
+func (s LocalStorageStore) Exists(name string) bool {
+	v, err := s.call("getItem", localStorageKeyPrefix+name)
+	return err == nil && !v.IsNull()
+}
+
+// Write replaces name with data.
+func (s LocalStorageStore) Write(name string, data []byte) error {
+	_, err := s.call("setItem", localStorageKeyPrefix+name, base64.StdEncoding.EncodeToString(data))
+	return err
+}
+
+// Remove deletes name.
+func (s LocalStorageStore) Remove(name string) error {
+	_, err := s.call("removeItem", localStorageKeyPrefix+name)
+	return err
+}
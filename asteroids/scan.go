@@ -0,0 +1,138 @@
+// File scan.go implements meteor scanning: keeping the ship's aim on a
+// meteor for a moment reveals its type, hit points, and point value in a
+// tooltip beside it.
+package asteroids
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/hajimehoshi/ebiten/v2"
+	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/solarlune/resolv"
+)
+
+// Scan tuning.
+const (
+	scanTime      = 1 * time.Second // Aim time needed to reveal a meteor.
+	scanRange     = 600.0           // Farthest a meteor can be scanned from.
+	scanHalfAngle = math.Pi / 36    // Half-width of the aim cone (5°).
+	scanRays      = 3               // Rays cast across the aim cone.
+	scanFontSize  = 12.0            // Tooltip text size.
+)
+
+// meteorProfile is what a completed scan reveals about a meteor.
+type meteorProfile struct {
+	Kind   string // Display name of the subtype.
+	HP     int    // Laser hits still needed to destroy it.
+	Points int    // Score for destroying it.
+}
+
+// profileOf describes a meteor for the scan tooltip.
+func (g *GameScene) profileOf(m *Meteor) meteorProfile {
+	switch {
+	case m.gold:
+		return meteorProfile{Kind: "Gold", HP: 1, Points: goldRushPoints * (g.goldChain + 1)}
+	case m.meteorObj.Tags().Has(TagSmall):
+		return meteorProfile{Kind: "Small", HP: 1, Points: meteorPointsSmall}
+	case m.meteorObj.Tags().Has(TagMedium):
+		return meteorProfile{Kind: "Medium", HP: 1, Points: meteorPointsMedium}
+	default:
+		return meteorProfile{Kind: "Large", HP: max(m.health, 1), Points: meteorPointsLarge}
+	}
+}
+
+// Scanner tracks the meteor under the ship's aim and how long it has stayed there.
+type Scanner struct {
+	target *Meteor // Meteor currently in the aim cone; nil if none.
+	timer  *Timer  // Aim time on target; ready once revealed.
+}
+
+// NewScanner returns an idle scanner.
+func NewScanner() *Scanner {
+	return &Scanner{timer: sim.NewTimer(scanTime)}
+}
+
+// updateScanner finds the meteor in the aim cone and advances the scan,
+// restarting it whenever the aim moves to a different meteor.
+func (g *GameScene) updateScanner() {
+	s := g.scanner
+	target := g.meteorInAim()
+	if target != s.target {
+		s.target = target
+		s.timer.Reset()
+	}
+	if s.target != nil {
+		s.timer.Update()
+	}
+}
+
+// meteorInAim returns the nearest intact meteor that a narrow fan of rays
+// from the ship's nose hits, or nil if the cone is clear.
+func (g *GameScene) meteorInAim() *Meteor {
+	if g.player.isDying {
+		return nil
+	}
+	origin := spriteCenter(g.player.position, g.player.sprite)
+
+	var nearest *Meteor
+	nearestDistance := math.Inf(1)
+	for i := 0; i < scanRays; i++ {
+		angle := g.player.rotation - scanHalfAngle + 2*scanHalfAngle*float64(i)/float64(scanRays-1)
+		hit, ok := g.Raycast(origin, shipHeading(angle), scanRange, TagMeteor)
+		if !ok || hit.Distance >= nearestDistance {
+			continue
+		}
+		// Debris blocks the ray but has nothing left to reveal.
+		if m := g.meteorForShape(hit.Shape); m != nil && !g.isExploding(m) {
+			nearest, nearestDistance = m, hit.Distance
+		}
+	}
+	return nearest
+}
+
+// meteorForShape maps a meteor collider back to its Meteor.
+func (g *GameScene) meteorForShape(shape resolv.IShape) *Meteor {
+	data, ok := shape.Data().(*ObjectData)
+	if !ok {
+		return nil
+	}
+	return g.meteors[data.index]
+}
+
+// drawScanTooltip renders the revealed profile beside the scanned meteor,
+// or a small progress arc while the scan is still running.
+func (g *GameScene) drawScanTooltip(screen *ebiten.Image) {
+	s := g.scanner
+	if s.target == nil || g.isExploding(s.target) {
+		return
+	}
+	center := spriteCenter(s.target.position, s.target.sprite)
+	radius := float64(s.target.sprite.Bounds().Dx()) / 2
+	hud := currentPalette().HUD
+
+	if !s.timer.IsReady() {
+		progress := float64(s.timer.Elapsed) / float64(s.timer.Target)
+		drawArcGauge(screen, float32(center.X), float32(center.Y), float32(radius+6), 1, progress, hud, nil)
+		return
+	}
+
+	p := g.profileOf(s.target)
+	label := fmt.Sprintf("%s  HP %d  %d PTS", p.Kind, p.HP, p.Points)
+	face := &text.GoTextFace{Source: assets.ScoreFont, Size: scanFontSize * hudScale()}
+	w, h := text.Measure(label, face, 0)
+
+	x := center.X + radius + 8
+	y := center.Y - h/2
+	fillRect(screen, float32(x-4), float32(y-2), float32(w+8), float32(h+4), color.RGBA{A: 180}, false)
+	strokeRect(screen, float32(x-4), float32(y-2), float32(w+8), float32(h+4), 1, hud, false)
+
+	op := &text.DrawOptions{}
+	op.ColorScale.ScaleWithColor(hud)
+	op.GeoM.Translate(x, y)
+	drawText(screen, label, face, op)
+}
@@ -1,20 +1,23 @@
 // File scene_manager.go defines scene lifecycle primitives—Scene, SceneManager,
-// and State—and implements a simple cross-fade transition between scenes.
+// and State—and implements a simple cross-fade transition between scenes plus
+// a stack for overlay scenes that return to whatever they were opened from.
 package asteroids
 
 import "github.com/hajimehoshi/ebiten/v2"
 
 var (
 	// transitionFrom is a scratch buffer for rendering the current scene
-	// during transitions. Preallocated to avoid per-frame allocations.
-	transitionFrom = ebiten.NewImage(ScreenWidth, ScreenHeight)
+	// during transitions. Allocated by renderLayer on first use and reused
+	// to avoid per-frame allocations.
+	transitionFrom *ebiten.Image
 
 	// transiionTo is a scratch buffer for rendering the next scene
 	// during transitions. Name preserved to match existing code.
-	transiionTo = ebiten.NewImage(ScreenWidth, ScreenHeight)
+	transiionTo *ebiten.Image
 )
 
-// transitionMaxCount controls the duration (in frames) of the cross-fade.
+// transitionMaxCount controls the duration (in frames) of the cross-fade;
+// reduced motion lengthens it (see transitionLength).
 const transitionMaxCount = 25
 
 // Scene is the minimal contract for any drawable/updatable screen of the game.
@@ -35,9 +38,11 @@ type State struct {
 
 // SceneManager owns the active scene and handles cross-fade transitions.
 type SceneManager struct {
-	current         Scene // Currently visible/active scene.
-	next            Scene // Pending scene to transition into (if any).
-	transitionCount int   // Frames remaining in the current transition, 0 when idle.
+	current         Scene   // Currently visible/active scene.
+	next            Scene   // Pending scene to transition into (if any).
+	transitionCount int     // Frames remaining in the current transition, 0 when idle.
+	transitionTotal int     // Frames the current transition lasts in all.
+	stack           []Scene // Suspended scenes beneath current, most recent last.
 }
 
 // Draw renders either the current scene alone or a cross-fade between
@@ -50,22 +55,19 @@ func (s *SceneManager) Draw(r *ebiten.Image) {
 	}
 
 	// During a transition, first draw both scenes into offscreen buffers.
-	transitionFrom.Clear()
-	s.current.Draw(transitionFrom)
-
-	transiionTo.Clear()
-	s.next.Draw(transiionTo)
+	s.current.Draw(renderLayer(&transitionFrom))
+	s.next.Draw(renderLayer(&transiionTo))
 
 	// Compose the transition: start with the "from" scene at full opacity.
-	r.DrawImage(transitionFrom, nil)
+	drawLayer(r, transitionFrom, nil)
 
 	// Alpha increases from 0 -> 1 as transitionCount decreases from Max -> 0.
-	alpha := 1 - float32(s.transitionCount)/float32(transitionMaxCount)
+	alpha := 1 - float32(s.transitionCount)/float32(s.transitionTotal)
 	op := &ebiten.DrawImageOptions{}
 	op.ColorScale.ScaleAlpha(alpha)
 
 	// Draw the "to" scene on top, scaled by the computed alpha.
-	r.DrawImage(transiionTo, op)
+	drawLayer(r, transiionTo, op)
 }
 
 // Update advances the transition and delegates logic to the active scene.
@@ -74,15 +76,12 @@ func (s *SceneManager) Draw(r *ebiten.Image) {
 //   - When not transitioning, forwards Update to the current scene.
 //   - While transitioning, decrements the transition timer until it reaches 0,
 //     then swaps next into current.
-//
-// Note: Input is currently ignored here; scenes receive State without Input.
-// Hook it up by populating the State.Input field from the caller when ready.
-func (s *SceneManager) Update(_ *Input) error {
+func (s *SceneManager) Update(input *Input) error {
 	// No transition: update the active scene.
 	if s.transitionCount == 0 {
 		return s.current.Update(&State{
 			SceneManager: s,
-			// Input: nil (not wired here); fill from caller when available.
+			Input:        input,
 		})
 	}
 
@@ -109,6 +108,28 @@ func (s *SceneManager) GoToScene(scene Scene) {
 	} else {
 		// Defer switch via timed transition.
 		s.next = scene
-		s.transitionCount = transitionMaxCount
+		s.transitionTotal = transitionLength()
+		s.transitionCount = s.transitionTotal
+	}
+}
+
+// PushScene suspends the current scene and shows scene immediately.
+//
+// The suspended scene receives no Update calls until PopScene returns to it;
+// overlays that want it visible draw it themselves.
+func (s *SceneManager) PushScene(scene Scene) {
+	s.stack = append(s.stack, s.current)
+	s.current = scene
+}
+
+// PopScene discards the current scene and immediately resumes the most
+// recently suspended one. It does nothing if no scene is suspended.
+func (s *SceneManager) PopScene() {
+	n := len(s.stack) - 1
+	if n < 0 {
+		return
 	}
+	s.current = s.stack[n]
+	s.stack[n] = nil
+	s.stack = s.stack[:n]
 }
@@ -0,0 +1,69 @@
+// File score-popup.go defines score popups: the small "+50" a kill leaves
+// at its impact point, rising and fading over a second so the player sees
+// what each kill was worth next to where it happened.
+package asteroids
+
+import (
+	"fmt"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/hajimehoshi/ebiten/v2"
+	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Score popup tuning.
+const (
+	scorePopupLife = sim.TicksPerSecond // Ticks a popup lasts.
+	scorePopupRise = 30.0               // Distance a popup climbs over its life.
+	scorePopupSize = 14.0               // Font size of a popup.
+)
+
+// scorePopup is one floating score left by a kill.
+type scorePopup struct {
+	text     string // What the kill scored, such as "+50".
+	position Vector // World-space impact point the popup rises from.
+	life     int    // Ticks remaining.
+}
+
+// popScore leaves a popup showing points at the impact point at.
+func (g *GameScene) popScore(points int, at Vector) {
+	g.popups = append(g.popups, scorePopup{
+		text:     fmt.Sprintf("+%d", points),
+		position: at,
+		life:     scorePopupLife,
+	})
+}
+
+// updatePopups ages the score popups, dropping spent ones.
+func (g *GameScene) updatePopups() {
+	live := g.popups[:0]
+	for _, p := range g.popups {
+		p.life--
+		if p.life <= 0 {
+			continue
+		}
+		live = append(live, p)
+	}
+	g.popups = live
+}
+
+// drawPopups renders the score popups, rising and fading with age.
+func (g *GameScene) drawPopups(screen *ebiten.Image) {
+	for _, p := range g.popups {
+		t := float64(p.life) / scorePopupLife
+		op := &text.DrawOptions{
+			LayoutOptions: text.LayoutOptions{
+				PrimaryAlign:   text.AlignCenter,
+				SecondaryAlign: text.AlignCenter,
+			},
+		}
+		op.ColorScale.ScaleWithColor(currentPalette().HUD)
+		op.ColorScale.ScaleAlpha(float32(t))
+		op.GeoM.Translate(p.position.X, p.position.Y-scorePopupRise*(1-t))
+		drawText(screen, p.text, &text.GoTextFace{
+			Source: assets.ScoreFont,
+			Size:   scorePopupSize,
+		}, op)
+	}
+}
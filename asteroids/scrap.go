@@ -0,0 +1,137 @@
+// File scrap.go defines salvage: flecks of scrap thrown off by destroyed
+// meteors and aliens in modes with LevelUpgrades. Scrap drifts to a stop
+// and fades; near the ship it is pulled in and collected. The run's scrap
+// buys the upgrades offered between levels and the salvage shop's
+// supplies, is shown on the HUD, and what is left when the run ends is
+// salvaged into crystals for the shop.
+package asteroids
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/hajimehoshi/ebiten/v2"
+	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Scrap tuning.
+const (
+	scrapLargeMeteor   = 3                // Pieces a large meteor drops.
+	scrapMediumMeteor  = 2                // Pieces a medium meteor drops.
+	scrapSmallMeteor   = 1                // Pieces a small meteor drops.
+	scrapAlien         = 5                // Pieces an alien drops.
+	scrapSpeed         = 90.0             // Top speed pieces are thrown at.
+	scrapDrag          = 1.5              // Share of its speed a drifting piece loses per second.
+	scrapLifetime      = 10 * time.Second // Time a piece stays collectible.
+	scrapBlinkTime     = 2 * time.Second  // Final stretch during which it blinks.
+	scrapMagnetRange   = 160.0            // Distance from the ship's center at which pieces start to be pulled in.
+	scrapMagnetPull    = 900.0            // Acceleration toward the ship inside magnet range.
+	scrapCollectRadius = 28.0             // Distance from the ship's center at which a piece is collected.
+	scrapRadius        = 2.5              // Drawn radius of a piece.
+	scrapCrystalRate   = 5                // Unspent scrap salvaged into one crystal when the run ends.
+)
+
+// scrapColor is the glint of a piece of scrap.
+var scrapColor = color.RGBA{R: 210, G: 190, B: 140, A: 255}
+
+// scrapPiece is one fleck of salvage.
+type scrapPiece struct {
+	position Vector // World-space center.
+	movement Vector // Velocity.
+	life     *Timer // Counts down the collectible lifetime.
+}
+
+// dropScrap throws n pieces of scrap out from center, in modes where it
+// buys upgrades.
+func (g *GameScene) dropScrap(center Vector, n int) {
+	if !g.mode.LevelUpgrades {
+		return
+	}
+	rng := g.rng.Stream(streamSpawns)
+	for range n {
+		angle := rng.Float64() * 2 * math.Pi
+		speed := scrapSpeed * (0.3 + 0.7*rng.Float64())
+		g.scrapPieces = append(g.scrapPieces, scrapPiece{
+			position: center,
+			movement: Vector{X: math.Cos(angle) * speed, Y: math.Sin(angle) * speed},
+			life:     sim.NewTimer(scrapLifetime),
+		})
+	}
+}
+
+// scrapFrom returns the pieces m drops when shot down, by its size.
+func scrapFrom(m *Meteor) int {
+	switch tags := m.meteorObj.Tags(); {
+	case tags.Has(TagSmall):
+		return scrapSmallMeteor
+	case tags.Has(TagMedium):
+		return scrapMediumMeteor
+	}
+	return scrapLargeMeteor
+}
+
+// updateScrap drifts and ages the pieces, pulls those near a live ship in,
+// and collects the ones it reaches. Expired pieces are dropped.
+func (g *GameScene) updateScrap() {
+	p := g.player
+	alive := !p.isDying && !p.isDead
+	ship := spriteCenter(p.position, p.sprite)
+
+	pieces := g.scrapPieces[:0]
+	for _, s := range g.scrapPieces {
+		s.life.Update()
+		if s.life.IsReady() {
+			continue
+		}
+		d := distance(s.position, ship)
+		switch {
+		case alive && d <= scrapCollectRadius:
+			g.scrap++
+			sharedAudio().PlaySFXAt(sfxScrap, s.position.X)
+			continue
+		case alive && d <= scrapMagnetRange:
+			pull := sim.PerTick(scrapMagnetPull) / d
+			s.movement.X += (ship.X - s.position.X) * pull
+			s.movement.Y += (ship.Y - s.position.Y) * pull
+		default:
+			s.movement = s.movement.Scale(1 - sim.PerTick(scrapDrag))
+		}
+		sim.Advance(&s.position, s.movement)
+		pieces = append(pieces, s)
+	}
+	g.scrapPieces = pieces
+}
+
+// drawScrap renders the pieces, blinking during the last scrapBlinkTime.
+func (g *GameScene) drawScrap(screen *ebiten.Image) {
+	blinkTicks := sim.Ticks(scrapBlinkTime)
+	for _, s := range g.scrapPieces {
+		remaining := s.life.Target - s.life.Elapsed
+		if remaining < blinkTicks && (remaining/8)%2 == 0 {
+			continue
+		}
+		fillCircle(screen, float32(s.position.X), float32(s.position.Y), scrapRadius, scrapColor, true)
+	}
+}
+
+// drawScrap renders the run's scrap under the high score, in modes where
+// it buys upgrades.
+func (h *HUD) drawScrap(screen *ebiten.Image) {
+	if !h.game.mode.LevelUpgrades {
+		return
+	}
+	scale := hudScale()
+	op := &text.DrawOptions{
+		LayoutOptions: text.LayoutOptions{PrimaryAlign: text.AlignCenter},
+	}
+	op.ColorScale.ScaleWithColor(scrapColor)
+	op.GeoM.Translate(ScreenWidth/2, 105*scale)
+	drawText(screen, fmt.Sprintf("Scrap: %d", h.game.scrap), &text.GoTextFace{
+		Source: assets.ScoreFont,
+		Size:   16 * scale,
+	}, op)
+}
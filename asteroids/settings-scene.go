@@ -0,0 +1,313 @@
+// File settings-scene.go implements the SettingsScene, a navigable list of
+// audio, video, and control options. Every change is applied immediately and
+// written to the config file.
+package asteroids
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+	inpututil "github.com/hajimehoshi/ebiten/v2/inpututil"
+	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Settings layout and step sizes.
+const (
+	settingsRowTop     = 120  // Y of the first row.
+	settingsRowSpacing = 26   // Vertical distance between rows.
+	settingsRowsShown  = 20   // Rows visible at once; the list scrolls past that.
+	volumeStep         = 0.1  // Left/Right change for volume rows.
+	starDensityStep    = 0.25 // Left/Right change for star density.
+	hudScaleStep       = 0.25 // Left/Right change for HUD scale.
+)
+
+// settingsRow is one line of the settings list.
+type settingsRow struct {
+	label  string             // Left-hand column text.
+	value  func() string      // Right-hand column text.
+	adjust func(step int)     // Left/Right handler (step is -1 or +1); nil if not adjustable.
+	enter  func(state *State) // Enter handler; nil if not activatable.
+}
+
+// SettingsScene lists the options and edits the shared settings in place.
+//
+// It is pushed onto the SceneManager stack and pops itself on exit, so it
+// returns to whichever scene opened it.
+type SettingsScene struct {
+	backdrop  Scene         // Scene drawn dimmed behind the options; nil for a starfield.
+	rows      []settingsRow // Options, top to bottom.
+	selected  int           // Highlighted row.
+	scroll    int           // Index of the first visible row.
+	rebinding bool          // Waiting for a key to bind to the selected action.
+	action    Action        // Action being rebound while rebinding is set.
+	stars     []*Star       // Backdrop starfield (follows the density setting).
+}
+
+// NewSettingsScene builds the option rows. A non-nil backdrop (such as a
+// paused GameScene) is drawn behind them so changes can be previewed live.
+func NewSettingsScene(backdrop Scene) *SettingsScene {
+	s := &SettingsScene{
+		backdrop: backdrop,
+		stars:    GenerateStars(starCount(), ambientRNG),
+	}
+
+	s.rows = []settingsRow{
+		volumeRow("Master Volume", &config.MasterVolume, sharedAudio().SetMasterVolume),
+		volumeRow("Music Volume", &config.MusicVolume, sharedAudio().SetMusicVolume),
+		volumeRow("SFX Volume", &config.SFXVolume, sharedAudio().SetSFXVolume),
+		{
+			label:  "Fullscreen",
+			value:  func() string { return onOff(config.Fullscreen) },
+			adjust: func(int) { config.toggleFullscreen() },
+		},
+		{
+			label: "Quality",
+			value: func() string { return qualityPresetName(config) },
+			adjust: func(step int) {
+				cycleQualityPreset(config, step)
+				s.stars = GenerateStars(starCount(), ambientRNG)
+			},
+		},
+		{
+			label: "Render Scale",
+			value: func() string { return renderScaleLabel(config.RenderScale) },
+			adjust: func(step int) {
+				config.RenderScale = cycleRenderScale(config.RenderScale, step)
+			},
+		},
+		{
+			label: "Star Density",
+			value: func() string { return fmt.Sprintf("%d%%", int(config.StarDensity*100+0.5)) },
+			adjust: func(step int) {
+				config.StarDensity = clamp01(config.StarDensity + float64(step)*starDensityStep)
+				s.stars = GenerateStars(starCount(), ambientRNG)
+			},
+		},
+		{
+			label: "HUD Scale",
+			value: func() string { return fmt.Sprintf("%d%%", int(hudScale()*100+0.5)) },
+			adjust: func(step int) {
+				config.HUDScale = math.Max(hudScaleMin, math.Min(hudScaleMax, hudScale()+float64(step)*hudScaleStep))
+			},
+		},
+		{
+			label: "Palette",
+			value: func() string { return currentPalette().Name },
+			adjust: func(step int) {
+				config.Palette = cyclePalette(config.Palette, step)
+			},
+		},
+		toggleRow("Shot Shapes", &config.ShotShapes),
+		toggleRow("Reduced Motion", &config.ReducedMotion),
+		toggleRow("Ship Labels", &config.ShipLabels),
+		toggleRow("Radar", &config.Radar),
+		toggleRow("Realistic Asteroids", &config.MeteorCollisions),
+		toggleRow("Title Replay", &config.TitleReplay),
+		toggleRow("Despawn Effects", &config.DespawnEffects),
+		toggleRow("Director Log", &config.DirectorLog),
+		{
+			label: "Difficulty",
+			value: func() string { return difficultyNamed(config.Difficulty).Name },
+			adjust: func(step int) {
+				config.Difficulty = cycleDifficulty(config.Difficulty, step)
+			},
+		},
+		{
+			label: "Controls",
+			value: func() string { return controlPresetName(config.KeyBindings) },
+			adjust: func(step int) {
+				cycleControlPreset(config.KeyBindings, step)
+			},
+		},
+		assistRow("Toggle Fire", func(a *Assists) *bool { return &a.ToggleFire }),
+		assistRow("Auto-Fire", func(a *Assists) *bool { return &a.AutoFire }),
+		assistRow("Toggle Thrust", func(a *Assists) *bool { return &a.ToggleThrust }),
+		aimAssistRow(),
+		assistRow("Inertia Dampener", func(a *Assists) *bool { return &a.InertiaDampener }),
+	}
+
+	// One row per bindable action.
+	for a := Action(0); a < actionCount; a++ {
+		action := a
+		s.rows = append(s.rows, settingsRow{
+			label: action.Label(),
+			value: func() string { return keyLabel(config.KeyBindings[action]) },
+			enter: func(*State) {
+				s.rebinding = true
+				s.action = action
+			},
+		})
+	}
+
+	s.rows = append(s.rows, settingsRow{
+		label: "Back",
+		value: func() string { return "" },
+		enter: s.leave,
+	})
+
+	return s
+}
+
+// volumeRow returns a 0–100% row editing the volume at v. Each change is
+// also passed to set so playing sounds follow it immediately.
+func volumeRow(label string, v *float64, set func(float64)) settingsRow {
+	return settingsRow{
+		label: label,
+		value: func() string { return fmt.Sprintf("%d%%", int(*v*100+0.5)) },
+		adjust: func(step int) {
+			*v = clamp01(*v + float64(step)*volumeStep)
+			set(*v)
+		},
+	}
+}
+
+// Draw renders the backdrop (or starfield), heading, option rows, and a key hint.
+func (s *SettingsScene) Draw(screen *ebiten.Image) {
+	if s.backdrop != nil {
+		s.backdrop.Draw(screen)
+		dimScreen(screen, 160)
+	} else {
+		for _, star := range s.stars {
+			star.Draw(screen)
+		}
+	}
+
+	drawCenteredText(screen, "SETTINGS", assets.TitleFont, 48, ScreenWidth/2, 60, color.White)
+
+	face := &text.GoTextFace{Source: assets.ScoreFont, Size: 18}
+	for i := s.scroll; i < len(s.rows) && i < s.scroll+settingsRowsShown; i++ {
+		row := s.rows[i]
+		y := float64(settingsRowTop + (i-s.scroll)*settingsRowSpacing)
+		c := color.Color(color.White)
+		if i == s.selected {
+			c = color.RGBA{R: 255, G: 215, B: 0, A: 255} // gold-ish
+		}
+
+		// Label column, right-aligned against the center line.
+		op := &text.DrawOptions{
+			LayoutOptions: text.LayoutOptions{PrimaryAlign: text.AlignEnd},
+		}
+		op.ColorScale.ScaleWithColor(c)
+		op.GeoM.Translate(ScreenWidth/2-20, y)
+		drawText(screen, row.label, face, op)
+
+		// Value column, left-aligned after the center line.
+		value := row.value()
+		if s.rebinding && i == s.selected {
+			value = "press a key..."
+		}
+		op = &text.DrawOptions{}
+		op.ColorScale.ScaleWithColor(c)
+		op.GeoM.Translate(ScreenWidth/2+20, y)
+		drawText(screen, value, face, op)
+	}
+
+	// Ellipses mark rows scrolled out of view.
+	more := color.Gray{Y: 160}
+	if s.scroll > 0 {
+		drawCenteredText(screen, "...", assets.ScoreFont, 18, ScreenWidth/2, settingsRowTop-settingsRowSpacing, more)
+	}
+	if s.scroll+settingsRowsShown < len(s.rows) {
+		drawCenteredText(screen, "...", assets.ScoreFont, 18, ScreenWidth/2, settingsRowTop+settingsRowsShown*settingsRowSpacing, more)
+	}
+
+	const hint = "Up/Down select   Left/Right adjust   Enter rebind   Esc back"
+	drawCenteredText(screen, hint, assets.ScoreFont, 14, ScreenWidth/2, ScreenHeight-40, color.Gray{Y: 160})
+}
+
+// Update handles navigation, adjustment, and key capture for rebinding.
+func (s *SettingsScene) Update(state *State) error {
+	if s.rebinding {
+		s.captureBinding()
+		return nil
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		s.leave(state)
+		return nil
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
+		s.selected = (s.selected - 1 + len(s.rows)) % len(s.rows)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) {
+		s.selected = (s.selected + 1) % len(s.rows)
+	}
+
+	// Scroll just enough to keep the selection on screen.
+	s.scroll = min(s.scroll, s.selected)
+	s.scroll = max(s.scroll, s.selected-settingsRowsShown+1)
+
+	row := s.rows[s.selected]
+	if row.adjust != nil {
+		if inpututil.IsKeyJustPressed(ebiten.KeyLeft) {
+			row.adjust(-1)
+			s.save()
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyRight) {
+			row.adjust(1)
+			s.save()
+		}
+	}
+	if row.enter != nil && inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		row.enter(state)
+	}
+	return nil
+}
+
+// captureBinding binds the first key pressed this tick to the pending action.
+// Escape cancels without changing anything.
+func (s *SettingsScene) captureBinding() {
+	keys := inpututil.AppendJustPressedKeys(nil)
+	if len(keys) == 0 {
+		return
+	}
+	s.rebinding = false
+	if keys[0] == ebiten.KeyEscape {
+		return
+	}
+	config.KeyBindings.Bind(s.action, keys[0])
+	s.save()
+}
+
+// leave persists the settings and returns to the scene that opened this one.
+func (s *SettingsScene) leave(state *State) {
+	s.save()
+	state.SceneManager.PopScene()
+}
+
+// save writes the config file, logging (not failing) on error.
+func (s *SettingsScene) save() {
+	if err := config.Save(); err != nil {
+		log.Println("Error saving config", err)
+	}
+}
+
+// onOff formats a boolean as "On" or "Off".
+func onOff(b bool) string {
+	if b {
+		return "On"
+	}
+	return "Off"
+}
+
+// clamp01 limits v to [0, 1].
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+// drawCenteredText draws a single line of text centered on (x, y).
+func drawCenteredText(screen *ebiten.Image, s string, src *text.GoTextFaceSource, size, x, y float64, c color.Color) {
+	op := &text.DrawOptions{
+		LayoutOptions: text.LayoutOptions{PrimaryAlign: text.AlignCenter},
+	}
+	op.ColorScale.ScaleWithColor(c)
+	op.GeoM.Translate(x, y)
+	drawText(screen, s, &text.GoTextFace{
+		Source: src,
+		Size:   size,
+	}, op)
+}
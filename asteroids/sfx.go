@@ -0,0 +1,233 @@
+// File sfx.go defines the sound-effect voice pools behind PlaySFX. Each
+// effect is decoded once and played through a few players of its own, so a
+// second explosion or laser shot starts on an idle voice instead of being
+// dropped while the first is still sounding. When every voice is busy the
+// one furthest through its sound is cut off and restarted. PlaySFXAt pans
+// the chosen voice toward where on screen the effect happened, and effects
+// heard in quick runs, like lasers and explosions, play each copy at a
+// slightly different speed so a burst does not sound like one sample
+// repeated.
+package asteroids
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+// Voice pool tuning.
+const (
+	sfxVoices   = 4                     // Players per effect: how many copies can sound at once.
+	sfxStackGap = 30 * time.Millisecond // A copy started this recently swallows another, so a burst of hits in one tick is not played as one loud one.
+)
+
+// Sound effect names, as passed to PlaySFX.
+const (
+	sfxLaser          = "laser"
+	sfxSpreadShot     = "spread-shot"
+	sfxExplosion      = "explosion"
+	sfxBeatOne        = "beat-one"
+	sfxBeatTwo        = "beat-two"
+	sfxShieldsUp      = "shields-up"
+	sfxShieldRecharge = "shield-recharge"
+	sfxExtraLife      = "extra-life"
+	sfxScrap          = "scrap"
+	sfxPurchase       = "purchase"
+	sfxRefused        = "refused"
+	sfxHyperspace     = "hyperspace"
+	sfxAlienLaser     = "alien-laser"
+	sfxEMP            = "emp"
+	sfxWall           = "wall"
+)
+
+// sfxSources maps each effect name to the asset it is decoded from.
+var sfxSources = map[string]io.ReadSeeker{
+	sfxLaser:          assets.LaserSound,
+	sfxSpreadShot:     assets.SpreadShotSound,
+	sfxExplosion:      assets.ExplosionSound,
+	sfxBeatOne:        assets.BeatOneSound,
+	sfxBeatTwo:        assets.BeatTwoSound,
+	sfxShieldsUp:      assets.ShieldSound,
+	sfxShieldRecharge: chime(659.25, 0, 7, 14),    // E5 rising in fifths; synthesized, like the combo blips.
+	sfxExtraLife:      chime(523.25, 0, 4, 7, 12), // A C major arpeggio from C5.
+	sfxScrap:          chime(1318.51, 0),          // A lone E6 blip.
+	sfxPurchase:       chime(987.77, 0, 5),        // B5 up a fourth, a till ding.
+	sfxRefused:        chime(196.00, 0, -1),       // G3 sagging a semitone.
+	sfxHyperspace:     warpTone(),
+	sfxAlienLaser:     assets.AlienLaserSound,
+	sfxEMP:            assets.EMPSound,
+	sfxWall:           assets.WallSound,
+}
+
+// sfxPitchSpread is how far, as a share of normal speed either way, each
+// copy of an effect may be sped up or slowed down. Effects not listed
+// always play at normal speed.
+var sfxPitchSpread = map[string]float64{
+	sfxLaser:      0.06,
+	sfxSpreadShot: 0.06,
+	sfxExplosion:  0.1,
+	sfxAlienLaser: 0.06,
+}
+
+// sfxVoice is one player of a sound effect and the streams it plays through.
+type sfxVoice struct {
+	player *audio.Player  // Player in the sound-effect channel.
+	stream *pannedStream  // Pans the pitched samples.
+	pitch  *pitchedStream // The effect's decoded samples, at the copy's speed.
+}
+
+// voicePool is the set of voices for one sound effect.
+type voicePool struct {
+	voices []sfxVoice // Voices over the effect's decoded samples.
+	spread float64    // Pitch variation, from sfxPitchSpread.
+}
+
+// PlaySFX plays the named sound effect centered, on a free voice or failing
+// that on the voice furthest through it.
+//
+// Panics on an unknown name, matching the fail-fast asset loading elsewhere.
+func (a *AudioManager) PlaySFX(name string) {
+	a.PlaySFXAt(name, ScreenWidth/2)
+}
+
+// PlaySFXAt plays the named sound effect like PlaySFX, panned toward x, the
+// screen-space column it happened at. Nothing plays while the mix is muted.
+func (a *AudioManager) PlaySFXAt(name string, x float64) {
+	if a.muted {
+		return
+	}
+	var voice *sfxVoice
+	pool := a.voicePool(name)
+	for i := range pool.voices {
+		v := &pool.voices[i]
+		if !v.player.IsPlaying() {
+			if voice == nil {
+				voice = v
+			}
+			continue
+		}
+		if v.player.Position() < sfxStackGap {
+			return
+		}
+		if voice == nil || (voice.player.IsPlaying() && v.player.Position() > voice.player.Position()) {
+			voice = v
+		}
+	}
+	if sfxDucking[name] {
+		a.duck()
+	}
+	voice.stream.setPan(screenPan(x))
+	// Pitch is not part of the run, so it rolls on the global source
+	// rather than a run stream.
+	voice.pitch.setRate(1 + pool.spread*(2*rand.Float64()-1))
+	_ = voice.player.Rewind()
+	voice.player.Play()
+}
+
+// voicePool returns the pool for the named effect, decoding the sound and
+// creating its players on first use.
+func (a *AudioManager) voicePool(name string) *voicePool {
+	if pool, ok := a.voices[name]; ok {
+		return pool
+	}
+	src, ok := sfxSources[name]
+	if !ok {
+		panic(fmt.Sprintf("asteroids: unknown sound effect %q", name))
+	}
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		panic(err)
+	}
+	pcm, err := io.ReadAll(src)
+	if err != nil {
+		panic(err)
+	}
+
+	pool := &voicePool{voices: make([]sfxVoice, sfxVoices), spread: sfxPitchSpread[name]}
+	for i := range pool.voices {
+		pitch := newPitchedStream(pcm)
+		stream := &pannedStream{src: pitch}
+		player := a.newPlayer(stream, soundSFX, 1)
+		if sfxDucked[name] {
+			a.setDucked(player)
+		}
+		pool.voices[i] = sfxVoice{player: player.Player, stream: stream, pitch: pitch}
+	}
+	if a.voices == nil {
+		a.voices = make(map[string]*voicePool)
+	}
+	a.voices[name] = pool
+	return pool
+}
+
+// pitchedStream plays 16-bit little-endian stereo PCM at a variable speed,
+// interpolating between frames, which raises or lowers its pitch with it.
+// The rate is read on the audio goroutine, hence the atomic.
+type pitchedStream struct {
+	pcm  []byte        // Decoded source samples.
+	pos  float64       // Read position, in source frames.
+	rate atomic.Uint64 // Float64 bits of the speed: source frames per output frame.
+}
+
+// newPitchedStream returns a stream over pcm at normal speed.
+func newPitchedStream(pcm []byte) *pitchedStream {
+	s := &pitchedStream{pcm: pcm}
+	s.setRate(1)
+	return s
+}
+
+// setRate sets the playback speed, 1 being normal.
+func (s *pitchedStream) setRate(rate float64) {
+	s.rate.Store(math.Float64bits(rate))
+}
+
+// Read fills p with whole frames resampled at the current rate.
+func (s *pitchedStream) Read(p []byte) (int, error) {
+	frames := len(s.pcm) / 4
+	rate := math.Float64frombits(s.rate.Load())
+	n := 0
+	for ; n+4 <= len(p); n += 4 {
+		i := int(s.pos)
+		if i+1 >= frames {
+			if n == 0 {
+				return 0, io.EOF
+			}
+			break
+		}
+		t := s.pos - float64(i)
+		for c := 0; c < 4; c += 2 {
+			a := float64(int16(binary.LittleEndian.Uint16(s.pcm[i*4+c:])))
+			b := float64(int16(binary.LittleEndian.Uint16(s.pcm[(i+1)*4+c:])))
+			binary.LittleEndian.PutUint16(p[n+c:], uint16(int16(a+(b-a)*t)))
+		}
+		s.pos += rate
+	}
+	return n, nil
+}
+
+// Seek moves the read position, in bytes of the source, letting the player
+// rewind the effect.
+func (s *pitchedStream) Seek(offset int64, whence int) (int64, error) {
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = int64(s.pos)*4 + offset
+	case io.SeekEnd:
+		abs = int64(len(s.pcm)) + offset
+	default:
+		return 0, fmt.Errorf("asteroids: invalid whence %d", whence)
+	}
+	if abs < 0 {
+		return 0, fmt.Errorf("asteroids: negative position %d", abs)
+	}
+	s.pos = float64(abs / 4)
+	return abs, nil
+}
@@ -46,5 +46,5 @@ func (si *ShieldIndicator) Draw(screen *ebiten.Image) {
 	cm := colorm.ColorM{}
 	cm.Scale(1.0, 1.0, 1.0, 0.2)
 
-	colorm.DrawImage(screen, si.sprite, cm, op)
+	drawSpriteColorM(screen, si.sprite, cm, op)
 }
@@ -1,6 +1,7 @@
 // File shield.go defines the Shield entity used by the player for
 // temporary protection. The shield visually surrounds the ship and
-// synchronizes its position and rotation with the player each frame.
+// synchronizes its position and rotation with the player each frame. A
+// ring around it runs down with the shield's time left.
 package asteroids
 
 import (
@@ -9,6 +10,13 @@ import (
 	"github.com/solarlune/resolv"
 )
 
+// Shield time ring look.
+const (
+	shieldRingGap   = 4.0  // Space between the shield and its time ring.
+	shieldRingWidth = 2.0  // Thickness of the time ring.
+	shieldRingLow   = 0.25 // Share of the time left below which the ring takes the warning color.
+)
+
 // Shield represents a temporary energy field around the player.
 // It includes rendering, collision data, and positional sync logic.
 type Shield struct {
@@ -16,14 +24,14 @@ type Shield struct {
 	rotation  float64        // Rotation matching the player ship.
 	sprite    *ebiten.Image  // Shield sprite image.
 	shieldObj *resolv.Circle // Circular collider for overlap detection.
-	game      *GameScene     // Reference to owning scene (for player, space, etc.).
+	player    *Player        // Ship the shield surrounds.
 }
 
 // NewShield constructs and registers a Shield collider in the physics space.
 //
 // The position is centered around the player's ship, and a circular
 // collision object is created proportional to the sprite radius.
-func NewShield(position Vector, rotation float64, game *GameScene) *Shield {
+func NewShield(position Vector, rotation float64, player *Player) *Shield {
 	sprite := assets.ShieldSprite
 
 	// Compute center origin offsets.
@@ -40,12 +48,12 @@ func NewShield(position Vector, rotation float64, game *GameScene) *Shield {
 		position:  position,
 		rotation:  rotation,
 		sprite:    sprite,
-		game:      game,
+		player:    player,
 		shieldObj: shieldObj,
 	}
 
 	// Add shield to collision space for overlap tracking.
-	s.game.space.Add(shieldObj)
+	player.game.space.Add(shieldObj)
 
 	return s
 }
@@ -57,17 +65,17 @@ func NewShield(position Vector, rotation float64, game *GameScene) *Shield {
 // components track the player perfectly even during movement or rotation.
 func (s *Shield) Update() {
 	// Calculate difference between shield and player sprite sizes.
-	diffX := float64(s.sprite.Bounds().Dx()-s.game.player.sprite.Bounds().Dx()) * 0.5
-	diffY := float64(s.sprite.Bounds().Dy()-s.game.player.sprite.Bounds().Dy()) * 0.5
+	diffX := float64(s.sprite.Bounds().Dx()-s.player.sprite.Bounds().Dx()) * 0.5
+	diffY := float64(s.sprite.Bounds().Dy()-s.player.sprite.Bounds().Dy()) * 0.5
 
 	// Align position to player center.
 	position := Vector{
-		X: s.game.player.position.X - diffX,
-		Y: s.game.player.position.Y - diffY,
+		X: s.player.position.X - diffX,
+		Y: s.player.position.Y - diffY,
 	}
 
 	s.position = position
-	s.rotation = s.game.player.rotation
+	s.rotation = s.player.rotation
 
 	// Sync collider position to new location.
 	s.shieldObj.Move(position.X, position.Y)
@@ -86,6 +94,29 @@ func (s *Shield) Draw(screen *ebiten.Image) {
 	op.GeoM.Rotate(s.rotation)
 	op.GeoM.Translate(halfW, halfH)
 	op.GeoM.Translate(s.position.X, s.position.Y)
+	if tint := currentPalette().Shield; tint.A != 0 {
+		op.ColorScale.ScaleWithColor(tint)
+	}
+
+	drawSprite(screen, s.sprite, op)
+	s.drawTimeLeft(screen)
+}
 
-	screen.DrawImage(s.sprite, op)
+// drawTimeLeft renders a ring around the shield covering the share of its
+// duration left, running down clockwise, in the warning color near the end.
+func (s *Shield) drawTimeLeft(screen *ebiten.Image) {
+	t := s.player.shieldTimer
+	if t == nil {
+		return
+	}
+	left := 1 - float64(t.Elapsed)/float64(t.Target)
+	clr := currentPalette().Meter
+	if left < shieldRingLow {
+		clr = currentPalette().Warning
+	}
+
+	b := s.sprite.Bounds()
+	halfW, halfH := float64(b.Dx())/2, float64(b.Dy())/2
+	r := float32(max(halfW, halfH) + shieldRingGap)
+	drawArcGauge(screen, float32(s.position.X+halfW), float32(s.position.Y+halfH), r, shieldRingWidth, left, clr, nil)
 }
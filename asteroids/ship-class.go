@@ -0,0 +1,58 @@
+// File ship-class.go defines the ship classes picked on the ship-select
+// screen: each is a hull with its own turn rate, top speed, shots per
+// burst, and spare shields, and the Player reads them where it once read
+// package constants. A run keeps the class it started with; replays and
+// suspended runs record it, and modes with NoUpgrades fly the stock ship.
+package asteroids
+
+import (
+	"math"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ShipClass is one selectable ship.
+type ShipClass struct {
+	Name      string        // Display name and config-file identifier.
+	Role      string        // One-line summary for the ship-select screen.
+	Sprite    *ebiten.Image // Hull.
+	TurnRate  float64       // Radians a second the ship turns.
+	TopSpeed  float64       // Top speed thrust or reverse can build up to.
+	BurstSize int           // Lasers a burst fires before the burst cooldown.
+	Shields   int           // Shield charges added to the difficulty's; negative takes some away.
+}
+
+// shipClasses lists the ships in ship-select order; the first is the stock
+// ship.
+var shipClasses = []ShipClass{
+	{Name: "Falcon", Role: "All-rounder", Sprite: assets.PlayerSprite, TurnRate: math.Pi, TopSpeed: 480, BurstSize: 3},
+	{Name: "Dart", Role: "Quick and nimble, but thinly shielded", Sprite: assets.DartSprite, TurnRate: 1.35 * math.Pi, TopSpeed: 600, BurstSize: 2, Shields: -1},
+	{Name: "Hammer", Role: "Long bursts, heavy handling", Sprite: assets.HammerSprite, TurnRate: 0.85 * math.Pi, TopSpeed: 420, BurstSize: 5},
+	{Name: "Bastion", Role: "Slow, with extra shields", Sprite: assets.BastionSprite, TurnRate: 0.75 * math.Pi, TopSpeed: 380, BurstSize: 3, Shields: 2},
+}
+
+// shipClassIndex returns the index of the ship called name, or the stock
+// ship if there is none.
+func shipClassIndex(name string) int {
+	for i, s := range shipClasses {
+		if s.Name == name {
+			return i
+		}
+	}
+	return 0
+}
+
+// shipClassNamed returns the ship called name, or the stock ship.
+func shipClassNamed(name string) ShipClass {
+	return shipClasses[shipClassIndex(name)]
+}
+
+// runShipClass returns the ship a new run of mode flies: the one last
+// picked, except that modes with NoUpgrades fly the stock ship.
+func runShipClass(mode Mode) ShipClass {
+	if mode.NoUpgrades {
+		return shipClasses[0]
+	}
+	return shipClassNamed(config.Ship)
+}
@@ -0,0 +1,135 @@
+// File ship-label.go renders name labels above player ships for streamed or
+// tournament play: each label takes its player's color, labels are nudged
+// apart so they never overlap, and they fade while their ships overlap.
+package asteroids
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Ship label layout.
+const (
+	shipLabelFontSize     = 12.0 // Text size before HUD scaling.
+	shipLabelGap          = 6.0  // Space between a ship and its label.
+	shipLabelStep         = 4.0  // Vertical nudge per placement attempt.
+	shipLabelMaxTries     = 12   // Placement attempts before giving up on a clear spot.
+	shipLabelOverlapAlpha = 0.3  // Opacity of labels whose ships fully overlap.
+)
+
+// playerColors are the per-player label colors, indexed by player slot.
+var playerColors = []color.RGBA{
+	{R: 120, G: 220, B: 255, A: 255}, // Player 1: cyan.
+	{R: 255, G: 140, B: 60, A: 255},  // Player 2: orange.
+	{R: 160, G: 255, B: 120, A: 255}, // Player 3: green.
+	{R: 255, G: 110, B: 200, A: 255}, // Player 4: pink.
+}
+
+// playerColor returns the label color for player slot, cycling when there
+// are more players than colors.
+func playerColor(slot int) color.RGBA {
+	return playerColors[slot%len(playerColors)]
+}
+
+// ShipLabel is a name tag to draw above one ship.
+type ShipLabel struct {
+	Text   string     // Name shown.
+	Color  color.RGBA // Text color.
+	Anchor Vector     // Ship center in world space.
+	Radius float64    // Ship radius; the label sits just above it.
+}
+
+// labelFor returns the label for the ship in player slot on the given player.
+func labelFor(name string, slot int, p *Player) ShipLabel {
+	b := p.sprite.Bounds()
+	return ShipLabel{
+		Text:   name,
+		Color:  playerColor(slot),
+		Anchor: spriteCenter(p.position, p.sprite),
+		Radius: float64(max(b.Dx(), b.Dy())) / 2,
+	}
+}
+
+// drawShipLabels renders labels in order. Each is placed above its ship,
+// or nudged upward (then below the ship) until it clears the labels already
+// placed, and faded in proportion to how far its ship overlaps another.
+func drawShipLabels(screen *ebiten.Image, labels []ShipLabel) {
+	face := &text.GoTextFace{Source: assets.ScoreFont, Size: shipLabelFontSize * hudScale()}
+	var placed []image.Rectangle
+
+	for i, l := range labels {
+		w, h := text.Measure(l.Text, face, 0)
+		rect := placeShipLabel(l, w, h, placed)
+		placed = append(placed, rect)
+
+		alpha := 1.0
+		for j, other := range labels {
+			if j != i {
+				alpha = math.Min(alpha, shipLabelFade(l, other))
+			}
+		}
+
+		op := &text.DrawOptions{}
+		op.ColorScale.ScaleWithColor(l.Color)
+		op.ColorScale.ScaleAlpha(float32(alpha))
+		op.GeoM.Translate(float64(rect.Min.X), float64(rect.Min.Y))
+		drawText(screen, l.Text, face, op)
+	}
+}
+
+// placeShipLabel returns a w×h rectangle for l that avoids every rectangle
+// in placed, trying above the ship first and then below it. If no spot is
+// clear, the preferred spot is used anyway.
+func placeShipLabel(l ShipLabel, w, h float64, placed []image.Rectangle) image.Rectangle {
+	left := l.Anchor.X - w/2
+	above := l.Anchor.Y - l.Radius - shipLabelGap - h
+	below := l.Anchor.Y + l.Radius + shipLabelGap
+
+	rectAt := func(top float64) image.Rectangle {
+		return image.Rect(int(left), int(top), int(left+w), int(top+h))
+	}
+	isClear := func(r image.Rectangle) bool {
+		for _, p := range placed {
+			if r.Overlaps(p) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for try := 0; try < shipLabelMaxTries; try++ {
+		if r := rectAt(above - float64(try)*shipLabelStep); isClear(r) {
+			return r
+		}
+		if r := rectAt(below + float64(try)*shipLabelStep); isClear(r) {
+			return r
+		}
+	}
+	return rectAt(above)
+}
+
+// shipLabelFade returns the opacity of l given another ship: 1 while the two
+// ships are apart, falling to shipLabelOverlapAlpha as they fully overlap.
+func shipLabelFade(l, other ShipLabel) float64 {
+	reach := l.Radius + other.Radius
+	d := distance(l.Anchor, other.Anchor)
+	if d >= reach || reach == 0 {
+		return 1
+	}
+	overlap := 1 - d/reach
+	return 1 - (1-shipLabelOverlapAlpha)*overlap
+}
+
+// shipLabels returns the labels for every ship in play. Single-player runs
+// label their one ship as player 1.
+func (g *GameScene) shipLabels() []ShipLabel {
+	if g.player.isDying || g.player.isDead {
+		return nil
+	}
+	return []ShipLabel{labelFor("P1", 0, g.player)}
+}
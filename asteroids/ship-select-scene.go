@@ -0,0 +1,124 @@
+// File ship-select-scene.go implements the ShipSelectScene, shown between
+// the title menu and a new run, where the player picks which ship class to
+// fly. It opens on the ship picked last time and remembers the new pick.
+package asteroids
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+	inpututil "github.com/hajimehoshi/ebiten/v2/inpututil"
+	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Ship-select layout.
+const (
+	shipSelectMenuX    = ScreenWidth/2 - 260 // Center line of the ship list.
+	shipSelectPreviewX = ScreenWidth/2 + 200 // Center line of the selected ship's preview.
+	shipSelectStatW    = 220.0               // Width of a stat bar.
+	shipSelectStatH    = 10.0                // Height of a stat bar.
+)
+
+// ShipSelectScene lists the ship classes beside a preview of the selected
+// one and its stats.
+type ShipSelectScene struct {
+	mode  Mode    // Mode of the run the pick starts.
+	stars []*Star // Backdrop starfield.
+	menu  *Menu   // One row per ship class, then Back.
+}
+
+// NewShipSelectScene returns the ship-select screen for a new run of mode,
+// with the last ship picked selected.
+func NewShipSelectScene(mode Mode) *ShipSelectScene {
+	items := make([]string, 0, len(shipClasses)+1)
+	for _, s := range shipClasses {
+		items = append(items, s.Name)
+	}
+	s := &ShipSelectScene{
+		mode:  mode,
+		stars: GenerateStars(starCount(), ambientRNG),
+		menu:  NewMenu(append(items, "Back")...),
+	}
+	s.menu.selected = shipClassIndex(config.Ship)
+	return s
+}
+
+// Update handles menu input.
+//
+// Ship:        remember the pick and start the run flying it.
+// Back/Escape: return to the title screen.
+func (s *ShipSelectScene) Update(state *State) error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		state.SceneManager.GoToScene(NewTitleScene())
+		return nil
+	}
+
+	choice := s.menu.Update()
+	switch {
+	case choice == len(shipClasses):
+		state.SceneManager.GoToScene(NewTitleScene())
+	case choice >= 0:
+		config.Ship = shipClasses[choice].Name
+		if err := config.Save(); err != nil {
+			log.Println("Error saving config", err)
+		}
+		state.SceneManager.GoToScene(NewGameScene(s.mode))
+	}
+	return nil
+}
+
+// Draw renders the ship list and, for the selected ship, its hull, role,
+// and stats, each bar measured against the best of any ship.
+func (s *ShipSelectScene) Draw(screen *ebiten.Image) {
+	for _, star := range s.stars {
+		star.Draw(screen)
+	}
+	gray := color.Gray{Y: 180}
+	drawCenteredText(screen, "SELECT SHIP", assets.TitleFont, 48, ScreenWidth/2, 60, color.White)
+	drawCenteredText(screen, s.mode.Name, assets.ScoreFont, 20, ScreenWidth/2, 140, gray)
+
+	s.menu.Draw(screen, shipSelectMenuX, 240)
+	if s.menu.selected >= len(shipClasses) {
+		return
+	}
+	ship := shipClasses[s.menu.selected]
+
+	// The hull, nose up.
+	size := spriteSize(ship.Sprite)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(shipSelectPreviewX-size.X/2, 250-size.Y/2)
+	drawSprite(screen, ship.Sprite, op)
+	drawCenteredText(screen, ship.Role, assets.ScoreFont, 16, shipSelectPreviewX, 310, gray)
+
+	var best ShipClass
+	for _, c := range shipClasses {
+		best.TurnRate = max(best.TurnRate, c.TurnRate)
+		best.TopSpeed = max(best.TopSpeed, c.TopSpeed)
+		best.BurstSize = max(best.BurstSize, c.BurstSize)
+	}
+	stats := []struct {
+		label    string
+		fraction float64
+	}{
+		{"TURN", ship.TurnRate / best.TurnRate},
+		{"SPEED", ship.TopSpeed / best.TopSpeed},
+		{"BURST", float64(ship.BurstSize) / float64(best.BurstSize)},
+	}
+	x := float32(shipSelectPreviewX - shipSelectStatW/2)
+	for i, stat := range stats {
+		y := 370 + float64(i)*40
+		drawAlignedText(screen, stat.label, assets.ScoreFont, 14, float64(x)-16, y-4, text.AlignEnd, gray)
+		drawBar(screen, x, float32(y), shipSelectStatW, shipSelectStatH, stat.fraction, currentPalette().Meter, gray)
+	}
+
+	// Shields are a count, worked out on the difficulty the run will use.
+	shields := "Shields draw on energy"
+	if !s.mode.EnergyHandling {
+		n := runDifficulty(s.mode).Shields + ship.Shields + runUpgrades(s.mode)[upgradeShieldCapacity]
+		shields = fmt.Sprintf("Shields: %d", n)
+	}
+	drawCenteredText(screen, shields, assets.ScoreFont, 16, shipSelectPreviewX, 500, color.White)
+}
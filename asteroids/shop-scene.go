@@ -0,0 +1,96 @@
+// File shop-scene.go implements the ShopScene, where crystals earned in
+// ranked runs buy the next level of an upgrade.
+package asteroids
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+	inpututil "github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// ShopScene lists the upgrade catalog with the profile's levels and prices.
+type ShopScene struct {
+	stars  []*Star // Backdrop starfield.
+	menu   *Menu   // One row per upgrade, then Back.
+	status string  // Result of the last purchase, shown under the menu.
+}
+
+// NewShopScene returns the shop screen.
+func NewShopScene() *ShopScene {
+	s := &ShopScene{
+		stars: GenerateStars(starCount(), ambientRNG),
+		menu:  NewMenu(),
+	}
+	s.refresh()
+	return s
+}
+
+// refresh rebuilds the menu rows from the profile's current levels.
+func (s *ShopScene) refresh() {
+	items := make([]string, 0, len(upgradeCatalog)+1)
+	for _, u := range upgradeCatalog {
+		level := profile.Upgrades[u.ID]
+		price := "MAX"
+		if cost, ok := u.nextCost(level); ok {
+			price = fmt.Sprintf("%d crystals", cost)
+		}
+		items = append(items, fmt.Sprintf("%s  %d/%d  %s", u.Name, level, len(u.Costs), price))
+	}
+	s.menu.items = append(items, "Back")
+}
+
+// Update handles menu input.
+//
+// Upgrade:     buy its next level, if affordable.
+// Back/Escape: return to the screen that opened this one.
+func (s *ShopScene) Update(state *State) error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		state.SceneManager.PopScene()
+		return nil
+	}
+
+	choice := s.menu.Update()
+	switch {
+	case choice == len(upgradeCatalog):
+		state.SceneManager.PopScene()
+	case choice >= 0:
+		u := upgradeCatalog[choice]
+		cost, ok := u.nextCost(profile.Upgrades[u.ID])
+		switch {
+		case !ok:
+			s.status = u.Name + " is fully upgraded"
+		case !profile.buy(u):
+			s.status = fmt.Sprintf("%s needs %d crystals", u.Name, cost)
+		default:
+			s.status = fmt.Sprintf("Bought %s level %d", u.Name, profile.Upgrades[u.ID])
+		}
+		s.refresh()
+	}
+	return nil
+}
+
+// Draw renders the crystal balance, the upgrade rows, and the effect of the
+// selected upgrade.
+func (s *ShopScene) Draw(screen *ebiten.Image) {
+	for _, star := range s.stars {
+		star.Draw(screen)
+	}
+	gray := color.Gray{Y: 180}
+	drawCenteredText(screen, "SHOP", assets.TitleFont, 48, ScreenWidth/2, 60, color.White)
+	drawCenteredText(screen, fmt.Sprintf("Crystals: %d", profile.Crystals), assets.ScoreFont, 20, ScreenWidth/2, 140,
+		color.RGBA{R: 120, G: 200, B: 255, A: 255})
+
+	s.menu.Draw(screen, ScreenWidth/2, 220)
+
+	if s.menu.selected < len(upgradeCatalog) {
+		drawCenteredText(screen, upgradeCatalog[s.menu.selected].Effect, assets.ScoreFont, 16, ScreenWidth/2, 480, gray)
+	}
+	if s.status != "" {
+		drawCenteredText(screen, s.status, assets.ScoreFont, 14, ScreenWidth/2, 540, gray)
+	}
+	drawCenteredText(screen, fmt.Sprintf("Ranked runs earn a crystal per %d points", crystalScoreRate),
+		assets.ScoreFont, 14, ScreenWidth/2, ScreenHeight-60, gray)
+}
@@ -0,0 +1,41 @@
+// File sim.go binds the asteroids package to internal/sim, the ebiten-free
+// rules it steps each tick. The shared types are aliased here so entities
+// and scenes keep using them by their short names.
+package asteroids
+
+import "github.com/bensabler/asteroids/internal/sim"
+
+// Simulation types used throughout the package.
+type (
+	Vector      = sim.Vector      // Positions and velocities in world units.
+	Timer       = sim.Timer       // Tick-based countdowns.
+	Level       = sim.Level       // Tuning for one numbered level.
+	WaveKind    = sim.WaveKind    // How a level's wave plays.
+	WaveManager = sim.WaveManager // A level's meteor budget and alive count.
+	Action      = sim.Action      // Logical commands bound to keys.
+)
+
+// Wave kinds.
+const (
+	WaveStandard = sim.WaveStandard
+	WaveGoldRush = sim.WaveGoldRush
+	WaveBoss     = sim.WaveBoss
+)
+
+// Bindable gameplay actions.
+const (
+	ActionRotateLeft  = sim.ActionRotateLeft
+	ActionRotateRight = sim.ActionRotateRight
+	ActionThrust      = sim.ActionThrust
+	ActionReverse     = sim.ActionReverse
+	ActionFire        = sim.ActionFire
+	ActionShield      = sim.ActionShield
+	ActionHyperspace  = sim.ActionHyperspace
+	ActionBoost       = sim.ActionBoost
+	ActionSmartBomb   = sim.ActionSmartBomb
+	ActionTractor     = sim.ActionTractor
+	actionCount       = sim.ActionCount
+)
+
+// Input reports actions to the rules the same way every front end does.
+var _ sim.Controls = (*Input)(nil)
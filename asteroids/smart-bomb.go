@@ -0,0 +1,141 @@
+// File smart-bomb.go implements the once-per-level smart bomb: detonation
+// around the player, the expanding Shockwave effect, and the HUD charge
+// indicator. Spares bought in the salvage shop go off once the level's own
+// bomb is spent.
+package asteroids
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/hajimehoshi/ebiten/v2"
+	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Smart bomb tuning.
+const (
+	smartBombRadius       = 250.0                  // Blast radius around the ship center.
+	smartBombMeteorPoints = 2                      // Score per meteor destroyed.
+	smartBombLaserPoints  = 1                      // Score per alien laser destroyed.
+	shockwaveDuration     = 500 * time.Millisecond // Time for the ring to reach full radius.
+)
+
+// Shockwave is the expanding ring drawn when a smart bomb goes off.
+type Shockwave struct {
+	center Vector // Ring center in world space.
+	timer  *Timer // Progress from detonation to full radius.
+}
+
+// NewShockwave starts a ring at center.
+func NewShockwave(center Vector) *Shockwave {
+	return &Shockwave{center: center, timer: sim.NewTimer(shockwaveDuration)}
+}
+
+// Update advances the ring by one tick.
+func (s *Shockwave) Update() {
+	s.timer.Update()
+}
+
+// isDone reports whether the ring has finished expanding.
+func (s *Shockwave) isDone() bool {
+	return s.timer.IsReady()
+}
+
+// Draw renders the ring, growing toward smartBombRadius while fading out.
+func (s *Shockwave) Draw(screen *ebiten.Image) {
+	t := float32(s.timer.Elapsed) / float32(max(1, s.timer.Target))
+	radius := float32(smartBombRadius) * t
+	alpha := uint8(255 * (1 - t))
+	c := color.RGBA{R: alpha, G: alpha, B: alpha, A: alpha} // Premultiplied white.
+	strokeCircle(screen, float32(s.center.X), float32(s.center.Y), radius, 4, c, true)
+}
+
+// SmartBombIndicator shows whether this level's smart bomb is still
+// available, and how many spares the ship carries.
+type SmartBombIndicator struct {
+	position Vector // Center of the icon in screen space.
+}
+
+// NewSmartBombIndicator creates an indicator centered at position.
+func NewSmartBombIndicator(position Vector) *SmartBombIndicator {
+	return &SmartBombIndicator{position: position}
+}
+
+// Draw renders a filled ring when a bomb is ready and a faint outline once
+// every bomb is spent, with the spare count beside it.
+func (si *SmartBombIndicator) Draw(screen *ebiten.Image, ready bool, spares int) {
+	x, y := float32(si.position.X), float32(si.position.Y)
+	if spares > 0 {
+		drawAlignedText(screen, fmt.Sprintf("+%d", spares), assets.ScoreFont, 14, si.position.X+16, si.position.Y-8, text.AlignStart,
+			color.RGBA{R: 255, G: 140, B: 0, A: 255})
+	}
+	if ready || spares > 0 {
+		fillCircle(screen, x, y, 6, color.RGBA{R: 255, G: 140, B: 0, A: 255}, true)
+		strokeCircle(screen, x, y, 10, 2, color.RGBA{R: 255, G: 140, B: 0, A: 255}, true)
+		return
+	}
+	strokeCircle(screen, x, y, 10, 1, color.RGBA{R: 50, G: 50, B: 50, A: 50}, true)
+}
+
+// detonateSmartBomb spends the level's bomb, or failing that a spare,
+// destroying every meteor and alien laser within smartBombRadius of the
+// ship and scoring each one.
+//
+// Large meteors are destroyed outright instead of splitting.
+func (g *GameScene) detonateSmartBomb() {
+	switch {
+	case g.smartBombReady:
+		g.smartBombReady = false
+	case g.spareSmartBombs > 0:
+		g.spareSmartBombs--
+	default:
+		return
+	}
+
+	center := spriteCenter(g.player.position, g.player.sprite)
+	g.shockwave = NewShockwave(center)
+
+	for _, m := range inOrder(g.meteors) {
+		at := spriteCenter(m.position, m.sprite)
+		if g.isExploding(m) || m.gold || !withinRadius(at, center, smartBombRadius) {
+			continue
+		}
+		g.explodeMeteor(m)
+		g.scoreKill(smartBombMeteorPoints, at)
+	}
+
+	for i, al := range inOrder(g.alienLasers) {
+		if withinRadius(al.position, center, smartBombRadius) {
+			g.removeAlienLaser(i)
+			g.score += smartBombLaserPoints
+		}
+	}
+
+	sharedAudio().PlaySFXAt(sfxExplosion, center.X)
+}
+
+// updateShockwave advances the smart bomb ring and drops it once finished.
+func (g *GameScene) updateShockwave() {
+	if g.shockwave == nil {
+		return
+	}
+	g.shockwave.Update()
+	if g.shockwave.isDone() {
+		g.shockwave = nil
+	}
+}
+
+// spriteCenter returns the center of a sprite drawn with its top-left at position.
+func spriteCenter(position Vector, sprite *ebiten.Image) Vector {
+	b := sprite.Bounds()
+	return Vector{X: position.X + float64(b.Dx())/2, Y: position.Y + float64(b.Dy())/2}
+}
+
+// withinRadius reports whether p lies within r of center.
+func withinRadius(p, center Vector, r float64) bool {
+	return math.Hypot(p.X-center.X, p.Y-center.Y) <= r
+}
@@ -0,0 +1,119 @@
+// File snapshot.go describes a GameScene as a sim.Snapshot: the ship and
+// every meteor, alien, boss, and laser in play, each under an ID made of
+// its kind and its key in the scene's entity map. Replays checkpoint runs
+// with these snapshots and suspended runs check their rebuild against one.
+package asteroids
+
+import (
+	"slices"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
+)
+
+// Entity kinds as snapshots number them. Append only: the numbers are
+// stored in replays and suspended runs.
+const (
+	entityShip sim.EntityKind = iota
+	entityMeteor
+	entitySmallMeteor
+	entityGoldMeteor
+	entityAlien
+	entityBoss
+	entityLaser
+	entityAlienLaser
+	entityMediumMeteor
+)
+
+// entityKindShift places the kind above an entity's map key in its ID.
+const entityKindShift = 24
+
+// entityID returns the snapshot ID of the entity of kind stored under key.
+func entityID(kind sim.EntityKind, key int) uint32 {
+	return uint32(kind)<<entityKindShift | uint32(key)&(1<<entityKindShift-1)
+}
+
+// snapshot returns the scene's entities at tick.
+func (g *GameScene) snapshot(tick int) sim.Snapshot {
+	var entities []sim.Entity
+	if !g.player.isDead {
+		p := g.player
+		entities = append(entities, sim.Entity{
+			ID:       entityID(entityShip, 0),
+			Kind:     entityShip,
+			Position: p.position,
+			Velocity: p.velocity,
+			Rotation: p.rotation,
+		})
+	}
+	for key, m := range inOrder(g.meteors) {
+		kind, sprites := entityMeteor, assets.MeteorSprites
+		switch tags := m.meteorObj.Tags(); {
+		case tags.Has(TagSmall):
+			kind, sprites = entitySmallMeteor, assets.MeteorSpritesSmall
+		case tags.Has(TagMedium):
+			kind, sprites = entityMediumMeteor, assets.MeteorSpritesMedium
+		}
+		if m.gold {
+			kind = entityGoldMeteor
+		}
+		entities = append(entities, sim.Entity{
+			ID:       entityID(kind, key),
+			Kind:     kind,
+			State:    uint32(max(slices.Index(sprites, m.sprite), 0)) | uint32(max(m.health, 0))<<8, // Sprite index, then health above it.
+			Position: m.position,
+			Velocity: m.movement,
+			Rotation: m.rotation,
+		})
+	}
+	for key, a := range inOrder(g.aliens) {
+		entities = append(entities, sim.Entity{
+			ID:       entityID(entityAlien, key),
+			Kind:     entityAlien,
+			State:    uint32(max(a.armor, 0)),
+			Position: a.position,
+			Velocity: a.movement,
+		})
+	}
+	if b := g.boss; b != nil {
+		health := 0
+		for _, wp := range b.weakPoints {
+			health += max(wp.health, 0)
+		}
+		entities = append(entities, sim.Entity{
+			ID:       entityID(entityBoss, 0),
+			Kind:     entityBoss,
+			State:    uint32(health),
+			Position: b.center,
+			Rotation: b.rotation,
+		})
+	}
+	for key, l := range inOrder(g.lasers) {
+		entities = append(entities, sim.Entity{ID: entityID(entityLaser, key), Kind: entityLaser, Position: l.position, Rotation: l.rotation})
+	}
+	for key, l := range inOrder(g.alienLasers) {
+		entities = append(entities, sim.Entity{ID: entityID(entityAlienLaser, key), Kind: entityAlienLaser, Position: l.position, Rotation: l.rotation})
+	}
+
+	// IDs are unique by construction: each kind has its own map.
+	s, _ := sim.NewSnapshot(uint32(tick), entities)
+	return s
+}
+
+// suspendableSnapshot returns the part of the scene's snapshot a suspended
+// run keeps, numbered the way a resumed run will number it: the ship,
+// meteors, aliens, and boss, each kind keyed from 1 in map order.
+func (g *GameScene) suspendableSnapshot(tick int) sim.Snapshot {
+	ordinals := map[sim.EntityKind]int{}
+	var entities []sim.Entity
+	for _, e := range g.snapshot(tick).Entities {
+		if e.Kind == entityLaser || e.Kind == entityAlienLaser {
+			continue
+		}
+		ordinals[e.Kind]++
+		e.ID = entityID(e.Kind, ordinals[e.Kind])
+		entities = append(entities, e)
+	}
+	s, _ := sim.NewSnapshot(uint32(tick), entities)
+	return s
+}
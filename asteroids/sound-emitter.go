@@ -0,0 +1,119 @@
+// File sound-emitter.go defines SoundEmitter, a looping sound attached to an
+// entity on the play field. Each tick the emitter asks where its entity is:
+// the sound pans toward that side of the screen, grows quieter with distance
+// from the ship, and stops on its own once the entity is gone.
+package asteroids
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"sync/atomic"
+)
+
+// Emitter tuning.
+const (
+	emitterFalloff = 900.0 // Distance from the ship at which an emitter reaches emitterMinGain.
+	emitterMinGain = 0.25  // Share of its gain a distant emitter keeps.
+)
+
+// soundSource reports where an emitter's entity is, and false once there is
+// no entity left to hear.
+type soundSource func() (Vector, bool)
+
+// SoundEmitter plays a looping sound from an entity's position.
+type SoundEmitter struct {
+	emitterVoice
+	gain   float64     // Level relative to the channel when next to the ship.
+	source soundSource // Position of the entity the sound follows.
+}
+
+// emitterVoice is the player behind a SoundEmitter and the stream it plays,
+// shared by every emitter of the same name.
+type emitterVoice struct {
+	player *managedPlayer // Player in the sound-effect channel.
+	stream *pannedStream  // Source with the stereo pan applied.
+}
+
+// NewSoundEmitter returns a stopped emitter playing src at gain on behalf of
+// whatever entity source reports. Emitters of the same name share one
+// player over src, made the first time.
+func NewSoundEmitter(name string, src io.ReadSeeker, gain float64, source soundSource) *SoundEmitter {
+	a := sharedAudio()
+	voice := sharedLoop(a, name, func() emitterVoice {
+		stream := &pannedStream{src: src}
+		return emitterVoice{player: a.newPlayer(stream, soundSFX, gain), stream: stream}
+	})
+	e := &SoundEmitter{emitterVoice: voice, gain: gain, source: source}
+	e.Pause()
+	return e
+}
+
+// Update places the sound at its entity relative to listener, restarting
+// the loop as needed, or stops it when the entity is gone.
+func (e *SoundEmitter) Update(listener Vector) {
+	position, ok := e.source()
+	if !ok {
+		e.Pause()
+		return
+	}
+
+	e.stream.setPan(screenPan(position.X))
+	falloff := clamp01(distance(position, listener) / emitterFalloff)
+	sharedAudio().setGain(e.player, e.gain*(1-(1-emitterMinGain)*falloff))
+
+	if !e.player.IsPlaying() {
+		_ = e.player.Rewind()
+		sharedAudio().play(e.player.Player)
+	}
+}
+
+// Pause silences the emitter until its next Update finds the entity.
+func (e *SoundEmitter) Pause() {
+	if e.player.IsPlaying() {
+		e.player.Pause()
+	}
+}
+
+// screenPan returns the pan for a sound at screen column x: -1 at the left
+// edge, 0 in the middle, and 1 at the right edge.
+func screenPan(x float64) float64 {
+	return (x - ScreenWidth/2) / (ScreenWidth / 2)
+}
+
+// pannedStream scales the channels of 16-bit stereo PCM to pan it left or
+// right. The pan is read on the audio goroutine, hence the atomic.
+type pannedStream struct {
+	src io.ReadSeeker // Decoded 16-bit little-endian stereo source.
+	pan atomic.Uint64 // Float64 bits of the pan, -1 (left) to 1 (right).
+}
+
+// setPan sets the pan, clamped to -1–1.
+func (s *pannedStream) setPan(pan float64) {
+	s.pan.Store(math.Float64bits(max(-1, min(1, pan))))
+}
+
+// Read fills p with whole frames of the source, panned. The far channel
+// fades linearly; the centered sound plays at full level on both sides.
+func (s *pannedStream) Read(p []byte) (int, error) {
+	p = p[:len(p)&^3] // Whole 4-byte frames only, so samples never straddle reads.
+	n, err := io.ReadFull(s.src, p)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+
+	pan := math.Float64frombits(s.pan.Load())
+	left, right := min(1, 1-pan), min(1, 1+pan)
+	for i := 0; i+4 <= n; i += 4 {
+		l := int16(binary.LittleEndian.Uint16(p[i:]))
+		r := int16(binary.LittleEndian.Uint16(p[i+2:]))
+		binary.LittleEndian.PutUint16(p[i:], uint16(int16(float64(l)*left)))
+		binary.LittleEndian.PutUint16(p[i+2:], uint16(int16(float64(r)*right)))
+	}
+	return n, err
+}
+
+// Seek repositions the source, letting the player rewind the loop.
+func (s *pannedStream) Seek(offset int64, whence int) (int64, error) {
+	return s.src.Seek(offset, whence)
+}
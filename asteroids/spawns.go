@@ -0,0 +1,84 @@
+// File spawns.go feeds the run's sim.SpawnDirector and asks it where new
+// meteors and aliens enter: the director learns which edges the ship hugs
+// and which way it is not looking, and the run's difficulty sets how hard
+// spawns are steered away from there. Competitive modes turn it off, and
+// the Director Log option records its steering in the run's stats.
+package asteroids
+
+import (
+	"math"
+
+	"github.com/bensabler/asteroids/internal/sim"
+)
+
+// spawnSteeringEpsilon is the smallest shift, in radians, the director log
+// counts as steering rather than rounding.
+const spawnSteeringEpsilon = 1e-9
+
+// observeSpawns shows the director the ship for this tick. A ship that is
+// not in play teaches it nothing.
+func (g *GameScene) observeSpawns() {
+	p := g.player
+	if p.isDead || p.isDying {
+		return
+	}
+	bounds := p.sprite.Bounds()
+	center := Vector{X: p.position.X + float64(bounds.Dx())/2, Y: p.position.Y + float64(bounds.Dy())/2}
+	g.spawns.Observe(center, shipHeading(p.rotation))
+}
+
+// spawnFairness returns how hard a run of mode on difficulty steers
+// spawns: the difficulty's fairness, or 0 where the mode turns the
+// director off.
+func spawnFairness(mode Mode, difficulty Difficulty) float64 {
+	if mode.NoSpawnDirector {
+		return 0
+	}
+	return difficulty.Fairness
+}
+
+// spawnAngle maps a uniform roll in [0, 1) to the angle around screen
+// center a meteor or hunter enters from.
+func (g *GameScene) spawnAngle(roll float64) float64 {
+	angle := g.spawns.Angle(roll)
+	shift := math.Abs(angle - roll*2*math.Pi)
+	g.logSteering(min(shift, 2*math.Pi-shift))
+	return angle
+}
+
+// entryAngle maps a uniform roll in [0, 1) to the angle around screen
+// center a scripted spawn enters from: within the quarter of the ring facing
+// the side from, or wherever the director picks for sim.SideAny.
+func (g *GameScene) entryAngle(from sim.Side, roll float64) float64 {
+	if from == sim.SideAny {
+		return g.spawnAngle(roll)
+	}
+	return (float64(from) + roll - 0.5) * math.Pi / 2
+}
+
+// spawnFromRight maps a uniform roll in [0, 1) to the side edge a sweeping
+// alien or formation enters from: true for the right edge, false for the
+// left.
+func (g *GameScene) spawnFromRight(roll float64) bool {
+	right := g.spawns.Choose(roll, 0, math.Pi) == 0
+	if right == (roll < 0.5) {
+		g.logSteering(0)
+	} else {
+		g.logSteering(math.Pi) // Sent in from the other side.
+	}
+	return right
+}
+
+// logSteering records in the run's director log, if it keeps one, a spawn
+// the director moved by shift radians from where an unweighted roll puts it.
+func (g *GameScene) logSteering(shift float64) {
+	d := g.stats.Director
+	if d == nil {
+		return
+	}
+	d.Spawns++
+	if shift > spawnSteeringEpsilon {
+		d.Steered++
+		d.Shift += shift * 180 / math.Pi
+	}
+}
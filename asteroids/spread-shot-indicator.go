@@ -0,0 +1,44 @@
+// File spread-shot-indicator.go defines the SpreadShotIndicator HUD element,
+// which shows that the spread-shot power-up is active and how long it lasts.
+package asteroids
+
+import (
+	"image/color"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Spread-shot indicator layout.
+const (
+	spreadShotBarWidth  = 40 // Width of the full time bar.
+	spreadShotBarHeight = 4  // Height of the time bar.
+)
+
+// SpreadShotIndicator draws the spread-shot icon with a draining time bar.
+type SpreadShotIndicator struct {
+	position  Vector        // Top-left of the icon in screen space.
+	sprite    *ebiten.Image // Spread-shot icon.
+	remaining float64       // Fraction of the effect left (0–1); set by the player.
+}
+
+// NewSpreadShotIndicator creates an indicator at the given HUD coordinates.
+func NewSpreadShotIndicator(position Vector) *SpreadShotIndicator {
+	return &SpreadShotIndicator{
+		position:  position,
+		sprite:    assets.SpreadShotSprite,
+		remaining: 1,
+	}
+}
+
+// Draw renders the icon and, beside it, a bar proportional to the time left.
+func (si *SpreadShotIndicator) Draw(screen *ebiten.Image) {
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(si.position.X, si.position.Y)
+	drawSprite(screen, si.sprite, op)
+
+	b := si.sprite.Bounds()
+	x := float32(si.position.X) + float32(b.Dx()) + 6
+	y := float32(si.position.Y) + float32(b.Dy()-spreadShotBarHeight)/2
+	drawBar(screen, x, y, spreadShotBarWidth, spreadShotBarHeight, si.remaining, currentPalette().Meter, color.Gray{Y: 160})
+}
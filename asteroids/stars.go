@@ -7,7 +7,6 @@ import (
 	"math/rand"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
 // Star represents a single background light point.
@@ -22,15 +21,15 @@ type Star struct {
 }
 
 // NewStar creates and returns a randomly positioned and
-// parameterized star within screen bounds.
+// parameterized star within screen bounds, rolled from rng.
 //
 // Brightness and radius are randomized to simulate depth variance.
-func NewStar() *Star {
+func NewStar(rng *rand.Rand) *Star {
 	return &Star{
-		x:          rand.Float32() * ScreenWidth,
-		y:          rand.Float32() * ScreenHeight,
-		r:          rand.Float32() * (3 - 1), // 1–3px radius variation
-		brightness: rand.Float32() * 0xff,    // 0–255 brightness range
+		x:          rng.Float32() * ScreenWidth,
+		y:          rng.Float32() * ScreenHeight,
+		r:          rng.Float32() * (3 - 1), // 1–3px radius variation
+		brightness: rng.Float32() * 0xff,    // 0–255 brightness range
 	}
 }
 
@@ -47,7 +46,7 @@ func (s *Star) Draw(screen *ebiten.Image) {
 	}
 
 	// Draw the star as a small filled circle.
-	vector.FillCircle(screen, s.x, s.y, s.r, c, true)
+	fillCircle(screen, s.x, s.y, s.r, c, true)
 }
 
 // Update advances the star state per frame.
@@ -60,10 +59,10 @@ func (s *Star) Update() {
 // GenerateStars returns a slice of randomly generated stars.
 //
 // Used by scenes such as the title screen for dynamic backgrounds.
-func GenerateStars(n int) []*Star {
+func GenerateStars(n int, rng *rand.Rand) []*Star {
 	stars := make([]*Star, 0, n)
 	for i := 0; i < n; i++ {
-		stars = append(stars, NewStar())
+		stars = append(stars, NewStar(rng))
 	}
 	return stars
 }
@@ -0,0 +1,119 @@
+// File stats-scene.go implements the StatsScene, which summarizes lifetime
+// statistics and recent runs and exports them to CSV or JSON files in the
+// save directory.
+package asteroids
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+	inpututil "github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Stats menu option indices.
+const (
+	statsExportCSV = iota
+	statsExportJSON
+	statsBack
+)
+
+// statsRecentRuns is how many recent runs the screen lists.
+const statsRecentRuns = 5
+
+// StatsScene shows the statistics log over a starfield.
+type StatsScene struct {
+	stars  []*Star // Backdrop starfield.
+	menu   *Menu   // Export CSV / Export JSON / Back.
+	status string  // Result of the last export, shown under the menu.
+}
+
+// NewStatsScene returns the statistics screen.
+func NewStatsScene() *StatsScene {
+	return &StatsScene{
+		stars: GenerateStars(starCount(), ambientRNG),
+		menu:  NewMenu("Export CSV", "Export JSON", "Back"),
+	}
+}
+
+// Update handles menu input.
+//
+// Export CSV:  write stats-runs.csv and stats-lifetime.csv.
+// Export JSON: write stats-export.json.
+// Back/Escape: return to the screen that opened this one.
+func (s *StatsScene) Update(state *State) error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		state.SceneManager.PopScene()
+		return nil
+	}
+
+	switch s.menu.Update() {
+	case statsExportCSV:
+		s.report(stats.ExportCSV())
+	case statsExportJSON:
+		s.report(stats.ExportJSON())
+	case statsBack:
+		state.SceneManager.PopScene()
+	}
+	return nil
+}
+
+// report sets the status line from an export's result, logging failures.
+func (s *StatsScene) report(path string, err error) {
+	if err != nil {
+		log.Println("Error exporting stats", err)
+		s.status = "Export failed: " + err.Error()
+		return
+	}
+	s.status = "Exported to " + path
+}
+
+// Draw renders the lifetime totals, the most recent runs, and the menu.
+func (s *StatsScene) Draw(screen *ebiten.Image) {
+	for _, star := range s.stars {
+		star.Draw(screen)
+	}
+	drawCenteredText(screen, "STATISTICS", assets.TitleFont, 48, ScreenWidth/2, 60, color.White)
+
+	l := stats.Lifetime
+	lines := []string{
+		fmt.Sprintf("Runs %d   Time played %s", l.Runs, formatDuration(l.Seconds)),
+		fmt.Sprintf("Best score %d   Best level %d   Total score %d", l.BestScore, l.BestLevel, l.TotalScore),
+		fmt.Sprintf("Accuracy %.0f%%   Meteors %d   Aliens %d", l.accuracy()*100, l.MeteorsDestroyed, l.AliensDestroyed),
+	}
+	for i, line := range lines {
+		drawCenteredText(screen, line, assets.ScoreFont, 18, ScreenWidth/2, float64(140+i*30), color.White)
+	}
+
+	gray := color.Gray{Y: 180}
+	drawCenteredText(screen, "RECENT RUNS", assets.ScoreFont, 18, ScreenWidth/2, 260, gray)
+	if len(stats.Runs) == 0 {
+		drawCenteredText(screen, "No runs yet", assets.ScoreFont, 16, ScreenWidth/2, 290, gray)
+	}
+	for i := 0; i < statsRecentRuns && i < len(stats.Runs); i++ {
+		r := stats.Runs[len(stats.Runs)-1-i]
+		line := fmt.Sprintf("%s  %-9s  score %6d  level %2d  accuracy %3.0f%%  %s",
+			r.Started.Format("2006-01-02"), r.Mode, r.Score, r.Level, r.accuracy()*100, formatDuration(r.Seconds))
+		if r.Assisted {
+			line += "  assisted"
+		}
+		drawCenteredText(screen, line, assets.ScoreFont, 16, ScreenWidth/2, float64(290+i*26), gray)
+	}
+
+	s.menu.Draw(screen, ScreenWidth/2, 460)
+	if s.status != "" {
+		drawCenteredText(screen, s.status, assets.ScoreFont, 14, ScreenWidth/2, 600, gray)
+	}
+}
+
+// formatDuration renders seconds as m:ss, or h:mm:ss from an hour up.
+func formatDuration(seconds float64) string {
+	total := int(seconds)
+	h, m, sec := total/3600, total/60%60, total%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, sec)
+	}
+	return fmt.Sprintf("%d:%02d", m, sec)
+}
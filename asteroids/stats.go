@@ -0,0 +1,227 @@
+// File stats.go tracks run statistics: each GameScene fills in a RunStats as
+// it plays, finished runs are folded into lifetime totals, and both are
+// persisted next to the high-score file and can be exported as CSV or JSON.
+package asteroids
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/bensabler/asteroids/internal/sim"
+)
+
+// Stats files inside the save directory.
+const (
+	statsFileName          = "stats.json"
+	statsExportJSONName    = "stats-export.json"
+	statsExportRunsCSV     = "stats-runs.csv"
+	statsExportLifetimeCSV = "stats-lifetime.csv"
+)
+
+// statsMaxRuns caps how many finished runs are kept individually; lifetime
+// totals still include every run.
+const statsMaxRuns = 100
+
+// RunStats describes one run.
+type RunStats struct {
+	Mode             string       `json:"mode"`                  // Mode name.
+	Seed             int64        `json:"seed"`                  // RNG seed the run started from.
+	Started          time.Time    `json:"started"`               // Wall-clock start.
+	Seconds          float64      `json:"seconds"`               // Time in play.
+	Score            int          `json:"score"`                 // Final score.
+	Level            int          `json:"level"`                 // Level reached.
+	ShotsFired       int          `json:"shotsFired"`            // Player lasers fired.
+	ShotsHit         int          `json:"shotsHit"`              // Player lasers that hit something.
+	MeteorsDestroyed int          `json:"meteorsDestroyed"`      // Meteors destroyed by any means.
+	AliensDestroyed  int          `json:"aliensDestroyed"`       // Aliens destroyed by any means.
+	Assisted         bool         `json:"assisted"`              // An assist option was used.
+	DirectorOff      bool         `json:"directorOff,omitempty"` // The mode turned the spawn director off.
+	Director         *DirectorLog `json:"director,omitempty"`    // What the spawn director did, with the Director Log option on.
+	ticks            int          // Ticks in play, converted to Seconds at the end.
+}
+
+// DirectorLog records how the spawn director steered a run's spawns.
+type DirectorLog struct {
+	Spawns  int     `json:"spawns"`  // Spawns the director placed.
+	Steered int     `json:"steered"` // Spawns it moved off the direction an unweighted roll gives.
+	Shift   float64 `json:"shift"`   // Total degrees it moved them by.
+}
+
+// LifetimeStats are totals over every finished run.
+type LifetimeStats struct {
+	Runs             int     `json:"runs"`             // Runs finished.
+	Seconds          float64 `json:"seconds"`          // Total time in play.
+	BestScore        int     `json:"bestScore"`        // Highest run score.
+	TotalScore       int     `json:"totalScore"`       // Sum of run scores.
+	BestLevel        int     `json:"bestLevel"`        // Highest level reached.
+	ShotsFired       int     `json:"shotsFired"`       // Player lasers fired.
+	ShotsHit         int     `json:"shotsHit"`         // Player lasers that hit something.
+	MeteorsDestroyed int     `json:"meteorsDestroyed"` // Meteors destroyed.
+	AliensDestroyed  int     `json:"aliensDestroyed"`  // Aliens destroyed.
+}
+
+// StatsLog is the persisted statistics: lifetime totals plus recent runs.
+type StatsLog struct {
+	Lifetime LifetimeStats `json:"lifetime"` // Totals over every run.
+	Runs     []RunStats    `json:"runs"`     // Most recent runs, oldest first.
+}
+
+// stats is the statistics log, loaded at startup.
+var stats = &StatsLog{}
+
+// init loads persisted statistics (best-effort).
+func init() {
+	s, err := loadStats()
+	if err != nil {
+		log.Println("Error loading stats", err)
+		return
+	}
+	stats = s
+}
+
+// newRunStats starts the statistics for a run of mode from seed, logging
+// the spawn director if the config asks and the mode has one.
+func newRunStats(mode Mode, seed int64) *RunStats {
+	r := &RunStats{Mode: mode.Name, Seed: seed, Started: time.Now(), Level: 1, DirectorOff: mode.NoSpawnDirector}
+	if config.DirectorLog && !mode.NoSpawnDirector {
+		r.Director = &DirectorLog{}
+	}
+	return r
+}
+
+// accuracy returns the share of shots that hit, or 0 before any shot.
+func (r *RunStats) accuracy() float64 {
+	return hitRatio(r.ShotsHit, r.ShotsFired)
+}
+
+// accuracy returns the share of all shots that hit, or 0 before any shot.
+func (l *LifetimeStats) accuracy() float64 {
+	return hitRatio(l.ShotsHit, l.ShotsFired)
+}
+
+// hitRatio returns hit/fired, or 0 when nothing was fired.
+func hitRatio(hit, fired int) float64 {
+	if fired == 0 {
+		return 0
+	}
+	return float64(hit) / float64(fired)
+}
+
+// record folds a finished run into the log and saves it.
+func (s *StatsLog) record(run RunStats) {
+	run.Seconds = float64(run.ticks) * sim.TickSeconds()
+
+	l := &s.Lifetime
+	l.Runs++
+	l.Seconds += run.Seconds
+	l.BestScore = max(l.BestScore, run.Score)
+	l.TotalScore += run.Score
+	l.BestLevel = max(l.BestLevel, run.Level)
+	l.ShotsFired += run.ShotsFired
+	l.ShotsHit += run.ShotsHit
+	l.MeteorsDestroyed += run.MeteorsDestroyed
+	l.AliensDestroyed += run.AliensDestroyed
+
+	s.Runs = append(s.Runs, run)
+	if len(s.Runs) > statsMaxRuns {
+		s.Runs = s.Runs[len(s.Runs)-statsMaxRuns:]
+	}
+	if err := s.Save(); err != nil {
+		log.Println("Error saving stats", err)
+	}
+}
+
+// loadStats reads the stats file; a missing file is an empty log.
+func loadStats() (*StatsLog, error) {
+	s := &StatsLog{}
+	data, err := saves.Read(statsFileName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return &StatsLog{}, err
+	}
+	return s, nil
+}
+
+// Save writes the stats file, creating the save directory if needed.
+func (s *StatsLog) Save() error {
+	return s.saveAs(statsFileName)
+}
+
+// ExportJSON writes lifetime and per-run statistics to one JSON file in the
+// save directory and returns its path.
+func (s *StatsLog) ExportJSON() (string, error) {
+	if err := s.saveAs(statsExportJSONName); err != nil {
+		return "", err
+	}
+	return saves.Path(statsExportJSONName), nil
+}
+
+// saveAs writes the log as indented JSON to name in the save directory.
+func (s *StatsLog) saveAs(name string) error {
+	return saves.Write(name, func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(s)
+	})
+}
+
+// ExportCSV writes per-run statistics and lifetime totals to two CSV files
+// in the save directory and returns the directory.
+func (s *StatsLog) ExportCSV() (string, error) {
+	runs := [][]string{{"started", "mode", "seed", "seconds", "score", "level", "shots_fired", "shots_hit", "accuracy", "meteors_destroyed", "aliens_destroyed", "assisted"}}
+	for _, r := range s.Runs {
+		runs = append(runs, []string{
+			r.Started.Format(time.RFC3339),
+			r.Mode,
+			strconv.FormatInt(r.Seed, 10),
+			strconv.FormatFloat(r.Seconds, 'f', 1, 64),
+			strconv.Itoa(r.Score),
+			strconv.Itoa(r.Level),
+			strconv.Itoa(r.ShotsFired),
+			strconv.Itoa(r.ShotsHit),
+			strconv.FormatFloat(r.accuracy(), 'f', 3, 64),
+			strconv.Itoa(r.MeteorsDestroyed),
+			strconv.Itoa(r.AliensDestroyed),
+			strconv.FormatBool(r.Assisted),
+		})
+	}
+	if err := writeCSV(statsExportRunsCSV, runs); err != nil {
+		return "", err
+	}
+
+	l := s.Lifetime
+	lifetime := [][]string{
+		{"stat", "value"},
+		{"runs", strconv.Itoa(l.Runs)},
+		{"seconds", strconv.FormatFloat(l.Seconds, 'f', 1, 64)},
+		{"best_score", strconv.Itoa(l.BestScore)},
+		{"total_score", strconv.Itoa(l.TotalScore)},
+		{"best_level", strconv.Itoa(l.BestLevel)},
+		{"shots_fired", strconv.Itoa(l.ShotsFired)},
+		{"shots_hit", strconv.Itoa(l.ShotsHit)},
+		{"meteors_destroyed", strconv.Itoa(l.MeteorsDestroyed)},
+		{"aliens_destroyed", strconv.Itoa(l.AliensDestroyed)},
+	}
+	if err := writeCSV(statsExportLifetimeCSV, lifetime); err != nil {
+		return "", err
+	}
+	return saves.Path(""), nil
+}
+
+// writeCSV writes rows to name in the save directory.
+func writeCSV(name string, rows [][]string) error {
+	return saves.Write(name, func(w io.Writer) error {
+		return csv.NewWriter(w).WriteAll(rows)
+	})
+}
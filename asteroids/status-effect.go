@@ -0,0 +1,208 @@
+// File status-effect.go defines StatusEffects, the timed conditions that can
+// afflict the ship: Slow cuts its speed, EMP locks out its weapons, and
+// Burning deals damage over time. Each kind has a stacking rule for being
+// applied again while active, a HUD icon with a draining ring, and is
+// advanced centrally by the scene each tick.
+package asteroids
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/hajimehoshi/ebiten/v2"
+	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Status effect tuning and HUD layout.
+const (
+	burnLethalDamage = 100.0 // Burn damage that destroys a ship without energy handling.
+	statusIconRadius = 14    // Radius of a HUD icon.
+	statusIconGap    = 10    // Space between HUD icons.
+	statusIconTop    = 60    // Y of the HUD icon centers; the row runs left from the right edge.
+)
+
+// StatusKind identifies one kind of status effect.
+type StatusKind int
+
+// Status effect kinds, in HUD order.
+const (
+	StatusSlow      StatusKind = iota // Scales the ship's speed down by its magnitude (0–1).
+	StatusEMP                         // Locks out the ship's weapons.
+	StatusBurning                     // Deals magnitude damage per second for each stack.
+	statusKindCount                   // Number of kinds; keep last.
+)
+
+// Stacking is how an effect responds to being applied while already active.
+type Stacking int
+
+// Stacking rules.
+const (
+	StackRefresh   Stacking = iota // Restart at the longer duration and the stronger magnitude.
+	StackExtend                    // Add the new duration to the time left.
+	StackIntensify                 // Add a stack, up to the kind's limit, and restart.
+)
+
+// statusKindInfo describes how one kind stacks and how it reads on the HUD.
+type statusKindInfo struct {
+	label     string     // One-letter HUD glyph.
+	clr       color.RGBA // HUD icon color.
+	stacking  Stacking   // Rule for reapplying while active.
+	maxStacks int        // Stack limit under StackIntensify.
+}
+
+// statusKinds lists every kind, indexed by StatusKind.
+var statusKinds = [statusKindCount]statusKindInfo{
+	StatusSlow:    {label: "S", clr: color.RGBA{R: 140, G: 200, B: 255, A: 255}, stacking: StackRefresh, maxStacks: 1},
+	StatusEMP:     {label: "E", clr: color.RGBA{R: 200, G: 120, B: 255, A: 255}, stacking: StackExtend, maxStacks: 1},
+	StatusBurning: {label: "B", clr: color.RGBA{R: 255, G: 130, B: 40, A: 255}, stacking: StackIntensify, maxStacks: 3},
+}
+
+// StatusEffect is one active condition.
+type StatusEffect struct {
+	timer     *Timer  // Time until the effect wears off.
+	magnitude float64 // Strength; its meaning depends on the kind.
+	stacks    int     // Times applied, under StackIntensify.
+	dealt     float64 // Burn damage dealt so far.
+}
+
+// remaining returns the fraction of the effect's duration left.
+func (e *StatusEffect) remaining() float64 {
+	if e.timer.Target == 0 {
+		return 0
+	}
+	return 1 - float64(e.timer.Elapsed)/float64(e.timer.Target)
+}
+
+// StatusEffects is the set of conditions on one ship, at most one per kind.
+type StatusEffects struct {
+	active [statusKindCount]*StatusEffect // Active effect by kind; nil when clear.
+}
+
+// apply starts an effect of kind lasting d, or stacks it onto the active one
+// by the kind's rule.
+func (s *StatusEffects) apply(kind StatusKind, d time.Duration, magnitude float64) {
+	e := s.active[kind]
+	if e == nil {
+		s.active[kind] = &StatusEffect{timer: sim.NewTimer(d), magnitude: magnitude, stacks: 1}
+		return
+	}
+	ticks := sim.NewTimer(d).Target
+	left := e.timer.Target - e.timer.Elapsed
+	switch info := statusKinds[kind]; info.stacking {
+	case StackRefresh:
+		e.timer = &Timer{Target: max(ticks, left)}
+		e.magnitude = math.Max(e.magnitude, magnitude)
+	case StackExtend:
+		e.timer = &Timer{Target: left + ticks}
+		e.magnitude = math.Max(e.magnitude, magnitude)
+	case StackIntensify:
+		e.timer = &Timer{Target: max(ticks, left)}
+		e.magnitude = math.Max(e.magnitude, magnitude)
+		e.stacks = min(e.stacks+1, info.maxStacks)
+	}
+}
+
+// has reports whether an effect of kind is active.
+func (s *StatusEffects) has(kind StatusKind) bool {
+	return s.active[kind] != nil
+}
+
+// clear ends an effect of kind early.
+func (s *StatusEffects) clear(kind StatusKind) {
+	s.active[kind] = nil
+}
+
+// Update advances every effect by one tick and drops those that have worn off.
+func (s *StatusEffects) Update() {
+	for kind, e := range s.active {
+		if e == nil {
+			continue
+		}
+		e.timer.Update()
+		if e.timer.IsReady() {
+			s.active[kind] = nil
+		}
+	}
+}
+
+// speedFactor returns the multiplier on the ship's speed.
+func (s *StatusEffects) speedFactor() float64 {
+	if e := s.active[StatusSlow]; e != nil {
+		return 1 - clamp01(e.magnitude)
+	}
+	return 1
+}
+
+// burnDamage returns the burn damage due this tick.
+func (s *StatusEffects) burnDamage() float64 {
+	e := s.active[StatusBurning]
+	if e == nil {
+		return 0
+	}
+	return sim.PerTick(e.magnitude * float64(e.stacks))
+}
+
+// Draw renders an icon for each active effect in a row running left from
+// the right edge, each ringed by the time it has left.
+func (s *StatusEffects) Draw(screen *ebiten.Image) {
+	x := float32(ScreenWidth - 40)
+	for kind, e := range s.active {
+		if e == nil {
+			continue
+		}
+		info := statusKinds[kind]
+		fillCircle(screen, x, statusIconTop, statusIconRadius-3, color.RGBA{A: 160}, true)
+		drawArcGauge(screen, x, statusIconTop, statusIconRadius, 3, e.remaining(), info.clr, color.Gray{Y: 70})
+
+		label := info.label
+		if e.stacks > 1 {
+			label = fmt.Sprintf("%s%d", info.label, e.stacks)
+		}
+		op := &text.DrawOptions{
+			LayoutOptions: text.LayoutOptions{
+				PrimaryAlign:   text.AlignCenter,
+				SecondaryAlign: text.AlignCenter,
+			},
+		}
+		op.GeoM.Translate(float64(x), statusIconTop)
+		op.ColorScale.ScaleWithColor(info.clr)
+		drawText(screen, label, &text.GoTextFace{Source: assets.ScoreFont, Size: 12}, op)
+
+		x -= 2*statusIconRadius + statusIconGap
+	}
+}
+
+// updateStatusEffects advances the ship's status effects and lets Burning
+// do its damage.
+func (g *GameScene) updateStatusEffects() {
+	p := g.player
+	p.status.Update()
+	if dmg := p.status.burnDamage(); dmg > 0 {
+		p.burn(dmg)
+	}
+}
+
+// burn deals damage to the ship. A raised shield takes it instead. Under
+// energy handling it drains the energy pool; otherwise the fire adds up and
+// destroys the ship once it reaches burnLethalDamage.
+func (p *Player) burn(damage float64) {
+	if p.isShielded || p.isDying || p.isDead {
+		return
+	}
+	if p.energy != nil {
+		p.energy.drain(damage)
+		return
+	}
+	e := p.status.active[StatusBurning]
+	e.dealt += damage
+	if e.dealt < burnLethalDamage {
+		return
+	}
+	p.status.clear(StatusBurning)
+	p.isDying = true
+	sharedAudio().PlaySFXAt(sfxExplosion, spriteCenter(p.position, p.sprite).X)
+}
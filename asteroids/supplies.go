@@ -0,0 +1,88 @@
+// File supplies.go defines the supplies sold in the salvage shop that opens
+// between some levels: a spare smart bomb, a shield charge, and an extra
+// life, each bought with the run's scrap at a fixed price. Spare smart
+// bombs are kept for when the level's own has been spent. Every purchase,
+// and leaving the shop, is recorded in the run's replay so playback buys
+// the same ones.
+package asteroids
+
+// Supply is one of the consumables the salvage shop sells.
+type Supply int
+
+// Supplies, in the order the shop lists them.
+const (
+	supplySmartBomb Supply = iota
+	supplyShieldCharge
+	supplyExtraLife
+	supplyCount // Number of supplies; keep last.
+
+	noSupply Supply = -1 // Leaving the shop.
+)
+
+// Salvage shop tuning.
+const (
+	salvageShopInterval = 3 // Levels between visits; the shop opens before levels 4, 7, 10, and so on.
+	maxSpareSmartBombs  = 3 // Most spare smart bombs the ship can carry.
+)
+
+// supplyInfo names each supply, says what it does, and prices it in scrap,
+// for the shop.
+var supplyInfo = [supplyCount]struct {
+	name, effect string
+	price        int
+}{
+	supplySmartBomb:    {"Smart Bomb", "A spare bomb for after the level's own is spent", 15},
+	supplyShieldCharge: {"Shield Charge", "Refills one spent shield charge", 10},
+	supplyExtraLife:    {"Extra Life", "+1 life", 40},
+}
+
+// offersSalvageShop reports whether the level about to start opens with a
+// salvage shop visit: in modes with LevelUpgrades, every salvageShopInterval
+// numbered levels, while the run's scrap can pay for a supply the ship has
+// room for.
+func (g *GameScene) offersSalvageShop() bool {
+	if !g.mode.LevelUpgrades || g.isBonusRound() || g.currentLevel <= 1 || (g.currentLevel-1)%salvageShopInterval != 0 {
+		return false
+	}
+	for s := Supply(0); s < supplyCount; s++ {
+		if g.canStock(s) && g.scrap >= supplyInfo[s].price {
+			return true
+		}
+	}
+	return false
+}
+
+// canStock reports whether the ship has room for another s. Energy
+// handling has no shield charges to refill.
+func (g *GameScene) canStock(s Supply) bool {
+	p := g.player
+	switch s {
+	case supplySmartBomb:
+		return g.spareSmartBombs < maxSpareSmartBombs
+	case supplyShieldCharge:
+		return p.energy == nil && p.shieldsRemaning < p.maxShields()
+	case supplyExtraLife:
+		return p.livesRemaning < maxLives
+	}
+	return false
+}
+
+// buySupply pays for s and stocks it, and records the purchase in the
+// run's replay. noSupply records leaving the shop.
+func (g *GameScene) buySupply(s Supply) {
+	if g.replay != nil {
+		g.replay.purchases = append(g.replay.purchases, s)
+	}
+	if s == noSupply {
+		return
+	}
+	g.scrap -= supplyInfo[s].price
+	switch s {
+	case supplySmartBomb:
+		g.spareSmartBombs++
+	case supplyShieldCharge:
+		g.player.shieldsRemaning++
+	case supplyExtraLife:
+		g.player.livesRemaning++
+	}
+}
@@ -0,0 +1,434 @@
+// File suspended-run.go saves an in-progress run to disk when the player
+// picks "Save & Quit" from the pause menu, and rebuilds it when they pick
+// "Continue" on the title screen. The snapshot keeps what the player would
+// notice: score, level, lives, shields, the ship, and every meteor, alien,
+// and boss in play. Short-lived effects (lasers, pickups, comets, mines,
+// cooldowns) are left out. There is one slot, and resuming empties it, so a
+// run can only be continued once.
+//
+// The snapshot also carries the kept entities as an encoded sim.Snapshot,
+// and resuming compares the rebuilt field against it, so a field that does
+// not survive the round trip is noticed rather than silently changed.
+package asteroids
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/solarlune/resolv"
+)
+
+// suspendedRunFileName is the suspended run inside the save directory.
+const suspendedRunFileName = "suspended-run.json"
+
+// suspendedRunVersion is the snapshot format; other versions are refused.
+const suspendedRunVersion = 7
+
+// SuspendedRun is a snapshot of a run left mid-level.
+type SuspendedRun struct {
+	Version      int                `json:"version"`      // suspendedRunVersion.
+	Saved        time.Time          `json:"saved"`        // When the run was suspended.
+	Mode         string             `json:"mode"`         // Mode name, as in replays.
+	Seed         int64              `json:"seed"`         // RNG seed the run started from.
+	Draws        map[string]int64   `json:"draws"`        // Values drawn from each of the run's RNG streams so far.
+	Upgrades     Upgrades           `json:"upgrades"`     // Upgrade levels the run flies with.
+	Difficulty   string             `json:"difficulty"`   // Name of the run's Difficulty.
+	Ship         string             `json:"ship"`         // Name of the run's ShipClass.
+	Score        int                `json:"score"`        // Score so far.
+	Scrap        int                `json:"scrap"`        // Unspent scrap.
+	Level        int                `json:"level"`        // Numbered level reached.
+	BonusRound   bool               `json:"bonusRound"`   // The run is in the gold rush after Level.
+	LevelTicks   int                `json:"levelTicks"`   // Ticks into the level's speed curve.
+	BaseVelocity float64            `json:"baseVelocity"` // Current meteor speed.
+	BeatWait     int                `json:"beatWait"`     // Heartbeat interval in milliseconds.
+	Spawned      int                `json:"spawned"`      // Large meteors the level has spawned.
+	ClockTicks   int                `json:"clockTicks"`   // Elapsed ticks of a timed wave.
+	BossStanding bool               `json:"bossStanding"` // A boss level's boss is still to be beaten.
+	GoldChain    int                `json:"goldChain"`    // Gold meteors caught in a row.
+	ComboKills   int                `json:"comboKills"`   // Kills in the running combo.
+	ComboTicks   int                `json:"comboTicks"`   // Ticks since the combo's last kill.
+	SmartBomb    bool               `json:"smartBomb"`    // The level's smart bomb is unused.
+	SpareBombs   int                `json:"spareBombs"`   // Smart bombs bought in the salvage shop.
+	Assisted     bool               `json:"assisted"`     // An assist has been used.
+	Stats        RunStats           `json:"stats"`        // Statistics so far.
+	Ticks        int                `json:"ticks"`        // Ticks in play so far.
+	Player       suspendedShip      `json:"player"`       // The ship.
+	Meteors      []suspendedRock    `json:"meteors"`      // Meteors in play, in ID order.
+	Aliens       []suspendedAlien   `json:"aliens"`       // Aliens in play, in ID order.
+	Boss         *suspendedBoss     `json:"boss"`         // The boss, if one is on the field.
+	ArenaInset   float64            `json:"arenaInset"`   // How far a shrinking arena has closed in.
+	ArenaDelay   int                `json:"arenaDelay"`   // Elapsed ticks of the arena's grace period.
+	Spawns       sim.SpawnHistory   `json:"spawns"`       // What the spawn director has seen of the ship.
+	Script       sim.ScriptProgress `json:"script"`       // How far the level's script has got.
+	State        []byte             `json:"state"`        // Encoded sim.Snapshot of the kept entities.
+}
+
+// suspendedShip is the saved state of the player's ship.
+type suspendedShip struct {
+	Position Vector               `json:"position"` // Top-left of the sprite.
+	Rotation float64              `json:"rotation"` // Heading in radians.
+	Velocity Vector               `json:"velocity"` // World units per second.
+	Lives    int                  `json:"lives"`    // Lives remaining.
+	Shields  int                  `json:"shields"`  // Shield charges remaining.
+	Energy   float64              `json:"energy"`   // Energy pool, for modes with energy handling.
+	Upgrades [runUpgradeCount]int `json:"upgrades"` // Levels of each run upgrade taken.
+}
+
+// suspendedRock is the saved state of one meteor.
+type suspendedRock struct {
+	Small         bool    `json:"small"`         // Uses the small sprites.
+	Medium        bool    `json:"medium"`        // Uses the medium sprites.
+	Sprite        int     `json:"sprite"`        // Index into its sprite set.
+	Gold          bool    `json:"gold"`          // Bonus-round meteor.
+	Position      Vector  `json:"position"`      // World position.
+	Movement      Vector  `json:"movement"`      // Velocity.
+	Rotation      float64 `json:"rotation"`      // Current rotation.
+	RotationSpeed float64 `json:"rotationSpeed"` // Spin in radians per second.
+	Angle         float64 `json:"angle"`         // Rotation seed.
+	Health        int     `json:"health"`        // Laser hits left before it splits.
+}
+
+// suspendedAlien is the saved state of one alien.
+type suspendedAlien struct {
+	Sprite      int            `json:"sprite"`      // Index into assets.AlienSprites.
+	Position    Vector         `json:"position"`    // World position.
+	Movement    Vector         `json:"movement"`    // Velocity.
+	Intelligent bool           `json:"intelligent"` // Tracks the player.
+	Armor       int            `json:"armor"`       // Hits left to shrug off.
+	Orbit       *suspendedRing `json:"orbit"`       // Ring motion; nil for straight flight.
+}
+
+// suspendedRing is the saved state of a circling alien's ring.
+type suspendedRing struct {
+	Center Vector  `json:"center"` // Ring center.
+	Drift  Vector  `json:"drift"`  // Velocity of the center.
+	Radius float64 `json:"radius"` // Distance from the center.
+	Angle  float64 `json:"angle"`  // Current angle around the center.
+	Spin   float64 `json:"spin"`   // Radians per second.
+}
+
+// suspendedBoss is the saved state of the boss.
+type suspendedBoss struct {
+	OrbitAngle float64 `json:"orbitAngle"` // Angle around screen center.
+	OrbitDist  float64 `json:"orbitDist"`  // Distance from screen center.
+	Rotation   float64 `json:"rotation"`   // Body rotation.
+	Health     []int   `json:"health"`     // Hits left on each weak point.
+}
+
+// canSuspend reports whether the run can be saved for later: a live run of
+// a mode replays know about, with the ship not in the middle of exploding.
+func (g *GameScene) canSuspend() bool {
+	_, ok := modeNamed(g.mode.Name)
+	return ok && g.playback == nil && !g.player.isDying && !g.playerIsDead
+}
+
+// suspend writes the run to the suspended-run slot, replacing any run
+// already there.
+func (g *GameScene) suspend() error {
+	if !g.canSuspend() {
+		return fmt.Errorf("asteroids: %s run cannot be suspended", g.mode.Name)
+	}
+	r := SuspendedRun{
+		Version:      suspendedRunVersion,
+		Saved:        time.Now(),
+		Mode:         g.mode.Name,
+		Seed:         g.seed,
+		Draws:        g.rng.draws(),
+		Upgrades:     g.upgrades,
+		Difficulty:   g.difficulty.Name,
+		Ship:         g.ship.Name,
+		Score:        g.score,
+		Scrap:        g.scrap,
+		Level:        g.currentLevel,
+		BonusRound:   g.isBonusRound(),
+		LevelTicks:   g.levelTicks,
+		BaseVelocity: g.baseVelocity,
+		BeatWait:     g.beatWaitTime,
+		GoldChain:    g.goldChain,
+		ComboKills:   g.combo.kills,
+		ComboTicks:   g.combo.window.Elapsed,
+		SmartBomb:    g.smartBombReady,
+		SpareBombs:   g.spareSmartBombs,
+		Assisted:     g.assisted,
+		Stats:        *g.stats,
+		Ticks:        g.stats.ticks,
+		Spawns:       g.spawns.History,
+		Script:       g.script.Progress(),
+		Player: suspendedShip{
+			Position: g.player.position,
+			Rotation: g.player.rotation,
+			Velocity: g.player.velocity,
+			Lives:    g.player.livesRemaning,
+			Shields:  g.player.shieldsRemaning,
+			Upgrades: g.player.runUpgrades,
+		},
+	}
+	wave := g.waves.Progress()
+	r.Spawned, r.BossStanding, r.ClockTicks = wave.Spawned, wave.BossStanding, wave.ClockTicks
+	if g.arena != nil {
+		r.ArenaInset, r.ArenaDelay = g.arena.inset, g.arena.delay.Elapsed
+	}
+	if g.player.energy != nil {
+		r.Player.Energy = g.player.energy.current
+	}
+	for _, m := range inOrder(g.meteors) {
+		small, medium := m.meteorObj.Tags().Has(TagSmall), m.meteorObj.Tags().Has(TagMedium)
+		sprites := assets.MeteorSprites
+		switch {
+		case small:
+			sprites = assets.MeteorSpritesSmall
+		case medium:
+			sprites = assets.MeteorSpritesMedium
+		}
+		r.Meteors = append(r.Meteors, suspendedRock{
+			Small:         small,
+			Medium:        medium,
+			Sprite:        slices.Index(sprites, m.sprite),
+			Gold:          m.gold,
+			Position:      m.position,
+			Movement:      m.movement,
+			Rotation:      m.rotation,
+			RotationSpeed: m.rotationSpeed,
+			Angle:         m.angle,
+			Health:        m.health,
+		})
+	}
+	for _, a := range inOrder(g.aliens) {
+		saved := suspendedAlien{
+			Sprite:      slices.Index(assets.AlienSprites, a.sprite),
+			Position:    a.position,
+			Movement:    a.movement,
+			Intelligent: a.isIntelligent,
+			Armor:       a.armor,
+		}
+		if o := a.orbit; o != nil {
+			saved.Orbit = &suspendedRing{Center: o.center, Drift: o.drift, Radius: o.radius, Angle: o.angle, Spin: o.spin}
+		}
+		r.Aliens = append(r.Aliens, saved)
+	}
+	if b := g.boss; b != nil {
+		saved := &suspendedBoss{OrbitAngle: b.orbitAngle, OrbitDist: b.orbitDist, Rotation: b.rotation}
+		for _, wp := range b.weakPoints {
+			saved.Health = append(saved.Health, wp.health)
+		}
+		r.Boss = saved
+	}
+
+	state, err := g.suspendableSnapshot(g.stats.ticks).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	r.State = state
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return saves.Write(suspendedRunFileName, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// hasSuspendedRun reports whether a suspended run is waiting to be resumed.
+func hasSuspendedRun() bool {
+	return saves.Exists(suspendedRunFileName)
+}
+
+// loadSuspendedRun reads the suspended run.
+func loadSuspendedRun() (*SuspendedRun, error) {
+	data, err := saves.Read(suspendedRunFileName)
+	if err != nil {
+		return nil, err
+	}
+	r := &SuspendedRun{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, err
+	}
+	if r.Version != suspendedRunVersion {
+		return nil, fmt.Errorf("asteroids: unsupported suspended run version %d", r.Version)
+	}
+	return r, nil
+}
+
+// discardSuspendedRun empties the suspended-run slot.
+func discardSuspendedRun() error {
+	return saves.Remove(suspendedRunFileName)
+}
+
+// ResumeRun rebuilds the suspended run and empties the slot. The resumed
+// run records no replay, since it does not start from the top.
+func ResumeRun() (*GameScene, error) {
+	r, err := loadSuspendedRun()
+	if err != nil {
+		return nil, err
+	}
+	if err := discardSuspendedRun(); err != nil {
+		return nil, err
+	}
+	mode, ok := modeNamed(r.Mode)
+	if !ok {
+		return nil, fmt.Errorf("asteroids: suspended run of unknown mode %q", r.Mode)
+	}
+	g := newGameScene(mode, r.Seed, r.Upgrades, difficultyNamed(r.Difficulty), shipClassNamed(r.Ship))
+	g.replay = nil
+
+	// Level and pacing.
+	g.currentLevel = r.Level
+	g.level = g.levelFor(r.Level)
+	if r.BonusRound {
+		g.level = levels.BonusRoundFor(r.Level)
+	}
+	g.waves.StartLevel(g.level)
+	g.waves.Resume(sim.WaveProgress{Spawned: r.Spawned, BossStanding: r.BossStanding, ClockTicks: r.ClockTicks})
+	g.levelTicks = r.LevelTicks
+	g.baseVelocity = r.BaseVelocity
+	g.beatWaitTime = r.BeatWait
+	if g.arena != nil {
+		g.arena.inset, g.arena.delay.Elapsed = r.ArenaInset, r.ArenaDelay
+	}
+	g.beatTimer = sim.NewTimer(time.Millisecond * time.Duration(g.beatWaitTime))
+	g.goldChain = r.GoldChain
+	g.combo.kills, g.combo.window.Elapsed = r.ComboKills, min(r.ComboTicks, g.combo.window.Target)
+	g.smartBombReady = r.SmartBomb
+	g.spareSmartBombs = r.SpareBombs
+	g.score = r.Score
+	g.scrap = r.Scrap
+	g.assisted = r.Assisted
+	g.stats = &r.Stats
+	g.stats.ticks = r.Ticks
+	g.wave = newWaveStats(g)
+	g.spawns.History = r.Spawns
+	g.script = sim.NewLevelScript(g.level)
+	g.script.Resume(r.Script)
+
+	g.restoreShip(r.Player)
+	for _, m := range r.Meteors {
+		if err := g.restoreMeteor(m); err != nil {
+			return nil, err
+		}
+	}
+	for _, a := range r.Aliens {
+		if err := g.restoreAlien(a); err != nil {
+			return nil, err
+		}
+	}
+	if r.Boss != nil {
+		g.restoreBoss(*r.Boss)
+	}
+
+	// Last, so the rolls made rebuilding the boss don't count.
+	g.rng = newRunRNG(r.Seed, r.Draws)
+	g.checkRestoredState(r.State)
+	return g, nil
+}
+
+// checkRestoredState logs where the rebuilt field differs from the encoded
+// snapshot of the field that was suspended.
+func (g *GameScene) checkRestoredState(state []byte) {
+	var saved sim.Snapshot
+	if err := saved.UnmarshalBinary(state); err != nil {
+		log.Println("Error reading suspended run state", err)
+		return
+	}
+	if d := sim.Diff(saved, g.suspendableSnapshot(int(saved.Tick))); !d.IsEmpty() {
+		log.Printf("Suspended run restored inexactly: %d created, %d changed, %d destroyed", len(d.Created), len(d.Updated), len(d.Destroyed))
+	}
+}
+
+// restoreShip puts the ship back where it was, with the saved lives and
+// shields shown in the HUD.
+func (g *GameScene) restoreShip(s suspendedShip) {
+	p := g.player
+	p.position = s.Position
+	p.rotation = s.Rotation
+	p.velocity = s.Velocity
+	p.playerObj.SetPosition(s.Position.X, s.Position.Y)
+	if p.energy != nil {
+		p.energy.current = s.Energy
+	}
+
+	p.livesRemaning = s.Lives
+	p.shieldsRemaning = s.Shields
+	p.runUpgrades = s.Upgrades
+}
+
+// restoreMeteor adds a saved meteor to the field.
+func (g *GameScene) restoreMeteor(s suspendedRock) error {
+	sprites, tags := assets.MeteorSprites, TagMeteor|TagLarge
+	switch {
+	case s.Small:
+		sprites, tags = assets.MeteorSpritesSmall, TagMeteor|TagSmall
+	case s.Medium:
+		sprites, tags = assets.MeteorSpritesMedium, TagMeteor|TagMedium
+	}
+	if s.Sprite < 0 || s.Sprite >= len(sprites) {
+		return fmt.Errorf("asteroids: suspended meteor has unknown sprite %d", s.Sprite)
+	}
+	m := g.pools.meteors.Get()
+	m.reuse(Meteor{
+		position:      s.Position,
+		movement:      s.Movement,
+		rotation:      s.Rotation,
+		rotationSpeed: s.RotationSpeed,
+		angle:         s.Angle,
+		sprite:        sprites[s.Sprite],
+		gold:          s.Gold,
+		health:        s.Health,
+	})
+	m.attach(g, g.meteorCount+1, tags)
+	g.addMeteor(m)
+	g.waves.TrackSplit() // Alive again, without touching the restored budget.
+	return nil
+}
+
+// restoreAlien adds a saved alien to the field.
+func (g *GameScene) restoreAlien(s suspendedAlien) error {
+	if s.Sprite < 0 || s.Sprite >= len(assets.AlienSprites) {
+		return fmt.Errorf("asteroids: suspended alien has unknown sprite %d", s.Sprite)
+	}
+	sprite := assets.AlienSprites[s.Sprite]
+	a := &Alien{
+		game:          g,
+		sprite:        sprite,
+		position:      s.Position,
+		movement:      s.Movement,
+		isIntelligent: s.Intelligent,
+		armor:         s.Armor,
+		alienObj:      resolv.NewCircle(s.Position.X, s.Position.Y, float64(sprite.Bounds().Dx()/2)),
+	}
+	if o := s.Orbit; o != nil {
+		a.orbit = &alienOrbit{center: o.Center, drift: o.Drift, radius: o.Radius, angle: o.Angle, spin: o.Spin}
+	}
+	a.alienObj.SetPosition(s.Position.X, s.Position.Y)
+	a.alienObj.Tags().Set(TagAlien)
+	g.space.Add(a.alienObj)
+	g.alienCount++
+	g.aliens[g.alienCount] = a
+	return nil
+}
+
+// restoreBoss brings the boss back with its saved orbit and damage.
+func (g *GameScene) restoreBoss(s suspendedBoss) {
+	b := NewBoss(g)
+	b.orbitAngle, b.orbitDist, b.rotation = s.OrbitAngle, s.OrbitDist, s.Rotation
+	for i, wp := range b.weakPoints {
+		if i < len(s.Health) {
+			wp.health = s.Health[i]
+		}
+	}
+	b.place()
+	g.boss = b
+	g.space.Add(b.bodyObj)
+	for _, wp := range b.weakPoints {
+		if wp.health > 0 {
+			g.space.Add(wp.obj)
+		}
+	}
+}
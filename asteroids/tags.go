@@ -9,10 +9,18 @@ import "github.com/solarlune/resolv"
 //
 // Example: player lasers collide only with TagMeteor or TagAlien objects.
 var (
-	TagPlayer = resolv.NewTag("player") // Marks the player ship.
-	TagAlien  = resolv.NewTag("alien")  // Marks alien ships.
-	TagLaser  = resolv.NewTag("laser")  // Marks both player and alien lasers.
-	TagMeteor = resolv.NewTag("meteor") // Marks meteors of all sizes.
-	TagSmall  = resolv.NewTag("small")  // Subtag for small meteor fragments.
-	TagLarge  = resolv.NewTag("large")  // Subtag for large meteor bodies.
+	TagPlayer  = resolv.NewTag("player")   // Marks the player ship.
+	TagAlien   = resolv.NewTag("alien")    // Marks alien ships.
+	TagLaser   = resolv.NewTag("laser")    // Marks both player and alien lasers.
+	TagBolt    = resolv.NewTag("bolt")     // Subtag for player lasers.
+	TagOrb     = resolv.NewTag("orb")      // Subtag for alien lasers.
+	TagMeteor  = resolv.NewTag("meteor")   // Marks meteors of all sizes.
+	TagSmall   = resolv.NewTag("small")    // Subtag for small meteor fragments.
+	TagMedium  = resolv.NewTag("medium")   // Subtag for medium meteors, the pieces of a large one.
+	TagLarge   = resolv.NewTag("large")    // Subtag for large meteor bodies.
+	TagPowerUp = resolv.NewTag("power-up") // Marks collectible power-ups.
+	TagBoss    = resolv.NewTag("boss")     // Marks every boss collider.
+	TagWeak    = resolv.NewTag("weak")     // Subtag for boss weak points.
+	TagComet   = resolv.NewTag("comet")    // Marks the comet hazard.
+	TagMine    = resolv.NewTag("mine")     // Marks proximity mine triggers.
 )
@@ -0,0 +1,92 @@
+// File title-replay.go plays the best saved run back, muted and dimmed,
+// behind the title menu.
+//
+// The run is the deterministic Replay saved as the best one, re-simulated
+// tick by tick through a GameScene exactly as the -replay flag plays it,
+// level banners included. Scene changes cut rather than cross-fade, and
+// when the run plays out it starts again from the top.
+package asteroids
+
+import (
+	"errors"
+	"io/fs"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// titleReplayDim is the darkening applied over the playback.
+const titleReplayDim = 150
+
+// TitleReplay re-simulates a saved run for the title screen.
+type TitleReplay struct {
+	replay *Replay       // The run being played.
+	scenes *SceneManager // Scenes the run passes through; never transitioning.
+	input  *Input        // Input handed to those scenes, which the replay overrides.
+}
+
+// loadTitleReplay returns the best saved run ready to play behind the title
+// menu, or nil when the option is off, no run has been saved, or the saved
+// one cannot be played.
+func loadTitleReplay() *TitleReplay {
+	if !config.TitleReplay {
+		return nil
+	}
+	data, err := saves.Read(replayBestFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		log.Println("Error loading title replay", err)
+		return nil
+	}
+	r := &Replay{}
+	if err := r.UnmarshalBinary(data); err != nil {
+		log.Println("Error loading title replay", err)
+		return nil
+	}
+	t := &TitleReplay{replay: r, input: &Input{}}
+	if !t.restart() {
+		return nil
+	}
+	return t
+}
+
+// restart plays the run again from its first tick, reporting whether it
+// could be rebuilt.
+func (t *TitleReplay) restart() bool {
+	g, err := NewReplayScene(t.replay)
+	if err != nil {
+		log.Println("Error loading title replay", err)
+		return false
+	}
+	g.playback.background = true
+	t.scenes = &SceneManager{}
+	t.scenes.GoToScene(g)
+	return true
+}
+
+// Update advances the run one tick without a sound, cutting straight to
+// any scene it moves on to and starting over once it reaches game over.
+func (t *TitleReplay) Update() error {
+	var err error
+	sharedAudio().whileMuted(func() {
+		err = t.scenes.Update(t.input)
+	})
+	if err != nil {
+		return err
+	}
+	if next := t.scenes.next; next != nil {
+		t.scenes.current, t.scenes.next, t.scenes.transitionCount = next, nil, 0
+	}
+	if _, over := t.scenes.current.(*GameOverScene); over {
+		t.restart()
+	}
+	return nil
+}
+
+// Draw renders the run's current scene, dimmed to sit behind the menu.
+func (t *TitleReplay) Draw(screen *ebiten.Image) {
+	t.scenes.Draw(screen)
+	dimScreen(screen, titleReplayDim)
+}
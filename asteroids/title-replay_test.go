@@ -0,0 +1,38 @@
+// File title-replay_test.go checks that the title screen's replay plays a
+// recorded run through to its end and then starts it over.
+package asteroids
+
+import "testing"
+
+func TestTitleReplayStartsOverAtTheEnd(t *testing.T) {
+	const ticks = 90
+	live := newGameScene(ModeStandard, 3, Upgrades{}, difficulties[0], shipClasses[0])
+	state := &State{SceneManager: &SceneManager{}, Input: &Input{}}
+	state.SceneManager.GoToScene(live)
+	for range ticks {
+		if err := live.Update(state); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tr := &TitleReplay{replay: live.replay, input: &Input{}}
+	if !tr.restart() {
+		t.Fatal("the recorded run does not play")
+	}
+	first := tr.scenes.current
+	for range ticks + 1 {
+		if err := tr.Update(); err != nil {
+			t.Fatal(err)
+		}
+		if sharedAudio().muted {
+			t.Fatal("the mix stayed muted after the replay's tick")
+		}
+	}
+	g, ok := tr.scenes.current.(*GameScene)
+	if !ok || g == first {
+		t.Fatalf("after the run played out the title shows %T, want the run from the top", tr.scenes.current)
+	}
+	if g.playback.played != 0 || !g.playback.background {
+		t.Errorf("restarted replay has played %d ticks, background %t; want 0, true", g.playback.played, g.playback.background)
+	}
+}
@@ -1,5 +1,6 @@
 // File title_scene.go implements the TitleScene, which draws the title screen,
-// animated background (stars + drifting meteors), and handles start input.
+// animated background (the best saved run replaying, or stars + drifting
+// meteors), and the main menu.
 package asteroids
 
 import (
@@ -8,43 +9,68 @@ import (
 
 	"github.com/bensabler/asteroids/assets"
 	"github.com/hajimehoshi/ebiten/v2"
-	inpututil "github.com/hajimehoshi/ebiten/v2/inpututil"
 	text "github.com/hajimehoshi/ebiten/v2/text/v2"
 )
 
+// Title menu option indices.
+const (
+	titleStart = iota
+	titleClassic
+	titleModern
+	titleNewGamePlus
+	titleArena
+	titleWalls
+	titlePractice
+	titleTournament
+	titleVersus
+	titleStats
+	titleHighScores
+	titleShop
+	titleSettings
+	titleQuit
+)
+
 // TitleScene renders the title UI and ambient background elements.
 type TitleScene struct {
 	meteors     map[int]*Meteor // Background drifting meteors.
 	meteorCount int             // Monotonic ID source for meteors.
 	stars       []*Star         // Starfield for depth/parallax.
+	menu        *Menu           // [Continue /] Start / Classic / Modern / New Game+ / Arena / Walls / Practice / Tournament / Versus / Stats / High Scores / Shop / Settings / Quit.
+	replay      *TitleReplay    // Best saved run playing behind the menu; nil for the drifting meteors.
+	replayOn    bool            // Config.TitleReplay when the replay was last loaded.
+	continuable bool            // A suspended run heads the menu as "Continue".
 }
 
-// highScore is the best score observed across sessions.
-// originalHighScore captures the value at boot for display/reference.
-var (
-	highScore         int
-	originalHighScore int
-)
-
-// init loads persisted high score (best-effort).
-func init() {
-	hs, err := getHighScore()
-	if err != nil {
-		log.Println("Error getting high score", err)
+// NewTitleScene returns a title screen with a fresh starfield and an empty
+// meteor collection that fills in gradually.
+func NewTitleScene() *TitleScene {
+	t := &TitleScene{
+		meteors:  make(map[int]*Meteor),
+		stars:    GenerateStars(starCount(), ambientRNG),
+		menu:     NewMenu("Start", "Classic", "Modern", newGamePlusLabel(), "Arena", "Walls", "Practice", "Tournament", "Versus", "Stats", "High Scores", "Shop", "Settings", "Quit"),
+		replay:   loadTitleReplay(),
+		replayOn: config.TitleReplay,
+	}
+	if hasSuspendedRun() {
+		t.continuable = true
+		t.menu.items = append([]string{"Continue"}, t.menu.items...)
 	}
-	highScore = hs
-	originalHighScore = hs
+	return t
 }
 
-// Draw renders the starfield, title text, and atmospheric meteors.
+// Draw renders the starfield, title text, atmospheric meteors, and menu.
 func (t *TitleScene) Draw(screen *ebiten.Image) {
-	// 1) Background stars.
-	for _, star := range t.stars {
-		star.Draw(screen)
+	// 1) The best run's replay when there is one, else background stars.
+	if t.replay != nil {
+		t.replay.Draw(screen)
+	} else {
+		for _, star := range t.stars {
+			star.Draw(screen)
+		}
 	}
 
-	// 2) Title text centered on the screen.
-	//    LayoutOptions controls alignment; GeoM translates to screen center.
+	// 2) Title text above the screen center.
+	//    LayoutOptions controls alignment; GeoM translates into place.
 	const title = "ASTEROIDS"
 	op := &text.DrawOptions{
 		LayoutOptions: text.LayoutOptions{
@@ -52,38 +78,120 @@ func (t *TitleScene) Draw(screen *ebiten.Image) {
 		},
 	}
 	op.ColorScale.ScaleWithColor(color.White)
-	op.GeoM.Translate(float64(ScreenWidth/2), float64(ScreenHeight/2))
-	text.Draw(screen, title, &text.GoTextFace{
+	op.GeoM.Translate(float64(ScreenWidth/2), float64(ScreenHeight/2-320))
+	drawText(screen, title, &text.GoTextFace{
 		Source: assets.TitleFont,
 		Size:   72,
 	}, op)
 
-	// 3) Foreground meteors for motion/interest.
-	for _, m := range t.meteors {
-		m.Draw(screen)
+	// 3) Foreground meteors for motion/interest, unless the replay provides it.
+	if t.replay == nil {
+		for _, m := range t.meteors {
+			m.Draw(screen)
+		}
 	}
+
+	// 4) Menu below the title.
+	t.menu.Draw(screen, float64(ScreenWidth/2), float64(ScreenHeight/2-230))
 }
 
-// Update advances background animations and handles "start" input.
+// Update advances background animations and handles menu input.
 //
-// Input:
-//   - Space key: transition from TitleScene to the main GameScene.
+// Menu:
+//   - Continue: resume a run suspended from the pause menu, paused, when
+//     there is one.
+//   - Start:    pick a ship on the ShipSelectScene, then play the main GameScene.
+//   - Classic:  same, using the original arcade ruleset.
+//   - Modern:   same, with shield, hyperspace, and afterburner on one energy meter.
+//   - New Game+: same, harder, once unlocked by reaching the milestone level.
+//   - Arena:    same, inside an energy wall that closes in every level.
+//   - Walls:    same, with solid screen edges that everything bounces off.
+//   - Practice: same, as a sandbox with infinite lives and chosen spawns.
+//   - Tournament: enter player names for a local knockout bracket.
+//   - Versus:   two ships duel on one keyboard.
+//   - Stats:    view and export run statistics, returning here afterwards.
+//   - High Scores: view the top-ten table, returning here afterwards.
+//   - Shop:     spend crystals on upgrades, returning here afterwards.
+//   - Settings: open the SettingsScene, returning here afterwards.
+//   - Quit:     request Ebiten termination.
 //
 // Behavior:
-//   - Ensures up to 10 ambient meteors exist; spawns gradually.
-//   - Steps all meteors one tick.
+//   - Plays the best saved run one tick, when the title replays it.
+//   - Otherwise ensures up to 10 ambient meteors exist, spawning them
+//     gradually, and steps all meteors one tick.
 func (t *TitleScene) Update(state *State) error {
-	// Start game on Space.
-	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
-		state.SceneManager.GoToScene(NewGameScene())
+	choice := t.menu.Update()
+	if t.continuable && choice >= 0 {
+		if choice == 0 {
+			t.resume(state)
+			return nil
+		}
+		choice-- // The fixed options sit below Continue.
+	}
+
+	switch choice {
+	case titleStart:
+		state.SceneManager.GoToScene(NewShipSelectScene(ModeStandard))
+		return nil
+	case titleClassic:
+		state.SceneManager.GoToScene(NewShipSelectScene(ModeClassic))
+		return nil
+	case titleModern:
+		state.SceneManager.GoToScene(NewShipSelectScene(ModeModern))
+		return nil
+	case titleNewGamePlus:
+		if profile.NewGamePlus.Unlocked {
+			state.SceneManager.GoToScene(NewShipSelectScene(ModeNewGamePlus))
+		}
+		return nil
+	case titleArena:
+		state.SceneManager.GoToScene(NewShipSelectScene(ModeArena))
+		return nil
+	case titleWalls:
+		state.SceneManager.GoToScene(NewShipSelectScene(ModeWalls))
+		return nil
+	case titlePractice:
+		state.SceneManager.GoToScene(NewShipSelectScene(ModePractice))
 		return nil
+	case titleTournament:
+		state.SceneManager.GoToScene(NewTournamentEntryScene())
+		return nil
+	case titleVersus:
+		state.SceneManager.GoToScene(NewVersusScene())
+		return nil
+	case titleStats:
+		state.SceneManager.PushScene(NewStatsScene())
+		return nil
+	case titleHighScores:
+		state.SceneManager.PushScene(NewHighScoresScene())
+		return nil
+	case titleShop:
+		state.SceneManager.PushScene(NewShopScene())
+		return nil
+	case titleSettings:
+		state.SceneManager.PushScene(NewSettingsScene(nil))
+		return nil
+	case titleQuit:
+		return ebiten.Termination
+	}
+
+	// Keep the starfield in step with the density setting.
+	if len(t.stars) != starCount() {
+		t.stars = GenerateStars(starCount(), ambientRNG)
+	}
+
+	// The settings may have turned the replay on or off.
+	if config.TitleReplay != t.replayOn {
+		t.replay, t.replayOn = loadTitleReplay(), config.TitleReplay
+	}
+	if t.replay != nil {
+		return t.replay.Update()
 	}
 
 	// Maintain a small pool of ambient meteors (cap: 10).
 	if len(t.meteors) < 10 {
 		// Base velocity tuned low for a gentle drift on title.
-		// GameScene receiver is unused here; NewMeteor requires it.
-		meteor := NewMeteor(0.25, &GameScene{}, len(t.meteors)-1)
+		meteor := NewDecorativeMeteor(baseMeteorVelocity)
 		t.meteorCount++
 		t.meteors[t.meteorCount] = meteor
 	}
@@ -94,3 +202,21 @@ func (t *TitleScene) Update(state *State) error {
 	}
 	return nil
 }
+
+// resume rebuilds the suspended run and opens it behind the pause menu, so
+// the player can get their bearings. A run that cannot be read is discarded
+// and dropped from the menu.
+func (t *TitleScene) resume(state *State) {
+	g, err := ResumeRun()
+	if err != nil {
+		log.Println("Error resuming run", err)
+		if err := discardSuspendedRun(); err != nil {
+			log.Println("Error discarding run", err)
+		}
+		t.continuable = false
+		t.menu.items = t.menu.items[1:]
+		t.menu.selected = 0
+		return
+	}
+	state.SceneManager.GoToScene(NewPauseScene(g))
+}
@@ -0,0 +1,73 @@
+// File toast.go defines Toast, a short notice shown over whichever scene is
+// active, optionally offering an action the player can take with one key
+// while it is up.
+package asteroids
+
+import (
+	"image/color"
+	"time"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/hajimehoshi/ebiten/v2"
+	inpututil "github.com/hajimehoshi/ebiten/v2/inpututil"
+	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Toast layout.
+const (
+	toastFontSize = 16.0 // Font size of the message.
+	toastPadding  = 10.0 // Space between the message and the panel's edge.
+	toastBottom   = 80.0 // Distance from the screen's bottom to the panel's.
+	toastFade     = 20   // Ticks the toast fades out over at the end.
+)
+
+// Toast is a timed notice drawn on top of every scene.
+type Toast struct {
+	message string     // Text shown, including the key hint for the action.
+	key     ebiten.Key // Key that runs action.
+	action  func()     // Run when key is pressed while the toast is up; nil for none.
+	timer   *Timer     // Time the toast has been up; it is done when ready.
+}
+
+// NewToast returns a toast showing message for d.
+func NewToast(message string, d time.Duration) *Toast {
+	return &Toast{message: message, timer: sim.NewTimer(d)}
+}
+
+// withAction has pressing key while the toast is up run action and close
+// the toast.
+func (t *Toast) withAction(key ebiten.Key, action func()) *Toast {
+	t.key, t.action = key, action
+	return t
+}
+
+// Update ages the toast and runs its action if the key was pressed. It
+// reports whether the toast is done.
+func (t *Toast) Update() bool {
+	if t.action != nil && inpututil.IsKeyJustPressed(t.key) {
+		t.action()
+		return true
+	}
+	t.timer.Update()
+	return t.timer.IsReady()
+}
+
+// Draw renders the message on a dark panel near the bottom of the screen,
+// fading out over its last toastFade ticks.
+func (t *Toast) Draw(screen *ebiten.Image) {
+	alpha := min(1, float32(t.timer.Target-t.timer.Elapsed)/toastFade)
+	face := &text.GoTextFace{Source: assets.ScoreFont, Size: toastFontSize}
+	w, h := text.Measure(t.message, face, 0)
+	x := float32(ScreenWidth/2 - w/2 - toastPadding)
+	y := float32(ScreenHeight - toastBottom - h - 2*toastPadding)
+	pw, ph := float32(w+2*toastPadding), float32(h+2*toastPadding)
+	fillRect(screen, x, y, pw, ph, color.RGBA{A: uint8(200 * alpha)}, false)
+	strokeRect(screen, x, y, pw, ph, 1, color.RGBA{R: uint8(160 * alpha), G: uint8(160 * alpha), B: uint8(160 * alpha), A: uint8(255 * alpha)}, false)
+
+	op := &text.DrawOptions{}
+	op.ColorScale.ScaleWithColor(color.White)
+	op.ColorScale.ScaleAlpha(alpha)
+	op.GeoM.Translate(float64(x)+toastPadding, float64(y)+toastPadding)
+	drawText(screen, t.message, face, op)
+}
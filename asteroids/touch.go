@@ -0,0 +1,153 @@
+// File touch.go defines the touch control layer for phones, tablets, and
+// touch laptops: virtual rotate and thrust buttons under the left thumb,
+// and fire and shield zones under the right. It turns on with the first
+// touch and off again at the next key press, and while on it holds actions
+// down on Input alongside the keyboard, so the rest of the game reads
+// touches the same way as keys. touchTapped lets the menus and prompts be
+// answered with a tap.
+package asteroids
+
+import (
+	"image/color"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+	inpututil "github.com/hajimehoshi/ebiten/v2/inpututil"
+	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Touch control layout and look.
+const (
+	touchButtonRadius = 56.0 // Radius of a virtual button.
+	touchButtonSlop   = 1.2  // How far past its radius, as a multiple, a button still takes a touch.
+	touchLabelSize    = 24.0 // Font size of the control labels.
+	touchIdleAlpha    = 40   // Fill of a control not being touched.
+	touchHeldAlpha    = 110  // Fill of a control being touched.
+)
+
+// touchButton is a round virtual button.
+type touchButton struct {
+	action Action // Action held while the button is touched.
+	label  string // Drawn at its center.
+	center Vector // Screen-space center.
+}
+
+// touchZone is a rectangular region of the screen that holds an action.
+type touchZone struct {
+	action Action // Action held while the zone is touched.
+	label  string // Drawn at its center.
+	min    Vector // Top-left corner.
+	max    Vector // Bottom-right corner.
+}
+
+// touchButtons are the flight controls in the bottom-left corner.
+var touchButtons = []touchButton{
+	{action: ActionRotateLeft, label: "<", center: Vector{X: 100, Y: ScreenHeight - 100}},
+	{action: ActionRotateRight, label: ">", center: Vector{X: 240, Y: ScreenHeight - 100}},
+	{action: ActionThrust, label: "^", center: Vector{X: 170, Y: ScreenHeight - 225}},
+}
+
+// touchZones are the weapon controls along the right of the screen.
+var touchZones = []touchZone{
+	{action: ActionShield, label: "SHIELD", min: Vector{X: ScreenWidth * 0.7, Y: ScreenHeight * 0.25}, max: Vector{X: ScreenWidth, Y: ScreenHeight * 0.5}},
+	{action: ActionFire, label: "FIRE", min: Vector{X: ScreenWidth * 0.7, Y: ScreenHeight * 0.5}, max: Vector{X: ScreenWidth, Y: ScreenHeight}},
+}
+
+// TouchControls tracks which virtual controls are being touched.
+type TouchControls struct {
+	active bool              // Touch input has been seen since the last key press.
+	held   [actionCount]bool // Actions held by a touch this frame.
+	ids    []ebiten.TouchID  // Scratch buffer for the current touches.
+	keys   []ebiten.Key      // Scratch buffer for keys pressed this frame.
+}
+
+// Update reads the current touches. Any touch turns the layer on and any
+// key press turns it off.
+func (t *TouchControls) Update() {
+	t.ids = ebiten.AppendTouchIDs(t.ids[:0])
+	t.keys = inpututil.AppendJustPressedKeys(t.keys[:0])
+	switch {
+	case len(t.ids) > 0:
+		t.active = true
+	case len(t.keys) > 0:
+		t.active = false
+	}
+
+	t.held = [actionCount]bool{}
+	if !t.active {
+		return
+	}
+	for _, id := range t.ids {
+		x, y := ebiten.TouchPosition(id)
+		if a, ok := touchControlAt(Vector{X: float64(x), Y: float64(y)}); ok {
+			t.held[a] = true
+		}
+	}
+}
+
+// touchControlAt returns the action of the control under p, if any.
+func touchControlAt(p Vector) (Action, bool) {
+	for _, b := range touchButtons {
+		if withinRadius(p, b.center, touchButtonRadius*touchButtonSlop) {
+			return b.action, true
+		}
+	}
+	for _, z := range touchZones {
+		if p.X >= z.min.X && p.X < z.max.X && p.Y >= z.min.Y && p.Y < z.max.Y {
+			return z.action, true
+		}
+	}
+	return 0, false
+}
+
+// Draw renders the controls while the layer is on, brighter where they are
+// being touched.
+func (t *TouchControls) Draw(screen *ebiten.Image) {
+	if !t.active {
+		return
+	}
+	for _, b := range touchButtons {
+		x, y := float32(b.center.X), float32(b.center.Y)
+		fillCircle(screen, x, y, touchButtonRadius, t.fill(b.action), true)
+		strokeCircle(screen, x, y, touchButtonRadius, 2, touchOutline, true)
+		drawTouchLabel(screen, b.label, b.center)
+	}
+	for _, z := range touchZones {
+		x, y := float32(z.min.X), float32(z.min.Y)
+		w, h := float32(z.max.X-z.min.X), float32(z.max.Y-z.min.Y)
+		fillRect(screen, x, y, w, h, t.fill(z.action), false)
+		strokeRect(screen, x, y, w, h, 2, touchOutline, false)
+		drawTouchLabel(screen, z.label, Vector{X: (z.min.X + z.max.X) / 2, Y: (z.min.Y + z.max.Y) / 2})
+	}
+}
+
+// touchOutline is the edge of every control.
+var touchOutline = color.RGBA{R: 160, G: 160, B: 160, A: 160} // Premultiplied.
+
+// fill returns the fill of a control holding a, in premultiplied white.
+func (t *TouchControls) fill(a Action) color.RGBA {
+	alpha := uint8(touchIdleAlpha)
+	if t.held[a] {
+		alpha = touchHeldAlpha
+	}
+	return color.RGBA{R: alpha, G: alpha, B: alpha, A: alpha}
+}
+
+// drawTouchLabel draws label centered on at.
+func drawTouchLabel(screen *ebiten.Image, label string, at Vector) {
+	op := &text.DrawOptions{
+		LayoutOptions: text.LayoutOptions{
+			PrimaryAlign:   text.AlignCenter,
+			SecondaryAlign: text.AlignCenter,
+		},
+	}
+	op.ColorScale.ScaleWithColor(touchOutline)
+	op.GeoM.Translate(at.X, at.Y)
+	drawText(screen, label, &text.GoTextFace{Source: assets.ScoreFont, Size: touchLabelSize}, op)
+}
+
+// touchTapped reports whether a touch began this frame, for prompts that
+// a tap anywhere answers.
+func touchTapped() bool {
+	return len(inpututil.AppendJustPressedTouchIDs(nil)) > 0
+}
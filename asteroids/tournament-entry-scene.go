@@ -0,0 +1,95 @@
+// File tournament-entry-scene.go implements the TournamentEntryScene, where
+// the entrants of a local tournament type in their names before the bracket
+// is drawn.
+package asteroids
+
+import (
+	"fmt"
+	"image/color"
+	"unicode"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+	inpututil "github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// tournamentNameLength caps the characters in an entrant's name.
+const tournamentNameLength = 10
+
+// TournamentEntryScene collects 4–8 player names.
+type TournamentEntryScene struct {
+	names []string // Names entered so far.
+	draft []rune   // Name being typed.
+	stars []*Star  // Backdrop starfield.
+}
+
+// NewTournamentEntryScene returns an empty name entry screen.
+func NewTournamentEntryScene() *TournamentEntryScene {
+	return &TournamentEntryScene{stars: GenerateStars(starCount(), ambientRNG)}
+}
+
+// Update handles typing.
+//
+// Letters/digits: extend the name being typed.
+// Backspace:      delete a character, or the last name if the draft is empty.
+// Enter:          add the typed name; on an empty draft, start the bracket.
+// Escape:         return to the title screen.
+func (s *TournamentEntryScene) Update(state *State) error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		state.SceneManager.GoToScene(NewTitleScene())
+		return nil
+	}
+
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if len(s.draft) < tournamentNameLength && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			s.draft = append(s.draft, unicode.ToUpper(r))
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) {
+		if len(s.draft) > 0 {
+			s.draft = s.draft[:len(s.draft)-1]
+		} else if len(s.names) > 0 {
+			s.names = s.names[:len(s.names)-1]
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		switch {
+		case len(s.draft) > 0 && len(s.names) < tournamentMaxPlayers:
+			s.names = append(s.names, string(s.draft))
+			s.draft = nil
+		case len(s.draft) == 0 && len(s.names) >= tournamentMinPlayers:
+			state.SceneManager.GoToScene(NewTournamentScene(NewTournament(s.names)))
+		}
+	}
+	return nil
+}
+
+// Draw renders the entered names, the name being typed, and a prompt.
+func (s *TournamentEntryScene) Draw(screen *ebiten.Image) {
+	for _, star := range s.stars {
+		star.Draw(screen)
+	}
+
+	drawCenteredText(screen, "TOURNAMENT", assets.TitleFont, 48, ScreenWidth/2, ScreenHeight/2-260, color.White)
+
+	for i, name := range s.names {
+		label := fmt.Sprintf("%d. %s", i+1, name)
+		drawCenteredText(screen, label, assets.ScoreFont, 24, ScreenWidth/2, float64(ScreenHeight/2-180+i*36), playerColor(i))
+	}
+
+	hint := "ENTER A NAME"
+	if len(s.names) < tournamentMaxPlayers {
+		row := float64(ScreenHeight/2 - 180 + len(s.names)*36)
+		drawCenteredText(screen, fmt.Sprintf("%d. %s_", len(s.names)+1, string(s.draft)), assets.ScoreFont, 24, ScreenWidth/2, row, color.RGBA{R: 255, G: 215, B: 0, A: 255})
+	} else {
+		hint = "BRACKET FULL"
+	}
+	if len(s.names) >= tournamentMinPlayers {
+		hint += " - ENTER ON AN EMPTY NAME TO START"
+	} else {
+		hint += fmt.Sprintf(" - %d MORE NEEDED", tournamentMinPlayers-len(s.names))
+	}
+	drawCenteredText(screen, hint, assets.ScoreFont, 16, ScreenWidth/2, ScreenHeight-80, color.Gray{Y: 180})
+}
@@ -0,0 +1,149 @@
+// File tournament-scene.go implements the TournamentScene, which shows the
+// bracket between matches, announces which two entrants meet next, and
+// crowns the champion once the final is decided.
+package asteroids
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+	inpututil "github.com/hajimehoshi/ebiten/v2/inpututil"
+	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Bracket layout.
+const (
+	bracketTop      = 130.0 // Top of the bracket area.
+	bracketBottom   = 600.0 // Bottom of the bracket area.
+	bracketMargin   = 60.0  // Left and right screen margins.
+	bracketBoxWidth = 200.0 // Width of a match's name column.
+	bracketRowGap   = 14.0  // Vertical offset of each name from its match center.
+)
+
+// TournamentScene is the bracket screen between tournament matches.
+type TournamentScene struct {
+	tournament *Tournament // Bracket in progress.
+	stars      []*Star     // Backdrop starfield.
+}
+
+// NewTournamentScene returns the bracket screen for t.
+func NewTournamentScene(t *Tournament) *TournamentScene {
+	return &TournamentScene{tournament: t, stars: GenerateStars(starCount(), ambientRNG)}
+}
+
+// Update starts the next match on Space, or after the final returns to the
+// title screen. Escape abandons the tournament.
+func (s *TournamentScene) Update(state *State) error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		state.SceneManager.GoToScene(NewTitleScene())
+		return nil
+	}
+	if !inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		return nil
+	}
+	if s.tournament.champion() != noPlayer {
+		state.SceneManager.GoToScene(NewTitleScene())
+		return nil
+	}
+	state.SceneManager.GoToScene(NewTournamentMatch(s.tournament))
+	return nil
+}
+
+// Draw renders the bracket and the prompt for the next match or the champion.
+func (s *TournamentScene) Draw(screen *ebiten.Image) {
+	for _, star := range s.stars {
+		star.Draw(screen)
+	}
+	t := s.tournament
+	drawCenteredText(screen, "TOURNAMENT", assets.TitleFont, 48, ScreenWidth/2, 40, color.White)
+	if ModeVersus.NoSpawnDirector {
+		drawCenteredText(screen, "director: off", assets.ScoreFont, 14, ScreenWidth/2, 88, color.Gray{Y: 150})
+	}
+
+	// One column per round plus one for the champion.
+	columns := len(t.rounds) + 1
+	step := (ScreenWidth - 2*bracketMargin - bracketBoxWidth) / float64(columns-1)
+	for r, round := range t.rounds {
+		x := bracketMargin + float64(r)*step
+		for i, m := range round {
+			y := bracketMatchY(i, len(round))
+			s.drawMatch(screen, m, x, y, r == 0)
+
+			// Elbow connector into the next round (or the champion slot).
+			nx := x + step
+			ny := bracketMatchY(i/2, max(1, len(round)/2))
+			if r+1 < len(t.rounds) {
+				ny += bracketRowGap * float64(2*(i%2)-1)
+			}
+			mid := float32(x + bracketBoxWidth + (step-bracketBoxWidth)/2)
+			line := color.Gray{Y: 90}
+			strokeLine(screen, float32(x+bracketBoxWidth), float32(y), mid, float32(y), 1, line, false)
+			strokeLine(screen, mid, float32(y), mid, float32(ny), 1, line, false)
+			strokeLine(screen, mid, float32(ny), float32(nx), float32(ny), 1, line, false)
+		}
+	}
+
+	champ := t.champion()
+	if champ != noPlayer {
+		x := bracketMargin + float64(columns-1)*step
+		s.drawName(screen, t.names[champ], champ, x, bracketMatchY(0, 1), true)
+		drawCenteredText(screen, "CHAMPION: "+t.names[champ], assets.TitleFont, 36, ScreenWidth/2, bracketBottom+20, color.RGBA{R: 255, G: 215, B: 0, A: 255})
+		drawCenteredText(screen, "PRESS SPACE TO FINISH", assets.ScoreFont, 16, ScreenWidth/2, ScreenHeight-50, color.Gray{Y: 180})
+		return
+	}
+
+	m := t.current()
+	status := fmt.Sprintf("%s (LEFT KEYS) VS %s (ARROWS)", t.names[m.players[0]], t.names[m.players[1]])
+	drawCenteredText(screen, status, assets.ScoreFont, 24, ScreenWidth/2, bracketBottom+20, color.White)
+	drawCenteredText(screen, fmt.Sprintf("FIRST TO %d ROUNDS - PRESS SPACE TO FIGHT", versusWinsNeeded),
+		assets.ScoreFont, 16, ScreenWidth/2, ScreenHeight-50, color.Gray{Y: 180})
+}
+
+// bracketMatchY returns the vertical center of match i of count in a round.
+func bracketMatchY(i, count int) float64 {
+	return bracketTop + (bracketBottom-bracketTop)*(float64(i)+0.5)/float64(count)
+}
+
+// drawMatch renders both slots of a match centered on y, with rounds won
+// once played. The winner is highlighted; empty slots read "BYE" in the first
+// round and "---" later on.
+func (s *TournamentScene) drawMatch(screen *ebiten.Image, m *Match, x, y float64, firstRound bool) {
+	t := s.tournament
+	for slot, p := range m.players {
+		row := y + bracketRowGap*float64(2*slot-1)
+		if p == noPlayer {
+			label := "---"
+			if firstRound {
+				label = "BYE"
+			}
+			s.drawLabel(screen, label, x, row, color.Gray{Y: 100})
+			continue
+		}
+		name := t.names[p]
+		if m.winner != noPlayer && m.players[1-slot] != noPlayer {
+			name = fmt.Sprintf("%-10s %2d", name, m.wins[slot])
+		}
+		s.drawName(screen, name, p, x, row, m.winner == p)
+	}
+}
+
+// drawName renders a player's name in their color, gold if they won.
+func (s *TournamentScene) drawName(screen *ebiten.Image, name string, player int, x, y float64, won bool) {
+	c := color.Color(playerColor(player))
+	if won {
+		c = color.RGBA{R: 255, G: 215, B: 0, A: 255}
+	}
+	s.drawLabel(screen, name, x, y, c)
+}
+
+// drawLabel draws msg left-aligned at x and vertically centered on y.
+func (s *TournamentScene) drawLabel(screen *ebiten.Image, msg string, x, y float64, c color.Color) {
+	op := &text.DrawOptions{
+		LayoutOptions: text.LayoutOptions{SecondaryAlign: text.AlignCenter},
+	}
+	op.ColorScale.ScaleWithColor(c)
+	op.GeoM.Translate(x, y)
+	drawText(screen, msg, &text.GoTextFace{Source: assets.ScoreFont, Size: 14}, op)
+}
@@ -0,0 +1,115 @@
+// File tournament.go defines Tournament, a single-elimination bracket for
+// local events. Players enter their names, are paired into 1v1 matches, and
+// winners advance round by round until one champion remains.
+//
+// Each match is a versus match between its two entrants (see
+// versus-scene.go). Drawn rounds cannot stall the bracket: a match still
+// undecided after tournamentMaxRounds goes to a tiebreak.
+package asteroids
+
+// Tournament limits.
+const (
+	tournamentMinPlayers = 4
+	tournamentMaxPlayers = 8
+	tournamentMaxRounds  = 7 // Rounds a match may run before the tiebreak decides it.
+)
+
+// noPlayer marks an empty bracket slot: a bye, or a match whose feeder
+// matches are still undecided.
+const noPlayer = -1
+
+// Match is one 1v1 pairing in the bracket.
+type Match struct {
+	players [2]int // Indices into Tournament.names, or noPlayer.
+	wins    [2]int // Versus rounds each player won, once the match is played.
+	winner  int    // Index of the winner, or noPlayer while undecided.
+}
+
+// Tournament tracks the bracket and which match is being played.
+type Tournament struct {
+	names  []string   // Entrants, in seeding order.
+	rounds [][]*Match // Matches per round, first round first.
+}
+
+// NewTournament seeds names into a bracket, padding the first round with
+// byes up to a power of two. Players drawn against a bye advance at once.
+func NewTournament(names []string) *Tournament {
+	size := 2
+	for size < len(names) {
+		size *= 2
+	}
+
+	// Spread byes across the first round so no match pairs two of them.
+	slots := make([]int, size)
+	for i := range slots {
+		slots[i] = noPlayer
+	}
+	for i := range names {
+		if i < size/2 {
+			slots[2*i] = i
+		} else {
+			slots[2*(i-size/2)+1] = i
+		}
+	}
+
+	t := &Tournament{names: names}
+	for n := size / 2; n >= 1; n /= 2 {
+		round := make([]*Match, n)
+		for i := range round {
+			round[i] = &Match{players: [2]int{noPlayer, noPlayer}, winner: noPlayer}
+		}
+		t.rounds = append(t.rounds, round)
+	}
+	for i, m := range t.rounds[0] {
+		m.players = [2]int{slots[2*i], slots[2*i+1]}
+	}
+	t.advance()
+	return t
+}
+
+// current returns the match to be played next, or nil once a champion is
+// crowned.
+func (t *Tournament) current() *Match {
+	for _, round := range t.rounds {
+		for _, m := range round {
+			if m.winner == noPlayer && m.players[0] != noPlayer && m.players[1] != noPlayer {
+				return m
+			}
+		}
+	}
+	return nil
+}
+
+// recordMatch settles m with each player's round wins and the seat, 0 or
+// 1, whose player took it, and advances the winner.
+func (t *Tournament) recordMatch(m *Match, wins [2]int, seat int) {
+	m.wins = wins
+	m.winner = m.players[seat]
+	t.advance()
+}
+
+// advance settles byes and moves every decided winner into its slot in the
+// next round.
+func (t *Tournament) advance() {
+	for r, round := range t.rounds {
+		for i, m := range round {
+			// A player facing a bye in the first round walks over.
+			if r == 0 && m.winner == noPlayer {
+				if m.players[1] == noPlayer {
+					m.winner = m.players[0]
+				} else if m.players[0] == noPlayer {
+					m.winner = m.players[1]
+				}
+			}
+			if m.winner != noPlayer && r+1 < len(t.rounds) {
+				t.rounds[r+1][i/2].players[i%2] = m.winner
+			}
+		}
+	}
+}
+
+// champion returns the index of the tournament winner, or noPlayer while
+// matches remain.
+func (t *Tournament) champion() int {
+	return t.rounds[len(t.rounds)-1][0].winner
+}
@@ -0,0 +1,78 @@
+// File tournament_test.go checks the bracket: versus winners advance round
+// by round to a champion, and a tournament match that runs out of rounds
+// is settled by the tiebreak and recorded in the bracket.
+package asteroids
+
+import (
+	"testing"
+
+	"github.com/bensabler/asteroids/internal/sim"
+)
+
+func TestTournamentAdvancesMatchWinners(t *testing.T) {
+	tr := NewTournament([]string{"ANN", "BOB", "CY", "DEE", "EVE"})
+	played := 0
+	for tr.champion() == noPlayer {
+		m := tr.current()
+		if m == nil {
+			t.Fatal("no match to play, but no champion either")
+		}
+		tr.recordMatch(m, [2]int{versusWinsNeeded, 1}, 0)
+		played++
+	}
+	// Eight slots: EVE meets ANN, the others take byes.
+	if played != 4 {
+		t.Errorf("played %d matches, want 4", played)
+	}
+	if got := tr.names[tr.champion()]; got != "ANN" {
+		t.Errorf("champion %s, want ANN", got)
+	}
+	if final := tr.rounds[len(tr.rounds)-1][0]; final.wins != [2]int{versusWinsNeeded, 1} {
+		t.Errorf("final recorded %v rounds won", final.wins)
+	}
+}
+
+func TestTournamentMatchTiebreak(t *testing.T) {
+	tests := []struct {
+		name   string
+		wins   [2]int
+		scores [2]int
+		want   int // Seat.
+	}{
+		{"more rounds won", [2]int{1, 2}, [2]int{30, 0}, 1},
+		{"more points", [2]int{1, 1}, [2]int{12, 30}, 1},
+		{"higher seed", [2]int{1, 1}, [2]int{20, 20}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := NewTournament([]string{"ANN", "BOB", "CY", "DEE"})
+			v := NewTournamentMatch(tr)
+			m := v.match
+			v.wins, v.scores = tt.wins, tt.scores
+
+			// Rounds short of the cap never settle a match without a winner.
+			v.round = tournamentMaxRounds - 1
+			v.roundOver = sim.NewTimer(versusRoundPause)
+			if seat := v.matchWinner(); seat != noPlayer {
+				t.Fatalf("round %d settled the match for seat %d", v.round, seat)
+			}
+
+			// The last round's pause ends the match on the tiebreak.
+			v.round = tournamentMaxRounds
+			state := &State{SceneManager: &SceneManager{}}
+			state.SceneManager.GoToScene(v)
+			for range sim.Ticks(versusRoundPause) {
+				v.checkRoundOver(state)
+			}
+			if m.winner != m.players[tt.want] {
+				t.Errorf("winner %d, want %d", m.winner, m.players[tt.want])
+			}
+			if _, ok := state.SceneManager.next.(*VersusResultsScene); !ok {
+				t.Errorf("went to %T, want the results", state.SceneManager.next)
+			}
+			if tr.current() == m {
+				t.Error("the bracket still has the settled match to play")
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+// File tractor-beam.go implements the tractor beam: holding the tractor
+// action latches the nearest small meteor and holds it ahead of the ship;
+// letting go flings it as a projectile that destroys the meteors and aliens it hits.
+package asteroids
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Tractor beam tuning.
+const (
+	tractorUnlockLevel    = 3               // First level on which the beam works.
+	tractorRange          = 250.0           // Farthest a meteor can be latched from.
+	tractorHoldDistance   = 70.0            // Distance ahead of the ship a meteor is held.
+	tractorPull           = 12.0            // Rate at which a held meteor closes the gap to the hold point, per second.
+	tractorThrowSpeed     = 540.0           // Speed of a flung meteor.
+	tractorThrownLifetime = 2 * time.Second // How long a flung meteor stays dangerous.
+	tractorThrowPoints    = 2               // Score for each meteor a flung meteor destroys.
+	tractorBeamWidth      = 6               // Outer beam stroke width.
+)
+
+// TractorBeam is the latch between the ship and a held meteor.
+type TractorBeam struct {
+	meteor *Meteor // The held meteor.
+	index  int     // The held meteor's key in GameScene.meteors.
+}
+
+// tractorUnlocked reports whether the run has reached the beam yet.
+// Practice mode has everything unlocked.
+func (g *GameScene) tractorUnlocked() bool {
+	return g.practice != nil || g.currentLevel >= tractorUnlockLevel
+}
+
+// updateTractorBeam latches, holds, or flings a meteor depending on the
+// tractor action. Holding steers the meteor toward a point ahead of the
+// nose through its movement, so ordinary meteor movement carries it.
+func (g *GameScene) updateTractorBeam() {
+	p := g.player
+	if g.tractor != nil && !g.isHeld(g.tractor) {
+		g.tractor = nil // Destroyed or removed while held.
+	}
+
+	holding := g.input.IsPressed(ActionTractor) && !p.isDying && g.tractorUnlocked()
+	if !holding {
+		if g.tractor != nil {
+			g.flingMeteor(g.tractor.meteor)
+			g.tractor = nil
+		}
+		return
+	}
+
+	if g.tractor == nil {
+		g.tractor = g.latchNearestMeteor()
+		if g.tractor == nil {
+			return
+		}
+	}
+
+	m := g.tractor.meteor
+	hold := spriteCenter(p.position, p.sprite)
+	forward := shipHeading(p.rotation)
+	hold.X += forward.X * tractorHoldDistance
+	hold.Y += forward.Y * tractorHoldDistance
+	center := spriteCenter(m.position, m.sprite)
+	m.movement = Vector{X: hold.X - center.X, Y: hold.Y - center.Y}.Scale(tractorPull)
+}
+
+// latchNearestMeteor returns a latch on the closest small meteor within
+// range, or nil if there is none.
+func (g *GameScene) latchNearestMeteor() *TractorBeam {
+	ship := spriteCenter(g.player.position, g.player.sprite)
+	var best *TractorBeam
+	bestDistance := tractorRange
+	for i, m := range inOrder(g.meteors) {
+		if m.gold || m.thrownTimer != nil || g.isExploding(m) || !m.meteorObj.Tags().Has(TagSmall) {
+			continue
+		}
+		if d := distance(spriteCenter(m.position, m.sprite), ship); d <= bestDistance {
+			best = &TractorBeam{meteor: m, index: i}
+			bestDistance = d
+		}
+	}
+	return best
+}
+
+// isHeld reports whether a latched meteor is still intact and in play.
+func (g *GameScene) isHeld(t *TractorBeam) bool {
+	m, ok := g.meteors[t.index]
+	return ok && m == t.meteor && !g.isExploding(m)
+}
+
+// flingMeteor launches a meteor along the ship's heading as a projectile.
+func (g *GameScene) flingMeteor(m *Meteor) {
+	forward := shipHeading(g.player.rotation)
+	m.movement = forward.Scale(tractorThrowSpeed)
+	m.thrownTimer = sim.NewTimer(tractorThrownLifetime)
+}
+
+// updateThrownMeteors counts down flung meteors and returns spent ones to an
+// ordinary drift.
+func (g *GameScene) updateThrownMeteors() {
+	for _, m := range inOrder(g.meteors) {
+		if m.thrownTimer == nil {
+			continue
+		}
+		m.thrownTimer.Update()
+		if m.thrownTimer.IsReady() {
+			m.thrownTimer = nil
+			m.movement = m.movement.Normalize().Scale(g.baseVelocity)
+		}
+	}
+}
+
+// isThrownMeteorHittingEnemies destroys every meteor or alien a flung meteor
+// touches. The flung meteor shatters on its first impact.
+func (g *GameScene) isThrownMeteorHittingEnemies() {
+	for _, thrown := range inOrder(g.meteors) {
+		if thrown.thrownTimer == nil || g.isExploding(thrown) {
+			continue
+		}
+		hit := false
+		for _, m := range inOrder(g.meteors) {
+			if m == thrown || m.gold || g.isExploding(m) {
+				continue
+			}
+			if g.collisions.intersects(thrown.meteorObj, m.meteorObj) {
+				g.collisions.consume(m.meteorObj)
+				g.shatterMeteor(m, tractorThrowPoints)
+				hit = true
+			}
+		}
+		for _, a := range inOrder(g.aliens) {
+			if a.sprite == g.explosionSmallSprite {
+				continue
+			}
+			if g.collisions.intersects(thrown.meteorObj, a.alienObj) {
+				g.collisions.consume(a.alienObj)
+				a.sprite = g.explosionSmallSprite
+				g.scoreKill(50, a.position) // Same as a laser kill.
+				hit = true
+			}
+		}
+		if hit {
+			g.collisions.consume(thrown.meteorObj)
+			thrown.thrownTimer = nil
+			thrown.sprite = g.explosionSmallSprite
+			sharedAudio().PlaySFXAt(sfxExplosion, spriteCenter(thrown.position, thrown.sprite).X)
+		}
+	}
+}
+
+// shatterMeteor destroys a meteor outright, without splitting, and scores it.
+func (g *GameScene) shatterMeteor(m *Meteor, points int) {
+	at := spriteCenter(m.position, m.sprite)
+	g.explodeMeteor(m)
+	g.scoreKill(points, at)
+}
+
+// isShipSafeFrom reports whether a meteor cannot hurt the ship: held and
+// flung meteors belong to the player.
+func (g *GameScene) isShipSafeFrom(m *Meteor) bool {
+	return m.thrownTimer != nil || (g.tractor != nil && g.tractor.meteor == m)
+}
+
+// drawTractorBeam renders the beam between the ship and the held meteor: a
+// wide translucent glow with a bright core.
+func (g *GameScene) drawTractorBeam(screen *ebiten.Image) {
+	if g.tractor == nil {
+		return
+	}
+	from := spriteCenter(g.player.position, g.player.sprite)
+	to := spriteCenter(g.tractor.meteor.position, g.tractor.meteor.sprite)
+	x0, y0, x1, y1 := float32(from.X), float32(from.Y), float32(to.X), float32(to.Y)
+	strokeLine(screen, x0, y0, x1, y1, tractorBeamWidth, color.RGBA{R: 40, G: 120, B: 140, A: 120}, true)
+	strokeLine(screen, x0, y0, x1, y1, 1.5, color.RGBA{R: 160, G: 255, B: 255, A: 255}, true)
+}
+
+// shipHeading returns the unit vector the ship's nose points along.
+func shipHeading(rotation float64) Vector {
+	return Vector{X: math.Sin(rotation), Y: -math.Cos(rotation)}
+}
+
+// distance returns the straight-line distance between a and b.
+func distance(a, b Vector) float64 {
+	return math.Hypot(a.X-b.X, a.Y-b.Y)
+}
@@ -0,0 +1,167 @@
+// File upgrades.go defines the permanent ship upgrades sold in the shop for
+// crystals. Each upgrade is a row of upgradeCatalog: its levels and their
+// costs are data, and the ship asks for its current level wherever the
+// upgrade changes a rule. A run takes a snapshot of the profile's levels
+// when it starts, so buying mid-session never changes a run in progress and
+// replays can record exactly what the ship had.
+package asteroids
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Upgrade IDs, the stable keys used in the profile and in replays.
+const (
+	upgradeShieldCapacity     = "shield-capacity"
+	upgradeShieldDuration     = "shield-duration"
+	upgradeHyperspaceCooldown = "hyperspace-cooldown"
+	upgradeSafeHyperspace     = "safe-hyperspace"
+)
+
+// Upgrade effect sizes, per level bought.
+const (
+	shieldDurationStep     = 1 * time.Second // Added to shieldDuration.
+	hyperspaceCooldownStep = 2 * time.Second // Taken off hyperSpaceCooldown.
+)
+
+// Upgrade is one purchasable line of upgrades.
+type Upgrade struct {
+	ID     string                 // Stable key; see the upgrade IDs.
+	Name   string                 // Display name in the shop.
+	Effect string                 // What each level does, for the shop.
+	Costs  []int                  // Crystal price of each level; its length is the top level.
+	hud    func(p *Player) string // HUD readout while owned; "" hides it.
+}
+
+// upgradeCatalog lists the upgrades in shop order.
+var upgradeCatalog = []Upgrade{
+	{
+		ID:     upgradeShieldCapacity,
+		Name:   "Shield Capacity",
+		Effect: "+1 shield slot",
+		Costs:  []int{60},
+		hud: func(p *Player) string {
+			if p.energy != nil {
+				return "" // Energy handling has no charges.
+			}
+			return fmt.Sprintf("Shields %d/%d", p.shieldsRemaning, p.maxShields())
+		},
+	},
+	{
+		ID:     upgradeShieldDuration,
+		Name:   "Shield Duration",
+		Effect: "+1s per shield",
+		Costs:  []int{25, 50, 100},
+		hud: func(p *Player) string {
+			return fmt.Sprintf("Shield %ds", int(p.shieldDuration().Seconds()))
+		},
+	},
+	{
+		ID:     upgradeHyperspaceCooldown,
+		Name:   "Hyperspace Cooldown",
+		Effect: "-2s between jumps",
+		Costs:  []int{30, 60, 120},
+		hud: func(p *Player) string {
+			if p.energy != nil {
+				return "" // Energy is the real limit.
+			}
+			return fmt.Sprintf("Jump cooldown %ds", int(p.hyperspaceCooldown().Seconds()))
+		},
+	},
+	{
+		ID:     upgradeSafeHyperspace,
+		Name:   "Safe Hyperspace",
+		Effect: "No jump malfunctions",
+		Costs:  []int{150},
+		hud: func(p *Player) string {
+			if !p.game.mode.HyperspaceRisk {
+				return ""
+			}
+			return "Safe jump"
+		},
+	},
+}
+
+// nextCost returns the price of the level after level, or false if level is
+// already the top one.
+func (u Upgrade) nextCost(level int) (int, bool) {
+	if level >= len(u.Costs) {
+		return 0, false
+	}
+	return u.Costs[level], true
+}
+
+// Upgrades maps upgrade IDs to the level owned; missing IDs are level 0.
+type Upgrades map[string]int
+
+// runUpgrades returns the snapshot of the profile's upgrades a new run of
+// mode flies with; modes with NoUpgrades fly stock ships.
+func runUpgrades(mode Mode) Upgrades {
+	if mode.NoUpgrades {
+		return nil
+	}
+	u := Upgrades{}
+	for id, level := range profile.Upgrades {
+		if level > 0 {
+			u[id] = level
+		}
+	}
+	return u
+}
+
+// maxShields returns how many shield charges the ship can hold.
+func (p *Player) maxShields() int {
+	return p.game.difficulty.Shields + p.class.Shields + p.game.upgrades[upgradeShieldCapacity] + p.runUpgrades[runUpgradeShield]
+}
+
+// shieldDuration returns how long one shield lasts.
+func (p *Player) shieldDuration() time.Duration {
+	return shieldDuration + time.Duration(p.game.upgrades[upgradeShieldDuration])*shieldDurationStep
+}
+
+// hyperspaceCooldown returns the wait between hyperspace jumps.
+func (p *Player) hyperspaceCooldown() time.Duration {
+	if p.energy != nil {
+		return energyHyperspaceCooldown
+	}
+	return hyperSpaceCooldown - time.Duration(p.game.upgrades[upgradeHyperspaceCooldown])*hyperspaceCooldownStep
+}
+
+// hyperspaceMalfunctions rolls for a failed jump, which destroys the ship.
+// Only modes with HyperspaceRisk roll, and never with Safe Hyperspace or
+// during a bonus round, where nothing can kill the ship.
+func (p *Player) hyperspaceMalfunctions() bool {
+	if !p.game.mode.HyperspaceRisk || p.game.upgrades[upgradeSafeHyperspace] > 0 || p.game.isBonusRound() {
+		return false
+	}
+	return p.game.rng.Stream(streamShip).Float64() < hyperspaceMalfunctionChance
+}
+
+// drawUpgrades lists the readouts of the owned upgrades in the lower-left
+// corner of the HUD.
+func (g *GameScene) drawUpgrades(screen *ebiten.Image) {
+	var lines []string
+	for _, u := range upgradeCatalog {
+		if g.upgrades[u.ID] == 0 {
+			continue
+		}
+		if line := u.hud(g.player); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	scale := hudScale()
+	for i, line := range lines {
+		op := &text.DrawOptions{}
+		op.ColorScale.ScaleWithColor(currentPalette().HUD)
+		op.GeoM.Translate(20, ScreenHeight-(40+float64(len(lines)-1-i)*22)*scale)
+		drawText(screen, line, &text.GoTextFace{
+			Source: assets.LevelFont,
+			Size:   14 * scale,
+		}, op)
+	}
+}
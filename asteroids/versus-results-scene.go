@@ -0,0 +1,65 @@
+// File versus-results-scene.go implements the VersusResultsScene, which
+// announces the winner of a versus match over the final arena and offers a
+// rematch, or after a tournament match the way back to the bracket.
+package asteroids
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+	inpututil "github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// VersusResultsScene shows the outcome of a finished versus match.
+type VersusResultsScene struct {
+	versus *VersusScene // The finished match, drawn behind the results.
+}
+
+// NewVersusResultsScene returns the results screen for v.
+func NewVersusResultsScene(v *VersusScene) *VersusResultsScene {
+	return &VersusResultsScene{versus: v}
+}
+
+// Update keeps the arena drifting and handles the rematch prompt.
+//
+// Space:  start a new match, or return to the bracket after a tournament match.
+// Escape: return to the title screen.
+func (r *VersusResultsScene) Update(state *State) error {
+	r.versus.world.updateBackground()
+	r.versus.world.music.Update()
+
+	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		if t := r.versus.tournament; t != nil {
+			state.SceneManager.GoToScene(NewTournamentScene(t))
+		} else {
+			state.SceneManager.GoToScene(NewVersusScene())
+		}
+		return nil
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		state.SceneManager.GoToScene(NewTitleScene())
+	}
+	return nil
+}
+
+// Draw renders the dimmed arena, the winner, and each player's totals.
+func (r *VersusResultsScene) Draw(screen *ebiten.Image) {
+	v := r.versus
+	v.drawArena(screen)
+	dimScreen(screen, 160)
+
+	winner := v.matchWinner()
+	drawCenteredText(screen, v.name(winner)+" WINS", assets.TitleFont, 72, ScreenWidth/2, ScreenHeight/2-120, playerColor(v.slot(winner)))
+	drawCenteredText(screen, fmt.Sprintf("%d - %d", v.wins[0], v.wins[1]), assets.TitleFont, 48, ScreenWidth/2, ScreenHeight/2-20, color.White)
+	for seat := range v.ships {
+		line := fmt.Sprintf("%s   Rounds %d   Score %d", v.name(seat), v.wins[seat], v.scores[seat])
+		drawCenteredText(screen, line, assets.ScoreFont, 18, ScreenWidth/2, float64(ScreenHeight/2+60+seat*30), playerColor(v.slot(seat)))
+	}
+	prompt := "Space: rematch   Esc: title"
+	if v.tournament != nil {
+		prompt = "Space: bracket   Esc: leave the tournament"
+	}
+	drawCenteredText(screen, prompt, assets.ScoreFont, 16, ScreenWidth/2, ScreenHeight-60, color.Gray{Y: 180})
+}
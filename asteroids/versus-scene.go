@@ -0,0 +1,432 @@
+// File versus-scene.go implements the VersusScene, a local two-player duel:
+// both ships share one keyboard and one field of meteors, lasers hit the
+// other ship as well as meteors, and the first player to win
+// versusWinsNeeded rounds takes the match. Tournament matches are played
+// the same way between the two entrants the bracket pairs.
+package asteroids
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bensabler/asteroids/assets"
+	"github.com/bensabler/asteroids/internal/sim"
+	"github.com/hajimehoshi/ebiten/v2"
+	inpututil "github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Versus tuning.
+const (
+	versusWinsNeeded = 3               // Round wins that take the match.
+	versusMeteors    = 6               // Large meteors kept in play.
+	versusKillPoints = 10              // Score for shooting the other ship.
+	versusRoundPause = 3 * time.Second // Pause between a round's end and the next.
+)
+
+// ModeVersus is the ruleset behind the versus world: no lives to lose, no
+// high score, and no assists or upgrades.
+var ModeVersus = Mode{Name: "Versus", Completion: CompleteOnMeteors, Unranked: true, NoAssists: true, NoUpgrades: true, NoSpawnDirector: true}
+
+// versusBindings are the fixed controls of the two seats: WASD on the left
+// of the keyboard and the arrow cluster on the right.
+var versusBindings = [2]func() KeyBindings{
+	func() KeyBindings {
+		return KeyBindings{
+			ActionRotateLeft:  ebiten.KeyA,
+			ActionRotateRight: ebiten.KeyD,
+			ActionThrust:      ebiten.KeyW,
+			ActionReverse:     ebiten.KeyS,
+			ActionFire:        ebiten.KeySpace,
+			ActionShield:      ebiten.KeyQ,
+			ActionHyperspace:  ebiten.KeyE,
+			ActionBoost:       ebiten.KeyShiftLeft,
+		}
+	},
+	func() KeyBindings {
+		return KeyBindings{
+			ActionRotateLeft:  ebiten.KeyLeft,
+			ActionRotateRight: ebiten.KeyRight,
+			ActionThrust:      ebiten.KeyUp,
+			ActionReverse:     ebiten.KeyDown,
+			ActionFire:        ebiten.KeyEnter,
+			ActionShield:      ebiten.KeyShiftRight,
+			ActionHyperspace:  ebiten.KeySlash,
+			ActionBoost:       ebiten.KeyPeriod,
+		}
+	},
+}
+
+// VersusScene is a versus match in progress.
+//
+// The meteors, lasers, sounds, and collision space live in an embedded
+// GameScene world that never runs its own Update; the VersusScene drives it
+// and applies the duel's collision rules.
+type VersusScene struct {
+	world       *GameScene  // Shared play field.
+	ships       [2]*Player  // Ships by seat.
+	inputs      [2]*Input   // Controls by seat.
+	scores      [2]int      // Match score by seat.
+	wins        [2]int      // Rounds won by seat.
+	round       int         // Current round, from 1.
+	roundOver   *Timer      // Pause after a round ends; nil while it is being played.
+	roundWinner int         // Seat that won the last round, or noPlayer for a draw.
+	hint        string      // Both seats' controls, named for the keyboard layout.
+	tournament  *Tournament // Bracket this match is part of; nil for a friendly match.
+	match       *Match      // The bracket match being played; nil for a friendly match.
+}
+
+// NewVersusScene starts a friendly match at round one.
+func NewVersusScene() *VersusScene {
+	return newVersusScene(nil)
+}
+
+// NewTournamentMatch starts the next match of t, its first entrant in the
+// left seat.
+func NewTournamentMatch(t *Tournament) *VersusScene {
+	return newVersusScene(t)
+}
+
+// newVersusScene starts a match at round one, for the current match of t
+// when t is non-nil.
+func newVersusScene(t *Tournament) *VersusScene {
+	v := &VersusScene{world: NewGameScene(ModeVersus)}
+	if t != nil {
+		v.tournament, v.match = t, t.current()
+	}
+	for seat := range v.inputs {
+		v.inputs[seat] = NewInput(versusBindings[seat]())
+	}
+	v.hint = fmt.Sprintf("%s: %s   %s: %s   Esc: quit",
+		v.name(0), controlsHint(v.inputs[0].bindings), v.name(1), controlsHint(v.inputs[1].bindings))
+	v.startRound()
+	return v
+}
+
+// startRound clears the field and puts both ships back at their starting
+// spots, facing each other across the center.
+func (v *VersusScene) startRound() {
+	v.round++
+	v.roundOver = nil
+	v.world.Reset()
+
+	v.ships[0] = v.world.player
+	v.ships[1] = NewPlayer(v.world)
+	v.world.space.Add(v.ships[1].playerObj)
+	for seat, ship := range v.ships {
+		ship.controls = v.inputs[seat]
+		ship.position.X = ScreenWidth/4 + float64(seat)*ScreenWidth/2 - float64(ship.sprite.Bounds().Dx())/2
+		ship.rotation = math.Pi / 2 * float64(1-2*seat) // Seat 0 faces right, seat 1 left.
+		ship.playerObj.SetPosition(ship.position.X, ship.position.Y)
+	}
+}
+
+// Update runs one tick of the duel.
+func (v *VersusScene) Update(state *State) error {
+	w := v.world
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		v.leave()
+		state.SceneManager.GoToScene(NewTitleScene())
+		return nil
+	}
+
+	w.music.Play()
+	for seat, ship := range v.ships {
+		v.inputs[seat].Update()
+		if ship.isDead {
+			continue
+		}
+		ship.Update()
+		ship.updateDying(w.explosionFrames)
+		if ship.exhaust != nil {
+			ship.exhaust.Update()
+		}
+		if ship.shield != nil {
+			ship.shield.Update()
+		}
+	}
+
+	v.spawnMeteors()
+	w.updateBackground()
+
+	w.collisions.reset()
+	v.shipsHitByMeteors()
+	v.meteorsHitByLasers()
+	v.shipsHitByLasers()
+
+	v.checkRoundOver(state)
+	return nil
+}
+
+// spawnMeteors keeps versusMeteors large meteors drifting through the arena.
+func (v *VersusScene) spawnMeteors() {
+	w := v.world
+	w.meteorSpawnTimer.Update()
+	if !w.meteorSpawnTimer.IsReady() {
+		return
+	}
+	w.meteorSpawnTimer.Reset()
+
+	large := 0
+	for _, m := range w.meteors {
+		if m.meteorObj.Tags().Has(TagLarge) && !w.isExploding(m) {
+			large++
+		}
+	}
+	if large < versusMeteors {
+		w.addMeteor(NewMeteor(baseMeteorVelocity, w, w.meteorCount+1))
+	}
+}
+
+// alive reports whether ship can still be hit.
+func alive(ship *Player) bool {
+	return !ship.isDying && !ship.isDead
+}
+
+// destroyShip starts ship's death animation with an explosion.
+func (v *VersusScene) destroyShip(ship *Player) {
+	ship.isDying = true
+	sharedAudio().PlaySFXAt(sfxExplosion, spriteCenter(ship.position, ship.sprite).X)
+}
+
+// shipsHitByMeteors destroys unshielded ships that touch a meteor; shields
+// push the meteor away instead.
+func (v *VersusScene) shipsHitByMeteors() {
+	w := v.world
+	for _, ship := range v.ships {
+		if !alive(ship) {
+			continue
+		}
+		for _, m := range inOrder(w.meteors) {
+			if w.isExploding(m) || !w.collisions.intersects(m.meteorObj, ship.playerObj) {
+				continue
+			}
+			if !ship.isShielded {
+				v.destroyShip(ship)
+				break
+			}
+			w.bounceMeteor(m)
+		}
+	}
+}
+
+// meteorsHitByLasers shatters meteors hit by either player's lasers and
+// scores them for the shooter.
+func (v *VersusScene) meteorsHitByLasers() {
+	w := v.world
+	for _, meteor := range inOrder(w.meteors) {
+		if w.isExploding(meteor) {
+			continue
+		}
+		for i, laser := range inOrder(w.lasers) {
+			if !w.collisions.intersects(meteor.meteorObj, laser.laserObj) {
+				continue
+			}
+			w.collisions.consume(meteor.meteorObj, laser.laserObj)
+			v.scores[v.seatOf(laser.owner)]++
+			w.removeLaser(i)
+			w.splitMeteor(meteor)
+			break
+		}
+	}
+}
+
+// shipsHitByLasers lets each player's lasers destroy the other ship. A
+// shield absorbs the laser.
+func (v *VersusScene) shipsHitByLasers() {
+	w := v.world
+	for i, laser := range inOrder(w.lasers) {
+		for _, ship := range v.ships {
+			if ship == laser.owner || !alive(ship) || !w.collisions.intersects(laser.laserObj, ship.playerObj) {
+				continue
+			}
+			owner := laser.owner // The laser goes back to the pool, which may reuse it.
+			w.removeLaser(i)
+			if !ship.isShielded {
+				v.scores[v.seatOf(owner)] += versusKillPoints
+				v.destroyShip(ship)
+			}
+			break
+		}
+	}
+}
+
+// seatOf returns the seat of ship.
+func (v *VersusScene) seatOf(ship *Player) int {
+	if ship == v.ships[1] {
+		return 1
+	}
+	return 0
+}
+
+// checkRoundOver ends the round once a ship has finished exploding (and the
+// other is not mid-explosion), then after a pause starts the next round or
+// shows the results.
+func (v *VersusScene) checkRoundOver(state *State) {
+	a, b := v.ships[0], v.ships[1]
+	if v.roundOver == nil {
+		if !(a.isDead || b.isDead) || a.isDying || b.isDying {
+			return
+		}
+		v.roundWinner = noPlayer
+		switch {
+		case a.isDead && !b.isDead:
+			v.roundWinner = 1
+		case b.isDead && !a.isDead:
+			v.roundWinner = 0
+		}
+		if v.roundWinner != noPlayer {
+			v.wins[v.roundWinner]++
+		}
+		v.roundOver = sim.NewTimer(versusRoundPause)
+		return
+	}
+
+	v.roundOver.Update()
+	if !v.roundOver.IsReady() {
+		return
+	}
+	if winner := v.matchWinner(); winner != noPlayer {
+		v.leave()
+		if v.tournament != nil {
+			v.tournament.recordMatch(v.match, v.wins, winner)
+		}
+		state.SceneManager.GoToScene(NewVersusResultsScene(v))
+		return
+	}
+	v.startRound()
+}
+
+// matchWinner returns the seat that has won the match, or noPlayer.
+//
+// A tournament match that reaches tournamentMaxRounds without a winner,
+// after a run of drawn rounds, is decided once that round is over: by
+// rounds won, then by score, and failing both for the higher seed.
+func (v *VersusScene) matchWinner() int {
+	for seat, wins := range v.wins {
+		if wins >= versusWinsNeeded {
+			return seat
+		}
+	}
+	if v.match == nil || v.round < tournamentMaxRounds || v.roundOver == nil {
+		return noPlayer
+	}
+	switch {
+	case v.wins[0] != v.wins[1]:
+		return leadingSeat(v.wins)
+	case v.scores[0] != v.scores[1]:
+		return leadingSeat(v.scores)
+	case v.match.players[1] < v.match.players[0]:
+		return 1
+	}
+	return 0
+}
+
+// leadingSeat returns the seat with the greater of two unequal counts.
+func leadingSeat(counts [2]int) int {
+	if counts[1] > counts[0] {
+		return 1
+	}
+	return 0
+}
+
+// leave silences the match before another scene takes over.
+func (v *VersusScene) leave() {
+	v.world.pauseLoopingSounds()
+	v.world.music.FadeOut()
+}
+
+// versusName returns the display name of seat in a friendly match.
+func versusName(seat int) string {
+	return fmt.Sprintf("P%d", seat+1)
+}
+
+// name returns the display name of seat: its entrant's in a tournament
+// match.
+func (v *VersusScene) name(seat int) string {
+	if v.match != nil {
+		return v.tournament.names[v.match.players[seat]]
+	}
+	return versusName(seat)
+}
+
+// slot returns the color slot of seat: its entrant's in a tournament match,
+// so players keep the color the bracket shows them in.
+func (v *VersusScene) slot(seat int) int {
+	if v.match != nil {
+		return v.match.players[seat]
+	}
+	return seat
+}
+
+// controlsHint names a seat's flight keys (thrust, turn left, reverse, turn
+// right), then its fire, shield, and hyperspace keys, as the keyboard
+// layout labels them.
+func controlsHint(b KeyBindings) string {
+	flight := []ebiten.Key{b[ActionThrust], b[ActionRotateLeft], b[ActionReverse], b[ActionRotateRight]}
+	cluster := "Arrows"
+	if !slices.Equal(flight, []ebiten.Key{ebiten.KeyArrowUp, ebiten.KeyArrowLeft, ebiten.KeyArrowDown, ebiten.KeyArrowRight}) {
+		labels := make([]string, len(flight))
+		sep := ""
+		for i, key := range flight {
+			labels[i] = keyLabel(key)
+			if utf8.RuneCountInString(labels[i]) > 1 {
+				sep = "/" // Run single characters together, as in "WASD".
+			}
+		}
+		cluster = strings.Join(labels, sep)
+	}
+	return strings.Join([]string{cluster, keyLabel(b[ActionFire]), keyLabel(b[ActionShield]), keyLabel(b[ActionHyperspace])}, " ")
+}
+
+// Draw renders the arena, both ships with their labels, and the score bar.
+func (v *VersusScene) Draw(screen *ebiten.Image) {
+	v.drawArena(screen)
+
+	// Score bar: each seat on its side, the round in the middle.
+	for seat := range v.ships {
+		label := fmt.Sprintf("%s   Score %d   Wins %d/%d", v.name(seat), v.scores[seat], v.wins[seat], versusWinsNeeded)
+		x := ScreenWidth/4 + float64(seat)*ScreenWidth/2
+		drawCenteredText(screen, label, assets.ScoreFont, 18*hudScale(), x, 30, playerColor(v.slot(seat)))
+	}
+	drawCenteredText(screen, fmt.Sprintf("Round %d", v.round), assets.LevelFont, 16*hudScale(), ScreenWidth/2, 30, currentPalette().HUD)
+
+	if v.roundOver != nil && v.matchWinner() == noPlayer {
+		banner, c := "DRAW", color.Color(color.White)
+		if v.roundWinner != noPlayer {
+			banner, c = v.name(v.roundWinner)+" WINS THE ROUND", playerColor(v.slot(v.roundWinner))
+		}
+		drawCenteredText(screen, banner, assets.TitleFont, 48, ScreenWidth/2, ScreenHeight/2-24, c)
+	}
+	drawCenteredText(screen, v.hint, assets.ScoreFont, 14, ScreenWidth/2, ScreenHeight-30, color.Gray{Y: 160})
+}
+
+// drawArena renders the stars, meteors, lasers, and ships.
+func (v *VersusScene) drawArena(screen *ebiten.Image) {
+	w := v.world
+	for _, star := range w.stars {
+		star.Draw(screen)
+	}
+	for _, meteor := range w.meteors {
+		meteor.Draw(screen)
+	}
+	for _, laser := range w.lasers {
+		laser.Draw(screen)
+	}
+
+	var labels []ShipLabel
+	for seat, ship := range v.ships {
+		if ship.isDead {
+			continue
+		}
+		ship.Draw(screen)
+		ship.drawEffects(screen)
+		labels = append(labels, labelFor(v.name(seat), v.slot(seat), ship))
+	}
+	w.drawWarps(screen)
+	drawShipLabels(screen, labels)
+}
@@ -0,0 +1,31 @@
+// File versus-scene_test.go checks the versus controls hint against the
+// seat bindings and that a laser hitting the other ship scores for the
+// seat that fired it.
+package asteroids
+
+import "testing"
+
+func TestControlsHintNamesSeatBindings(t *testing.T) {
+	// Without a running game no key has a layout name, so every key falls
+	// back to Ebiten's name for it.
+	for seat, want := range []string{"WASD Space Q E", "Arrows Enter ShiftRight Slash"} {
+		if got := controlsHint(versusBindings[seat]()); got != want {
+			t.Errorf("seat %d hint = %q, want %q", seat, got, want)
+		}
+	}
+}
+
+func TestVersusLaserScoresForItsOwner(t *testing.T) {
+	v := NewVersusScene()
+	target := v.ships[1]
+	v.ships[0].spawnLaser(spriteCenter(target.position, target.sprite), 0)
+	v.world.collisions.reset()
+
+	v.shipsHitByLasers()
+	if !target.isDying {
+		t.Fatal("laser on the other ship did not destroy it")
+	}
+	if v.scores != [2]int{versusKillPoints, 0} {
+		t.Errorf("scores = %v, want [%d 0]", v.scores, versusKillPoints)
+	}
+}
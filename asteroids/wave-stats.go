@@ -0,0 +1,58 @@
+// File wave-stats.go tracks statistics for the wave in play. Each GameScene
+// starts a WaveStats with every wave and closes it when the wave is cleared;
+// the level-start interlude then shows the closed one as a summary before
+// the next "LEVEL N" banner.
+package asteroids
+
+import "github.com/bensabler/asteroids/internal/sim"
+
+// WaveStats describes one wave: a numbered level or a bonus round.
+type WaveStats struct {
+	Level      int  // Level number the wave belonged to.
+	BonusRound bool // The wave was a gold-rush round.
+	Points     int  // Score earned during the wave; set by finish.
+	ShotsFired int  // Player lasers fired.
+	ShotsHit   int  // Player lasers that hit something.
+	BestChain  int  // Longest run of hits without a miss.
+	chain      int  // Current run of hits without a miss.
+	startScore int  // Score when the wave began.
+	ticks      int  // Ticks in play.
+}
+
+// newWaveStats starts the statistics for the scene's current wave.
+func newWaveStats(g *GameScene) *WaveStats {
+	return &WaveStats{Level: g.currentLevel, BonusRound: g.isBonusRound(), startScore: g.score}
+}
+
+// fired counts a player laser leaving the ship.
+func (w *WaveStats) fired() {
+	w.ShotsFired++
+}
+
+// hit counts a player laser striking something and extends the chain.
+func (w *WaveStats) hit() {
+	w.ShotsHit++
+	w.chain++
+	w.BestChain = max(w.BestChain, w.chain)
+}
+
+// miss breaks the chain for a player laser that left play without a hit.
+func (w *WaveStats) miss() {
+	w.chain = 0
+}
+
+// finish closes the wave at score and returns it.
+func (w *WaveStats) finish(score int) *WaveStats {
+	w.Points = score - w.startScore
+	return w
+}
+
+// accuracy returns the share of shots that hit, or 0 before any shot.
+func (w *WaveStats) accuracy() float64 {
+	return hitRatio(w.ShotsHit, w.ShotsFired)
+}
+
+// seconds returns the wave's time in play.
+func (w *WaveStats) seconds() float64 {
+	return float64(w.ticks) * sim.TickSeconds()
+}
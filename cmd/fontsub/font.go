@@ -0,0 +1,378 @@
+// File font.go reads the TrueType tables fontsub needs and writes a font
+// back out with unused glyph outlines emptied.
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"unicode"
+)
+
+// TrueType constants.
+const (
+	sfntVersionTrueType = 0x00010000 // sfnt version of a TrueType-outline font.
+	checksumMagic       = 0xB1B0AFBA // head.checkSumAdjustment makes the file sum to this.
+	headAdjustOffset    = 8          // Offset of checkSumAdjustment in head.
+	headIndexToLoc      = 50         // Offset of indexToLocFormat in head.
+	maxpNumGlyphs       = 4          // Offset of numGlyphs in maxp.
+
+	// Composite glyph component flags.
+	argsAreWords   = 0x0001
+	haveScale      = 0x0008
+	moreComponents = 0x0020
+	haveXYScale    = 0x0040
+	haveTwoByTwo   = 0x0080
+)
+
+// dropTables are tables subsetting invalidates and that nothing needs.
+var dropTables = []string{
+	"DSIG", // Signature over the original bytes.
+}
+
+// font is a parsed TrueType font.
+type font struct {
+	tags   []string          // Table tags in directory order.
+	tables map[string][]byte // Table data by tag.
+	loca   []uint32          // Offset of each glyph in glyf, plus the end.
+	cmap   func(r rune) int  // Glyph ID for a rune; 0 if unmapped.
+}
+
+// parseFont reads the table directory and the glyph location and mapping
+// tables of a TrueType font.
+func parseFont(data []byte) (*font, error) {
+	if len(data) < 12 || binary.BigEndian.Uint32(data) != sfntVersionTrueType {
+		return nil, errors.New("not a TrueType font")
+	}
+	f := &font{tables: make(map[string][]byte)}
+	n := int(binary.BigEndian.Uint16(data[4:]))
+	for i := range n {
+		rec := 12 + 16*i
+		if rec+16 > len(data) {
+			return nil, errors.New("truncated table directory")
+		}
+		tag := string(data[rec : rec+4])
+		off := binary.BigEndian.Uint32(data[rec+8:])
+		length := binary.BigEndian.Uint32(data[rec+12:])
+		if uint64(off)+uint64(length) > uint64(len(data)) {
+			return nil, fmt.Errorf("table %s runs past the end of the file", tag)
+		}
+		f.tags = append(f.tags, tag)
+		f.tables[tag] = data[off : off+length]
+	}
+	for _, tag := range []string{"head", "maxp", "loca", "glyf", "cmap"} {
+		if f.tables[tag] == nil {
+			return nil, fmt.Errorf("no %s table", tag)
+		}
+	}
+
+	if err := f.parseLoca(); err != nil {
+		return nil, err
+	}
+	var err error
+	if f.cmap, err = parseCmap(f.tables["cmap"]); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// parseLoca reads the glyph offsets in either loca format.
+func (f *font) parseLoca() error {
+	head, maxp, loca := f.tables["head"], f.tables["maxp"], f.tables["loca"]
+	if len(head) < headIndexToLoc+2 || len(maxp) < maxpNumGlyphs+2 {
+		return errors.New("truncated head or maxp")
+	}
+	long := binary.BigEndian.Uint16(head[headIndexToLoc:]) == 1
+	n := int(binary.BigEndian.Uint16(maxp[maxpNumGlyphs:])) + 1
+	size := 2
+	if long {
+		size = 4
+	}
+	if len(loca) < n*size {
+		return errors.New("truncated loca")
+	}
+	f.loca = make([]uint32, n)
+	for i := range f.loca {
+		if long {
+			f.loca[i] = binary.BigEndian.Uint32(loca[4*i:])
+		} else {
+			f.loca[i] = 2 * uint32(binary.BigEndian.Uint16(loca[2*i:]))
+		}
+	}
+	glyf := uint32(len(f.tables["glyf"]))
+	for i := 1; i < n; i++ {
+		if f.loca[i] < f.loca[i-1] || f.loca[i] > glyf {
+			return fmt.Errorf("bad loca entry for glyph %d", i-1)
+		}
+	}
+	return nil
+}
+
+// glyph returns the outline data of glyph gid; empty for a blank glyph.
+func (f *font) glyph(gid int) []byte {
+	if gid < 0 || gid+1 >= len(f.loca) {
+		return nil
+	}
+	return f.tables["glyf"][f.loca[gid]:f.loca[gid+1]]
+}
+
+// draws reports whether the font has a glyph for r: mapped, and with an
+// outline unless r is whitespace, which draws nothing anyway.
+func (f *font) draws(r rune) bool {
+	gid := f.cmap(r)
+	return gid != 0 && (len(f.glyph(gid)) > 0 || unicode.IsSpace(r))
+}
+
+// components returns the glyph IDs a composite glyph is built from; none
+// for a simple glyph.
+func components(g []byte) []int {
+	if len(g) < 10 || int16(binary.BigEndian.Uint16(g)) >= 0 {
+		return nil // Simple glyphs have a non-negative contour count.
+	}
+	var ids []int
+	for p := 10; p+4 <= len(g); {
+		flags := binary.BigEndian.Uint16(g[p:])
+		ids = append(ids, int(binary.BigEndian.Uint16(g[p+2:])))
+		p += 4
+		if flags&argsAreWords != 0 {
+			p += 4
+		} else {
+			p += 2
+		}
+		switch {
+		case flags&haveScale != 0:
+			p += 2
+		case flags&haveXYScale != 0:
+			p += 4
+		case flags&haveTwoByTwo != 0:
+			p += 8
+		}
+		if flags&moreComponents == 0 {
+			break
+		}
+	}
+	return ids
+}
+
+// subset returns the font with every glyph not needed to draw keep emptied
+// and the tables dropTables lists removed. Glyph 0, drawn for unmapped
+// runes, is always kept.
+func (f *font) subset(keep map[rune]bool) ([]byte, error) {
+	used := map[int]bool{0: true}
+	var queue []int
+	for r := range keep {
+		if gid := f.cmap(r); gid != 0 {
+			queue = append(queue, gid)
+		}
+	}
+	queue = append(queue, 0)
+	for len(queue) > 0 {
+		gid := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		for _, c := range components(f.glyph(gid)) {
+			if !used[c] {
+				used[c] = true
+				queue = append(queue, c)
+			}
+		}
+		used[gid] = true
+	}
+
+	var glyf []byte
+	loca := make([]uint32, len(f.loca))
+	for gid := range len(f.loca) - 1 {
+		loca[gid] = uint32(len(glyf))
+		if used[gid] {
+			glyf = append(glyf, f.glyph(gid)...)
+			for len(glyf)%4 != 0 {
+				glyf = append(glyf, 0)
+			}
+		}
+	}
+	loca[len(loca)-1] = uint32(len(glyf))
+
+	tables := make(map[string][]byte, len(f.tables))
+	for tag, data := range f.tables {
+		tables[tag] = data
+	}
+	tables["glyf"] = glyf
+	tables["loca"] = encodeLoca(loca, binary.BigEndian.Uint16(f.tables["head"][headIndexToLoc:]) == 1)
+	tags := slices.DeleteFunc(slices.Clone(f.tags), func(tag string) bool {
+		return slices.Contains(dropTables, tag)
+	})
+	return writeFont(tags, tables), nil
+}
+
+// encodeLoca encodes glyph offsets in the long or short loca format.
+func encodeLoca(loca []uint32, long bool) []byte {
+	if long {
+		out := make([]byte, 4*len(loca))
+		for i, off := range loca {
+			binary.BigEndian.PutUint32(out[4*i:], off)
+		}
+		return out
+	}
+	out := make([]byte, 2*len(loca))
+	for i, off := range loca {
+		binary.BigEndian.PutUint16(out[2*i:], uint16(off/2))
+	}
+	return out
+}
+
+// writeFont lays out tables in the order of tags behind a fresh table
+// directory, with checksums and head.checkSumAdjustment recomputed.
+func writeFont(tags []string, tables map[string][]byte) []byte {
+	n := len(tags)
+	sorted := slices.Clone(tags)
+	slices.Sort(sorted) // The directory lists tables in tag order.
+
+	// Search parameters describe the largest power of two at most n.
+	entrySelector := 0
+	for 1<<(entrySelector+1) <= n {
+		entrySelector++
+	}
+	searchRange := 16 << entrySelector
+
+	out := make([]byte, 12+16*n)
+	binary.BigEndian.PutUint32(out, sfntVersionTrueType)
+	binary.BigEndian.PutUint16(out[4:], uint16(n))
+	binary.BigEndian.PutUint16(out[6:], uint16(searchRange))
+	binary.BigEndian.PutUint16(out[8:], uint16(entrySelector))
+	binary.BigEndian.PutUint16(out[10:], uint16(16*n-searchRange))
+
+	offsets := make(map[string]int, n)
+	for _, tag := range tags {
+		data := tables[tag]
+		if tag == "head" {
+			data = slices.Clone(data)
+			binary.BigEndian.PutUint32(data[headAdjustOffset:], 0)
+			tables[tag] = data
+		}
+		offsets[tag] = len(out)
+		out = append(out, data...)
+		for len(out)%4 != 0 {
+			out = append(out, 0)
+		}
+	}
+	for i, tag := range sorted {
+		rec := out[12+16*i:]
+		data := tables[tag]
+		copy(rec, tag)
+		binary.BigEndian.PutUint32(rec[4:], checksum(data))
+		binary.BigEndian.PutUint32(rec[8:], uint32(offsets[tag]))
+		binary.BigEndian.PutUint32(rec[12:], uint32(len(data)))
+	}
+
+	adjust := checksumMagic - checksum(out)
+	binary.BigEndian.PutUint32(out[offsets["head"]+headAdjustOffset:], adjust)
+	return out
+}
+
+// checksum returns the sum of data as big-endian uint32s, zero-padded.
+func checksum(data []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(data); i += 4 {
+		var word [4]byte
+		copy(word[:], data[i:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+	return sum
+}
+
+// parseCmap returns a rune lookup for the best Unicode subtable of a cmap
+// table: format 12 for full Unicode, else format 4 for the BMP.
+func parseCmap(cmap []byte) (func(rune) int, error) {
+	if len(cmap) < 4 {
+		return nil, errors.New("truncated cmap")
+	}
+	var best func(rune) int
+	bestRank := 0
+	n := int(binary.BigEndian.Uint16(cmap[2:]))
+	for i := range n {
+		rec := 4 + 8*i
+		if rec+8 > len(cmap) {
+			return nil, errors.New("truncated cmap")
+		}
+		platform := binary.BigEndian.Uint16(cmap[rec:])
+		encoding := binary.BigEndian.Uint16(cmap[rec+2:])
+		off := binary.BigEndian.Uint32(cmap[rec+4:])
+		if platform != 0 && (platform != 3 || (encoding != 1 && encoding != 10)) {
+			continue // Not a Unicode subtable.
+		}
+		if uint64(off)+2 > uint64(len(cmap)) {
+			return nil, errors.New("cmap subtable past the end of the table")
+		}
+		sub := cmap[off:]
+		switch binary.BigEndian.Uint16(sub) {
+		case 4:
+			if bestRank < 1 {
+				best, bestRank = cmapFormat4(sub), 1
+			}
+		case 12:
+			if bestRank < 2 {
+				best, bestRank = cmapFormat12(sub), 2
+			}
+		}
+	}
+	if best == nil {
+		return nil, errors.New("no Unicode cmap subtable")
+	}
+	return best, nil
+}
+
+// cmapFormat4 returns the lookup of a segment-mapping subtable.
+func cmapFormat4(sub []byte) func(rune) int {
+	u16 := func(p int) int {
+		if p < 0 || p+2 > len(sub) {
+			return 0
+		}
+		return int(binary.BigEndian.Uint16(sub[p:]))
+	}
+	segs := u16(6) / 2
+	ends, starts, deltas, ranges := 14, 16+2*segs, 16+4*segs, 16+6*segs
+	return func(r rune) int {
+		if r > 0xFFFF {
+			return 0
+		}
+		c := int(r)
+		for i := range segs {
+			if c > u16(ends+2*i) {
+				continue
+			}
+			start := u16(starts + 2*i)
+			if c < start {
+				return 0
+			}
+			delta, ro := u16(deltas+2*i), u16(ranges+2*i)
+			if ro == 0 {
+				return (c + delta) & 0xFFFF
+			}
+			gid := u16(ranges + 2*i + ro + 2*(c-start))
+			if gid == 0 {
+				return 0
+			}
+			return (gid + delta) & 0xFFFF
+		}
+		return 0
+	}
+}
+
+// cmapFormat12 returns the lookup of a segmented-coverage subtable.
+func cmapFormat12(sub []byte) func(rune) int {
+	if len(sub) < 16 {
+		return func(rune) int { return 0 }
+	}
+	n := int(binary.BigEndian.Uint32(sub[12:]))
+	n = min(n, (len(sub)-16)/12)
+	return func(r rune) int {
+		for i := range n {
+			g := sub[16+12*i:]
+			start, end := rune(binary.BigEndian.Uint32(g)), rune(binary.BigEndian.Uint32(g[4:]))
+			if r >= start && r <= end {
+				return int(binary.BigEndian.Uint32(g[8:])) + int(r-start)
+			}
+		}
+		return 0
+	}
+}
@@ -0,0 +1,197 @@
+// Command fontsub subsets the game's TrueType fonts to the glyphs its text
+// can use, so the embedded fonts stay small however many languages the
+// locale files come to cover.
+//
+// The text is printable ASCII, always kept for what the game formats at
+// run time (scores, initials, key names), plus every rune of the locale
+// files named on the command line. A locale file is a JSON document whose
+// string values are the translated text; any other file counts as text in
+// full. Glyphs outside the set are emptied while glyph IDs stay put, so
+// kerning, metrics, and the cmap need no rewriting.
+//
+//	go run ./cmd/fontsub -o build/fonts locales/*.json
+//
+// With -verify it instead checks that each font draws every rune of the
+// locale files, listing any that would render as tofu, and exits non-zero
+// if one would. Run it against the subset fonts to check a subsetting run:
+//
+//	go run ./cmd/fontsub -verify -fonts 'build/fonts/*.ttf' locales/*.json
+//
+// The package's tests make the same check of the embedded fonts against
+// printable ASCII and the string literals of the game's source, so go test
+// fails on tofu.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// main subsets or verifies the fonts against the named locale files.
+func main() {
+	fonts := flag.String("fonts", "assets/fonts/*.ttf", "glob of the fonts to subset or verify")
+	out := flag.String("o", "", "directory to write subset fonts to; required unless -verify")
+	verify := flag.Bool("verify", false, "check the fonts draw every locale rune instead of subsetting")
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("fontsub: ")
+
+	paths, err := filepath.Glob(*fonts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(paths) == 0 {
+		log.Fatalf("no fonts match %s", *fonts)
+	}
+	strs, err := localeStrings(flag.Args())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *verify {
+		if !verifyFonts(paths, strs) {
+			os.Exit(1)
+		}
+		return
+	}
+	if *out == "" {
+		log.Fatal("-o is required to subset")
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		log.Fatal(err)
+	}
+	keep := runeSet(strs)
+	for _, path := range paths {
+		if err := subsetFile(path, filepath.Join(*out, filepath.Base(path)), keep); err != nil {
+			log.Fatalf("%s: %v", path, err)
+		}
+	}
+}
+
+// localeStrings returns the text of the locale files at paths.
+func localeStrings(paths []string) ([]string, error) {
+	var strs []string
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if filepath.Ext(path) != ".json" {
+			strs = append(strs, string(data))
+			continue
+		}
+		var doc any
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		strs = appendStrings(strs, doc)
+	}
+	return strs, nil
+}
+
+// appendStrings appends every string value in the decoded JSON v to strs.
+func appendStrings(strs []string, v any) []string {
+	switch v := v.(type) {
+	case string:
+		strs = append(strs, v)
+	case []any:
+		for _, e := range v {
+			strs = appendStrings(strs, e)
+		}
+	case map[string]any:
+		for _, e := range v {
+			strs = appendStrings(strs, e)
+		}
+	}
+	return strs
+}
+
+// printableASCII returns the runes from space to tilde, which the game's
+// own text and run-time formatting need whatever the locale.
+func printableASCII() string {
+	var b strings.Builder
+	for r := rune(' '); r <= '~'; r++ {
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// runeSet returns printable ASCII and every rune of strs.
+func runeSet(strs []string) map[rune]bool {
+	set := make(map[rune]bool)
+	for _, s := range append(strs, printableASCII()) {
+		for _, r := range s {
+			set[r] = true
+		}
+	}
+	return set
+}
+
+// subsetFile writes to out the font at path subset to keep, and reports
+// the saving.
+func subsetFile(path, out string, keep map[rune]bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	f, err := parseFont(data)
+	if err != nil {
+		return err
+	}
+	sub, err := f.subset(keep)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(out, sub, 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("%s: %d -> %d bytes\n", out, len(data), len(sub))
+	return nil
+}
+
+// verifyFonts reports whether every font at paths draws every rune of
+// strs, listing each one that would not and the first string needing it.
+func verifyFonts(paths, strs []string) bool {
+	ok := true
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		f, err := parseFont(data)
+		if err != nil {
+			log.Fatalf("%s: %v", path, err)
+		}
+
+		checked := make(map[rune]bool)
+		missing := make(map[rune]string)
+		for _, s := range strs {
+			for _, r := range s {
+				if checked[r] || unicode.IsControl(r) {
+					continue
+				}
+				checked[r] = true
+				if !f.draws(r) {
+					missing[r] = s
+				}
+			}
+		}
+		runes := make([]rune, 0, len(missing))
+		for r := range missing {
+			runes = append(runes, r)
+		}
+		slices.Sort(runes)
+		for _, r := range runes {
+			fmt.Printf("%s: no glyph for %q (U+%04X) in %q\n", path, r, r, strings.TrimSpace(missing[r]))
+			ok = false
+		}
+	}
+	return ok
+}
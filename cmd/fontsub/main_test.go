@@ -0,0 +1,162 @@
+// File main_test.go checks the embedded fonts against the game's text: each
+// must draw printable ASCII and every rune of the string literals in the
+// game's source, so a missing glyph fails the build instead of rendering
+// as tofu.
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+// Paths, relative to this package, of the fonts the game embeds and of the
+// source whose text they draw.
+const (
+	embeddedFonts = "../../assets/fonts/*.ttf"
+	gameSources   = "../../asteroids/*.go"
+)
+
+// knownTofu lists, by font file, the runes a font is known not to draw and
+// the game never draws in it: the title font has no tilde, and only
+// headings and initials are set in it. A rune listed here that the font
+// comes to draw fails the test too, so the list cannot go stale.
+var knownTofu = map[string]string{
+	"title.ttf": "~",
+}
+
+// loadFonts parses every font matching pattern, keyed by path.
+func loadFonts(t *testing.T, pattern string) map[string]*font {
+	t.Helper()
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatalf("no fonts match %s", pattern)
+	}
+	fonts := make(map[string]*font, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := parseFont(data)
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		fonts[path] = f
+	}
+	return fonts
+}
+
+// sourceStrings returns the string literals of the Go files matching
+// pattern, tests and struct tags aside: every piece of text the game can
+// draw is built from them, or formatted from printable ASCII.
+func sourceStrings(t *testing.T, pattern string) []string {
+	t.Helper()
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var strs []string
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tags := make(map[*ast.BasicLit]bool)
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.Field:
+				tags[n.Tag] = true
+			case *ast.BasicLit:
+				if n.Kind != token.STRING || tags[n] {
+					break
+				}
+				s, err := strconv.Unquote(n.Value)
+				if err != nil {
+					t.Fatalf("%s: %v", fset.Position(n.Pos()), err)
+				}
+				strs = append(strs, s)
+			}
+			return true
+		})
+	}
+	return strs
+}
+
+func TestEmbeddedFontsDrawGameText(t *testing.T) {
+	strs := sourceStrings(t, gameSources)
+	// A sample the game is known to draw, so a source that moved or a
+	// parse that found nothing fails rather than checking ASCII alone.
+	if !slices.Contains(strs, "SALVAGE SHOP") {
+		t.Fatalf("found no game text in %s", gameSources)
+	}
+	strs = append(strs, printableASCII())
+
+	for path, f := range loadFonts(t, embeddedFonts) {
+		known := knownTofu[filepath.Base(path)]
+		for _, r := range known {
+			if f.draws(r) {
+				t.Errorf("%s: draws %q now; drop it from knownTofu", path, r)
+			}
+		}
+		checked := make(map[rune]bool)
+		for _, s := range strs {
+			for _, r := range s {
+				if checked[r] || unicode.IsControl(r) || strings.ContainsRune(known, r) {
+					continue
+				}
+				checked[r] = true
+				if !f.draws(r) {
+					t.Errorf("%s: no glyph for %q (U+%04X), needed by %q", path, r, r, s)
+				}
+			}
+		}
+	}
+}
+
+func TestSubsetKeepsGameText(t *testing.T) {
+	keep := runeSet([]string{"Énergie épuisée"})
+	for path, f := range loadFonts(t, embeddedFonts) {
+		data, err := f.subset(keep)
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		sub, err := parseFont(data)
+		if err != nil {
+			t.Fatalf("%s: subset does not parse: %v", path, err)
+		}
+		for r := range keep {
+			if f.draws(r) && !sub.draws(r) {
+				t.Errorf("%s: subset lost the glyph for %q", path, r)
+			}
+		}
+	}
+}
+
+func TestVerifyFontsReportsTofu(t *testing.T) {
+	paths, err := filepath.Glob(embeddedFonts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !verifyFonts(paths, []string{"GAME OVER", "Score: 1,200"}) {
+		t.Error("verifyFonts rejected text both fonts draw")
+	}
+	// No arcade font carries CJK, so this must be reported.
+	if verifyFonts(paths, []string{"ゲームオーバー"}) {
+		t.Error("verifyFonts accepted text the fonts cannot draw")
+	}
+}
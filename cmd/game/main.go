@@ -5,17 +5,38 @@
 package main
 
 import (
+	"flag"
+
 	"github.com/bensabler/asteroids/asteroids"
+	"github.com/bensabler/asteroids/internal/sim"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
 // main configures the window and hands control to Ebiten's game loop.
 // Panics on a non-nil error to surface fatal startup/runtime issues.
 func main() {
+	// Command-line switches.
+	flag.BoolVar(&asteroids.SingleThreaded, "single-threaded", false, "run all per-tick work on one goroutine")
+	flag.Int64Var(&asteroids.Seed, "seed", 0, "seed every run with this value (0 picks a random seed)")
+	flag.StringVar(&asteroids.ReplayFile, "replay", "", `play back a replay file ("last" or "best" for the saved ones)`)
+	challenge := flag.String("challenge", "", "seed every run from this challenge code (overrides -seed)")
+	flag.Parse()
+	if *challenge != "" {
+		asteroids.Seed = asteroids.SeedFromCode(*challenge)
+	}
+
+	// Step the game at the simulation's fixed tick rate.
+	ebiten.SetTPS(sim.TicksPerSecond)
+
 	// Window title and logical size (backed by asteroids package constants).
 	ebiten.SetWindowTitle("Asteroids!")
 	ebiten.SetWindowSize(asteroids.ScreenWidth, asteroids.ScreenHeight)
 
+	// Let the OS window be resized; Game.Layout keeps the logical surface fixed
+	// and Ebiten letterboxes it. The lower limit keeps HUD text legible.
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	ebiten.SetWindowSizeLimits(asteroids.ScreenWidth/2, asteroids.ScreenHeight/2, -1, -1)
+
 	// Enter Ebiten's loop using our asteroids.Game implementation.
 	if err := ebiten.RunGame(&asteroids.Game{}); err != nil {
 		panic(err)
@@ -0,0 +1,82 @@
+// File canvas.go implements the braille canvas the terminal front end draws
+// on. Each character cell holds a 2 × 4 block of dots, one Unicode braille
+// pattern, so an 80 × 24 terminal gives a 160 × 96 dot picture.
+package main
+
+import (
+	"math"
+	"strings"
+)
+
+// brailleBlank is the empty braille pattern; dot bits are added to it.
+const brailleBlank = 0x2800
+
+// brailleDots maps a dot's position in its cell, [row][column], to its bit.
+var brailleDots = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// Canvas is a grid of character cells addressed in dots.
+type Canvas struct {
+	cols, rows int    // Size in character cells.
+	cells      []rune // Dot bits per cell, row-major.
+}
+
+// NewCanvas returns a blank canvas of cols × rows character cells.
+func NewCanvas(cols, rows int) *Canvas {
+	return &Canvas{cols: cols, rows: rows, cells: make([]rune, cols*rows)}
+}
+
+// Width returns the canvas width in dots.
+func (c *Canvas) Width() int { return c.cols * 2 }
+
+// Height returns the canvas height in dots.
+func (c *Canvas) Height() int { return c.rows * 4 }
+
+// Clear blanks every dot.
+func (c *Canvas) Clear() {
+	clear(c.cells)
+}
+
+// Set turns on the dot at (x, y); dots off the canvas are ignored.
+func (c *Canvas) Set(x, y int) {
+	if x < 0 || y < 0 || x >= c.Width() || y >= c.Height() {
+		return
+	}
+	c.cells[(y/4)*c.cols+x/2] |= brailleDots[y%4][x%2]
+}
+
+// Line draws a straight line between two dot positions.
+func (c *Canvas) Line(x0, y0, x1, y1 float64) {
+	steps := int(math.Max(math.Abs(x1-x0), math.Abs(y1-y0))) + 1
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		c.Set(int(math.Round(x0+(x1-x0)*t)), int(math.Round(y0+(y1-y0)*t)))
+	}
+}
+
+// Circle draws the outline of a circle around a dot position.
+func (c *Canvas) Circle(x, y, r float64) {
+	steps := int(2*math.Pi*r) + 8
+	for i := range steps {
+		angle := 2 * math.Pi * float64(i) / float64(steps)
+		c.Set(int(math.Round(x+math.Cos(angle)*r)), int(math.Round(y+math.Sin(angle)*r)))
+	}
+}
+
+// Rows returns the canvas as one string per character row.
+func (c *Canvas) Rows() []string {
+	rows := make([]string, c.rows)
+	var b strings.Builder
+	for r := range c.rows {
+		b.Reset()
+		for _, bits := range c.cells[r*c.cols : (r+1)*c.cols] {
+			b.WriteRune(brailleBlank + bits)
+		}
+		rows[r] = b.String()
+	}
+	return rows
+}
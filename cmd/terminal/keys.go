@@ -0,0 +1,106 @@
+// File keys.go turns the bytes a raw terminal sends into sim actions.
+//
+// A terminal reports key presses, never releases: holding a key sends it
+// once, then again at the keyboard's repeat rate. Keyboard therefore treats
+// an action as held for holdDuration after each press, long enough to
+// bridge the usual delay before key repeat starts.
+package main
+
+import (
+	"time"
+
+	"github.com/bensabler/asteroids/internal/sim"
+)
+
+// holdDuration is how long one key press keeps its action held.
+const holdDuration = 300 * time.Millisecond
+
+// keyActions maps single-byte keys to actions. Arrow keys arrive as escape
+// sequences and are decoded separately.
+var keyActions = map[byte]sim.Action{
+	'a': sim.ActionRotateLeft,
+	'd': sim.ActionRotateRight,
+	'w': sim.ActionThrust,
+	's': sim.ActionReverse,
+	' ': sim.ActionFire,
+}
+
+// arrowActions maps the final byte of an arrow key's escape sequence
+// (ESC [ A through ESC [ D) to its action.
+var arrowActions = map[byte]sim.Action{
+	'A': sim.ActionThrust,
+	'B': sim.ActionReverse,
+	'C': sim.ActionRotateRight,
+	'D': sim.ActionRotateLeft,
+}
+
+// Keyboard is the terminal's sim.Controls: the actions pressed recently
+// enough to count as held.
+type Keyboard struct {
+	held     [sim.ActionCount]int  // Ticks each action stays held.
+	previous [sim.ActionCount]bool // Action state last tick.
+	quit     bool                  // The player asked to leave.
+	escape   int                   // Bytes of an escape sequence seen so far.
+}
+
+// Feed decodes bytes read from the terminal, pressing their actions.
+func (k *Keyboard) Feed(input []byte) {
+	for _, b := range input {
+		switch {
+		case k.escape == 1 && b == '[':
+			k.escape = 2
+			continue
+		case k.escape == 2:
+			k.escape = 0
+			if a, ok := arrowActions[b]; ok {
+				k.press(a)
+			}
+			continue
+		}
+		k.escape = 0
+		switch b {
+		case 0x1b:
+			k.escape = 1
+		case 'q', 0x03: // q or Ctrl-C.
+			k.quit = true
+		default:
+			if 'A' <= b && b <= 'Z' {
+				b += 'a' - 'A' // Caps lock or Shift still steers.
+			}
+			if a, ok := keyActions[b]; ok {
+				k.press(a)
+			}
+		}
+	}
+}
+
+// press holds a for holdDuration from now.
+func (k *Keyboard) press(a sim.Action) {
+	k.held[a] = sim.Ticks(holdDuration)
+}
+
+// Tick ages every held action by one tick. Call it after the rules have
+// read the keyboard for the tick.
+func (k *Keyboard) Tick() {
+	for a := range k.held {
+		k.previous[a] = k.held[a] > 0
+		if k.held[a] > 0 {
+			k.held[a]--
+		}
+	}
+}
+
+// IsPressed reports whether the action is held this tick.
+func (k *Keyboard) IsPressed(a sim.Action) bool {
+	return k.held[a] > 0
+}
+
+// IsJustPressed reports whether the action went down this tick.
+func (k *Keyboard) IsJustPressed(a sim.Action) bool {
+	return k.held[a] > 0 && !k.previous[a]
+}
+
+// Quit reports whether the player asked to leave.
+func (k *Keyboard) Quit() bool {
+	return k.quit
+}
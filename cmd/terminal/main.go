@@ -0,0 +1,110 @@
+// Command terminal plays Asteroids in a text terminal, drawn in braille
+// characters, on the same rules as the windowed game.
+//
+// It is the proof that the rules in internal/sim stand apart from their
+// renderer: the field steps through sim timers, levels, waves, and bounces,
+// and the player's keys reach it as sim actions through sim.Controls, the
+// interface the windowed game's Input also satisfies. Nothing here imports
+// Ebiten; the front end writes ANSI escape sequences and reads a raw TTY.
+//
+//	go run ./cmd/terminal -level 2
+//
+// Steer with the arrow keys or WASD, fire with Space, and leave with q.
+// Boss levels need the full game's sprites and are skipped.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bensabler/asteroids/internal/sim"
+)
+
+// Terminal size used when the real one cannot be read.
+const (
+	defaultCols = 80
+	defaultRows = 24
+)
+
+// frameEvery is how many ticks pass between redraws; most terminals cannot
+// keep up with a full redraw every tick.
+const frameEvery = 2
+
+// main plays a run until the player quits.
+func main() {
+	level := flag.Int("level", 1, "level to start on")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "random seed")
+	flag.Parse()
+
+	cols, rows, err := terminalSize()
+	if err != nil || cols <= 0 || rows <= 1 {
+		cols, rows = defaultCols, defaultRows
+	}
+
+	tty, err := makeRaw()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "terminal:", err)
+		os.Exit(1)
+	}
+	fmt.Print(enterScreen)
+	defer func() {
+		fmt.Print(leaveScreen)
+		_ = tty.Close()
+	}()
+
+	keys := make(chan []byte, 16)
+	go readKeys(keys)
+
+	world := NewWorld(*level, *seed)
+	canvas := NewCanvas(cols, rows-1) // One row for the status bar.
+	var keyboard Keyboard
+	ticker := time.NewTicker(time.Second / sim.TicksPerSecond)
+	defer ticker.Stop()
+
+	for tick := 0; ; tick++ {
+		<-ticker.C
+		drainKeys(keys, &keyboard)
+		if keyboard.Quit() {
+			return
+		}
+
+		world.Update(&keyboard)
+		keyboard.Tick()
+		if tick%frameEvery == 0 {
+			draw(world, canvas)
+			fmt.Print(frame(world, canvas))
+		}
+	}
+}
+
+// readKeys forwards everything typed on standard input until it closes.
+func readKeys(keys chan<- []byte) {
+	buf := make([]byte, 64)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if n > 0 {
+			keys <- append([]byte(nil), buf[:n]...)
+		}
+		if err != nil {
+			close(keys)
+			return
+		}
+	}
+}
+
+// drainKeys feeds every key read since the last tick to the keyboard.
+func drainKeys(keys <-chan []byte, keyboard *Keyboard) {
+	for {
+		select {
+		case input, ok := <-keys:
+			if !ok {
+				return
+			}
+			keyboard.Feed(input)
+		default:
+			return
+		}
+	}
+}
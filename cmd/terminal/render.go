@@ -0,0 +1,69 @@
+// File render.go draws a World onto a braille canvas and frames it with a
+// one-line status bar for the terminal.
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Escape sequences the front end writes.
+const (
+	enterScreen = "\x1b[?1049h\x1b[?25l" // Alternate screen, hidden cursor.
+	leaveScreen = "\x1b[?25h\x1b[?1049l" // Cursor shown, main screen back.
+	homeCursor  = "\x1b[H"               // Top-left corner.
+	clearLine   = "\x1b[K"               // Erase to the end of the line.
+)
+
+// shipOutline is the ship's outline as (angle from heading, share of
+// shipRadius) points.
+var shipOutline = [...][2]float64{
+	{0, 1.2},
+	{2.5, 1},
+	{math.Pi, 0.4},
+	{-2.5, 1},
+}
+
+// draw renders the field of w onto c, scaled to fill it.
+func draw(w *World, c *Canvas) {
+	c.Clear()
+	sx, sy := float64(c.Width())/fieldWidth, float64(c.Height())/fieldHeight
+
+	for _, m := range w.meteors {
+		c.Circle(m.position.X*sx, m.position.Y*sy, meteorRadii[m.size]*sx)
+	}
+	for _, l := range w.lasers {
+		c.Set(int(l.position.X*sx), int(l.position.Y*sy))
+	}
+	if w.alive {
+		for i, p := range shipOutline {
+			q := shipOutline[(i+1)%len(shipOutline)]
+			c.Line(
+				(w.position.X+math.Sin(w.rotation+p[0])*shipRadius*p[1])*sx,
+				(w.position.Y-math.Cos(w.rotation+p[0])*shipRadius*p[1])*sy,
+				(w.position.X+math.Sin(w.rotation+q[0])*shipRadius*q[1])*sx,
+				(w.position.Y-math.Cos(w.rotation+q[0])*shipRadius*q[1])*sy,
+			)
+		}
+	}
+}
+
+// frame returns the escape sequences and text for one full screen: the
+// status bar above the canvas.
+func frame(w *World, c *Canvas) string {
+	status := fmt.Sprintf("Score: %d   Lives: %d   Level: %d", w.score, w.lives, w.level.Number)
+	if w.over {
+		status += "   GAME OVER - press q to quit"
+	}
+
+	var b strings.Builder
+	b.WriteString(homeCursor)
+	b.WriteString(status)
+	b.WriteString(clearLine)
+	for _, row := range c.Rows() {
+		b.WriteString("\r\n") // Raw mode does not turn \n into \r\n.
+		b.WriteString(row)
+	}
+	return b.String()
+}
@@ -0,0 +1,12 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+
+// File tty-bsd.go names the termios requests on macOS and the BSDs.
+package main
+
+import "golang.org/x/sys/unix"
+
+// Requests for reading and writing the terminal mode.
+const (
+	ioctlGetTermios = unix.TIOCGETA
+	ioctlSetTermios = unix.TIOCSETA
+)
@@ -0,0 +1,12 @@
+//go:build linux
+
+// File tty-linux.go names the termios requests on Linux.
+package main
+
+import "golang.org/x/sys/unix"
+
+// Requests for reading and writing the terminal mode.
+const (
+	ioctlGetTermios = unix.TCGETS
+	ioctlSetTermios = unix.TCSETS
+)
@@ -0,0 +1,27 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+// File tty-other.go stands in for raw mode where termios is unavailable.
+package main
+
+import "errors"
+
+// errNoRawMode is returned on systems the front end cannot drive.
+var errNoRawMode = errors.New("terminal: raw mode is not supported on this system")
+
+// rawTerminal is never created on these systems.
+type rawTerminal struct{}
+
+// makeRaw always fails on these systems.
+func makeRaw() (*rawTerminal, error) {
+	return nil, errNoRawMode
+}
+
+// Close does nothing.
+func (t *rawTerminal) Close() error {
+	return nil
+}
+
+// terminalSize always fails on these systems.
+func terminalSize() (cols, rows int, err error) {
+	return 0, 0, errNoRawMode
+}
@@ -0,0 +1,54 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+// File tty-unix.go puts the controlling terminal into raw mode on systems
+// with termios, so key presses arrive one byte at a time without echo.
+package main
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// rawTerminal restores the terminal's original mode when closed.
+type rawTerminal struct {
+	fd    int           // Descriptor of standard input.
+	saved *unix.Termios // Mode to restore.
+}
+
+// makeRaw switches standard input to raw mode and returns a handle that
+// restores it.
+func makeRaw() (*rawTerminal, error) {
+	fd := int(os.Stdin.Fd())
+	saved, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := *saved
+	raw.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	raw.Oflag &^= unix.OPOST
+	raw.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	raw.Cflag &^= unix.CSIZE | unix.PARENB
+	raw.Cflag |= unix.CS8
+	raw.Cc[unix.VMIN] = 1
+	raw.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(fd, ioctlSetTermios, &raw); err != nil {
+		return nil, err
+	}
+	return &rawTerminal{fd: fd, saved: saved}, nil
+}
+
+// Close restores the terminal's original mode.
+func (t *rawTerminal) Close() error {
+	return unix.IoctlSetTermios(t.fd, ioctlSetTermios, t.saved)
+}
+
+// terminalSize returns the size of standard output in character cells.
+func terminalSize() (cols, rows int, err error) {
+	ws, err := unix.IoctlGetWinsize(int(os.Stdout.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(ws.Col), int(ws.Row), nil
+}
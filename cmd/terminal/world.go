@@ -0,0 +1,319 @@
+// File world.go holds the terminal front end's play field: a ship, its
+// lasers, and the meteors a level releases. Motion, timers, the level table,
+// the wave manager, and meteor bounces all come from internal/sim; only the
+// handful of entity rules below are this front end's own.
+package main
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/bensabler/asteroids/internal/sim"
+)
+
+// Field and tuning for the terminal game. Speeds are world units per second.
+const (
+	fieldWidth         = 1280.0                  // World units; the field wraps.
+	fieldHeight        = 720.0                   // World units; the field wraps.
+	turnRate           = math.Pi                 // Radians per second.
+	thrustAcceleration = 480.0                   // World units per second per second.
+	shipMaxSpeed       = 480.0                   // Top speed under thrust.
+	shipDrag           = 0.6                     // Share of speed lost per second.
+	shipRadius         = 16.0                    // Collision size of the ship.
+	laserSpeed         = 900.0                   // Laser speed.
+	laserLifetime      = 800 * time.Millisecond  // How long a laser flies.
+	fireInterval       = 200 * time.Millisecond  // Shortest gap between shots.
+	spawnInterval      = 1500 * time.Millisecond // Gap between meteor spawns.
+	respawnDelay       = 2 * time.Second         // Time out of play after a hit.
+	startingLives      = 3                       // Ships at the start of a run.
+	restitution        = 0.9                     // Share of closing speed kept after a bounce.
+	safeRespawnRadius  = 120.0                   // Clearance a respawning ship waits for.
+)
+
+// Vector is the simulation's world-space vector.
+type Vector = sim.Vector
+
+// meteorSize is a meteor's size class; large meteors split into medium
+// ones and medium into small.
+type meteorSize int
+
+// Meteor sizes, largest first.
+const (
+	meteorLarge meteorSize = iota
+	meteorMedium
+	meteorSmall
+)
+
+// meteorRadii and meteorScores are indexed by meteorSize.
+var (
+	meteorRadii  = [...]float64{40, 22, 11}
+	meteorScores = [...]int{20, 50, 100}
+)
+
+// meteor is one meteor in play.
+type meteor struct {
+	position Vector     // Center in world units.
+	velocity Vector     // World units per second.
+	size     meteorSize // Size class.
+}
+
+// body returns the meteor as the collision response sees it.
+func (m *meteor) body() sim.Body {
+	return sim.Body{Position: &m.position, Velocity: &m.velocity, Radius: meteorRadii[m.size]}
+}
+
+// laser is one shot in flight.
+type laser struct {
+	position Vector // World units.
+	velocity Vector // World units per second.
+	ticks    int    // Ticks left to fly.
+}
+
+// World is one terminal run.
+type World struct {
+	rng      *rand.Rand       // Spawn positions and fragment spread.
+	level    sim.Level        // Level being played.
+	waves    *sim.WaveManager // The level's meteor budget and alive count.
+	spawn    *sim.Timer       // Gap between meteor spawns.
+	fire     *sim.Timer       // Gap between shots.
+	respawn  *sim.Timer       // Time out of play after a hit.
+	ticks    int              // Ticks into the current level.
+	position Vector           // Ship center in world units.
+	velocity Vector           // Ship velocity in world units per second.
+	rotation float64          // Ship heading in radians; 0 points up.
+	alive    bool             // False while waiting to respawn.
+	lives    int              // Ships left, including the one in play.
+	score    int              // Points scored this run.
+	over     bool             // No ships left.
+	meteors  []*meteor        // Meteors in play.
+	lasers   []*laser         // Shots in flight.
+}
+
+// NewWorld starts a run at the given level.
+func NewWorld(level int, seed int64) *World {
+	w := &World{
+		rng:     rand.New(rand.NewSource(seed)),
+		spawn:   sim.NewTimer(spawnInterval),
+		fire:    sim.NewTimer(fireInterval),
+		respawn: sim.NewTimer(respawnDelay),
+		lives:   startingLives,
+	}
+	w.startLevel(level)
+	w.spawnShip()
+	return w
+}
+
+// startLevel begins level n. Boss fights and their sprites live in the
+// full game, so boss levels are passed over here.
+func (w *World) startLevel(n int) {
+	for sim.LevelFor(n).Kind == sim.WaveBoss {
+		n++
+	}
+	w.level = sim.LevelFor(n)
+	w.waves = sim.NewWaveManager(w.level)
+	w.ticks = 0
+}
+
+// spawnShip puts the ship at rest in the middle of the field.
+func (w *World) spawnShip() {
+	w.position = Vector{X: fieldWidth / 2, Y: fieldHeight / 2}
+	w.velocity = Vector{}
+	w.rotation = 0
+	w.alive = true
+}
+
+// isCenterClear reports whether no meteor is near enough to the middle of
+// the field to destroy a ship respawning there.
+func (w *World) isCenterClear() bool {
+	for _, m := range w.meteors {
+		if math.Hypot(m.position.X-fieldWidth/2, m.position.Y-fieldHeight/2) < meteorRadii[m.size]+safeRespawnRadius {
+			return false
+		}
+	}
+	return true
+}
+
+// heading returns the unit vector the ship points along.
+func (w *World) heading() Vector {
+	return Vector{X: math.Sin(w.rotation), Y: -math.Cos(w.rotation)}
+}
+
+// Update advances the run one tick under the given controls.
+func (w *World) Update(c sim.Controls) {
+	if w.over {
+		return
+	}
+	w.ticks++
+	w.waves.Tick()
+	w.fire.Update()
+	w.updateShip(c)
+	w.spawnMeteors()
+	w.moveMeteors()
+	w.moveLasers()
+	w.resolveHits()
+
+	if w.waves.IsCleared() {
+		w.startLevel(w.level.Number + 1)
+	}
+}
+
+// updateShip steers, thrusts, and fires, or counts down to a respawn.
+func (w *World) updateShip(c sim.Controls) {
+	if !w.alive {
+		w.respawn.Update()
+		if w.respawn.IsReady() && w.isCenterClear() {
+			w.respawn.Reset()
+			w.spawnShip()
+		}
+		return
+	}
+
+	if c.IsPressed(sim.ActionRotateLeft) {
+		w.rotation -= sim.PerTick(turnRate)
+	}
+	if c.IsPressed(sim.ActionRotateRight) {
+		w.rotation += sim.PerTick(turnRate)
+	}
+	if c.IsPressed(sim.ActionThrust) {
+		push := w.heading().Scale(sim.PerTick(thrustAcceleration))
+		w.velocity = Vector{X: w.velocity.X + push.X, Y: w.velocity.Y + push.Y}
+		if speed := math.Hypot(w.velocity.X, w.velocity.Y); speed > shipMaxSpeed {
+			w.velocity = w.velocity.Scale(shipMaxSpeed / speed)
+		}
+	}
+	if c.IsPressed(sim.ActionReverse) {
+		w.velocity = w.velocity.Scale(1 - sim.PerTick(4*shipDrag))
+	}
+	w.velocity = w.velocity.Scale(1 - sim.PerTick(shipDrag))
+	sim.Advance(&w.position, w.velocity)
+	wrap(&w.position)
+
+	if c.IsPressed(sim.ActionFire) && w.fire.IsReady() {
+		w.fire.Reset()
+		heading := w.heading()
+		w.lasers = append(w.lasers, &laser{
+			position: Vector{X: w.position.X + heading.X*shipRadius, Y: w.position.Y + heading.Y*shipRadius},
+			velocity: heading.Scale(laserSpeed),
+			ticks:    sim.Ticks(laserLifetime),
+		})
+	}
+}
+
+// spawnMeteors releases the level's budget one large meteor per interval,
+// from a random edge toward a point near the middle of the field.
+func (w *World) spawnMeteors() {
+	w.spawn.Update()
+	if !w.spawn.IsReady() || !w.waves.CanSpawn() {
+		return
+	}
+	w.spawn.Reset()
+
+	var position Vector
+	if w.rng.Intn(2) == 0 {
+		position = Vector{X: w.rng.Float64() * fieldWidth}
+	} else {
+		position = Vector{Y: w.rng.Float64() * fieldHeight}
+	}
+	target := Vector{
+		X: fieldWidth/2 + (w.rng.Float64()-0.5)*fieldWidth/2,
+		Y: fieldHeight/2 + (w.rng.Float64()-0.5)*fieldHeight/2,
+	}
+	heading := Vector{X: target.X - position.X, Y: target.Y - position.Y}.Normalize()
+	w.meteors = append(w.meteors, &meteor{
+		position: position,
+		velocity: heading.Scale(w.level.MeteorVelocityAt(w.ticks)),
+		size:     meteorLarge,
+	})
+	w.waves.TrackSpawn()
+}
+
+// moveMeteors moves every meteor one tick, then bounces overlapping pairs
+// apart.
+func (w *World) moveMeteors() {
+	for _, m := range w.meteors {
+		sim.Advance(&m.position, m.velocity)
+		wrap(&m.position)
+	}
+	for i, a := range w.meteors {
+		for _, b := range w.meteors[i+1:] {
+			if sim.Overlapping(a.body(), b.body()) {
+				sim.Collide(a.body(), b.body(), restitution)
+			}
+		}
+	}
+}
+
+// moveLasers moves every laser one tick and drops the spent ones.
+func (w *World) moveLasers() {
+	live := w.lasers[:0]
+	for _, l := range w.lasers {
+		sim.Advance(&l.position, l.velocity)
+		wrap(&l.position)
+		if l.ticks--; l.ticks > 0 {
+			live = append(live, l)
+		}
+	}
+	w.lasers = live
+}
+
+// resolveHits splits meteors struck by lasers and takes a life from a ship
+// struck by a meteor.
+func (w *World) resolveHits() {
+	var split []*meteor
+	live := w.meteors[:0]
+	for _, m := range w.meteors {
+		if hit := w.laserHitting(m); hit >= 0 {
+			w.lasers = append(w.lasers[:hit], w.lasers[hit+1:]...)
+			w.score += meteorScores[m.size]
+			split = append(split, w.fragments(m)...)
+			w.waves.TrackRemoval()
+			continue
+		}
+		if w.alive && math.Hypot(m.position.X-w.position.X, m.position.Y-w.position.Y) < meteorRadii[m.size]+shipRadius {
+			w.alive = false
+			if w.lives--; w.lives <= 0 {
+				w.over = true
+			}
+		}
+		live = append(live, m)
+	}
+	w.meteors = append(live, split...)
+}
+
+// laserHitting returns the index of a laser inside m, or -1.
+func (w *World) laserHitting(m *meteor) int {
+	for i, l := range w.lasers {
+		if math.Hypot(l.position.X-m.position.X, l.position.Y-m.position.Y) < meteorRadii[m.size] {
+			return i
+		}
+	}
+	return -1
+}
+
+// fragments returns the two pieces a destroyed meteor breaks into, flying
+// off either side of its path; small meteors leave nothing.
+func (w *World) fragments(m *meteor) []*meteor {
+	if m.size == meteorSmall {
+		return nil
+	}
+	speed := math.Hypot(m.velocity.X, m.velocity.Y)*1.3 + 30
+	angle := math.Atan2(m.velocity.Y, m.velocity.X)
+	pieces := make([]*meteor, 0, 2)
+	for _, spread := range [...]float64{-0.6, 0.6} {
+		a := angle + spread + (w.rng.Float64()-0.5)*0.4
+		pieces = append(pieces, &meteor{
+			position: m.position,
+			velocity: Vector{X: math.Cos(a) * speed, Y: math.Sin(a) * speed},
+			size:     m.size + 1,
+		})
+		w.waves.TrackSplit()
+	}
+	return pieces
+}
+
+// wrap moves a position that has left the field back in from the far edge.
+func wrap(p *Vector) {
+	p.X = math.Mod(p.X+fieldWidth, fieldWidth)
+	p.Y = math.Mod(p.Y+fieldHeight, fieldHeight)
+}
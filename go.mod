@@ -5,6 +5,7 @@ go 1.24.6
 require (
 	github.com/hajimehoshi/ebiten/v2 v2.9.0
 	github.com/solarlune/resolv v0.8.1
+	golang.org/x/sys v0.36.0
 )
 
 require (
@@ -19,6 +20,5 @@ require (
 	github.com/rivo/uniseg v0.4.7 // indirect
 	golang.org/x/image v0.31.0 // indirect
 	golang.org/x/sync v0.17.0 // indirect
-	golang.org/x/sys v0.36.0 // indirect
 	golang.org/x/text v0.29.0 // indirect
 )
@@ -0,0 +1,92 @@
+// File action.go defines the logical commands a player gives the ship and
+// the Controls a front end reports them through. The Ebiten adapter reads
+// them from bound keys; the terminal front end reads them from a raw TTY.
+package sim
+
+import "fmt"
+
+// Action is a logical gameplay command that can be bound to a key.
+type Action int
+
+// Bindable gameplay actions.
+const (
+	ActionRotateLeft Action = iota
+	ActionRotateRight
+	ActionThrust
+	ActionReverse
+	ActionFire
+	ActionShield
+	ActionHyperspace
+	ActionBoost
+	ActionSmartBomb
+	ActionTractor
+	ActionCount // Number of actions; keep last.
+)
+
+// actionNames are the stable identifiers used in config files.
+var actionNames = [ActionCount]string{
+	ActionRotateLeft:  "rotate-left",
+	ActionRotateRight: "rotate-right",
+	ActionThrust:      "thrust",
+	ActionReverse:     "reverse",
+	ActionFire:        "fire",
+	ActionShield:      "shield",
+	ActionHyperspace:  "hyperspace",
+	ActionBoost:       "boost",
+	ActionSmartBomb:   "smart-bomb",
+	ActionTractor:     "tractor",
+}
+
+// actionLabels are the human-readable names shown to players.
+var actionLabels = [ActionCount]string{
+	ActionRotateLeft:  "Rotate Left",
+	ActionRotateRight: "Rotate Right",
+	ActionThrust:      "Thrust",
+	ActionReverse:     "Reverse",
+	ActionFire:        "Fire",
+	ActionShield:      "Shield",
+	ActionHyperspace:  "Hyperspace",
+	ActionBoost:       "Afterburner",
+	ActionSmartBomb:   "Smart Bomb",
+	ActionTractor:     "Tractor Beam",
+}
+
+// String returns the action's config-file identifier.
+func (a Action) String() string {
+	if a < 0 || a >= ActionCount {
+		return fmt.Sprintf("action(%d)", int(a))
+	}
+	return actionNames[a]
+}
+
+// Label returns the action's display name.
+func (a Action) Label() string {
+	if a < 0 || a >= ActionCount {
+		return a.String()
+	}
+	return actionLabels[a]
+}
+
+// MarshalText implements encoding.TextMarshaler so actions can key JSON maps.
+func (a Action) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (a *Action) UnmarshalText(text []byte) error {
+	for i, name := range actionNames {
+		if name == string(text) {
+			*a = Action(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("sim: unknown action %q", string(text))
+}
+
+// Controls reports the actions a player is giving this tick. Front ends
+// sample their own devices once per tick and answer from that snapshot, so
+// the rules never see keys, only actions.
+type Controls interface {
+	IsPressed(a Action) bool     // Held this tick.
+	IsJustPressed(a Action) bool // Went down this tick.
+}
@@ -0,0 +1,120 @@
+// File example_test.go shows the simulation driven headless, with no
+// window, assets, or audio: the same loop cmd/headless runs, cut down to
+// what each piece does over a few simulated seconds.
+package sim_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bensabler/asteroids/internal/sim"
+)
+
+// A level's wave releases its budget of meteors on a spawn timer; they
+// drift at a speed in world units per second and clear as they are shot.
+func Example() {
+	waves := sim.NewWaveManager(sim.Level{MeteorBudget: 3})
+	spawn := sim.NewTimer(time.Second)
+	var meteors []sim.Vector
+
+	for tick := range 10 * sim.TicksPerSecond {
+		waves.Tick()
+		spawn.Update()
+		if spawn.IsReady() && waves.CanSpawn() {
+			spawn.Reset()
+			waves.TrackSpawn()
+			meteors = append(meteors, sim.Vector{})
+		}
+		for i := range meteors {
+			sim.Advance(&meteors[i], sim.Vector{X: 120})
+		}
+		// Shoot down any meteor that has come 300 world units.
+		for len(meteors) > 0 && meteors[0].X >= 300 {
+			meteors = meteors[1:]
+			waves.TrackRemoval()
+			fmt.Printf("%.2fs: shot one down, %d left\n", float64(tick+1)/sim.TicksPerSecond, waves.MeteorsLeft())
+		}
+		if waves.IsCleared() {
+			fmt.Printf("%.2fs: level cleared\n", float64(tick+1)/sim.TicksPerSecond)
+			break
+		}
+	}
+	// Output:
+	// 3.48s: shot one down, 2 left
+	// 4.48s: shot one down, 1 left
+	// 5.48s: shot one down, 0 left
+	// 5.48s: level cleared
+}
+
+// Advance moves a body one tick along a velocity in world units per
+// second, so a second of ticks covers the velocity itself.
+func ExampleAdvance() {
+	position := sim.Vector{X: 100, Y: 100}
+	velocity := sim.Vector{X: 240, Y: -90}
+	for range 2 * sim.TicksPerSecond {
+		sim.Advance(&position, velocity)
+	}
+	fmt.Printf("%.1f, %.1f\n", position.X, position.Y)
+	// Output: 580.0, -80.0
+}
+
+// A wave counts split fragments as alive but not against the budget, so
+// a level is cleared once the whole budget has spawned and everything in
+// play is gone.
+func ExampleWaveManager() {
+	waves := sim.NewWaveManager(sim.Level{MeteorBudget: 2})
+	waves.TrackSpawn()
+	waves.TrackSpawn()
+	fmt.Println("can spawn:", waves.CanSpawn(), "left:", waves.MeteorsLeft())
+
+	// One large meteor breaks into two fragments.
+	waves.TrackRemoval()
+	waves.TrackSplit()
+	waves.TrackSplit()
+	fmt.Println("left:", waves.MeteorsLeft())
+
+	for range waves.MeteorsLeft() {
+		waves.TrackRemoval()
+	}
+	fmt.Println("cleared:", waves.IsCleared())
+	// Output:
+	// can spawn: false left: 2
+	// left: 3
+	// cleared: true
+}
+
+// A timed wave ignores the budget and ends when its clock runs out.
+func ExampleWaveManager_timed() {
+	waves := sim.NewWaveManager(sim.Level{Kind: sim.WaveGoldRush, Duration: 2 * time.Second})
+	for range sim.TicksPerSecond / 2 {
+		waves.Tick()
+	}
+	fmt.Println("time left:", waves.TimeLeft(), "cleared:", waves.IsCleared())
+	for range 2 * sim.TicksPerSecond {
+		waves.Tick()
+	}
+	fmt.Println("time left:", waves.TimeLeft(), "cleared:", waves.IsCleared())
+	// Output:
+	// time left: 1.5s cleared: false
+	// time left: 0s cleared: true
+}
+
+// Collide pushes overlapping bodies apart and trades momentum by mass, so
+// a small rock ricochets off a large one that barely moves.
+func ExampleCollide() {
+	largeAt, largeVel := sim.Vector{X: 0}, sim.Vector{}
+	smallAt, smallVel := sim.Vector{X: 50}, sim.Vector{X: -100}
+	large := sim.Body{Position: &largeAt, Velocity: &largeVel, Radius: 40}
+	small := sim.Body{Position: &smallAt, Velocity: &smallVel, Radius: 20}
+
+	fmt.Println("overlapping:", sim.Overlapping(large, small))
+	sim.Collide(large, small, 1)
+	fmt.Printf("large: %.1f at %.1f\n", largeVel.X, largeAt.X)
+	fmt.Printf("small: %.1f at %.1f\n", smallVel.X, smallAt.X)
+	fmt.Println("overlapping:", sim.Overlapping(large, small))
+	// Output:
+	// overlapping: true
+	// large: -40.0 at -2.0
+	// small: 60.0 at 58.0
+	// overlapping: false
+}
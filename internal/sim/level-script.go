@@ -0,0 +1,183 @@
+// File level-script.go defines level scripts: special moments a level
+// definition schedules on top of the level's regular spawning, such as
+// "at 30s, three hunters from the left" or "with two meteors left, a
+// comet". A level entry lists them under "events":
+//
+//	{"number": 8, "events": [
+//	  {"at": 30, "spawn": "hunters", "count": 3, "from": "left"},
+//	  {"meteorsLeft": 2, "spawn": "comet"}
+//	]}
+//
+// Each event fires once per attempt at the level. The front end owns the
+// entities, so a LevelScript only says which events are due; the game
+// spawns them.
+package sim
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventTrigger is what makes a scripted event fire.
+type EventTrigger int
+
+const (
+	// TriggerTime fires once the level has been in play for At.
+	TriggerTime EventTrigger = iota
+
+	// TriggerMeteorsLeft fires once no more than MeteorsLeft meteors are
+	// left to clear, counting those still to spawn and those in play.
+	TriggerMeteorsLeft
+)
+
+// EventSpawn is what a scripted event brings into play.
+type EventSpawn int
+
+const (
+	SpawnMeteors  EventSpawn = iota // Large meteors, on top of the level's budget.
+	SpawnHunters                    // Aliens that home in on the ship.
+	SpawnSweepers                   // Aliens that sweep across from a side edge.
+	SpawnComet                      // A comet, replacing the level's random ones.
+)
+
+// SideAny lets the game pick an event's entry side.
+const SideAny Side = -1
+
+// LevelEvent is one scripted moment in a level.
+type LevelEvent struct {
+	Trigger     EventTrigger  // What makes it fire.
+	At          time.Duration // Time into the level, for TriggerTime.
+	MeteorsLeft int           // Meteors left to clear, for TriggerMeteorsLeft.
+	Spawn       EventSpawn    // What it brings in.
+	Count       int           // How many; comets come one at a time.
+	From        Side          // Edge it enters from; SideAny to let the game pick.
+}
+
+// levelEventFile is the JSON layout of a LevelEvent.
+type levelEventFile struct {
+	At          *float64 `json:"at"`          // Seconds into the level.
+	MeteorsLeft *int     `json:"meteorsLeft"` // Meteors left to clear.
+	Spawn       string   `json:"spawn"`       // "meteors", "hunters", "sweepers", or "comet".
+	Count       *int     `json:"count"`       // How many; 1 if left out.
+	From        string   `json:"from"`        // "left", "right", "top", "bottom", or empty for any.
+}
+
+// eventSpawns are the spawns an event may name.
+var eventSpawns = map[string]EventSpawn{
+	"meteors":  SpawnMeteors,
+	"hunters":  SpawnHunters,
+	"sweepers": SpawnSweepers,
+	"comet":    SpawnComet,
+}
+
+// eventSides are the entry sides an event may name.
+var eventSides = map[string]Side{
+	"":       SideAny,
+	"right":  SideRight,
+	"bottom": SideBottom,
+	"left":   SideLeft,
+	"top":    SideTop,
+}
+
+// event converts f, rejecting events that could never fire or spawn.
+func (f levelEventFile) event() (LevelEvent, error) {
+	e := LevelEvent{Count: 1}
+	switch {
+	case f.At != nil && f.MeteorsLeft != nil:
+		return e, fmt.Errorf("event sets both at and meteorsLeft")
+	case f.At != nil:
+		if *f.At < 0 {
+			return e, fmt.Errorf("event at a negative time")
+		}
+		e.Trigger, e.At = TriggerTime, seconds(*f.At)
+	case f.MeteorsLeft != nil:
+		// A level with no meteors left is over before the event could fire.
+		if *f.MeteorsLeft < 1 {
+			return e, fmt.Errorf("event meteorsLeft must be at least 1")
+		}
+		e.Trigger, e.MeteorsLeft = TriggerMeteorsLeft, *f.MeteorsLeft
+	default:
+		return e, fmt.Errorf("event needs at or meteorsLeft")
+	}
+
+	var ok bool
+	if e.Spawn, ok = eventSpawns[f.Spawn]; !ok {
+		return e, fmt.Errorf("event has unknown spawn %q", f.Spawn)
+	}
+	if e.From, ok = eventSides[f.From]; !ok {
+		return e, fmt.Errorf("event has unknown side %q", f.From)
+	}
+	if f.Count != nil {
+		if *f.Count < 1 {
+			return e, fmt.Errorf("event count must be at least 1")
+		}
+		e.Count = *f.Count
+	}
+	return e, nil
+}
+
+// Spawns reports whether any of the level's events brings in spawn.
+func (l Level) Spawns(spawn EventSpawn) bool {
+	for _, e := range l.Events {
+		if e.Spawn == spawn {
+			return true
+		}
+	}
+	return false
+}
+
+// LevelScript runs a level's events through one attempt at the level.
+type LevelScript struct {
+	events []LevelEvent // The level's events.
+	fired  []bool       // Events that have fired, by index.
+	ticks  int          // Ticks the level has been in play.
+}
+
+// NewLevelScript returns a script for an attempt at l with nothing fired.
+func NewLevelScript(l Level) *LevelScript {
+	return &LevelScript{events: l.Events, fired: make([]bool, len(l.Events))}
+}
+
+// Tick advances the level by one tick with meteorsLeft still to clear and
+// returns the events that came due, in script order.
+func (s *LevelScript) Tick(meteorsLeft int) []LevelEvent {
+	s.ticks++
+	var due []LevelEvent
+	for i, e := range s.events {
+		if s.fired[i] {
+			continue
+		}
+		switch e.Trigger {
+		case TriggerTime:
+			if s.ticks < Ticks(e.At) {
+				continue
+			}
+		case TriggerMeteorsLeft:
+			if meteorsLeft > e.MeteorsLeft {
+				continue
+			}
+		}
+		s.fired[i] = true
+		due = append(due, e)
+	}
+	return due
+}
+
+// ScriptProgress is how far a LevelScript has got, for suspending a run
+// and resuming it later.
+type ScriptProgress struct {
+	Ticks int    `json:"ticks"` // Ticks the level has been in play.
+	Fired []bool `json:"fired"` // Events that have fired, by index.
+}
+
+// Progress returns how far the script has got.
+func (s *LevelScript) Progress() ScriptProgress {
+	return ScriptProgress{Ticks: s.ticks, Fired: append([]bool(nil), s.fired...)}
+}
+
+// Resume restores progress saved by Progress onto a script for the same
+// level. Events the saved progress does not cover stay unfired.
+func (s *LevelScript) Resume(p ScriptProgress) {
+	s.ticks = p.Ticks
+	copy(s.fired, p.Fired)
+}
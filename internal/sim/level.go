@@ -0,0 +1,301 @@
+// File level.go defines per-level tuning data. Each Level describes how a
+// wave plays (kind, meteor budget, speed curve, and alien pressure) so
+// progression can be adjusted in one place instead of through scattered
+// constants.
+//
+// The progression itself is data: a LevelTable read from a JSON level
+// definition file. The built-in table is levels.json beside this file; a
+// front end may load another with ParseLevelTable. A table has a formula
+// that generates every level, events that recur every Nth level (boss
+// fights, and gold-rush bonus rounds after a level), and per-level entries
+// that override any of it, so levels past the formula can be authored
+// without code changes:
+//
+//	{
+//	  "version": 1,
+//	  "formula": {"meteorsPerLevel": 2, "meteorSpeedStart": 15, "bossEvery": 5, ...},
+//	  "bonusRound": {"seconds": 30, "meteorSpeed": 120},
+//	  "levels": [
+//	    {"number": 7, "meteors": 20, "alienSpawnRate": 2, "bonusRoundAfter": true},
+//	    {"number": 12, "kind": "boss"}
+//	  ]
+//	}
+//
+// A level entry may set kind ("standard" or "boss"), meteors,
+// meteorSpeedStart, meteorSpeedCap, meteorRampSeconds, alienSpawnRate, and
+// bonusRoundAfter; anything it leaves out comes from the formula. It may
+// also script events for the level, described in level-script.go.
+package sim
+
+import (
+	_ "embed" // levels.json
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MeteorBaseVelocity is the reference speed for meteors outside a level's
+// curve: fragments and decorative meteors. The built-in levels start their
+// curves here too.
+const MeteorBaseVelocity = 15.0
+
+// levelTableVersion is the level definition format; other versions are
+// refused.
+const levelTableVersion = 1
+
+// builtinLevels is the built-in level definition file.
+//
+//go:embed levels.json
+var builtinLevels []byte
+
+// Level holds the tuning for one numbered level.
+type Level struct {
+	Number              int           // 1-based level number.
+	Kind                WaveKind      // How the wave plays.
+	Duration            time.Duration // Length of a timed wave; 0 if it ends when cleared.
+	MeteorBudget        int           // Large meteors spawned over the level.
+	MeteorVelocityStart float64       // Base meteor velocity when the level starts.
+	MeteorVelocityCap   float64       // Base meteor velocity once the ramp completes.
+	MeteorRampDuration  time.Duration // Time taken to ramp from start to cap.
+	AlienSpawnRate      float64       // Multiplier on the chance aliens appear.
+	Events              []LevelEvent  // Scripted moments, in script order.
+}
+
+// LevelTable is a level progression read from a level definition file.
+type LevelTable struct {
+	formula levelFormula         // Generates every level.
+	bonus   bonusRoundFile       // The recurring gold-rush round.
+	entries map[int]levelEntry   // Per-level overrides by number.
+	events  map[int][]LevelEvent // Scripted events by level number.
+}
+
+// levelTableFile is the JSON layout of a level definition file.
+type levelTableFile struct {
+	Version    int            `json:"version"`    // levelTableVersion.
+	Formula    levelFormula   `json:"formula"`    // Generates every level.
+	BonusRound bonusRoundFile `json:"bonusRound"` // The recurring gold-rush round.
+	Levels     []levelEntry   `json:"levels"`     // Per-level overrides.
+}
+
+// levelFormula generates level n of a table.
+type levelFormula struct {
+	MeteorsPerLevel        int     `json:"meteorsPerLevel"`        // Large meteors added per level.
+	MeteorSpeedStart       float64 `json:"meteorSpeedStart"`       // Starting speed on every level.
+	MeteorSpeedCap         float64 `json:"meteorSpeedCap"`         // Cap for level 1 meteors.
+	MeteorSpeedCapPerLevel float64 `json:"meteorSpeedCapPerLevel"` // Cap increase per level.
+	MeteorSpeedCapLimit    float64 `json:"meteorSpeedCapLimit"`    // Hard ceiling for any level.
+	MeteorRampSeconds      float64 `json:"meteorRampSeconds"`      // Time to reach the cap.
+	AlienSpawnRate         float64 `json:"alienSpawnRate"`         // Multiplier on the alien spawn chance.
+	BossEvery              int     `json:"bossEvery"`              // Every Nth level is a boss fight; 0 for none.
+	BonusRoundEvery        int     `json:"bonusRoundEvery"`        // A bonus round follows every Nth level; 0 for none.
+}
+
+// bonusRoundFile describes the gold-rush bonus round.
+type bonusRoundFile struct {
+	Seconds     float64 `json:"seconds"`     // Length of the round.
+	MeteorSpeed float64 `json:"meteorSpeed"` // Speed of gold meteors.
+}
+
+// levelEntry overrides the formula for one level. Nil fields keep the
+// formula's value.
+type levelEntry struct {
+	Number            int              `json:"number"`            // Level overridden.
+	Kind              *string          `json:"kind"`              // "standard" or "boss".
+	Meteors           *int             `json:"meteors"`           // Large meteors spawned.
+	MeteorSpeedStart  *float64         `json:"meteorSpeedStart"`  // Starting speed.
+	MeteorSpeedCap    *float64         `json:"meteorSpeedCap"`    // Speed once ramped.
+	MeteorRampSeconds *float64         `json:"meteorRampSeconds"` // Time to reach the cap.
+	AlienSpawnRate    *float64         `json:"alienSpawnRate"`    // Multiplier on the alien spawn chance.
+	BonusRoundAfter   *bool            `json:"bonusRoundAfter"`   // A bonus round follows the level.
+	Events            []levelEventFile `json:"events"`            // Scripted moments.
+}
+
+// levelKinds are the wave kinds a level entry may name.
+var levelKinds = map[string]WaveKind{
+	"standard": WaveStandard,
+	"boss":     WaveBoss,
+}
+
+// ParseLevelTable reads a level definition file, rejecting other versions
+// and values no level could play with.
+func ParseLevelTable(data []byte) (*LevelTable, error) {
+	var f levelTableFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	if f.Version != levelTableVersion {
+		return nil, fmt.Errorf("sim: unsupported level definition version %d", f.Version)
+	}
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+
+	t := &LevelTable{formula: f.Formula, bonus: f.BonusRound, entries: map[int]levelEntry{}, events: map[int][]LevelEvent{}}
+	for _, e := range f.Levels {
+		if _, ok := t.entries[e.Number]; ok {
+			return nil, fmt.Errorf("sim: level %d is defined twice", e.Number)
+		}
+		t.entries[e.Number] = e
+		for _, ef := range e.Events {
+			event, err := ef.event()
+			if err != nil {
+				return nil, fmt.Errorf("sim: level %d: %w", e.Number, err)
+			}
+			t.events[e.Number] = append(t.events[e.Number], event)
+		}
+	}
+	return t, nil
+}
+
+// validate reports the first value in f that no level could play with.
+func (f levelTableFile) validate() error {
+	fm := f.Formula
+	switch {
+	case fm.MeteorsPerLevel < 0, fm.MeteorSpeedStart < 0, fm.MeteorSpeedCap < 0,
+		fm.MeteorSpeedCapPerLevel < 0, fm.MeteorSpeedCapLimit < 0, fm.MeteorRampSeconds < 0,
+		fm.AlienSpawnRate < 0, fm.BossEvery < 0, fm.BonusRoundEvery < 0:
+		return fmt.Errorf("sim: level formula has a negative value")
+	case f.BonusRound.Seconds <= 0 && fm.BonusRoundEvery > 0:
+		return fmt.Errorf("sim: bonus rounds need a positive length")
+	case f.BonusRound.MeteorSpeed < 0:
+		return fmt.Errorf("sim: bonus round has a negative meteor speed")
+	}
+	for _, e := range f.Levels {
+		if e.Number < 1 {
+			return fmt.Errorf("sim: level number %d is not positive", e.Number)
+		}
+		if e.Kind != nil {
+			if _, ok := levelKinds[*e.Kind]; !ok {
+				return fmt.Errorf("sim: level %d has unknown kind %q", e.Number, *e.Kind)
+			}
+		}
+		for _, v := range []*float64{e.MeteorSpeedStart, e.MeteorSpeedCap, e.MeteorRampSeconds, e.AlienSpawnRate} {
+			if v != nil && *v < 0 {
+				return fmt.Errorf("sim: level %d has a negative value", e.Number)
+			}
+		}
+		if e.Meteors != nil && *e.Meteors < 0 {
+			return fmt.Errorf("sim: level %d has a negative meteor count", e.Number)
+		}
+	}
+	return nil
+}
+
+// DefaultLevels returns the built-in level table from levels.json.
+//
+// Panics if the file does not parse; it ships with the package, so that is
+// a build mistake.
+var DefaultLevels = sync.OnceValue(func() *LevelTable {
+	t, err := ParseLevelTable(builtinLevels)
+	if err != nil {
+		panic(err)
+	}
+	return t
+})
+
+// Level returns the definition for level n (1-based).
+//
+// Later levels ramp toward a higher cap, but every level starts from the
+// same gentle velocity so the opening seconds of a wave stay readable.
+func (t *LevelTable) Level(n int) Level {
+	fm := t.formula
+	velocityCap := min(fm.MeteorSpeedCap+fm.MeteorSpeedCapPerLevel*float64(n-1), fm.MeteorSpeedCapLimit)
+
+	l := Level{
+		Number:              n,
+		Kind:                WaveStandard,
+		MeteorBudget:        fm.MeteorsPerLevel * n,
+		MeteorVelocityStart: fm.MeteorSpeedStart,
+		MeteorVelocityCap:   velocityCap,
+		MeteorRampDuration:  seconds(fm.MeteorRampSeconds),
+		AlienSpawnRate:      fm.AlienSpawnRate,
+		Events:              t.events[n],
+	}
+	if fm.BossEvery > 0 && n%fm.BossEvery == 0 {
+		l.Kind = WaveBoss
+	}
+
+	e, ok := t.entries[n]
+	if ok {
+		if e.Kind != nil {
+			l.Kind = levelKinds[*e.Kind]
+		}
+		if e.Meteors != nil {
+			l.MeteorBudget = *e.Meteors
+		}
+		if e.MeteorSpeedStart != nil {
+			l.MeteorVelocityStart = *e.MeteorSpeedStart
+		}
+		if e.MeteorSpeedCap != nil {
+			l.MeteorVelocityCap = *e.MeteorSpeedCap
+		}
+		if e.MeteorRampSeconds != nil {
+			l.MeteorRampDuration = seconds(*e.MeteorRampSeconds)
+		}
+		if e.AlienSpawnRate != nil {
+			l.AlienSpawnRate = *e.AlienSpawnRate
+		}
+	}
+
+	// Boss levels spawn no budget of their own unless an entry gives them
+	// one; otherwise the only meteors are the ones the boss sheds as its
+	// weak points break.
+	if l.Kind == WaveBoss && (!ok || e.Meteors == nil) {
+		l.MeteorBudget = 0
+	}
+	return l
+}
+
+// HasBonusRoundAfter reports whether a gold-rush bonus round follows level n.
+func (t *LevelTable) HasBonusRoundAfter(n int) bool {
+	if e, ok := t.entries[n]; ok && e.BonusRoundAfter != nil {
+		return *e.BonusRoundAfter
+	}
+	return t.formula.BonusRoundEvery > 0 && n%t.formula.BonusRoundEvery == 0
+}
+
+// BonusRoundFor returns the gold-rush round played after level n. It keeps
+// the level's number; the next numbered level starts once the clock runs out.
+func (t *LevelTable) BonusRoundFor(n int) Level {
+	return Level{
+		Number:              n,
+		Kind:                WaveGoldRush,
+		Duration:            seconds(t.bonus.Seconds),
+		MeteorVelocityStart: t.bonus.MeteorSpeed,
+		MeteorVelocityCap:   t.bonus.MeteorSpeed,
+	}
+}
+
+// seconds converts a duration in seconds from a level file.
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// LevelFor returns level n of the built-in table.
+func LevelFor(n int) Level {
+	return DefaultLevels().Level(n)
+}
+
+// HasBonusRoundAfter reports whether the built-in table plays a bonus round
+// after level n.
+func HasBonusRoundAfter(n int) bool {
+	return DefaultLevels().HasBonusRoundAfter(n)
+}
+
+// BonusRoundFor returns the built-in table's bonus round after level n.
+func BonusRoundFor(n int) Level {
+	return DefaultLevels().BonusRoundFor(n)
+}
+
+// MeteorVelocityAt returns the base meteor velocity after ticks have elapsed
+// in the level: a linear ramp from start to cap that then holds at the cap.
+func (l Level) MeteorVelocityAt(ticks int) float64 {
+	rampTicks := Ticks(l.MeteorRampDuration)
+	if rampTicks <= 0 || ticks >= rampTicks {
+		return l.MeteorVelocityCap
+	}
+
+	t := float64(ticks) / float64(rampTicks)
+	return l.MeteorVelocityStart + (l.MeteorVelocityCap-l.MeteorVelocityStart)*t
+}
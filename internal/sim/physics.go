@@ -0,0 +1,53 @@
+// File physics.go implements the collision response between round bodies
+// that the "realistic asteroids" rule uses: overlapping bodies are pushed
+// apart and exchange momentum along the line between them.
+package sim
+
+import "math"
+
+// Body is a round body as the collision response sees it.
+type Body struct {
+	Position *Vector // Moved apart from the other body on contact.
+	Velocity *Vector // Exchanged with the other body along the contact normal.
+	Radius   float64 // Size; mass is proportional to its square.
+}
+
+// Collide separates two overlapping bodies along the line between their
+// positions and, if they are still closing, exchanges momentum along it,
+// keeping restitution of the closing speed (1 is perfectly elastic).
+//
+// Each body's mass is proportional to its area, so small bodies ricochet
+// off large ones while barely nudging them.
+func Collide(a, b Body, restitution float64) {
+	massA, massB := a.Radius*a.Radius, b.Radius*b.Radius
+
+	delta := Vector{X: b.Position.X - a.Position.X, Y: b.Position.Y - a.Position.Y}
+	dist := math.Hypot(delta.X, delta.Y)
+	normal := delta.Normalize()
+	if normal == (Vector{}) {
+		normal = Vector{X: 1} // Exactly coincident: push apart sideways.
+	}
+
+	// Push apart so they no longer overlap, the lighter one moving further.
+	overlap := a.Radius + b.Radius - dist
+	shareA := massB / (massA + massB)
+	a.Position.X -= normal.X * overlap * shareA
+	a.Position.Y -= normal.Y * overlap * shareA
+	b.Position.X += normal.X * overlap * (1 - shareA)
+	b.Position.Y += normal.Y * overlap * (1 - shareA)
+
+	// Exchange momentum along the normal if they are still closing.
+	closing := (a.Velocity.X-b.Velocity.X)*normal.X + (a.Velocity.Y-b.Velocity.Y)*normal.Y
+	if closing > 0 {
+		impulse := (1 + restitution) * closing / (1/massA + 1/massB)
+		a.Velocity.X -= impulse / massA * normal.X
+		a.Velocity.Y -= impulse / massA * normal.Y
+		b.Velocity.X += impulse / massB * normal.X
+		b.Velocity.Y += impulse / massB * normal.Y
+	}
+}
+
+// Overlapping reports whether two bodies touch.
+func Overlapping(a, b Body) bool {
+	return math.Hypot(b.Position.X-a.Position.X, b.Position.Y-a.Position.Y) < a.Radius+b.Radius
+}
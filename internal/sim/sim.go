@@ -0,0 +1,40 @@
+// Package sim is the part of Asteroids that needs no window, GPU, or
+// sound card: vectors and units, tick timers, the level table, the wave
+// manager, the collision response between round bodies, and the binary
+// snapshot and delta encoding of entities in play. It imports
+// nothing from Ebiten, so the same rules can drive a headless run, a
+// terminal renderer, or a server checking a submitted score.
+//
+// The asteroids package is its Ebiten adapter: it owns sprites, colliders,
+// audio, and input, and steps these rules once per tick. cmd/headless
+// drives them with no adapter at all, and cmd/terminal plays them in a text
+// terminal through the same Controls the game's Input satisfies.
+//
+// A simulation advances in ticks of fixed length:
+//
+//	spawn := sim.NewTimer(500 * time.Millisecond)
+//	for range 10 * sim.TicksPerSecond { // Ten simulated seconds.
+//		sim.Advance(&position, velocity)
+//		spawn.Update()
+//		if spawn.IsReady() {
+//			spawn.Reset()
+//			// ...
+//		}
+//	}
+package sim
+
+import "time"
+
+// TicksPerSecond is the fixed rate the simulation steps at. The game runs
+// Ebiten at this rate, so a tick of play is a tick of simulation.
+const TicksPerSecond = 60
+
+// Ticks returns the whole number of ticks in d.
+func Ticks(d time.Duration) int {
+	return int(d.Milliseconds()) * TicksPerSecond / 1000
+}
+
+// Duration returns the simulated time that ticks cover.
+func Duration(ticks int) time.Duration {
+	return time.Duration(ticks) * time.Second / TicksPerSecond
+}
@@ -0,0 +1,517 @@
+// File snapshot.go defines the binary encoding of simulation state: a
+// Snapshot lists every entity in play at one tick, and a Delta carries one
+// snapshot forward to a later one as entities created, updated, and
+// destroyed. The same two messages serve anything that moves state between
+// processes or through time: a networked peer, a replay's checkpoints, or a
+// save-state.
+//
+// Floats are stored as float32, so a snapshot is quantized to that
+// precision; Diff compares at the stored precision so a round trip never
+// reports phantom changes. Integers are varints, and entity IDs are stored
+// as gaps from the previous ID, so runs of nearby IDs take a byte each. The
+// layouts are:
+//
+//	snapshot: magic "ASNP", version byte
+//	    tick (uvarint), entity count (uvarint)
+//	    per entity, by ascending ID: ID gap (uvarint), entity fields
+//	delta: magic "ASDL", version byte
+//	    base tick (uvarint), tick (uvarint)
+//	    created count (uvarint), then per entity: ID gap (uvarint), entity fields
+//	    updated count (uvarint), then per entity: ID gap (uvarint),
+//	        field mask (byte), the masked entity fields
+//	    destroyed count (uvarint), then per entity: ID gap (uvarint)
+//	entity fields, in order: kind (byte), state (uvarint),
+//	    position X, Y, velocity X, Y, rotation (float32, little-endian)
+package sim
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// SnapshotVersion is the encoding version; decoders refuse others.
+const SnapshotVersion = 1
+
+// Message magics.
+const (
+	snapshotMagic = "ASNP"
+	deltaMagic    = "ASDL"
+)
+
+// EntityKind says what an entity is. The codec stores it without
+// interpreting it; each front end numbers its own kinds.
+type EntityKind uint8
+
+// Entity is one entity's state as snapshots carry it.
+type Entity struct {
+	ID       uint32     // Unique among the entities of a snapshot.
+	Kind     EntityKind // What the entity is.
+	State    uint32     // Kind-specific small state: a sprite index, armor, health.
+	Position Vector     // World units.
+	Velocity Vector     // World units per second.
+	Rotation float64    // Radians.
+}
+
+// Snapshot is every entity in play at one tick.
+type Snapshot struct {
+	Tick     uint32   // Tick the snapshot was taken at.
+	Entities []Entity // By ascending ID.
+}
+
+// Fields of an Entity a Delta update can carry.
+const (
+	FieldKind     = 1 << iota // Kind changed.
+	FieldState                // State changed.
+	FieldPosition             // Position changed.
+	FieldVelocity             // Velocity changed.
+	FieldRotation             // Rotation changed.
+	fieldAll      = FieldKind | FieldState | FieldPosition | FieldVelocity | FieldRotation
+)
+
+// EntityUpdate is a change to an entity that exists in the base snapshot.
+type EntityUpdate struct {
+	Fields uint8  // Mask of the fields that changed.
+	Entity Entity // The ID and the new values of the masked fields.
+}
+
+// Delta turns the snapshot at BaseTick into the one at Tick.
+type Delta struct {
+	BaseTick  uint32         // Tick of the snapshot the delta applies to.
+	Tick      uint32         // Tick of the snapshot it produces.
+	Created   []Entity       // Entities new since the base, by ascending ID.
+	Updated   []EntityUpdate // Entities changed since the base, by ascending ID.
+	Destroyed []uint32       // IDs gone since the base, ascending.
+}
+
+// NewSnapshot returns a snapshot of entities at tick, sorted by ID. It
+// reports an error if two entities share an ID.
+func NewSnapshot(tick uint32, entities []Entity) (Snapshot, error) {
+	sorted := slices.Clone(entities)
+	slices.SortFunc(sorted, func(a, b Entity) int { return cmp.Compare(a.ID, b.ID) })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].ID == sorted[i-1].ID {
+			return Snapshot{}, fmt.Errorf("sim: duplicate entity ID %d", sorted[i].ID)
+		}
+	}
+	return Snapshot{Tick: tick, Entities: sorted}, nil
+}
+
+// Diff returns the delta that turns base into next. Both must be sorted
+// by ID, as NewSnapshot leaves them.
+func Diff(base, next Snapshot) Delta {
+	d := Delta{BaseTick: base.Tick, Tick: next.Tick}
+	i, j := 0, 0
+	for i < len(base.Entities) || j < len(next.Entities) {
+		switch {
+		case j == len(next.Entities) || i < len(base.Entities) && base.Entities[i].ID < next.Entities[j].ID:
+			d.Destroyed = append(d.Destroyed, base.Entities[i].ID)
+			i++
+		case i == len(base.Entities) || next.Entities[j].ID < base.Entities[i].ID:
+			d.Created = append(d.Created, next.Entities[j])
+			j++
+		default:
+			if fields := changedFields(base.Entities[i], next.Entities[j]); fields != 0 {
+				d.Updated = append(d.Updated, EntityUpdate{Fields: fields, Entity: next.Entities[j]})
+			}
+			i++
+			j++
+		}
+	}
+	return d
+}
+
+// changedFields returns the mask of fields that differ between a and b at
+// the stored precision.
+func changedFields(a, b Entity) uint8 {
+	var fields uint8
+	if a.Kind != b.Kind {
+		fields |= FieldKind
+	}
+	if a.State != b.State {
+		fields |= FieldState
+	}
+	if !sameFloat(a.Position.X, b.Position.X) || !sameFloat(a.Position.Y, b.Position.Y) {
+		fields |= FieldPosition
+	}
+	if !sameFloat(a.Velocity.X, b.Velocity.X) || !sameFloat(a.Velocity.Y, b.Velocity.Y) {
+		fields |= FieldVelocity
+	}
+	if !sameFloat(a.Rotation, b.Rotation) {
+		fields |= FieldRotation
+	}
+	return fields
+}
+
+// sameFloat reports whether a and b are stored as the same float32.
+func sameFloat(a, b float64) bool {
+	return math.Float32bits(float32(a)) == math.Float32bits(float32(b))
+}
+
+// IsEmpty reports whether the delta changes nothing but the tick.
+func (d Delta) IsEmpty() bool {
+	return len(d.Created) == 0 && len(d.Updated) == 0 && len(d.Destroyed) == 0
+}
+
+// Apply returns the snapshot d produces from base. It reports an error if
+// base is not the snapshot d was made against: a different tick, a created
+// ID already present, or an updated or destroyed ID missing.
+func (d Delta) Apply(base Snapshot) (Snapshot, error) {
+	if base.Tick != d.BaseTick {
+		return Snapshot{}, fmt.Errorf("sim: delta for tick %d applied to tick %d", d.BaseTick, base.Tick)
+	}
+	byID := make(map[uint32]Entity, len(base.Entities)+len(d.Created))
+	for _, e := range base.Entities {
+		byID[e.ID] = e
+	}
+	for _, id := range d.Destroyed {
+		if _, ok := byID[id]; !ok {
+			return Snapshot{}, fmt.Errorf("sim: delta destroys missing entity %d", id)
+		}
+		delete(byID, id)
+	}
+	for _, u := range d.Updated {
+		e, ok := byID[u.Entity.ID]
+		if !ok {
+			return Snapshot{}, fmt.Errorf("sim: delta updates missing entity %d", u.Entity.ID)
+		}
+		byID[e.ID] = applyFields(e, u)
+	}
+	for _, e := range d.Created {
+		if _, ok := byID[e.ID]; ok {
+			return Snapshot{}, fmt.Errorf("sim: delta creates existing entity %d", e.ID)
+		}
+		byID[e.ID] = e
+	}
+
+	next := Snapshot{Tick: d.Tick, Entities: make([]Entity, 0, len(byID))}
+	for _, e := range byID {
+		next.Entities = append(next.Entities, e)
+	}
+	slices.SortFunc(next.Entities, func(a, b Entity) int { return cmp.Compare(a.ID, b.ID) })
+	return next, nil
+}
+
+// applyFields returns e with the fields u masks taken from u.
+func applyFields(e Entity, u EntityUpdate) Entity {
+	if u.Fields&FieldKind != 0 {
+		e.Kind = u.Entity.Kind
+	}
+	if u.Fields&FieldState != 0 {
+		e.State = u.Entity.State
+	}
+	if u.Fields&FieldPosition != 0 {
+		e.Position = u.Entity.Position
+	}
+	if u.Fields&FieldVelocity != 0 {
+		e.Velocity = u.Entity.Velocity
+	}
+	if u.Fields&FieldRotation != 0 {
+		e.Rotation = u.Entity.Rotation
+	}
+	return e
+}
+
+// MarshalBinary encodes the snapshot in the layout above.
+func (s Snapshot) MarshalBinary() ([]byte, error) {
+	buf := append([]byte(snapshotMagic), SnapshotVersion)
+	buf = binary.AppendUvarint(buf, uint64(s.Tick))
+	buf = binary.AppendUvarint(buf, uint64(len(s.Entities)))
+	var ids idWriter
+	for _, e := range s.Entities {
+		var err error
+		if buf, err = ids.append(buf, e.ID); err != nil {
+			return nil, err
+		}
+		buf = appendFields(buf, e, fieldAll)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary decodes a snapshot, rejecting other formats, versions,
+// and malformed input.
+func (s *Snapshot) UnmarshalBinary(data []byte) error {
+	r, err := newMessageReader(data, snapshotMagic)
+	if err != nil {
+		return err
+	}
+	decoded := Snapshot{Tick: r.uint32()}
+	n := r.count()
+	var ids idReader
+	for i := 0; i < n && r.err == nil; i++ {
+		e := Entity{ID: ids.next(r)}
+		decoded.Entities = append(decoded.Entities, r.fields(e, fieldAll))
+	}
+	if err := r.finish(); err != nil {
+		return err
+	}
+	*s = decoded
+	return nil
+}
+
+// MarshalBinary encodes the delta in the layout above.
+func (d Delta) MarshalBinary() ([]byte, error) {
+	buf := append([]byte(deltaMagic), SnapshotVersion)
+	buf = binary.AppendUvarint(buf, uint64(d.BaseTick))
+	buf = binary.AppendUvarint(buf, uint64(d.Tick))
+
+	var err error
+	buf = binary.AppendUvarint(buf, uint64(len(d.Created)))
+	var created idWriter
+	for _, e := range d.Created {
+		if buf, err = created.append(buf, e.ID); err != nil {
+			return nil, err
+		}
+		buf = appendFields(buf, e, fieldAll)
+	}
+
+	buf = binary.AppendUvarint(buf, uint64(len(d.Updated)))
+	var updated idWriter
+	for _, u := range d.Updated {
+		if buf, err = updated.append(buf, u.Entity.ID); err != nil {
+			return nil, err
+		}
+		buf = append(buf, u.Fields&fieldAll)
+		buf = appendFields(buf, u.Entity, u.Fields)
+	}
+
+	buf = binary.AppendUvarint(buf, uint64(len(d.Destroyed)))
+	var destroyed idWriter
+	for _, id := range d.Destroyed {
+		if buf, err = destroyed.append(buf, id); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary decodes a delta, rejecting other formats, versions, and
+// malformed input.
+func (d *Delta) UnmarshalBinary(data []byte) error {
+	r, err := newMessageReader(data, deltaMagic)
+	if err != nil {
+		return err
+	}
+	decoded := Delta{BaseTick: r.uint32(), Tick: r.uint32()}
+
+	n := r.count()
+	var created idReader
+	for i := 0; i < n && r.err == nil; i++ {
+		e := Entity{ID: created.next(r)}
+		decoded.Created = append(decoded.Created, r.fields(e, fieldAll))
+	}
+
+	n = r.count()
+	var updated idReader
+	for i := 0; i < n && r.err == nil; i++ {
+		e := Entity{ID: updated.next(r)}
+		fields := r.readByte()
+		if fields&^fieldAll != 0 || fields == 0 {
+			r.fail("bad field mask")
+		}
+		decoded.Updated = append(decoded.Updated, EntityUpdate{Fields: fields, Entity: r.fields(e, fields)})
+	}
+
+	n = r.count()
+	var destroyed idReader
+	for i := 0; i < n && r.err == nil; i++ {
+		decoded.Destroyed = append(decoded.Destroyed, destroyed.next(r))
+	}
+	if err := r.finish(); err != nil {
+		return err
+	}
+	*d = decoded
+	return nil
+}
+
+// appendFields appends the masked fields of e.
+func appendFields(buf []byte, e Entity, fields uint8) []byte {
+	if fields&FieldKind != 0 {
+		buf = append(buf, byte(e.Kind))
+	}
+	if fields&FieldState != 0 {
+		buf = binary.AppendUvarint(buf, uint64(e.State))
+	}
+	if fields&FieldPosition != 0 {
+		buf = appendFloat(buf, e.Position.X)
+		buf = appendFloat(buf, e.Position.Y)
+	}
+	if fields&FieldVelocity != 0 {
+		buf = appendFloat(buf, e.Velocity.X)
+		buf = appendFloat(buf, e.Velocity.Y)
+	}
+	if fields&FieldRotation != 0 {
+		buf = appendFloat(buf, e.Rotation)
+	}
+	return buf
+}
+
+// appendFloat appends v as a little-endian float32.
+func appendFloat(buf []byte, v float64) []byte {
+	return binary.LittleEndian.AppendUint32(buf, math.Float32bits(float32(v)))
+}
+
+// idWriter encodes a list of ascending IDs as gaps.
+type idWriter struct {
+	last    uint32 // Previous ID written.
+	started bool   // An ID has been written.
+}
+
+// append appends id as the gap from the previous one. IDs must ascend.
+func (w *idWriter) append(buf []byte, id uint32) ([]byte, error) {
+	if w.started && id <= w.last {
+		return nil, fmt.Errorf("sim: entity IDs out of order at %d", id)
+	}
+	gap := id
+	if w.started {
+		gap = id - w.last - 1
+	}
+	w.last, w.started = id, true
+	return binary.AppendUvarint(buf, uint64(gap)), nil
+}
+
+// idReader decodes a list of IDs written by idWriter.
+type idReader struct {
+	last    uint32 // Previous ID read.
+	started bool   // An ID has been read.
+}
+
+// next reads the next ID.
+func (ir *idReader) next(r *messageReader) uint32 {
+	gap := r.uvarint()
+	id := gap
+	if ir.started {
+		id = uint64(ir.last) + 1 + gap
+	}
+	if id > math.MaxUint32 {
+		r.fail("entity ID out of range")
+		return 0
+	}
+	ir.last, ir.started = uint32(id), true
+	return uint32(id)
+}
+
+// messageReader decodes a snapshot or delta, remembering the first error.
+type messageReader struct {
+	data []byte // Bytes not yet read.
+	err  error  // First decoding error.
+}
+
+// newMessageReader checks the header of data against magic and the
+// supported version and returns a reader positioned after it.
+func newMessageReader(data []byte, magic string) (*messageReader, error) {
+	if len(data) < len(magic)+1 || string(data[:len(magic)]) != magic {
+		return nil, errors.New("sim: not a " + messageName(magic))
+	}
+	if v := data[len(magic)]; v != SnapshotVersion {
+		return nil, fmt.Errorf("sim: unsupported %s version %d", messageName(magic), v)
+	}
+	return &messageReader{data: data[len(magic)+1:]}, nil
+}
+
+// messageName names the message a magic introduces, for errors.
+func messageName(magic string) string {
+	if magic == deltaMagic {
+		return "snapshot delta"
+	}
+	return "snapshot"
+}
+
+// fail records a decoding error unless one is already recorded.
+func (r *messageReader) fail(what string) {
+	if r.err == nil {
+		r.err = errors.New("sim: corrupt snapshot: " + what)
+	}
+	r.data = nil
+}
+
+// readByte reads one byte.
+func (r *messageReader) readByte() byte {
+	if len(r.data) < 1 {
+		r.fail("truncated")
+		return 0
+	}
+	b := r.data[0]
+	r.data = r.data[1:]
+	return b
+}
+
+// uvarint reads an unsigned varint, refusing padded encodings so every
+// message decodes from exactly one byte sequence.
+func (r *messageReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 || n > 1 && r.data[n-1] == 0 {
+		r.fail("bad varint")
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+// uint32 reads an unsigned varint that must fit in 32 bits.
+func (r *messageReader) uint32() uint32 {
+	v := r.uvarint()
+	if v > math.MaxUint32 {
+		r.fail("value out of range")
+		return 0
+	}
+	return uint32(v)
+}
+
+// count reads a list length, refusing one longer than the remaining input
+// could hold (every entry takes at least a byte), so a corrupt count cannot
+// make the decoder allocate more than the input is worth.
+func (r *messageReader) count() int {
+	n := r.uvarint()
+	if n > uint64(len(r.data)) {
+		r.fail("list longer than message")
+		return 0
+	}
+	return int(n)
+}
+
+// float reads a little-endian float32, refusing NaN and infinities.
+func (r *messageReader) float() float64 {
+	if len(r.data) < 4 {
+		r.fail("truncated")
+		return 0
+	}
+	v := math.Float32frombits(binary.LittleEndian.Uint32(r.data))
+	r.data = r.data[4:]
+	if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+		r.fail("non-finite value")
+		return 0
+	}
+	return float64(v)
+}
+
+// fields reads the masked fields into e.
+func (r *messageReader) fields(e Entity, fields uint8) Entity {
+	if fields&FieldKind != 0 {
+		e.Kind = EntityKind(r.readByte())
+	}
+	if fields&FieldState != 0 {
+		e.State = r.uint32()
+	}
+	if fields&FieldPosition != 0 {
+		e.Position = Vector{X: r.float(), Y: r.float()}
+	}
+	if fields&FieldVelocity != 0 {
+		e.Velocity = Vector{X: r.float(), Y: r.float()}
+	}
+	if fields&FieldRotation != 0 {
+		e.Rotation = r.float()
+	}
+	return e
+}
+
+// finish returns the first decoding error, or an error if input is left.
+func (r *messageReader) finish() error {
+	if r.err == nil && len(r.data) > 0 {
+		r.fail("trailing bytes")
+	}
+	return r.err
+}
@@ -0,0 +1,140 @@
+// File snapshot_test.go checks the snapshot and delta codecs: a Diff
+// applied to its base yields the later snapshot, and the decoders neither
+// panic on arbitrary input nor accept any that does not re-encode to the
+// same bytes.
+package sim
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// testSnapshots returns a base snapshot and a later one that creates,
+// updates, and destroys entities. Values are exact in float32 so a round
+// trip compares equal.
+func testSnapshots(t testing.TB) (base, next Snapshot) {
+	t.Helper()
+	base, err := NewSnapshot(10, []Entity{
+		{ID: 1, Kind: 0, Position: Vector{X: 100, Y: 200}, Velocity: Vector{X: 1.5}, Rotation: 0.25},
+		{ID: 2, Kind: 1, State: 3, Position: Vector{X: -40, Y: 8}},
+		{ID: 7, Kind: 2, State: 1, Velocity: Vector{Y: -90}},
+		{ID: 1 << 24, Kind: 4, State: 2, Position: Vector{X: 640, Y: 360}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	next, err = NewSnapshot(40, []Entity{
+		{ID: 1, Kind: 0, Position: Vector{X: 102.5, Y: 200}, Velocity: Vector{X: 1.5}, Rotation: 0.5},
+		{ID: 2, Kind: 1, State: 3, Position: Vector{X: -40, Y: 8}},
+		{ID: 9, Kind: 6, Position: Vector{X: 3, Y: 4}, Velocity: Vector{X: 600}},
+		{ID: 1 << 24, Kind: 4, State: 1, Position: Vector{X: 640, Y: 360}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base, next
+}
+
+func TestDiffApply(t *testing.T) {
+	base, next := testSnapshots(t)
+
+	d := Diff(base, next)
+	if got := len(d.Created); got != 1 {
+		t.Errorf("created %d entities, want 1", got)
+	}
+	if got := len(d.Updated); got != 2 {
+		t.Errorf("updated %d entities, want 2", got)
+	}
+	if got := len(d.Destroyed); got != 1 {
+		t.Errorf("destroyed %d entities, want 1", got)
+	}
+
+	// Through the codec and back onto the base.
+	data, err := d.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Delta
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	got, err := decoded.Apply(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, next) {
+		t.Errorf("Apply(base) = %+v, want %+v", got, next)
+	}
+	if d := Diff(got, next); !d.IsEmpty() {
+		t.Errorf("Diff of the result against next = %+v, want empty", d)
+	}
+
+	// A delta made against one tick refuses another.
+	if _, err := decoded.Apply(next); err == nil {
+		t.Error("Apply to the wrong base succeeded")
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	_, next := testSnapshots(t)
+	data, err := next.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Snapshot
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, next) {
+		t.Errorf("round trip = %+v, want %+v", got, next)
+	}
+}
+
+func FuzzSnapshotDecode(f *testing.F) {
+	base, next := testSnapshots(f)
+	for _, s := range []Snapshot{{}, base, next} {
+		data, err := s.MarshalBinary()
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var s Snapshot
+		if s.UnmarshalBinary(data) != nil {
+			return
+		}
+		again, err := s.MarshalBinary()
+		if err != nil {
+			t.Fatalf("decoded snapshot does not encode: %v", err)
+		}
+		if !bytes.Equal(again, data) {
+			t.Fatalf("re-encoded as %x, decoded from %x", again, data)
+		}
+	})
+}
+
+func FuzzDeltaDecode(f *testing.F) {
+	base, next := testSnapshots(f)
+	for _, d := range []Delta{{}, Diff(base, next), Diff(next, base)} {
+		data, err := d.MarshalBinary()
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var d Delta
+		if d.UnmarshalBinary(data) != nil {
+			return
+		}
+		again, err := d.MarshalBinary()
+		if err != nil {
+			t.Fatalf("decoded delta does not encode: %v", err)
+		}
+		if !bytes.Equal(again, data) {
+			t.Fatalf("re-encoded as %x, decoded from %x", again, data)
+		}
+	})
+}
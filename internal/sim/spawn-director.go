@@ -0,0 +1,170 @@
+// File spawn-director.go defines SpawnDirector, which steers where new
+// meteors and aliens enter the field. It watches the ship: which screen
+// edges it hugs, and which way it is not looking. It then weights spawn
+// directions away from those, so a rock does not arrive out of a blind
+// spot the player cannot react to.
+//
+// Spawns are rolled, not chosen: the director maps a uniform roll onto the
+// weighted directions, one roll per spawn just as a uniform angle takes, so
+// with Fairness 0 (or before it has seen the ship) every spawn lands
+// exactly where the unweighted roll always did.
+package sim
+
+import "math"
+
+// Side is a screen edge, numbered from the right in order of increasing
+// screen-space angle, so that side s lies at the spawn angle s·π/2.
+type Side int
+
+// Screen edges.
+const (
+	SideRight Side = iota
+	SideBottom
+	SideLeft
+	SideTop
+	SideCount // Number of sides; keep last.
+)
+
+// Spawn director tuning.
+const (
+	// spawnMemory is how long the director remembers where the ship was:
+	// the time constant its history decays over.
+	spawnMemory = 4 * TicksPerSecond
+
+	// spawnHugBand is how close to an edge the ship must be, as a share of
+	// the field's width or height, before it counts as hugging it.
+	spawnHugBand = 0.2
+
+	// spawnBlindSpot is how heavily the direction behind the ship counts
+	// against spawning there, next to a fully hugged edge's 1.
+	spawnBlindSpot = 0.5
+
+	// spawnSectors is how finely the director divides the circle of spawn
+	// directions when weighting them.
+	spawnSectors = 32
+)
+
+// SpawnHistory is what a SpawnDirector remembers of the ship: decaying
+// averages over the last spawnMemory ticks.
+type SpawnHistory struct {
+	Hugging [SideCount]float64 `json:"hugging"` // How closely the ship has kept to each edge, 0 to 1.
+	Behind  Vector             `json:"behind"`  // Average unit direction behind the ship.
+}
+
+// SpawnDirector weights spawn directions away from where the ship is
+// exposed.
+type SpawnDirector struct {
+	Fairness float64      // 0 spawns uniformly; 1 avoids exposed directions as far as possible.
+	History  SpawnHistory // What the director has seen of the ship.
+	width    float64      // Field width in world units.
+	height   float64      // Field height in world units.
+}
+
+// NewSpawnDirector returns a director for a field of width × height with
+// no history.
+func NewSpawnDirector(width, height, fairness float64) *SpawnDirector {
+	return &SpawnDirector{Fairness: fairness, width: width, height: height}
+}
+
+// Observe records one tick of the ship at position, facing heading (a unit
+// vector).
+func (d *SpawnDirector) Observe(position, heading Vector) {
+	proximity := [SideCount]float64{
+		SideRight:  hugging(d.width-position.X, d.width),
+		SideBottom: hugging(d.height-position.Y, d.height),
+		SideLeft:   hugging(position.X, d.width),
+		SideTop:    hugging(position.Y, d.height),
+	}
+
+	keep := 1 - 1.0/spawnMemory
+	h := &d.History
+	for s := range h.Hugging {
+		h.Hugging[s] = h.Hugging[s]*keep + proximity[s]*(1-keep)
+	}
+	h.Behind = h.Behind.Scale(keep)
+	h.Behind.X -= heading.X * (1 - keep)
+	h.Behind.Y -= heading.Y * (1 - keep)
+}
+
+// hugging returns how closely a ship gap from an edge of a field span
+// across keeps to it: 1 on the edge, falling to 0 at the hug band.
+func hugging(gap, span float64) float64 {
+	band := spawnHugBand * span
+	if band <= 0 {
+		return 0
+	}
+	return max(0, min(1, 1-gap/band))
+}
+
+// Exposure returns how exposed the ship is to a spawn from angle (radians,
+// screen coordinates, 0 toward the right edge), from 0 to 1: how hard it
+// hugs the edges facing that way, and how squarely the angle is behind it.
+func (d *SpawnDirector) Exposure(angle float64) float64 {
+	dir := Vector{X: math.Cos(angle), Y: math.Sin(angle)}
+	e := 0.0
+	for s, hug := range d.History.Hugging {
+		facing := float64(s) * math.Pi / 2
+		e += hug * max(0, dir.X*math.Cos(facing)+dir.Y*math.Sin(facing))
+	}
+	e += spawnBlindSpot * max(0, dir.X*d.History.Behind.X+dir.Y*d.History.Behind.Y)
+	return min(1, e)
+}
+
+// weight returns the relative chance of a spawn from angle.
+func (d *SpawnDirector) weight(angle float64) float64 {
+	return 1 - max(0, min(1, d.Fairness))*d.Exposure(angle)
+}
+
+// Angle maps a uniform roll in [0, 1) to a spawn angle in [0, 2π), weighted
+// away from exposed directions.
+func (d *SpawnDirector) Angle(roll float64) float64 {
+	const sector = 2 * math.Pi / spawnSectors
+	var weights [spawnSectors]float64
+	total := 0.0
+	for i := range weights {
+		weights[i] = d.weight((float64(i) + 0.5) * sector)
+		total += weights[i]
+	}
+	if total <= 0 {
+		return roll * 2 * math.Pi
+	}
+
+	// Walk the sectors to the one the roll lands in, then place the angle
+	// within it by how far into it the roll reached.
+	target := roll * total
+	for i, w := range weights {
+		if target < w || i == spawnSectors-1 {
+			into := 0.0
+			if w > 0 {
+				into = min(1, target/w)
+			}
+			return (float64(i) + into) * sector
+		}
+		target -= w
+	}
+	return 0
+}
+
+// Choose maps a uniform roll in [0, 1) to one of angles, spawn directions
+// for a caller with a fixed set of entries (such as either side edge),
+// weighted away from exposed ones. It returns the chosen index.
+func (d *SpawnDirector) Choose(roll float64, angles ...float64) int {
+	weights := make([]float64, len(angles))
+	total := 0.0
+	for i, a := range angles {
+		weights[i] = d.weight(a)
+		total += weights[i]
+	}
+	if total <= 0 {
+		return min(int(roll*float64(len(angles))), len(angles)-1)
+	}
+
+	target := roll * total
+	for i, w := range weights {
+		if target < w {
+			return i
+		}
+		target -= w
+	}
+	return len(angles) - 1
+}
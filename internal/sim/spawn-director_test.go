@@ -0,0 +1,101 @@
+// File spawn-director_test.go feeds SpawnDirector synthetic histories of a
+// ship hugging an edge and checks that spawns are steered off the hugged
+// edge and the blind spot behind it, and left alone with Fairness 0.
+package sim
+
+import (
+	"math"
+	"testing"
+)
+
+// Field size the tests use.
+const (
+	testFieldWidth  = 1280
+	testFieldHeight = 720
+)
+
+// huggingDirector returns a director with the given fairness that has
+// watched a ship sit on the left edge, facing up, for well over its
+// memory.
+func huggingDirector(fairness float64) *SpawnDirector {
+	d := NewSpawnDirector(testFieldWidth, testFieldHeight, fairness)
+	for range 10 * spawnMemory {
+		d.Observe(Vector{X: 10, Y: testFieldHeight / 2}, Vector{Y: -1})
+	}
+	return d
+}
+
+// Spawn angles toward each edge.
+const (
+	towardRight  = 0
+	towardBottom = math.Pi / 2
+	towardLeft   = math.Pi
+	towardTop    = 3 * math.Pi / 2
+)
+
+func TestSpawnDirectorLearnsHistory(t *testing.T) {
+	h := huggingDirector(1).History
+	if h.Hugging[SideLeft] < 0.9 {
+		t.Errorf("left edge hugging = %.3f, want near 1", h.Hugging[SideLeft])
+	}
+	for _, s := range []Side{SideRight, SideTop, SideBottom} {
+		if h.Hugging[s] != 0 {
+			t.Errorf("side %d hugging = %.3f, want 0", s, h.Hugging[s])
+		}
+	}
+	if h.Behind.Y < 0.9 || math.Abs(h.Behind.X) > 1e-9 {
+		t.Errorf("behind = %v, want near straight down", h.Behind)
+	}
+}
+
+func TestSpawnDirectorAvoidsExposedSides(t *testing.T) {
+	d := huggingDirector(1)
+	if d.Exposure(towardLeft) <= d.Exposure(towardRight) {
+		t.Errorf("exposure toward the hugged edge %.3f, open edge %.3f; want the hugged one higher",
+			d.Exposure(towardLeft), d.Exposure(towardRight))
+	}
+	if d.weight(towardBottom) >= d.weight(towardTop) {
+		t.Errorf("weight behind the ship %.3f, ahead %.3f; want behind lower",
+			d.weight(towardBottom), d.weight(towardTop))
+	}
+	if d.weight(towardLeft) >= d.weight(towardRight) {
+		t.Errorf("weight toward the hugged edge %.3f, open edge %.3f; want hugged lower",
+			d.weight(towardLeft), d.weight(towardRight))
+	}
+
+	// Uniform rolls land toward the hugged edge less than toward the open one.
+	var left, right int
+	const rolls = 1000
+	for i := range rolls {
+		a := d.Angle((float64(i) + 0.5) / rolls)
+		switch {
+		case math.Cos(a) < -math.Sqrt2/2:
+			left++
+		case math.Cos(a) > math.Sqrt2/2:
+			right++
+		}
+	}
+	if left >= right {
+		t.Errorf("%d rolls spawned toward the hugged edge and %d toward the open one; want fewer toward it", left, right)
+	}
+}
+
+func TestSpawnDirectorWithoutFairnessIsUniform(t *testing.T) {
+	d := huggingDirector(0)
+	for i := range spawnSectors {
+		a := float64(i) * 2 * math.Pi / spawnSectors
+		if w := d.weight(a); w != 1 {
+			t.Errorf("weight(%.3f) = %v, want 1", a, w)
+		}
+	}
+	for _, roll := range []float64{0, 0.1, 0.25, 0.5, 0.77, 0.999} {
+		if got, want := d.Angle(roll), roll*2*math.Pi; math.Abs(got-want) > 1e-9 {
+			t.Errorf("Angle(%v) = %.9f, want %.9f", roll, got, want)
+		}
+	}
+	for _, roll := range []float64{0, 0.49, 0.5, 0.99} {
+		if got, want := d.Choose(roll, towardLeft, towardRight), int(roll*2); got != want {
+			t.Errorf("Choose(%v) = %d, want %d", roll, got, want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// File timer.go defines a lightweight, tick-based timer abstraction.
+// Timers are central to spawning, pacing, cooldowns, and animations
+// throughout the game, synchronized to the simulation's tick rate rather
+// than wall time.
+package sim
+
+import "time"
+
+// Timer represents a simple counter-based timer that progresses one tick
+// per Update. Once Elapsed >= Target, the timer is considered "ready".
+type Timer struct {
+	Elapsed int // Elapsed tick count since last reset.
+	Target  int // Total ticks required before IsReady() is true.
+}
+
+// NewTimer returns a Timer for the specified duration.
+//
+// The provided duration (time.Duration) is converted to ticks at
+// TicksPerSecond, so timing is the same on every platform and frame rate.
+func NewTimer(d time.Duration) *Timer {
+	return &Timer{
+		Elapsed: 0,
+		Target:  Ticks(d),
+	}
+}
+
+// Update advances the timer by one tick, up to the target threshold.
+//
+// This should be called once per tick inside a scene or object’s Update().
+func (t *Timer) Update() {
+	if t.Elapsed < t.Target {
+		t.Elapsed++
+	}
+}
+
+// IsReady returns true when the timer has reached or exceeded its target.
+//
+// Example:
+//
+//	if timer.IsReady() {
+//	    spawnAlien()
+//	    timer.Reset()
+//	}
+func (t *Timer) IsReady() bool {
+	return t.Elapsed >= t.Target
+}
+
+// Reset sets the timer’s tick count back to zero, restarting the interval.
+func (t *Timer) Reset() {
+	t.Elapsed = 0
+}
@@ -0,0 +1,26 @@
+// File units.go defines the units motion is measured in. A world unit is
+// one pixel of the game's 1280 × 720 logical screen. Every speed, including
+// every movement Vector, is in world units per second, every acceleration
+// in world units per second per second, and every turn rate in radians per
+// second; no tuning value is written per tick. The physics step is the one
+// place rates meet the tick rate: bodies move with Advance and turn with
+// PerTick.
+package sim
+
+// TickSeconds returns the simulated time one tick covers.
+func TickSeconds() float64 {
+	return 1.0 / TicksPerSecond
+}
+
+// PerTick returns the share of a per-second rate that falls in one tick:
+// the distance covered at a speed, the angle turned at a turn rate, or the
+// speed gained at an acceleration.
+func PerTick(rate float64) float64 {
+	return rate * TickSeconds()
+}
+
+// Advance moves position one tick along velocity.
+func Advance(position *Vector, velocity Vector) {
+	position.X += PerTick(velocity.X)
+	position.Y += PerTick(velocity.Y)
+}
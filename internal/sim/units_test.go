@@ -0,0 +1,44 @@
+// File units_test.go checks that rates are per second whatever the tick
+// rate: a body advanced for N simulated seconds covers speed × N.
+package sim
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAdvanceCoversSpeedPerSecond(t *testing.T) {
+	tests := []struct {
+		velocity Vector
+		seconds  int
+	}{
+		{Vector{X: 240}, 1},
+		{Vector{X: 240}, 3},
+		{Vector{Y: -90}, 10},
+		{Vector{X: 300, Y: 400}, 2},
+	}
+	for _, tt := range tests {
+		var position Vector
+		for range TicksPerSecond * tt.seconds {
+			Advance(&position, tt.velocity)
+		}
+		want := Vector{X: tt.velocity.X * float64(tt.seconds), Y: tt.velocity.Y * float64(tt.seconds)}
+		if math.Abs(position.X-want.X) > 1e-9 || math.Abs(position.Y-want.Y) > 1e-9 {
+			t.Errorf("after %d s at %v: at %v, want %v", tt.seconds, tt.velocity, position, want)
+		}
+	}
+}
+
+func TestPerTickSumsToRate(t *testing.T) {
+	const turnRate = math.Pi // Radians a second.
+	var angle float64
+	for range TicksPerSecond * 2 {
+		angle += PerTick(turnRate)
+	}
+	if math.Abs(angle-2*turnRate) > 1e-9 {
+		t.Errorf("turned %.9f in 2 s at %.9f rad/s, want %.9f", angle, turnRate, 2*turnRate)
+	}
+	if got := PerTick(TicksPerSecond); math.Abs(got-1) > 1e-12 {
+		t.Errorf("PerTick(TicksPerSecond) = %v, want 1", got)
+	}
+}
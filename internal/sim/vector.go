@@ -1,6 +1,6 @@
 // File vector.go defines a lightweight 2D vector type used for
 // position and movement calculations throughout the game.
-package asteroids
+package sim
 
 import "math"
 
@@ -33,3 +33,16 @@ func (v Vector) Normalize() Vector {
 		Y: v.Y / magnitude,
 	}
 }
+
+// Length returns the magnitude of v: a velocity's speed.
+func (v Vector) Length() float64 {
+	return math.Hypot(v.X, v.Y)
+}
+
+// Scale returns v with both components multiplied by s.
+//
+// Paired with a unit vector, such as one from Normalize, it gives a
+// velocity of speed s along that direction.
+func (v Vector) Scale(s float64) Vector {
+	return Vector{X: v.X * s, Y: v.Y * s}
+}
@@ -0,0 +1,166 @@
+// File wave.go defines the WaveManager, which owns the per-level meteor spawn
+// budget and the count of meteors still alive. Spawning and level completion
+// both consult it instead of inferring progress from map sizes.
+package sim
+
+import "time"
+
+// WaveKind selects how a level's wave plays.
+type WaveKind int
+
+const (
+	// WaveStandard spawns a budget of large meteors that must all be destroyed.
+	WaveStandard WaveKind = iota
+
+	// WaveGoldRush is a timed bonus round: harmless gold meteors stream
+	// across the screen until the clock runs out.
+	WaveGoldRush
+
+	// WaveBoss is a boss fight: the level ends once the boss and every
+	// meteor it sheds have been destroyed.
+	WaveBoss
+)
+
+// WaveManager tracks how many large meteors a level may still spawn and how
+// many meteors of any size remain in play.
+//
+// Split fragments count toward alive but never toward the spawn budget, so
+// breaking a large meteor can neither stall nor shorten a level. Timed waves
+// ignore the budget and end when their clock runs out.
+type WaveManager struct {
+	kind         WaveKind // How the current level plays.
+	budget       int      // Large meteors the current level spawns in total.
+	spawned      int      // Large meteors spawned so far this level.
+	alive        int      // Meteors (large or fragment) currently in play.
+	clock        *Timer   // Countdown for timed waves; nil for budgeted ones.
+	bossStanding bool     // A boss level's boss has yet to be defeated.
+}
+
+// NewWaveManager returns a manager for the given level.
+func NewWaveManager(l Level) *WaveManager {
+	w := &WaveManager{}
+	w.StartLevel(l)
+	return w
+}
+
+// StartLevel begins a new level with a fresh budget (and clock, if timed).
+// Meteors still alive from the previous level (if any) keep counting.
+func (w *WaveManager) StartLevel(l Level) {
+	w.kind = l.Kind
+	w.budget = l.MeteorBudget
+	w.spawned = 0
+	w.bossStanding = l.Kind == WaveBoss
+	w.clock = nil
+	if l.Duration > 0 {
+		w.clock = NewTimer(l.Duration)
+	}
+}
+
+// RestartLevel rewinds the current level after the field has been cleared
+// (e.g. on respawn): the full budget is available again, nothing is alive,
+// and a boss level's boss returns at full health.
+func (w *WaveManager) RestartLevel() {
+	w.spawned = 0
+	w.alive = 0
+	w.bossStanding = w.kind == WaveBoss
+	if w.clock != nil {
+		w.clock.Reset()
+	}
+}
+
+// Tick advances a timed wave's clock by one Tick.
+func (w *WaveManager) Tick() {
+	if w.clock != nil {
+		w.clock.Update()
+	}
+}
+
+// TimeLeft returns what remains of a timed wave's clock (zero if untimed).
+func (w *WaveManager) TimeLeft() time.Duration {
+	if w.clock == nil {
+		return 0
+	}
+	return Duration(w.clock.Target - w.clock.Elapsed)
+}
+
+// CanSpawn reports whether the level's budget (or clock) allows another meteor.
+func (w *WaveManager) CanSpawn() bool {
+	if w.clock != nil {
+		return !w.clock.IsReady()
+	}
+	return w.spawned < w.budget
+}
+
+// TrackSpawn records a budgeted large meteor entering play.
+func (w *WaveManager) TrackSpawn() {
+	w.spawned++
+	w.alive++
+}
+
+// TrackSplit records a fragment entering play outside the budget.
+func (w *WaveManager) TrackSplit() {
+	w.alive++
+}
+
+// TrackRemoval records a meteor leaving play.
+func (w *WaveManager) TrackRemoval() {
+	if w.alive > 0 {
+		w.alive--
+	}
+}
+
+// MeteorsLeft returns how many meteors the level still needs cleared: its
+// unspawned budget plus those in play. Timed waves count only those in play.
+func (w *WaveManager) MeteorsLeft() int {
+	if w.clock != nil {
+		return w.alive
+	}
+	return max(w.budget-w.spawned, 0) + w.alive
+}
+
+// IsBossStanding reports whether the level still has a boss to defeat.
+func (w *WaveManager) IsBossStanding() bool {
+	return w.bossStanding
+}
+
+// TrackBossDefeated records the level's boss being destroyed.
+func (w *WaveManager) TrackBossDefeated() {
+	w.bossStanding = false
+}
+
+// IsCleared reports whether the whole budget has spawned and been destroyed
+// (along with any boss), or, for a timed wave, whether its clock has run out.
+func (w *WaveManager) IsCleared() bool {
+	if w.clock != nil {
+		return w.clock.IsReady()
+	}
+	return w.spawned >= w.budget && w.alive == 0 && !w.bossStanding
+}
+
+// WaveProgress is how far a level's wave has got, for suspending a run
+// and resuming it later.
+type WaveProgress struct {
+	Spawned      int  // Large meteors spawned so far.
+	BossStanding bool // The level's boss has yet to be defeated.
+	ClockTicks   int  // Ticks elapsed on a timed wave's clock; 0 if untimed.
+}
+
+// Progress returns how far the current level's wave has got.
+func (w *WaveManager) Progress() WaveProgress {
+	p := WaveProgress{Spawned: w.spawned, BossStanding: w.bossStanding}
+	if w.clock != nil {
+		p.ClockTicks = w.clock.Elapsed
+	}
+	return p
+}
+
+// Resume restores progress saved by Progress onto a wave that has just
+// started the same level. Meteors restored with it are counted back in
+// through TrackSplit, so they weigh on the alive count and not the budget.
+func (w *WaveManager) Resume(p WaveProgress) {
+	w.spawned = p.Spawned
+	w.bossStanding = p.BossStanding
+	if w.clock != nil {
+		w.clock.Elapsed = p.ClockTicks
+	}
+}